@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apikey"
+)
+
+const apiKeyUsage = `usage:
+  apikey [--workspace <slug>] mint --name <name> --scopes <scope>[,<scope>...] [--author <author id>]
+  apikey [--workspace <slug>] list
+  apikey [--workspace <slug>] revoke <id>
+
+scopes: articles:read, articles:write, articles:admin
+keys are bound to the workspace (default: default)`
+
+// runAPIKeyCommand はAPIキーを発行・一覧・失効するサブコマンドを実行する
+// ctxのワークスペースのキーを操作する
+func runAPIKeyCommand(ctx context.Context, uc *apikey.APIKeyUsecase, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand\n%s", apiKeyUsage)
+	}
+	switch args[0] {
+	case "mint":
+		fs := flag.NewFlagSet("apikey mint", flag.ContinueOnError)
+		fs.SetOutput(out)
+		name := fs.String("name", "", "name of the key (e.g. the client that uses it)")
+		scopes := fs.String("scopes", "", "comma-separated scopes")
+		authorID := fs.Uint64("author", 0, "id of the author whose articles the key owns")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		input := apikey.MintInput{Name: *name, Scopes: splitScopes(*scopes)}
+		if *authorID != 0 {
+			input.AuthorID = authorID
+		}
+		key, err := uc.Mint(ctx, input)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "id:     %d\nname:   %s\nscopes: %s\nkey:    %s\n\n", key.ID, key.Name, strings.Join(key.Scopes, ","), key.Key)
+		fmt.Fprintln(out, "Store the key now. It cannot be shown again.")
+		return nil
+	case "list":
+		keys, err := uc.List(ctx)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME\tPREFIX\tSCOPES\tCREATED\tLAST USED\tREVOKED")
+		for _, k := range keys {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				k.ID, k.Name, k.Prefix, strings.Join(k.Scopes, ","),
+				k.CreatedAt.Format(time.RFC3339), formatOptionalTime(k.LastUsedAt), formatOptionalTime(k.RevokedAt))
+		}
+		return tw.Flush()
+	case "revoke":
+		if len(args) != 2 {
+			return fmt.Errorf("revoke takes exactly one id\n%s", apiKeyUsage)
+		}
+		id, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid id: %q", args[1])
+		}
+		key, err := uc.Revoke(ctx, id)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "revoked api key %d (%s)\n", key.ID, key.Name)
+		return nil
+	default:
+		return fmt.Errorf("unknown subcommand: %s\n%s", args[0], apiKeyUsage)
+	}
+}
+
+func splitScopes(value string) []string {
+	var scopes []string
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+)
+
+const articlesUsage = `usage:
+  articles recompute-metadata
+
+recomputes the character count and reading time of all articles in all workspaces from their bodies`
+
+// runArticlesCommand は記事の保守用のサブコマンドを実行する
+func runArticlesCommand(ctx context.Context, uc *article.ArticleUsecase, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand\n%s", articlesUsage)
+	}
+	switch args[0] {
+	case "recompute-metadata":
+		output, err := uc.RecomputeMetadata(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%d articles scanned, %d updated\n", output.Scanned, output.Updated)
+		return nil
+	default:
+		return fmt.Errorf("unknown subcommand: %s\n%s", args[0], articlesUsage)
+	}
+}
@@ -1,12 +1,46 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/umekikazuya/momenture-article-hub/internal/config"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/handler"
+	infraapikey "github.com/umekikazuya/momenture-article-hub/internal/infrastructure/apikey"
+	infralinkcheck "github.com/umekikazuya/momenture-article-hub/internal/infrastructure/linkcheck"
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/markdown"
+	inframetrics "github.com/umekikazuya/momenture-article-hub/internal/infrastructure/metrics"
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/netguard"
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/ogp"
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/oidc"
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/outbox"
 	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/persistence/postgres"
+	infratrash "github.com/umekikazuya/momenture-article-hub/internal/infrastructure/trash"
+	infrawebhook "github.com/umekikazuya/momenture-article-hub/internal/infrastructure/webhook"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apikey"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/audit"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/author"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/comment"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/duplicate"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/feed"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/linkcheck"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/linkpreview"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/metrics"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/review"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/sitemap"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/trash"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/webhook"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/workspace"
 )
 
 func main() {
@@ -14,22 +48,279 @@ func main() {
 	if err != nil {
 		log.Fatal("Failed to load configuration:", err)
 	}
+
 	// データベース接続
-	_, err = postgres.NewPostgreSQLDB(&config.Database)
+	db, err := postgres.NewPostgreSQLDB(&config.Database)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// ワークスペース
+	var workspaceOpts []workspace.Option
+	if config.Auth.Enabled {
+		workspaceOpts = append(workspaceOpts, workspace.WithAuthorizer(auth.ScopeAuthorizer{}))
+	}
+	workspaceUsecase := workspace.NewWorkspaceUsecase(postgres.NewWorkspaceRepository(db), workspaceOpts...)
+	if len(os.Args) > 1 && os.Args[1] == "workspace" {
+		if err := runWorkspaceCommand(ctx, workspaceUsecase, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// APIキー
+	apiKeyUsecase := apikey.NewAPIKeyUsecase(postgres.NewAPIKeyRepository(db), infraapikey.NewArgon2idHasher())
+	if len(os.Args) > 1 && os.Args[1] == "apikey" {
+		cmdCtx, args, err := withWorkspaceFlag(ctx, workspaceUsecase, "apikey", os.Args[2:], os.Stdout)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := runAPIKeyCommand(cmdCtx, apiKeyUsecase, args, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// バックグラウンドの処理は全てのワークスペースを対象にする
+	workerCtx := repository.WithAllWorkspaces(ctx)
+
+	// 監査ログ
+	var auditOpts []audit.Option
+	if config.Auth.Enabled {
+		auditOpts = append(auditOpts, audit.WithAuthorizer(auth.ScopeAuthorizer{}))
+	}
+	auditUsecase := audit.NewAuditUsecase(postgres.NewAuditLogRepository(db), auditOpts...)
+
+	articleRepo := postgres.NewArticleRepository(db)
+	articleOpts := []article.Option{
+		article.WithBodyRenderer(markdown.NewCachedRenderer(markdown.NewGoldmarkRenderer(), config.Markdown.CacheSize)),
+		article.WithAuditLog(auditUsecase),
+		article.WithTrashRetention(config.Trash.Retention()),
+		article.WithBulkChunkSize(config.Article.BulkChunkSize),
+		article.WithBodyMaxSize(config.Article.BodyMaxSize),
+	}
+	if config.Auth.Enabled {
+		articleOpts = append(articleOpts, article.WithAuthorizer(auth.ArticlePolicy))
+	}
+	// インラインコメント
+	var commentOpts []comment.Option
+	if config.Auth.Enabled {
+		commentOpts = append(commentOpts, comment.WithAuthorizer(auth.ArticlePolicy))
+	}
+	commentUsecase := comment.NewCommentUsecase(articleRepo, postgres.NewInlineCommentRepository(db), postgres.NewTxManager(db), commentOpts...)
+
+	articleOpts = append(articleOpts, article.WithReviewPolicy(workspaceUsecase), article.WithCommentAnchorer(commentUsecase))
+	articleUsecase := article.NewArticleUsecase(articleRepo, postgres.NewTxManager(db), articleOpts...)
+	if len(os.Args) > 1 && os.Args[1] == "articles" {
+		if err := runArticlesCommand(workerCtx, articleUsecase, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// ゴミ箱
+	// 保持期間を過ぎた記事の完全な削除は、監査ログに実行した処理の名前で記録する
+	trashUsecase := trash.NewTrashUsecase(articleRepo, postgres.NewTxManager(db), config.Trash.Retention(), trash.WithAuditLog(auditUsecase))
+	if len(os.Args) > 1 && os.Args[1] == "trash" {
+		cmdCtx := auth.WithPrincipal(workerCtx, &auth.Principal{Name: "trash-cli"})
+		if err := runTrashCommand(cmdCtx, trashUsecase, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if config.Trash.RetentionDays > 0 {
+		go infratrash.NewWorker(trashUsecase, config.Trash.PurgeInterval).Run(auth.WithPrincipal(workerCtx, &auth.Principal{Name: "trash-retention"}))
+	}
+
+	// レビュー
+	reviewOpts := []review.Option{review.WithAuditLog(auditUsecase)}
+	if config.Auth.Enabled {
+		reviewOpts = append(reviewOpts, review.WithAuthorizer(auth.ArticlePolicy))
+	}
+	reviewUsecase := review.NewReviewUsecase(articleRepo, postgres.NewReviewRequestRepository(db), postgres.NewTxManager(db), reviewOpts...)
+
+	// 執筆者
+	authorRepo := postgres.NewAuthorRepository(db)
+	var authorOpts []author.Option
+	if config.Auth.Enabled {
+		authorOpts = append(authorOpts, author.WithAuthorizer(auth.ScopeAuthorizer{}))
+	}
+	authorUsecase := author.NewAuthorUsecase(authorRepo, articleUsecase, authorOpts...)
+
+	// Webhook
+	webhookUsecase := webhook.NewWebhookUsecase(
+		postgres.NewWebhookSubscriptionRepository(db),
+		postgres.NewWebhookDeliveryRepository(db, config.Webhook.ClaimLease),
+		infrawebhook.NewHTTPSender(netguard.NewClient(config.Webhook.RequestTimeout)),
+		webhook.WithRetryPolicy(config.Webhook.MaxAttempts, config.Webhook.RetryBaseBackoff, config.Webhook.RetryMaxBackoff),
+	)
+	go infrawebhook.NewWorker(webhookUsecase, config.Webhook.DeliveryInterval, config.Webhook.BatchSize).Run(workerCtx)
+
+	// エンゲージメント指標
+	metricsClient := &http.Client{Timeout: config.Metrics.RequestTimeout}
+	metricsOpts := []metrics.Option{
+		metrics.WithFetcher(vo.ProviderTypeQiita, inframetrics.NewQiitaFetcher(metricsClient, config.Metrics.QiitaBaseURL, config.Metrics.QiitaToken, inframetrics.WithCredentials(workspaceUsecase))),
+		metrics.WithFetcher(vo.ProviderTypeZenn, inframetrics.NewZennFetcher(metricsClient, config.Metrics.ZennBaseURL)),
+	}
+	if config.Auth.Enabled {
+		metricsOpts = append(metricsOpts, metrics.WithAuthorizer(auth.ArticlePolicy))
+	}
+	metricsUsecase := metrics.NewMetricsUsecase(postgres.NewArticleMetricRepository(db), articleRepo, metricsOpts...)
+	if config.Metrics.CollectInterval > 0 {
+		go inframetrics.NewWorker(metricsUsecase, config.Metrics.CollectInterval).Run(workerCtx)
+	}
+
+	// リンク切れチェック
+	linkCheckUsecase := linkcheck.NewLinkCheckUsecase(
+		postgres.NewLinkCheckRepository(db),
+		articleRepo,
+		infralinkcheck.NewHTTPChecker(
+			netguard.NewClient(config.LinkCheck.RequestTimeout),
+			infralinkcheck.WithUserAgent(config.LinkCheck.UserAgent),
+			infralinkcheck.WithHostInterval(config.LinkCheck.HostInterval),
+		),
+		linkcheck.WithConcurrency(config.LinkCheck.Concurrency),
+	)
+	if config.LinkCheck.Interval > 0 {
+		go infralinkcheck.NewWorker(linkCheckUsecase, config.LinkCheck.Interval).Run(workerCtx)
+	}
+
+	// リンク先のOGP
+	linkPreviewUsecase := linkpreview.NewLinkPreviewUsecase(
+		postgres.NewLinkPreviewRepository(db),
+		articleRepo,
+		ogp.NewHTTPFetcher(
+			netguard.NewClient(config.OGP.RequestTimeout),
+			ogp.WithUserAgent(config.OGP.UserAgent),
+			ogp.WithMaxBytes(config.OGP.MaxBytes),
+		),
+		linkpreview.WithRefreshPolicy(config.OGP.TTL, config.OGP.RetryAfter),
+		linkpreview.WithBatchSize(config.OGP.BatchSize),
+	)
+	if config.OGP.RefreshInterval > 0 {
+		go ogp.NewWorker(linkPreviewUsecase, config.OGP.RefreshInterval).Run(workerCtx)
+	}
+
+	// アウトボックスのリレーを起動
+	publisher, err := newOutboxPublisher(&config.Outbox)
+	if err != nil {
+		log.Fatal("Failed to create outbox publisher:", err)
+	}
+	relay := outbox.NewRelay(
+		postgres.NewOutboxStore(db, config.Outbox.ClaimLease),
+		outbox.NewMultiPublisher(publisher, infrawebhook.NewDispatcher(webhookUsecase)),
+		outbox.WithPollInterval(config.Outbox.PollInterval),
+		outbox.WithBatchSize(config.Outbox.BatchSize),
+		outbox.WithMaxAttempts(config.Outbox.MaxAttempts),
+		outbox.WithBackoff(config.Outbox.RetryBaseBackoff, config.Outbox.RetryMaxBackoff),
+	)
+	go relay.Run(workerCtx)
+
+	// 重複の候補
+	var duplicateOpts []duplicate.Option
+	if config.Auth.Enabled {
+		duplicateOpts = append(duplicateOpts, duplicate.WithAuthorizer(auth.ArticlePolicy))
+	}
+
+	mux := http.NewServeMux()
+	articleHandler := handler.NewArticleHandler(articleUsecase)
+	articleHandler.Register(mux)
+	handler.NewMetricsHandler(metricsUsecase).Register(mux, articleHandler)
+	handler.NewLinkCheckHandler(linkCheckUsecase).Register(mux, articleHandler)
+	handler.NewReviewHandler(reviewUsecase).Register(mux, articleHandler)
+	handler.NewCommentHandler(commentUsecase).Register(mux, articleHandler)
+	handler.NewLinkPreviewHandler(linkPreviewUsecase).Register(mux)
+	handler.NewAuthorHandler(authorUsecase).Register(mux)
+	handler.NewDuplicateHandler(duplicate.NewDuplicateUsecase(articleRepo, duplicateOpts...)).Register(mux)
+	handler.NewWebhookHandler(webhookUsecase).Register(mux)
+	handler.NewWorkspaceHandler(workspaceUsecase).Register(mux)
+	handler.NewAuditHandler(auditUsecase).Register(mux)
+	handler.NewTrashHandler(articleUsecase, trashUsecase).Register(mux)
+	handler.NewFeedHandler(feed.NewFeedUsecase(articleRepo, articleRepo, config.Feed.ItemLimit), handler.FeedMeta{
+		Title:       config.Feed.Title,
+		Description: config.Feed.Description,
+		Author:      config.Feed.Author,
+		SiteURL:     config.Feed.SiteURL,
+	}, handler.WithFeedSettings(workspaceUsecase)).Register(mux)
+	sitemapUsecase := sitemap.NewSitemapUsecase(articleRepo, config.Feed.SiteURL, config.Sitemap.URLsPerFile)
+	sitemapSettings := handler.WithSitemapSettings(workspaceUsecase)
+	handler.NewSitemapHandler(sitemapUsecase, sitemapSettings).Register(mux)
+	handler.NewRobotsHandler(config.Sitemap.RobotsDisallow, sitemapUsecase, sitemapSettings).Register(mux)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, "Hello World!")
 	})
 
 	// Health check endpoint
-	http.HandleFunc("/up", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/up", func(w http.ResponseWriter, r *http.Request) {
 		log.Println("Health check endpoint hit")
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintf(w, "OK")
 	})
 
+	var server http.Handler = mux
+	if config.Auth.Enabled {
+		credentials := auth.CredentialRouter{APIKey: apiKeyUsecase}
+		if config.OIDC.Enabled() {
+			tokenAuthenticator, err := newTokenAuthenticator(&config.OIDC, workspaceUsecase)
+			if err != nil {
+				log.Fatal("Failed to configure bearer token authentication:", err)
+			}
+			credentials.Token = author.NewSubjectResolver(tokenAuthenticator, authorRepo)
+		}
+		server = handler.NewAuthMiddleware(
+			credentials,
+			handler.WithPublicPaths("/", "/up", "/feed.xml", "/atom.xml", "/feed.json", "/sitemap.xml", "/sitemaps/", "/robots.txt"),
+			handler.WithPathScope("/webhooks", vo.ScopeArticlesAdmin),
+			handler.WithPathScope("/workspace", vo.ScopeArticlesAdmin),
+			handler.WithPathScope("/audit-log", vo.ScopeArticlesAdmin),
+			handler.WithPathScope("/trash", vo.ScopeArticlesAdmin),
+		).Wrap(mux)
+	} else {
+		log.Println("API authentication is disabled (AUTH_ENABLED=false)")
+	}
+	server = handler.NewTenantMiddleware(workspaceUsecase, handler.WithBaseDomain(config.Tenant.BaseDomain)).Wrap(server)
+	var requestOpts []handler.RequestOption
+	if config.Audit.TrustForwardedFor {
+		requestOpts = append(requestOpts, handler.WithTrustedForwardedFor())
+	}
+	server = handler.NewRequestMiddleware(requestOpts...).Wrap(server)
+
 	fmt.Printf("Server starting on port %s...\n", "8080")
-	log.Fatal(http.ListenAndServe(":"+"8080", nil))
+	log.Fatal(http.ListenAndServe(":"+"8080", server))
+}
+
+func newTokenAuthenticator(cfg *config.OIDCConfig, workspaces oidc.WorkspaceResolver) (*oidc.Authenticator, error) {
+	roleScopes, err := oidc.ParseRoleScopes(cfg.RoleScopes)
+	if err != nil {
+		return nil, err
+	}
+	var keys *oidc.KeySet
+	if cfg.JWKSFile != "" {
+		keys = oidc.NewFileKeySet(cfg.JWKSFile, oidc.WithRefreshInterval(cfg.JWKSRefreshInterval))
+	} else {
+		keys = oidc.NewURLKeySet(&http.Client{Timeout: 10 * time.Second}, cfg.JWKSURL, oidc.WithRefreshInterval(cfg.JWKSRefreshInterval))
+	}
+	verifier := oidc.NewVerifier(keys, cfg.Issuer, cfg.Audience, oidc.WithLeeway(cfg.ClockSkew))
+	var opts []oidc.AuthenticatorOption
+	if cfg.WorkspaceClaim != "" {
+		opts = append(opts, oidc.WithWorkspaceClaim(cfg.WorkspaceClaim, workspaces))
+	}
+	return oidc.NewAuthenticator(verifier, cfg.RolesClaim, roleScopes, opts...), nil
+}
+
+func newOutboxPublisher(cfg *config.OutboxConfig) (outbox.Publisher, error) {
+	switch cfg.Publisher {
+	case "log":
+		return outbox.NewLogPublisher(nil), nil
+	case "webhook":
+		return outbox.NewWebhookPublisher(cfg.WebhookURL, nil), nil
+	case "nats":
+		return outbox.NewNATSPublisher(cfg.NATSAddr, cfg.NATSSubjectPrefix), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher: %s", cfg.Publisher)
+	}
 }
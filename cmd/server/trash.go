@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/trash"
+)
+
+const trashUsage = `usage:
+  trash purge [--dry-run]
+
+purges articles deleted longer ago than TRASH_RETENTION_DAYS in all workspaces`
+
+// runTrashCommand はゴミ箱の記事を完全に削除するサブコマンドを実行する
+func runTrashCommand(ctx context.Context, uc *trash.TrashUsecase, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand\n%s", trashUsage)
+	}
+	switch args[0] {
+	case "purge":
+		fs := flag.NewFlagSet("trash purge", flag.ContinueOnError)
+		fs.SetOutput(out)
+		dryRun := fs.Bool("dry-run", false, "report the articles to purge without deleting them")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		report, err := uc.Purge(ctx, trash.PurgeInput{DryRun: *dryRun})
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tWORKSPACE\tSLUG\tTITLE\tDELETED")
+		for _, a := range report.Articles {
+			fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\n", a.ID, a.WorkspaceID, a.Slug, a.Title, a.DeletedAt.Format(time.RFC3339))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		if report.DryRun {
+			fmt.Fprintf(out, "\n%d articles deleted before %s would be purged (dry run)\n", len(report.Articles), report.Cutoff.Format(time.RFC3339))
+		} else {
+			fmt.Fprintf(out, "\n%d articles deleted before %s purged\n", report.Purged, report.Cutoff.Format(time.RFC3339))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown subcommand: %s\n%s", args[0], trashUsage)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/workspace"
+)
+
+const workspaceUsage = `usage:
+  workspace create --slug <slug> --name <name>
+  workspace list`
+
+// runWorkspaceCommand はワークスペースを作成・一覧するサブコマンドを実行する
+func runWorkspaceCommand(ctx context.Context, uc *workspace.WorkspaceUsecase, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand\n%s", workspaceUsage)
+	}
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("workspace create", flag.ContinueOnError)
+		fs.SetOutput(out)
+		slug := fs.String("slug", "", "slug used as the subdomain or in the X-Workspace header")
+		name := fs.String("name", "", "display name of the workspace")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		w, err := uc.Create(ctx, workspace.CreateWorkspaceInput{Slug: *slug, Name: *name})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "id:   %d\nslug: %s\nname: %s\n", w.ID, w.Slug, w.Name)
+		return nil
+	case "list":
+		workspaces, err := uc.List(ctx)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tSLUG\tNAME\tCREDENTIALS\tCREATED")
+		for _, w := range workspaces {
+			credentials := "-"
+			if len(w.CredentialProviders) > 0 {
+				credentials = strings.Join(w.CredentialProviders, ",")
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", w.ID, w.Slug, w.Name, credentials, w.CreatedAt.Format(time.RFC3339))
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown subcommand: %s\n%s", args[0], workspaceUsage)
+	}
+}
+
+// withWorkspaceFlag は先頭の--workspaceで指定されたワークスペースをctxに載せ、残りの引数を返す
+// 指定がなければ既定のワークスペースになる
+func withWorkspaceFlag(ctx context.Context, uc *workspace.WorkspaceUsecase, name string, args []string, out io.Writer) (context.Context, []string, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(out)
+	slug := fs.String("workspace", entity.DefaultWorkspaceSlug, "slug of the workspace to operate on")
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	id, err := uc.ResolveWorkspace(ctx, *slug)
+	if err != nil {
+		return nil, nil, err
+	}
+	return repository.WithWorkspace(ctx, id), fs.Args(), nil
+}
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS public.outbox (
+  id BIGSERIAL NOT NULL,
+  aggregate_type VARCHAR(50) NOT NULL,
+  aggregate_id BIGINT NOT NULL,
+  event_type VARCHAR(100) NOT NULL,
+  payload JSONB NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT NULL,
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  delivered_at TIMESTAMPTZ NULL,
+
+  CONSTRAINT outbox_pkey PRIMARY KEY (id),
+  CONSTRAINT outbox_status_check CHECK (status IN ('pending', 'delivered', 'dead'))
+) TABLESPACE pg_default;
+
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON public.outbox (next_attempt_at, id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_outbox_aggregate_pending ON public.outbox (aggregate_type, aggregate_id, id) WHERE status = 'pending';
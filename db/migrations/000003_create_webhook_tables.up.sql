@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS public.webhook_subscriptions (
+  id BIGSERIAL NOT NULL,
+  url VARCHAR(2048) NOT NULL,
+  secret VARCHAR(255) NOT NULL,
+  event_types JSONB NOT NULL DEFAULT '[]'::jsonb,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT webhook_subscriptions_pkey PRIMARY KEY (id)
+) TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_event_types ON public.webhook_subscriptions USING gin (event_types) WHERE active;
+
+
+CREATE TABLE IF NOT EXISTS public.webhook_deliveries (
+  id BIGSERIAL NOT NULL,
+  subscription_id BIGINT NOT NULL,
+  event_type VARCHAR(100) NOT NULL,
+  payload JSONB NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  response_status INTEGER NULL,
+  last_error TEXT NULL,
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  replay_of BIGINT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  delivered_at TIMESTAMPTZ NULL,
+
+  CONSTRAINT webhook_deliveries_pkey PRIMARY KEY (id),
+  CONSTRAINT webhook_deliveries_subscription_fkey FOREIGN KEY (subscription_id)
+    REFERENCES public.webhook_subscriptions (id) ON DELETE CASCADE,
+  CONSTRAINT webhook_deliveries_replay_of_fkey FOREIGN KEY (replay_of)
+    REFERENCES public.webhook_deliveries (id) ON DELETE SET NULL,
+  CONSTRAINT webhook_deliveries_status_check CHECK (status IN ('pending', 'succeeded', 'failed'))
+) TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON public.webhook_deliveries (subscription_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON public.webhook_deliveries (next_attempt_at, id) WHERE status = 'pending';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.idx_articles_published_created_at;
+DROP TABLE IF EXISTS public.article_tags;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS public.article_tags (
+  article_id BIGINT NOT NULL,
+  tag VARCHAR(50) NOT NULL,
+
+  CONSTRAINT article_tags_pkey PRIMARY KEY (article_id, tag),
+  CONSTRAINT article_tags_article_fkey FOREIGN KEY (article_id)
+    REFERENCES public.articles (id) ON DELETE CASCADE
+) TABLESPACE pg_default;
+
+
+CREATE INDEX IF NOT EXISTS idx_article_tags_tag ON public.article_tags USING btree (tag);
+
+-- 公開フィードは公開済み・未削除の記事を新しい順に取得する
+CREATE INDEX IF NOT EXISTS idx_articles_published_created_at ON public.articles (created_at DESC) WHERE status = 'published' AND deleted_at IS NULL;
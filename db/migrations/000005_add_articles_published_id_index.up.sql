@@ -0,0 +1,2 @@
+-- サイトマップは公開済み・未削除の記事をID順に走査する
+CREATE INDEX IF NOT EXISTS idx_articles_published_id ON public.articles (id) INCLUDE (updated_at) WHERE status = 'published' AND deleted_at IS NULL;
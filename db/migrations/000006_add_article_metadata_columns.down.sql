@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS public.idx_articles_reading_time;
+
+ALTER TABLE public.articles
+  DROP COLUMN IF EXISTS reading_time_minutes,
+  DROP COLUMN IF EXISTS char_count;
@@ -0,0 +1,12 @@
+ALTER TABLE public.articles
+  ADD COLUMN IF NOT EXISTS char_count INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS reading_time_minutes INTEGER NOT NULL DEFAULT 0;
+
+-- 既存の記事は本文の文字数から概算する。記事の更新時にアプリケーション側で正確な値に置き換わる
+UPDATE public.articles
+SET
+  char_count = char_length(regexp_replace(body, '\s', '', 'g')),
+  reading_time_minutes = CEIL(char_length(regexp_replace(body, '\s', '', 'g')) / 500.0)::INTEGER
+WHERE body IS NOT NULL AND body <> '';
+
+CREATE INDEX IF NOT EXISTS idx_articles_reading_time ON public.articles USING btree (reading_time_minutes) WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.article_slug_history;
+
+ALTER TABLE public.articles DROP CONSTRAINT IF EXISTS articles_slug_key;
+ALTER TABLE public.articles DROP COLUMN IF EXISTS slug;
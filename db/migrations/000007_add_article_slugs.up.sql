@@ -0,0 +1,21 @@
+ALTER TABLE public.articles ADD COLUMN IF NOT EXISTS slug VARCHAR(100) NULL;
+
+-- 既存の記事はIDから生成する。タイトルからの生成はアプリケーション側でのみ行う
+UPDATE public.articles SET slug = 'article-' || id WHERE slug IS NULL;
+
+ALTER TABLE public.articles ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE public.articles ADD CONSTRAINT articles_slug_key UNIQUE (slug);
+
+
+CREATE TABLE IF NOT EXISTS public.article_slug_history (
+  slug VARCHAR(100) NOT NULL,
+  article_id BIGINT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT article_slug_history_pkey PRIMARY KEY (slug),
+  CONSTRAINT article_slug_history_article_fkey FOREIGN KEY (article_id)
+    REFERENCES public.articles (id) ON DELETE CASCADE
+) TABLESPACE pg_default;
+
+
+CREATE INDEX IF NOT EXISTS idx_article_slug_history_article_id ON public.article_slug_history USING btree (article_id);
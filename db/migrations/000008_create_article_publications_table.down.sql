@@ -0,0 +1,17 @@
+ALTER TABLE public.articles ADD COLUMN IF NOT EXISTS provider_type VARCHAR(50) NULL;
+ALTER TABLE public.articles ADD COLUMN IF NOT EXISTS link VARCHAR(255) NULL;
+
+-- 正規の投稿先を記事へ戻す。クロスポストは失われる
+UPDATE public.articles a
+SET provider_type = p.provider_type, link = p.link
+FROM public.article_publications p
+WHERE p.article_id = a.id AND p.is_canonical;
+
+ALTER TABLE public.articles ADD CONSTRAINT articles_provider_type_check CHECK (
+  (provider_type IS NULL) OR
+  (provider_type IN ('qiita', 'zenn', 'note'))
+);
+CREATE INDEX IF NOT EXISTS idx_articles_provider_type ON public.articles USING btree (provider_type);
+CREATE INDEX IF NOT EXISTS idx_articles_provider_active ON public.articles (provider_type, status) WHERE deleted_at IS NULL;
+
+DROP TABLE IF EXISTS public.article_publications;
@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS public.article_publications (
+  id BIGSERIAL NOT NULL,
+  article_id BIGINT NOT NULL,
+  provider_type VARCHAR(50) NULL,
+  link VARCHAR(255) NULL,
+  external_id VARCHAR(255) NULL,
+  published_at TIMESTAMPTZ NULL,
+  sync_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+  is_canonical BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT article_publications_pkey PRIMARY KEY (id),
+  CONSTRAINT article_publications_article_fkey FOREIGN KEY (article_id)
+    REFERENCES public.articles (id) ON DELETE CASCADE,
+  CONSTRAINT article_publications_target_check CHECK (provider_type IS NOT NULL OR link IS NOT NULL),
+  CONSTRAINT article_publications_provider_type_check CHECK (
+    (provider_type IS NULL) OR
+    (provider_type IN ('qiita', 'zenn', 'note'))
+  ),
+  CONSTRAINT article_publications_sync_status_check CHECK (sync_status IN ('pending', 'synced', 'failed'))
+) TABLESPACE pg_default;
+
+
+-- 正規の投稿先は記事ごとに1件
+CREATE UNIQUE INDEX IF NOT EXISTS idx_article_publications_canonical ON public.article_publications (article_id) WHERE is_canonical;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_article_publications_article_link ON public.article_publications (article_id, link) WHERE link IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_article_publications_provider_type ON public.article_publications USING btree (provider_type, article_id);
+
+
+-- 既存のプロバイダとリンクを正規の投稿先として移行する
+INSERT INTO public.article_publications (article_id, provider_type, link, sync_status, is_canonical, created_at, updated_at)
+SELECT id, provider_type, link, CASE WHEN link IS NULL THEN 'pending' ELSE 'synced' END, TRUE, created_at, updated_at
+FROM public.articles
+WHERE provider_type IS NOT NULL OR link IS NOT NULL;
+
+DROP INDEX IF EXISTS public.idx_articles_provider_type;
+DROP INDEX IF EXISTS public.idx_articles_provider_active;
+ALTER TABLE public.articles DROP CONSTRAINT IF EXISTS articles_provider_type_check;
+ALTER TABLE public.articles DROP COLUMN IF EXISTS provider_type;
+ALTER TABLE public.articles DROP COLUMN IF EXISTS link;
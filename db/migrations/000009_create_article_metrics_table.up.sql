@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS public.article_metrics (
+  id BIGSERIAL NOT NULL,
+  article_id BIGINT NOT NULL,
+  publication_id BIGINT NOT NULL,
+  provider_type VARCHAR(50) NOT NULL,
+  likes INTEGER NOT NULL DEFAULT 0,
+  stocks INTEGER NOT NULL DEFAULT 0,
+  bookmarks INTEGER NOT NULL DEFAULT 0,
+  collected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT article_metrics_pkey PRIMARY KEY (id),
+  CONSTRAINT article_metrics_article_fkey FOREIGN KEY (article_id)
+    REFERENCES public.articles (id) ON DELETE CASCADE,
+  CONSTRAINT article_metrics_publication_fkey FOREIGN KEY (publication_id)
+    REFERENCES public.article_publications (id) ON DELETE CASCADE,
+  CONSTRAINT article_metrics_counters_check CHECK (likes >= 0 AND stocks >= 0 AND bookmarks >= 0)
+) TABLESPACE pg_default;
+
+
+CREATE INDEX IF NOT EXISTS idx_article_metrics_article_collected_at ON public.article_metrics USING btree (article_id, collected_at);
+-- 期間内の最初と最後のスナップショットを投稿先ごとに求める
+CREATE INDEX IF NOT EXISTS idx_article_metrics_collected_at_publication ON public.article_metrics USING btree (collected_at, publication_id);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS public.link_checks (
+  id BIGSERIAL NOT NULL,
+  article_id BIGINT NOT NULL,
+  url TEXT NOT NULL,
+  source VARCHAR(20) NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  status_code INTEGER,
+  redirects JSONB NOT NULL DEFAULT '[]'::jsonb,
+  last_error TEXT,
+  checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT link_checks_pkey PRIMARY KEY (id),
+  CONSTRAINT link_checks_article_fkey FOREIGN KEY (article_id)
+    REFERENCES public.articles (id) ON DELETE CASCADE,
+  CONSTRAINT link_checks_article_url_key UNIQUE (article_id, url),
+  CONSTRAINT link_checks_source_check CHECK (source IN ('publication', 'body')),
+  CONSTRAINT link_checks_status_check CHECK (status IN ('ok', 'broken', 'skipped'))
+) TABLESPACE pg_default;
+
+
+-- リンク切れのレポートと記事検索の絞り込みに利用する
+CREATE INDEX IF NOT EXISTS idx_link_checks_broken ON public.link_checks USING btree (article_id) WHERE status = 'broken';
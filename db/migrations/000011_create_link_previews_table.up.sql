@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS public.link_previews (
+  id BIGSERIAL NOT NULL,
+  url TEXT NOT NULL,
+  title TEXT NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  image TEXT NOT NULL DEFAULT '',
+  site_name TEXT NOT NULL DEFAULT '',
+  status VARCHAR(20) NOT NULL,
+  last_error TEXT,
+  fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT link_previews_pkey PRIMARY KEY (id),
+  CONSTRAINT link_previews_url_key UNIQUE (url),
+  CONSTRAINT link_previews_status_check CHECK (status IN ('fetched', 'failed'))
+) TABLESPACE pg_default;
+
+
+-- 取得し直す対象を取得日時の古い順に探す
+CREATE INDEX IF NOT EXISTS idx_link_previews_status_fetched_at ON public.link_previews USING btree (status, fetched_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.idx_articles_title_trgm;
+DROP INDEX IF EXISTS public.idx_articles_normalized_link;
+ALTER TABLE public.articles DROP COLUMN IF EXISTS normalized_link;
@@ -0,0 +1,30 @@
+ALTER TABLE public.articles ADD COLUMN IF NOT EXISTS normalized_link TEXT NULL;
+
+-- 既存の記事は正規の投稿先のリンクから概算する(スキームとホストの正規化、フラグメントと末尾のスラッシュの除去)
+-- 記事の更新時にアプリケーション側で正確な値に置き換わる
+UPDATE public.articles a
+SET normalized_link = rtrim(
+    regexp_replace(lower(substring(p.link from '^[A-Za-z][A-Za-z0-9+.-]*://[^/?#]+')), '^http://', 'https://')
+      || coalesce(substring(p.link from '^[A-Za-z][A-Za-z0-9+.-]*://[^/?#]+([^?#]*)'), ''),
+    '/'
+  ) || coalesce(substring(p.link from '(\?[^#]*)'), '')
+FROM public.article_publications p
+WHERE p.article_id = a.id AND p.is_canonical AND p.link IS NOT NULL;
+
+-- 既に重複している記事は最も古い記事だけに値を残す
+-- 残りの記事はリンクを変更するまで更新時に重複として扱われる
+UPDATE public.articles a
+SET normalized_link = NULL
+WHERE a.deleted_at IS NULL
+  AND EXISTS (
+    SELECT 1 FROM public.articles o
+    WHERE o.normalized_link = a.normalized_link AND o.deleted_at IS NULL AND o.id < a.id
+  );
+
+-- 未削除の記事の間でリンクは一意
+CREATE UNIQUE INDEX IF NOT EXISTS idx_articles_normalized_link ON public.articles (normalized_link) WHERE deleted_at IS NULL AND normalized_link IS NOT NULL;
+
+
+-- タイトルの類似度(トライグラム)で重複候補を探す
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_articles_title_trgm ON public.articles USING gin (title gin_trgm_ops) WHERE deleted_at IS NULL;
@@ -0,0 +1,5 @@
+-- 追加したステータスの記事は下書きに戻す
+UPDATE public.articles SET status = 'draft' WHERE status NOT IN ('draft', 'published');
+
+ALTER TABLE public.articles DROP CONSTRAINT IF EXISTS articles_status_check;
+ALTER TABLE public.articles ADD CONSTRAINT articles_status_check CHECK (status IN ('draft', 'published'));
@@ -0,0 +1,2 @@
+ALTER TABLE public.articles DROP CONSTRAINT IF EXISTS articles_status_check;
+ALTER TABLE public.articles ADD CONSTRAINT articles_status_check CHECK (status IN ('draft', 'scheduled', 'published', 'unlisted', 'archived'));
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS public.api_keys (
+  id BIGSERIAL NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  -- キーの検索に使う公開部分(シークレットはハッシュだけを保存する)
+  prefix VARCHAR(32) NOT NULL,
+  secret_hash TEXT NOT NULL,
+  scopes JSONB NOT NULL DEFAULT '[]'::jsonb,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_used_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+
+  CONSTRAINT api_keys_pkey PRIMARY KEY (id),
+  CONSTRAINT api_keys_prefix_key UNIQUE (prefix)
+) TABLESPACE pg_default;
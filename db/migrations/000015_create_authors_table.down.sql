@@ -0,0 +1,6 @@
+ALTER TABLE public.api_keys DROP COLUMN IF EXISTS author_id;
+
+DROP INDEX IF EXISTS public.articles_author_id_idx;
+ALTER TABLE public.articles DROP COLUMN IF EXISTS author_id;
+
+DROP TABLE IF EXISTS public.authors;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS public.authors (
+  id BIGSERIAL NOT NULL,
+  display_name VARCHAR(100) NOT NULL,
+  -- プロバイダ名からユーザー名への対応(例: {"qiita": "alice"})
+  provider_usernames JSONB NOT NULL DEFAULT '{}'::jsonb,
+  -- 執筆者として認証するIdPのsubject
+  subject TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT authors_pkey PRIMARY KEY (id),
+  CONSTRAINT authors_subject_key UNIQUE (subject)
+) TABLESPACE pg_default;
+
+ALTER TABLE public.articles
+  ADD COLUMN IF NOT EXISTS author_id BIGINT,
+  ADD CONSTRAINT articles_author_id_fkey FOREIGN KEY (author_id) REFERENCES public.authors (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS articles_author_id_idx ON public.articles (author_id);
+
+ALTER TABLE public.api_keys
+  ADD COLUMN IF NOT EXISTS author_id BIGINT,
+  ADD CONSTRAINT api_keys_author_id_fkey FOREIGN KEY (author_id) REFERENCES public.authors (id) ON DELETE SET NULL;
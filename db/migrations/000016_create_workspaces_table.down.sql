@@ -0,0 +1,83 @@
+DROP POLICY IF EXISTS webhook_deliveries_workspace_isolation ON public.webhook_deliveries;
+ALTER TABLE public.webhook_deliveries NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE public.webhook_deliveries DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS link_checks_workspace_isolation ON public.link_checks;
+ALTER TABLE public.link_checks NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE public.link_checks DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS article_metrics_workspace_isolation ON public.article_metrics;
+ALTER TABLE public.article_metrics NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE public.article_metrics DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS article_publications_workspace_isolation ON public.article_publications;
+ALTER TABLE public.article_publications NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE public.article_publications DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS article_tags_workspace_isolation ON public.article_tags;
+ALTER TABLE public.article_tags NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE public.article_tags DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS article_slug_history_workspace_isolation ON public.article_slug_history;
+ALTER TABLE public.article_slug_history NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE public.article_slug_history DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS webhook_subscriptions_workspace_isolation ON public.webhook_subscriptions;
+ALTER TABLE public.webhook_subscriptions NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE public.webhook_subscriptions DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS api_keys_workspace_isolation ON public.api_keys;
+ALTER TABLE public.api_keys NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE public.api_keys DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS authors_workspace_isolation ON public.authors;
+ALTER TABLE public.authors NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE public.authors DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS articles_workspace_isolation ON public.articles;
+ALTER TABLE public.articles NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE public.articles DISABLE ROW LEVEL SECURITY;
+
+DROP FUNCTION IF EXISTS public.workspace_visible(BIGINT);
+
+
+-- ワークスペースをまたいで重複している値は元の一意制約を満たさないため、既定のワークスペース以外のデータを先に削除する
+DELETE FROM public.api_keys WHERE workspace_id <> 1;
+DELETE FROM public.webhook_subscriptions WHERE workspace_id <> 1;
+DELETE FROM public.articles WHERE workspace_id <> 1;
+DELETE FROM public.authors WHERE workspace_id <> 1;
+DELETE FROM public.article_slug_history WHERE workspace_id <> 1;
+
+ALTER TABLE public.api_keys DROP CONSTRAINT IF EXISTS api_keys_author_id_fkey;
+ALTER TABLE public.api_keys ADD CONSTRAINT api_keys_author_id_fkey FOREIGN KEY (author_id)
+  REFERENCES public.authors (id) ON DELETE SET NULL;
+
+ALTER TABLE public.articles DROP CONSTRAINT IF EXISTS articles_author_id_fkey;
+ALTER TABLE public.articles ADD CONSTRAINT articles_author_id_fkey FOREIGN KEY (author_id)
+  REFERENCES public.authors (id) ON DELETE SET NULL;
+
+ALTER TABLE public.authors DROP CONSTRAINT IF EXISTS authors_workspace_id_id_key;
+
+ALTER TABLE public.article_slug_history DROP CONSTRAINT IF EXISTS article_slug_history_pkey;
+ALTER TABLE public.article_slug_history ADD CONSTRAINT article_slug_history_pkey PRIMARY KEY (slug);
+
+ALTER TABLE public.authors DROP CONSTRAINT IF EXISTS authors_subject_key;
+ALTER TABLE public.authors ADD CONSTRAINT authors_subject_key UNIQUE (subject);
+
+DROP INDEX IF EXISTS public.idx_articles_normalized_link;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_articles_normalized_link ON public.articles (normalized_link) WHERE deleted_at IS NULL AND normalized_link IS NOT NULL;
+
+ALTER TABLE public.articles DROP CONSTRAINT IF EXISTS articles_slug_key;
+ALTER TABLE public.articles ADD CONSTRAINT articles_slug_key UNIQUE (slug);
+
+DROP INDEX IF EXISTS public.webhook_subscriptions_workspace_id_idx;
+DROP INDEX IF EXISTS public.api_keys_workspace_id_idx;
+DROP INDEX IF EXISTS public.articles_workspace_id_idx;
+
+ALTER TABLE public.article_slug_history DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE public.webhook_subscriptions DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE public.api_keys DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE public.authors DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE public.articles DROP COLUMN IF EXISTS workspace_id;
+
+DROP TABLE IF EXISTS public.workspaces;
@@ -0,0 +1,132 @@
+CREATE TABLE IF NOT EXISTS public.workspaces (
+  id BIGSERIAL NOT NULL,
+  -- サブドメインとX-Workspaceヘッダで指定する識別子
+  slug VARCHAR(63) NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  -- フィードのタイトルなど、サーバー全体の設定を上書きする値
+  settings JSONB NOT NULL DEFAULT '{}'::jsonb,
+  -- プロバイダ名からアクセストークンへの対応(例: {"qiita": "..."})
+  provider_credentials JSONB NOT NULL DEFAULT '{}'::jsonb,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT workspaces_pkey PRIMARY KEY (id),
+  CONSTRAINT workspaces_slug_key UNIQUE (slug)
+) TABLESPACE pg_default;
+
+-- 既存のデータは既定のワークスペースに属する
+INSERT INTO public.workspaces (id, slug, name) VALUES (1, 'default', 'Default') ON CONFLICT (id) DO NOTHING;
+SELECT setval(pg_get_serial_sequence('public.workspaces', 'id'), GREATEST((SELECT MAX(id) FROM public.workspaces), 1));
+
+
+-- ワークスペースごとのデータを持つテーブル
+ALTER TABLE public.articles ADD COLUMN IF NOT EXISTS workspace_id BIGINT NOT NULL DEFAULT 1
+  CONSTRAINT articles_workspace_id_fkey REFERENCES public.workspaces (id);
+ALTER TABLE public.authors ADD COLUMN IF NOT EXISTS workspace_id BIGINT NOT NULL DEFAULT 1
+  CONSTRAINT authors_workspace_id_fkey REFERENCES public.workspaces (id);
+ALTER TABLE public.api_keys ADD COLUMN IF NOT EXISTS workspace_id BIGINT NOT NULL DEFAULT 1
+  CONSTRAINT api_keys_workspace_id_fkey REFERENCES public.workspaces (id);
+ALTER TABLE public.webhook_subscriptions ADD COLUMN IF NOT EXISTS workspace_id BIGINT NOT NULL DEFAULT 1
+  CONSTRAINT webhook_subscriptions_workspace_id_fkey REFERENCES public.workspaces (id);
+ALTER TABLE public.article_slug_history ADD COLUMN IF NOT EXISTS workspace_id BIGINT NOT NULL DEFAULT 1
+  CONSTRAINT article_slug_history_workspace_id_fkey REFERENCES public.workspaces (id);
+
+-- 値はアプリケーションが必ず指定する。既定値は既存の行の移行にだけ使う
+ALTER TABLE public.articles ALTER COLUMN workspace_id DROP DEFAULT;
+ALTER TABLE public.authors ALTER COLUMN workspace_id DROP DEFAULT;
+ALTER TABLE public.api_keys ALTER COLUMN workspace_id DROP DEFAULT;
+ALTER TABLE public.webhook_subscriptions ALTER COLUMN workspace_id DROP DEFAULT;
+ALTER TABLE public.article_slug_history ALTER COLUMN workspace_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS articles_workspace_id_idx ON public.articles (workspace_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS api_keys_workspace_id_idx ON public.api_keys (workspace_id);
+CREATE INDEX IF NOT EXISTS webhook_subscriptions_workspace_id_idx ON public.webhook_subscriptions (workspace_id);
+
+
+-- 一意性はワークスペースごとに判定する(制約名はアプリケーションのエラー判定に使うため変えない)
+ALTER TABLE public.articles DROP CONSTRAINT IF EXISTS articles_slug_key;
+ALTER TABLE public.articles ADD CONSTRAINT articles_slug_key UNIQUE (workspace_id, slug);
+
+DROP INDEX IF EXISTS public.idx_articles_normalized_link;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_articles_normalized_link ON public.articles (workspace_id, normalized_link) WHERE deleted_at IS NULL AND normalized_link IS NOT NULL;
+
+ALTER TABLE public.authors DROP CONSTRAINT IF EXISTS authors_subject_key;
+ALTER TABLE public.authors ADD CONSTRAINT authors_subject_key UNIQUE (workspace_id, subject);
+
+ALTER TABLE public.article_slug_history DROP CONSTRAINT IF EXISTS article_slug_history_pkey;
+ALTER TABLE public.article_slug_history ADD CONSTRAINT article_slug_history_pkey PRIMARY KEY (workspace_id, slug);
+
+
+-- 記事とAPIキーは同じワークスペースの執筆者だけを参照できる
+ALTER TABLE public.authors ADD CONSTRAINT authors_workspace_id_id_key UNIQUE (workspace_id, id);
+
+ALTER TABLE public.articles DROP CONSTRAINT IF EXISTS articles_author_id_fkey;
+ALTER TABLE public.articles ADD CONSTRAINT articles_author_id_fkey FOREIGN KEY (workspace_id, author_id)
+  REFERENCES public.authors (workspace_id, id) ON DELETE SET NULL (author_id);
+
+ALTER TABLE public.api_keys DROP CONSTRAINT IF EXISTS api_keys_author_id_fkey;
+ALTER TABLE public.api_keys ADD CONSTRAINT api_keys_author_id_fkey FOREIGN KEY (workspace_id, author_id)
+  REFERENCES public.authors (workspace_id, id) ON DELETE SET NULL (author_id);
+
+
+-- 行レベルセキュリティ
+-- アプリケーションはトランザクションの開始時にapp.workspace_id(またはワークスペースをまたぐ処理ではapp.all_workspaces)を設定する
+-- 設定のない接続(トランザクション外の読み取りやマイグレーション)はアプリケーション側の絞り込みに任せる
+-- スーパーユーザーとBYPASSRLS属性を持つロールには適用されないため、アプリケーションは専用のロールで接続すること
+CREATE OR REPLACE FUNCTION public.workspace_visible(ws BIGINT) RETURNS BOOLEAN
+LANGUAGE sql STABLE AS $$
+  SELECT COALESCE(current_setting('app.all_workspaces', true), '') = 'on'
+    OR COALESCE(current_setting('app.workspace_id', true), '') = ''
+    OR ws = NULLIF(current_setting('app.workspace_id', true), '')::BIGINT
+$$;
+
+ALTER TABLE public.articles ENABLE ROW LEVEL SECURITY;
+ALTER TABLE public.articles FORCE ROW LEVEL SECURITY;
+CREATE POLICY articles_workspace_isolation ON public.articles
+  USING (public.workspace_visible(workspace_id));
+
+ALTER TABLE public.authors ENABLE ROW LEVEL SECURITY;
+ALTER TABLE public.authors FORCE ROW LEVEL SECURITY;
+CREATE POLICY authors_workspace_isolation ON public.authors
+  USING (public.workspace_visible(workspace_id));
+
+ALTER TABLE public.api_keys ENABLE ROW LEVEL SECURITY;
+ALTER TABLE public.api_keys FORCE ROW LEVEL SECURITY;
+CREATE POLICY api_keys_workspace_isolation ON public.api_keys
+  USING (public.workspace_visible(workspace_id));
+
+ALTER TABLE public.webhook_subscriptions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE public.webhook_subscriptions FORCE ROW LEVEL SECURITY;
+CREATE POLICY webhook_subscriptions_workspace_isolation ON public.webhook_subscriptions
+  USING (public.workspace_visible(workspace_id));
+
+ALTER TABLE public.article_slug_history ENABLE ROW LEVEL SECURITY;
+ALTER TABLE public.article_slug_history FORCE ROW LEVEL SECURITY;
+CREATE POLICY article_slug_history_workspace_isolation ON public.article_slug_history
+  USING (public.workspace_visible(workspace_id));
+
+-- 記事に従属するテーブルは、参照できる記事の行だけを参照できる
+ALTER TABLE public.article_tags ENABLE ROW LEVEL SECURITY;
+ALTER TABLE public.article_tags FORCE ROW LEVEL SECURITY;
+CREATE POLICY article_tags_workspace_isolation ON public.article_tags
+  USING (EXISTS (SELECT 1 FROM public.articles a WHERE a.id = article_id));
+
+ALTER TABLE public.article_publications ENABLE ROW LEVEL SECURITY;
+ALTER TABLE public.article_publications FORCE ROW LEVEL SECURITY;
+CREATE POLICY article_publications_workspace_isolation ON public.article_publications
+  USING (EXISTS (SELECT 1 FROM public.articles a WHERE a.id = article_id));
+
+ALTER TABLE public.article_metrics ENABLE ROW LEVEL SECURITY;
+ALTER TABLE public.article_metrics FORCE ROW LEVEL SECURITY;
+CREATE POLICY article_metrics_workspace_isolation ON public.article_metrics
+  USING (EXISTS (SELECT 1 FROM public.articles a WHERE a.id = article_id));
+
+ALTER TABLE public.link_checks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE public.link_checks FORCE ROW LEVEL SECURITY;
+CREATE POLICY link_checks_workspace_isolation ON public.link_checks
+  USING (EXISTS (SELECT 1 FROM public.articles a WHERE a.id = article_id));
+
+ALTER TABLE public.webhook_deliveries ENABLE ROW LEVEL SECURITY;
+ALTER TABLE public.webhook_deliveries FORCE ROW LEVEL SECURITY;
+CREATE POLICY webhook_deliveries_workspace_isolation ON public.webhook_deliveries
+  USING (EXISTS (SELECT 1 FROM public.webhook_subscriptions s WHERE s.id = subscription_id));
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS public.review_comments;
+DROP TABLE IF EXISTS public.review_requests;
+
+ALTER TABLE public.articles DROP CONSTRAINT IF EXISTS articles_workspace_id_id_key;
+ALTER TABLE public.articles DROP COLUMN IF EXISTS approved_at;
+
+-- レビュー中の記事は下書きに戻す
+UPDATE public.articles SET status = 'draft' WHERE status = 'in_review';
+
+ALTER TABLE public.articles DROP CONSTRAINT IF EXISTS articles_status_check;
+ALTER TABLE public.articles ADD CONSTRAINT articles_status_check CHECK (status IN ('draft', 'scheduled', 'published', 'unlisted', 'archived'));
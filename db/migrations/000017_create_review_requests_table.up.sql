@@ -0,0 +1,71 @@
+ALTER TABLE public.articles DROP CONSTRAINT IF EXISTS articles_status_check;
+ALTER TABLE public.articles ADD CONSTRAINT articles_status_check CHECK (status IN ('draft', 'in_review', 'scheduled', 'published', 'unlisted', 'archived'));
+
+-- レビューで承認された日時(下書きに戻すと取り消される)
+ALTER TABLE public.articles ADD COLUMN IF NOT EXISTS approved_at TIMESTAMPTZ;
+
+-- レビュー依頼は同じワークスペースの記事だけを参照できる
+ALTER TABLE public.articles ADD CONSTRAINT articles_workspace_id_id_key UNIQUE (workspace_id, id);
+
+CREATE TABLE IF NOT EXISTS public.review_requests (
+  id BIGSERIAL NOT NULL,
+  workspace_id BIGINT NOT NULL,
+  article_id BIGINT NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  submitted_by_name VARCHAR(255) NOT NULL,
+  submitted_by_author_id BIGINT,
+  -- 承認・差し戻し・取り下げした呼び出し元と日時
+  decided_by_name VARCHAR(255),
+  decided_by_author_id BIGINT,
+  decided_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT review_requests_pkey PRIMARY KEY (id),
+  CONSTRAINT review_requests_workspace_id_id_key UNIQUE (workspace_id, id),
+  CONSTRAINT review_requests_status_check CHECK (status IN ('pending', 'approved', 'changes_requested', 'withdrawn')),
+  CONSTRAINT review_requests_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES public.workspaces (id),
+  CONSTRAINT review_requests_article_id_fkey FOREIGN KEY (workspace_id, article_id)
+    REFERENCES public.articles (workspace_id, id) ON DELETE CASCADE,
+  CONSTRAINT review_requests_submitted_by_author_id_fkey FOREIGN KEY (workspace_id, submitted_by_author_id)
+    REFERENCES public.authors (workspace_id, id) ON DELETE SET NULL (submitted_by_author_id),
+  CONSTRAINT review_requests_decided_by_author_id_fkey FOREIGN KEY (workspace_id, decided_by_author_id)
+    REFERENCES public.authors (workspace_id, id) ON DELETE SET NULL (decided_by_author_id)
+) TABLESPACE pg_default;
+
+-- 記事ごとに判断待ちの依頼は1件まで(承認済みの依頼は記事の公開後も履歴として残る)
+CREATE UNIQUE INDEX IF NOT EXISTS review_requests_pending_article_key ON public.review_requests (article_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS review_requests_article_id_idx ON public.review_requests (article_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS review_requests_status_idx ON public.review_requests (workspace_id, status, created_at);
+
+CREATE TABLE IF NOT EXISTS public.review_comments (
+  id BIGSERIAL NOT NULL,
+  workspace_id BIGINT NOT NULL,
+  review_request_id BIGINT NOT NULL,
+  -- スレッドの先頭のコメント(返信の場合)
+  parent_id BIGINT,
+  commenter_name VARCHAR(255) NOT NULL,
+  commenter_author_id BIGINT,
+  body TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT review_comments_pkey PRIMARY KEY (id),
+  CONSTRAINT review_comments_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES public.workspaces (id),
+  CONSTRAINT review_comments_review_request_id_fkey FOREIGN KEY (workspace_id, review_request_id)
+    REFERENCES public.review_requests (workspace_id, id) ON DELETE CASCADE,
+  CONSTRAINT review_comments_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES public.review_comments (id) ON DELETE CASCADE,
+  CONSTRAINT review_comments_commenter_author_id_fkey FOREIGN KEY (workspace_id, commenter_author_id)
+    REFERENCES public.authors (workspace_id, id) ON DELETE SET NULL (commenter_author_id)
+) TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS review_comments_review_request_id_idx ON public.review_comments (review_request_id, id);
+
+ALTER TABLE public.review_requests ENABLE ROW LEVEL SECURITY;
+ALTER TABLE public.review_requests FORCE ROW LEVEL SECURITY;
+CREATE POLICY review_requests_workspace_isolation ON public.review_requests
+  USING (public.workspace_visible(workspace_id));
+
+ALTER TABLE public.review_comments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE public.review_comments FORCE ROW LEVEL SECURITY;
+CREATE POLICY review_comments_workspace_isolation ON public.review_comments
+  USING (public.workspace_visible(workspace_id));
@@ -0,0 +1,45 @@
+CREATE TABLE IF NOT EXISTS public.inline_comments (
+  id BIGSERIAL NOT NULL,
+  workspace_id BIGINT NOT NULL,
+  article_id BIGINT NOT NULL,
+  -- スレッドの先頭のコメント(返信の場合)
+  parent_id BIGINT,
+  -- コメントを付けた本文の範囲(文字単位のオフセット)と、範囲を探し直すための引用と前後の文脈
+  anchor_start INTEGER,
+  anchor_end INTEGER,
+  anchor_quote TEXT,
+  anchor_prefix TEXT NOT NULL DEFAULT '',
+  anchor_suffix TEXT NOT NULL DEFAULT '',
+  -- 本文の変更で引用が見つからなくなったか
+  outdated BOOLEAN NOT NULL DEFAULT FALSE,
+  commenter_name VARCHAR(255) NOT NULL,
+  commenter_author_id BIGINT,
+  body TEXT NOT NULL,
+  resolved_by_name VARCHAR(255),
+  resolved_by_author_id BIGINT,
+  resolved_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT inline_comments_pkey PRIMARY KEY (id),
+  -- スレッドの先頭のコメントだけが範囲を持つ
+  CONSTRAINT inline_comments_anchor_check CHECK (
+    (parent_id IS NULL AND anchor_start IS NOT NULL AND anchor_end > anchor_start AND anchor_quote IS NOT NULL)
+    OR (parent_id IS NOT NULL AND anchor_start IS NULL AND anchor_end IS NULL AND anchor_quote IS NULL)
+  ),
+  CONSTRAINT inline_comments_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES public.workspaces (id),
+  CONSTRAINT inline_comments_article_id_fkey FOREIGN KEY (workspace_id, article_id)
+    REFERENCES public.articles (workspace_id, id) ON DELETE CASCADE,
+  CONSTRAINT inline_comments_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES public.inline_comments (id) ON DELETE CASCADE,
+  CONSTRAINT inline_comments_commenter_author_id_fkey FOREIGN KEY (workspace_id, commenter_author_id)
+    REFERENCES public.authors (workspace_id, id) ON DELETE SET NULL (commenter_author_id),
+  CONSTRAINT inline_comments_resolved_by_author_id_fkey FOREIGN KEY (workspace_id, resolved_by_author_id)
+    REFERENCES public.authors (workspace_id, id) ON DELETE SET NULL (resolved_by_author_id)
+) TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS inline_comments_article_id_idx ON public.inline_comments (article_id, id);
+
+ALTER TABLE public.inline_comments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE public.inline_comments FORCE ROW LEVEL SECURITY;
+CREATE POLICY inline_comments_workspace_isolation ON public.inline_comments
+  USING (public.workspace_visible(workspace_id));
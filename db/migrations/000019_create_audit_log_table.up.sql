@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS public.audit_log (
+  id BIGSERIAL NOT NULL,
+  workspace_id BIGINT NOT NULL,
+  -- 記事を完全に削除した後もログを残すため、articlesへの外部キーは持たない
+  article_id BIGINT NOT NULL,
+  action VARCHAR(20) NOT NULL,
+  actor_name VARCHAR(255) NOT NULL,
+  actor_subject VARCHAR(255) NOT NULL DEFAULT '',
+  actor_key_id BIGINT,
+  actor_author_id BIGINT,
+  -- 変更されたフィールドごとの変更前後の値 {"title": {"before": ..., "after": ...}}
+  changes JSONB NOT NULL DEFAULT '{}'::jsonb,
+  request_id VARCHAR(255) NOT NULL DEFAULT '',
+  ip VARCHAR(64) NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT audit_log_pkey PRIMARY KEY (id),
+  CONSTRAINT audit_log_action_check CHECK (action IN ('create', 'update', 'publish', 'delete', 'restore')),
+  CONSTRAINT audit_log_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES public.workspaces (id)
+) TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS audit_log_article_id_idx ON public.audit_log (article_id, id);
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON public.audit_log (created_at);
+
+-- ログは追記のみとし、記録後の変更・削除を拒否する
+CREATE OR REPLACE FUNCTION public.audit_log_reject_change() RETURNS TRIGGER
+  LANGUAGE plpgsql AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$;
+
+CREATE TRIGGER audit_log_append_only
+  BEFORE UPDATE OR DELETE ON public.audit_log
+  FOR EACH ROW EXECUTE FUNCTION public.audit_log_reject_change();
+
+CREATE TRIGGER audit_log_no_truncate
+  BEFORE TRUNCATE ON public.audit_log
+  FOR EACH STATEMENT EXECUTE FUNCTION public.audit_log_reject_change();
+
+ALTER TABLE public.audit_log ENABLE ROW LEVEL SECURITY;
+ALTER TABLE public.audit_log FORCE ROW LEVEL SECURITY;
+CREATE POLICY audit_log_workspace_isolation ON public.audit_log
+  USING (public.workspace_visible(workspace_id));
@@ -0,0 +1,5 @@
+ALTER TABLE public.audit_log DROP CONSTRAINT IF EXISTS audit_log_action_check;
+ALTER TABLE public.audit_log ADD CONSTRAINT audit_log_action_check
+  CHECK (action IN ('create', 'update', 'publish', 'delete', 'restore')) NOT VALID;
+
+DROP INDEX IF EXISTS public.articles_deleted_at_idx;
@@ -0,0 +1,7 @@
+-- 保持期間を過ぎた論理削除済みの記事を探すためのインデックス
+CREATE INDEX IF NOT EXISTS articles_deleted_at_idx ON public.articles (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- 監査ログにゴミ箱からの完全な削除を記録する
+ALTER TABLE public.audit_log DROP CONSTRAINT IF EXISTS audit_log_action_check;
+ALTER TABLE public.audit_log ADD CONSTRAINT audit_log_action_check
+  CHECK (action IN ('create', 'update', 'publish', 'delete', 'restore', 'purge'));
@@ -0,0 +1,6 @@
+CREATE OR REPLACE FUNCTION public.workspace_visible(ws BIGINT) RETURNS BOOLEAN
+LANGUAGE sql STABLE AS $$
+  SELECT COALESCE(current_setting('app.all_workspaces', true), '') = 'on'
+    OR COALESCE(current_setting('app.workspace_id', true), '') = ''
+    OR ws = NULLIF(current_setting('app.workspace_id', true), '')::BIGINT
+$$;
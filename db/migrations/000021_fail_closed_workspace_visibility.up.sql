@@ -0,0 +1,8 @@
+-- 行レベルセキュリティを、ワークスペースが設定されていない接続では行を見せないようにする
+-- アプリケーションはトランザクション外の操作もトランザクションで囲み、app.workspace_idを設定する
+-- ワークスペースをまたぐバックグラウンドの処理とマイグレーションでのデータの移行はapp.all_workspacesを設定する
+CREATE OR REPLACE FUNCTION public.workspace_visible(ws BIGINT) RETURNS BOOLEAN
+LANGUAGE sql STABLE AS $$
+  SELECT COALESCE(current_setting('app.all_workspaces', true), '') = 'on'
+    OR COALESCE(ws = NULLIF(current_setting('app.workspace_id', true), '')::BIGINT, FALSE)
+$$;
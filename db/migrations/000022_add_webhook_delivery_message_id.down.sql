@@ -0,0 +1,2 @@
+ALTER TABLE public.webhook_deliveries DROP CONSTRAINT IF EXISTS webhook_deliveries_message_subscription_key;
+ALTER TABLE public.webhook_deliveries DROP COLUMN IF EXISTS message_id;
@@ -0,0 +1,5 @@
+-- 配信の元になったアウトボックスのメッセージ(再送やメッセージ以外から作成した配信はNULL)
+ALTER TABLE public.webhook_deliveries ADD COLUMN IF NOT EXISTS message_id BIGINT NULL;
+
+-- 同じメッセージを再び処理しても購読ごとの配信は1件だけ作成する(NULLは重複として扱われない)
+ALTER TABLE public.webhook_deliveries ADD CONSTRAINT webhook_deliveries_message_subscription_key UNIQUE (message_id, subscription_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.idx_articles_published_at;
+ALTER TABLE public.articles DROP COLUMN IF EXISTS published_at;
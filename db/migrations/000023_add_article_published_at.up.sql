@@ -0,0 +1,19 @@
+-- 記事が最初に公開された日時(フィードの公開日時に使う。公開状態でなくなっても保持する)
+ALTER TABLE public.articles ADD COLUMN IF NOT EXISTS published_at TIMESTAMPTZ NULL;
+
+-- 既存の記事は、公開イベントが残っていればその日時、なければ正規の投稿先の公開日時、
+-- 公開中の記事でどちらもなければ作成日時で埋める
+BEGIN;
+SELECT set_config('app.all_workspaces', 'on', true);
+UPDATE public.articles a
+SET published_at = COALESCE(
+  (SELECT MIN(o.created_at) FROM public.outbox o
+    WHERE o.aggregate_type = 'article' AND o.aggregate_id = a.id AND o.event_type = 'article.published'),
+  (SELECT p.published_at FROM public.article_publications p
+    WHERE p.article_id = a.id AND p.is_canonical),
+  CASE WHEN a.status = 'published' THEN a.created_at END
+)
+WHERE a.published_at IS NULL;
+COMMIT;
+
+CREATE INDEX IF NOT EXISTS idx_articles_published_at ON public.articles USING btree (published_at DESC);
@@ -4,14 +4,29 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 // アプリケーションの全体設定を保持する。
 type Config struct {
-	AppEnv   string `mapstructure:"APP_ENV"`
-	Database DatabaseConfig
+	AppEnv    string `mapstructure:"APP_ENV"`
+	Database  DatabaseConfig
+	Outbox    OutboxConfig
+	Webhook   WebhookConfig
+	Feed      FeedConfig
+	Sitemap   SitemapConfig
+	Markdown  MarkdownConfig
+	Article   ArticleConfig
+	Metrics   MetricsConfig
+	LinkCheck LinkCheckConfig
+	OGP       OGPConfig
+	Auth      AuthConfig
+	OIDC      OIDCConfig
+	Tenant    TenantConfig
+	Audit     AuditConfig
+	Trash     TrashConfig
 }
 
 // データベース接続設定を保持する。
@@ -23,6 +38,165 @@ type DatabaseConfig struct {
 	Name     string `mapstructure:"POSTGRES_DB"`
 }
 
+// アウトボックスのリレー設定を保持する。
+type OutboxConfig struct {
+	// 配信先: log / webhook / nats
+	Publisher         string        `mapstructure:"OUTBOX_PUBLISHER"`
+	WebhookURL        string        `mapstructure:"OUTBOX_WEBHOOK_URL"`
+	NATSAddr          string        `mapstructure:"OUTBOX_NATS_ADDR"`
+	NATSSubjectPrefix string        `mapstructure:"OUTBOX_NATS_SUBJECT_PREFIX"`
+	PollInterval      time.Duration `mapstructure:"OUTBOX_POLL_INTERVAL"`
+	BatchSize         int           `mapstructure:"OUTBOX_BATCH_SIZE"`
+	MaxAttempts       int           `mapstructure:"OUTBOX_MAX_ATTEMPTS"`
+	RetryBaseBackoff  time.Duration `mapstructure:"OUTBOX_RETRY_BASE_BACKOFF"`
+	RetryMaxBackoff   time.Duration `mapstructure:"OUTBOX_RETRY_MAX_BACKOFF"`
+	// 取得したメッセージを他のリレーから確保しておく期間(1バッチの配信にかかる時間より長くする)
+	ClaimLease time.Duration `mapstructure:"OUTBOX_CLAIM_LEASE"`
+}
+
+// Webhook配信の設定を保持する。
+type WebhookConfig struct {
+	DeliveryInterval time.Duration `mapstructure:"WEBHOOK_DELIVERY_INTERVAL"`
+	BatchSize        int           `mapstructure:"WEBHOOK_BATCH_SIZE"`
+	MaxAttempts      int           `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+	RetryBaseBackoff time.Duration `mapstructure:"WEBHOOK_RETRY_BASE_BACKOFF"`
+	RetryMaxBackoff  time.Duration `mapstructure:"WEBHOOK_RETRY_MAX_BACKOFF"`
+	RequestTimeout   time.Duration `mapstructure:"WEBHOOK_REQUEST_TIMEOUT"`
+	// 取得した配信を他のワーカーから確保しておく期間(1バッチの配信にかかる時間より長くする)
+	ClaimLease time.Duration `mapstructure:"WEBHOOK_CLAIM_LEASE"`
+}
+
+// 公開フィードの設定を保持する。
+type FeedConfig struct {
+	Title       string `mapstructure:"FEED_TITLE"`
+	Description string `mapstructure:"FEED_DESCRIPTION"`
+	Author      string `mapstructure:"FEED_AUTHOR"`
+	SiteURL     string `mapstructure:"FEED_SITE_URL"`
+	ItemLimit   int    `mapstructure:"FEED_ITEM_LIMIT"`
+}
+
+// サイトマップとrobots.txtの設定を保持する。
+type SitemapConfig struct {
+	// 1ファイルあたりのURL数(上限50000)
+	URLsPerFile int `mapstructure:"SITEMAP_URLS_PER_FILE"`
+	// クロールを拒否するパス(カンマ区切り)
+	RobotsDisallow []string `mapstructure:"ROBOTS_DISALLOW"`
+}
+
+// Markdownレンダリングの設定を保持する。
+type MarkdownConfig struct {
+	// 変換結果をキャッシュする本文の件数
+	CacheSize int `mapstructure:"MARKDOWN_CACHE_SIZE"`
+}
+
+// 記事の入力制限の設定を保持する。
+type ArticleConfig struct {
+	// 本文の最大サイズ(バイト)
+	BodyMaxSize int `mapstructure:"ARTICLE_BODY_MAX_SIZE"`
+	// 一括操作で1つのトランザクションで変更する記事の数
+	BulkChunkSize int `mapstructure:"ARTICLE_BULK_CHUNK_SIZE"`
+}
+
+// エンゲージメント指標の収集設定を保持する。
+type MetricsConfig struct {
+	// 収集間隔(0以下の場合は収集しない)
+	CollectInterval time.Duration `mapstructure:"METRICS_COLLECT_INTERVAL"`
+	RequestTimeout  time.Duration `mapstructure:"METRICS_REQUEST_TIMEOUT"`
+	QiitaBaseURL    string        `mapstructure:"METRICS_QIITA_BASE_URL"`
+	// Qiitaのアクセストークン(任意。指定するとレート制限が緩和される)
+	QiitaToken  string `mapstructure:"METRICS_QIITA_TOKEN"`
+	ZennBaseURL string `mapstructure:"METRICS_ZENN_BASE_URL"`
+}
+
+// リンクチェックの設定を保持する。
+type LinkCheckConfig struct {
+	// チェック間隔(0以下の場合はチェックしない)
+	Interval    time.Duration `mapstructure:"LINKCHECK_INTERVAL"`
+	Concurrency int           `mapstructure:"LINKCHECK_CONCURRENCY"`
+	// 同じホストへのリクエストの最小間隔
+	HostInterval   time.Duration `mapstructure:"LINKCHECK_HOST_INTERVAL"`
+	RequestTimeout time.Duration `mapstructure:"LINKCHECK_REQUEST_TIMEOUT"`
+	// リクエストとrobots.txtの判定に利用するUser-Agent
+	UserAgent string `mapstructure:"LINKCHECK_USER_AGENT"`
+}
+
+// リンク先のOGP取得の設定を保持する。
+type OGPConfig struct {
+	// 取得し直す対象を探す間隔(0以下の場合は取得しない)
+	RefreshInterval time.Duration `mapstructure:"OGP_REFRESH_INTERVAL"`
+	// 取得に成功したOGPを取得し直すまでの期間
+	TTL time.Duration `mapstructure:"OGP_TTL"`
+	// 取得に失敗したURLを再試行するまでの期間
+	RetryAfter time.Duration `mapstructure:"OGP_RETRY_AFTER"`
+	BatchSize  int           `mapstructure:"OGP_BATCH_SIZE"`
+	// 1ページあたりの取得時間と読み込むサイズ(バイト)の上限
+	RequestTimeout time.Duration `mapstructure:"OGP_REQUEST_TIMEOUT"`
+	MaxBytes       int64         `mapstructure:"OGP_MAX_BYTES"`
+	UserAgent      string        `mapstructure:"OGP_USER_AGENT"`
+}
+
+// APIの認証設定を保持する。
+type AuthConfig struct {
+	// falseの場合はAPIキーなしで全ての操作を受け付ける(ローカル開発用)
+	Enabled bool `mapstructure:"AUTH_ENABLED"`
+}
+
+// IdPが発行したJWT(Bearerトークン)の検証設定を保持する。
+type OIDCConfig struct {
+	// 空の場合はBearerトークンによる認証を行わない
+	Issuer   string `mapstructure:"OIDC_ISSUER"`
+	Audience string `mapstructure:"OIDC_AUDIENCE"`
+	// 署名の検証に使うJWKSの取得先(URLとファイルのどちらか一方)
+	JWKSURL  string `mapstructure:"OIDC_JWKS_URL"`
+	JWKSFile string `mapstructure:"OIDC_JWKS_FILE"`
+	// JWKSを読み込み直す間隔
+	JWKSRefreshInterval time.Duration `mapstructure:"OIDC_JWKS_REFRESH_INTERVAL"`
+	// exp / nbf / iatの判定で許容する時刻のずれ
+	ClockSkew time.Duration `mapstructure:"OIDC_CLOCK_SKEW"`
+	// ロールを持つクレーム(ドット区切りで入れ子のクレームを指定できる)
+	RolesClaim string `mapstructure:"OIDC_ROLES_CLAIM"`
+	// ロールとスコープの対応(role=scopeのカンマ区切り)
+	RoleScopes []string `mapstructure:"OIDC_ROLE_SCOPES"`
+	// ワークスペースのスラッグを持つクレーム
+	// 空の場合はトークンをワークスペースに紐づけず、既定のワークスペースでのみ利用できる
+	WorkspaceClaim string `mapstructure:"OIDC_WORKSPACE_CLAIM"`
+}
+
+// ワークスペースの解決の設定を保持する。
+type TenantConfig struct {
+	// サブドメインでワークスペースを指定するときのベースドメイン(空の場合はX-Workspaceヘッダのみ)
+	BaseDomain string `mapstructure:"TENANT_BASE_DOMAIN"`
+	// 既定以外のワークスペースを作って運用する場合はtrueにする
+	// Bearerトークンによる認証と併用する場合はOIDC_WORKSPACE_CLAIMが必須になる
+	MultiWorkspace bool `mapstructure:"TENANT_MULTI_WORKSPACE"`
+}
+
+// 監査ログの設定を保持する。
+type AuditConfig struct {
+	// trueの場合は監査ログに記録するクライアントのIPアドレスをX-Forwarded-Forヘッダから取る
+	// (リバースプロキシの背後で運用し、プロキシがヘッダを上書きする場合のみ有効にする)
+	TrustForwardedFor bool `mapstructure:"AUDIT_TRUST_FORWARDED_FOR"`
+}
+
+// ゴミ箱(削除済みの記事)の設定を保持する。
+type TrashConfig struct {
+	// 削除済みの記事を完全に削除するまでの日数(0の場合は完全に削除しない)
+	// 有効にすると既存の削除済みの記事も対象になるため、既定では無効にしておく
+	RetentionDays int `mapstructure:"TRASH_RETENTION_DAYS"`
+	// 保持期間を過ぎた記事を探す間隔
+	PurgeInterval time.Duration `mapstructure:"TRASH_PURGE_INTERVAL"`
+}
+
+// Retention は削除済みの記事の保持期間を返す
+func (c TrashConfig) Retention() time.Duration {
+	return time.Duration(c.RetentionDays) * 24 * time.Hour
+}
+
+// Enabled はBearerトークンによる認証が有効かを判定する
+func (c OIDCConfig) Enabled() bool {
+	return c.Issuer != ""
+}
+
 func LoadConfig(envFilePath string) (*Config, error) {
 	// 環境変数の自動読み込みを有効化
 	viper.AutomaticEnv()
@@ -41,6 +215,67 @@ func LoadConfig(envFilePath string) (*Config, error) {
 		}
 	}
 
+	// 任意項目のデフォルト値
+	viper.SetDefault("OUTBOX_PUBLISHER", "log")
+	viper.SetDefault("OUTBOX_WEBHOOK_URL", "")
+	viper.SetDefault("OUTBOX_NATS_ADDR", "")
+	viper.SetDefault("OUTBOX_NATS_SUBJECT_PREFIX", "articlehub")
+	viper.SetDefault("OUTBOX_POLL_INTERVAL", "1s")
+	viper.SetDefault("OUTBOX_BATCH_SIZE", 100)
+	viper.SetDefault("OUTBOX_MAX_ATTEMPTS", 10)
+	viper.SetDefault("OUTBOX_RETRY_BASE_BACKOFF", "1s")
+	viper.SetDefault("OUTBOX_RETRY_MAX_BACKOFF", "10m")
+	viper.SetDefault("OUTBOX_CLAIM_LEASE", "5m")
+	viper.SetDefault("WEBHOOK_DELIVERY_INTERVAL", "5s")
+	viper.SetDefault("WEBHOOK_BATCH_SIZE", 50)
+	viper.SetDefault("WEBHOOK_MAX_ATTEMPTS", 8)
+	viper.SetDefault("WEBHOOK_RETRY_BASE_BACKOFF", "30s")
+	viper.SetDefault("WEBHOOK_RETRY_MAX_BACKOFF", "6h")
+	viper.SetDefault("WEBHOOK_REQUEST_TIMEOUT", "10s")
+	viper.SetDefault("WEBHOOK_CLAIM_LEASE", "5m")
+	viper.SetDefault("FEED_TITLE", "Momenture Article Hub")
+	viper.SetDefault("FEED_DESCRIPTION", "")
+	viper.SetDefault("FEED_AUTHOR", "")
+	viper.SetDefault("FEED_SITE_URL", "http://localhost:8080")
+	viper.SetDefault("FEED_ITEM_LIMIT", 50)
+	viper.SetDefault("SITEMAP_URLS_PER_FILE", 50000)
+	viper.SetDefault("ROBOTS_DISALLOW", "/webhooks")
+	viper.SetDefault("MARKDOWN_CACHE_SIZE", 1000)
+	viper.SetDefault("ARTICLE_BODY_MAX_SIZE", 1048576)
+	viper.SetDefault("ARTICLE_BULK_CHUNK_SIZE", 50)
+	viper.SetDefault("METRICS_COLLECT_INTERVAL", "6h")
+	viper.SetDefault("METRICS_REQUEST_TIMEOUT", "10s")
+	viper.SetDefault("METRICS_QIITA_BASE_URL", "https://qiita.com")
+	viper.SetDefault("METRICS_QIITA_TOKEN", "")
+	viper.SetDefault("METRICS_ZENN_BASE_URL", "https://zenn.dev")
+	viper.SetDefault("LINKCHECK_INTERVAL", "24h")
+	viper.SetDefault("LINKCHECK_CONCURRENCY", 4)
+	viper.SetDefault("LINKCHECK_HOST_INTERVAL", "1s")
+	viper.SetDefault("LINKCHECK_REQUEST_TIMEOUT", "10s")
+	viper.SetDefault("LINKCHECK_USER_AGENT", "momenture-article-hub-linkcheck")
+	viper.SetDefault("OGP_REFRESH_INTERVAL", "1h")
+	viper.SetDefault("OGP_TTL", "168h")
+	viper.SetDefault("OGP_RETRY_AFTER", "24h")
+	viper.SetDefault("OGP_BATCH_SIZE", 50)
+	viper.SetDefault("OGP_REQUEST_TIMEOUT", "5s")
+	viper.SetDefault("OGP_MAX_BYTES", 524288)
+	viper.SetDefault("OGP_USER_AGENT", "momenture-article-hub-ogp")
+	viper.SetDefault("AUTH_ENABLED", true)
+	viper.SetDefault("OIDC_ISSUER", "")
+	viper.SetDefault("OIDC_AUDIENCE", "")
+	viper.SetDefault("OIDC_JWKS_URL", "")
+	viper.SetDefault("OIDC_JWKS_FILE", "")
+	viper.SetDefault("OIDC_JWKS_REFRESH_INTERVAL", "1h")
+	viper.SetDefault("OIDC_CLOCK_SKEW", "1m")
+	viper.SetDefault("OIDC_ROLES_CLAIM", "roles")
+	viper.SetDefault("OIDC_ROLE_SCOPES", "admin=articles:admin,editor=articles:write,reviewer=articles:write,author=articles:write,viewer=articles:read")
+	viper.SetDefault("OIDC_WORKSPACE_CLAIM", "")
+	viper.SetDefault("TENANT_BASE_DOMAIN", "")
+	viper.SetDefault("TENANT_MULTI_WORKSPACE", false)
+	viper.SetDefault("AUDIT_TRUST_FORWARDED_FOR", false)
+	viper.SetDefault("TRASH_RETENTION_DAYS", 0)
+	viper.SetDefault("TRASH_PURGE_INTERVAL", "24h")
+
 	// 環境変数から設定を構築
 	var config Config
 	if err := viper.Unmarshal(&config); err != nil {
@@ -52,6 +287,62 @@ func LoadConfig(envFilePath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal database config: %w", err)
 	}
 
+	if err := viper.Unmarshal(&config.Outbox); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal outbox config: %w", err)
+	}
+
+	if err := viper.Unmarshal(&config.Webhook); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webhook config: %w", err)
+	}
+
+	if err := viper.Unmarshal(&config.Feed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal feed config: %w", err)
+	}
+
+	if err := viper.Unmarshal(&config.Sitemap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sitemap config: %w", err)
+	}
+
+	if err := viper.Unmarshal(&config.Markdown); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal markdown config: %w", err)
+	}
+
+	if err := viper.Unmarshal(&config.Article); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal article config: %w", err)
+	}
+
+	if err := viper.Unmarshal(&config.Metrics); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metrics config: %w", err)
+	}
+
+	if err := viper.Unmarshal(&config.LinkCheck); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal link check config: %w", err)
+	}
+
+	if err := viper.Unmarshal(&config.OGP); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ogp config: %w", err)
+	}
+
+	if err := viper.Unmarshal(&config.Auth); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal auth config: %w", err)
+	}
+
+	if err := viper.Unmarshal(&config.OIDC); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal oidc config: %w", err)
+	}
+
+	if err := viper.Unmarshal(&config.Tenant); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tenant config: %w", err)
+	}
+
+	if err := viper.Unmarshal(&config.Audit); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit config: %w", err)
+	}
+
+	if err := viper.Unmarshal(&config.Trash); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal trash config: %w", err)
+	}
+
 	if config.Database.Host == "" || config.Database.Port == "" || config.Database.User == "" || config.Database.Password == "" || config.Database.Name == "" {
 		return nil, fmt.Errorf("database connection parameters are incomplete in config")
 	}
@@ -65,5 +356,40 @@ func LoadConfig(envFilePath string) (*Config, error) {
 		return nil, fmt.Errorf("database port must be between 1 and 65535: %d", validPort)
 	}
 
+	if config.Article.BodyMaxSize <= 0 {
+		return nil, fmt.Errorf("ARTICLE_BODY_MAX_SIZE must be positive: %d", config.Article.BodyMaxSize)
+	}
+
+	if config.Trash.RetentionDays < 0 {
+		return nil, fmt.Errorf("TRASH_RETENTION_DAYS must not be negative: %d", config.Trash.RetentionDays)
+	}
+
+	if config.OIDC.Enabled() {
+		if config.OIDC.Audience == "" {
+			return nil, fmt.Errorf("OIDC_AUDIENCE is required when OIDC_ISSUER is set")
+		}
+		if (config.OIDC.JWKSURL == "") == (config.OIDC.JWKSFile == "") {
+			return nil, fmt.Errorf("exactly one of OIDC_JWKS_URL and OIDC_JWKS_FILE is required when OIDC_ISSUER is set")
+		}
+		// ワークスペースに紐づかないトークンは既定のワークスペースでしか使えないため、複数のワークスペースではクレームで紐づける
+		if config.Tenant.MultiWorkspace && config.OIDC.WorkspaceClaim == "" {
+			return nil, fmt.Errorf("OIDC_WORKSPACE_CLAIM is required when OIDC_ISSUER is set and TENANT_MULTI_WORKSPACE is true")
+		}
+	}
+
+	switch config.Outbox.Publisher {
+	case "log":
+	case "webhook":
+		if config.Outbox.WebhookURL == "" {
+			return nil, fmt.Errorf("OUTBOX_WEBHOOK_URL is required when OUTBOX_PUBLISHER is webhook")
+		}
+	case "nats":
+		if config.Outbox.NATSAddr == "" {
+			return nil, fmt.Errorf("OUTBOX_NATS_ADDR is required when OUTBOX_PUBLISHER is nats")
+		}
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher: %s", config.Outbox.Publisher)
+	}
+
 	return &config, nil
 }
@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+// APIキー名の最大文字数
+const maxAPIKeyNameLength = 100
+
+// ErrAPIKeyRevoked は失効済みのAPIキーを操作しようとした場合に返される
+var ErrAPIKeyRevoked = errors.New("api key is already revoked")
+
+// APIKey は記事APIを利用するためのAPIキーを表すエンティティ
+// シークレットは平文では保持せず、ハッシュだけを保持する
+// Prefixはキーの検索に使う公開部分で、平文のまま保持する
+type APIKey struct {
+	ID         uint64
+	Name       string
+	Prefix     string
+	SecretHash string
+	Scopes     []vo.Scope
+	// AuthorID はキーで認証した呼び出し元を記事の所有者として扱う執筆者
+	AuthorID *uint64
+	// WorkspaceID はキーで認証した呼び出し元が操作できるワークスペース
+	WorkspaceID uint64
+	CreatedAt   time.Time
+	LastUsedAt  *time.Time
+	RevokedAt   *time.Time
+}
+
+// NewAPIKey は新しいAPIキーを作成する
+func NewAPIKey(name, prefix, secretHash string, scopes []string) (*APIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("api key name must not be empty")
+	}
+	if len([]rune(name)) > maxAPIKeyNameLength {
+		return nil, fmt.Errorf("api key name must be at most %d characters", maxAPIKeyNameLength)
+	}
+	if prefix == "" || secretHash == "" {
+		return nil, fmt.Errorf("api key prefix and secret hash must not be empty")
+	}
+	parsed, err := parseScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("api key must have at least one scope")
+	}
+	return &APIKey{
+		Name:       name,
+		Prefix:     prefix,
+		SecretHash: secretHash,
+		Scopes:     parsed,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// ReconstituteAPIKey は永続化層から読み込んだデータからAPIキーを再構築する
+func ReconstituteAPIKey(
+	id uint64,
+	name string,
+	prefix string,
+	secretHash string,
+	scopes []string,
+	createdAt time.Time,
+	lastUsedAt *time.Time,
+	revokedAt *time.Time,
+) (*APIKey, error) {
+	parsed, err := parseScopes(scopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstitute api key: %w", err)
+	}
+	return &APIKey{
+		ID:         id,
+		Name:       name,
+		Prefix:     prefix,
+		SecretHash: secretHash,
+		Scopes:     parsed,
+		CreatedAt:  createdAt,
+		LastUsedAt: lastUsedAt,
+		RevokedAt:  revokedAt,
+	}, nil
+}
+
+// IsRevoked は失効済みかを判定する
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Revoke はAPIキーを失効させる
+func (k *APIKey) Revoke(at time.Time) error {
+	if k.IsRevoked() {
+		return ErrAPIKeyRevoked
+	}
+	k.RevokedAt = &at
+	return nil
+}
+
+// HasScope は指定したスコープの操作が許可されているかを判定する
+func (k *APIKey) HasScope(required vo.Scope) bool {
+	for _, s := range k.Scopes {
+		if s.Includes(required) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeStrings はスコープを文字列のスライスで返す
+func (k *APIKey) ScopeStrings() []string {
+	scopes := make([]string, len(k.Scopes))
+	for i, s := range k.Scopes {
+		scopes[i] = s.String()
+	}
+	return scopes
+}
+
+// parseScopes はスコープを検証し、重複を取り除く
+func parseScopes(values []string) ([]vo.Scope, error) {
+	scopes := make([]vo.Scope, 0, len(values))
+	seen := make(map[vo.Scope]bool, len(values))
+	for _, v := range values {
+		s, err := vo.NewScope(strings.TrimSpace(v))
+		if err != nil {
+			return nil, err
+		}
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		scopes = append(scopes, s)
+	}
+	return scopes, nil
+}
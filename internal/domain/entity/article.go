@@ -1,35 +1,72 @@
 package entity
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
 )
 
+// ErrInvalidStatusTransition は遷移表で許可されていないステータスの変更で返される
+var ErrInvalidStatusTransition = errors.New("invalid article status transition")
+
+// ErrReviewRequired はレビューが必要な記事を承認なしに公開しようとした場合に返される
+var ErrReviewRequired = errors.New("article must be approved in review before it is made public")
+
+// ErrArticleInReview はレビュー中の記事の内容の変更や、レビューを経ない下書きへの変更で返される
+var ErrArticleInReview = errors.New("article is in review")
+
 // Article は記事のドメインエンティティ
+// ProviderType / Link はPublicationsのうち正規(canonical)の投稿先の値を表す
+// LinkPreview はLinkのOGPで、リポジトリが読み込み時に設定する(記事の保存では更新しない)
 type Article struct {
 	ID           uint64
 	Title        vo.ArticleTitle
+	Slug         vo.Slug
 	Body         *vo.ArticleBody
 	Status       vo.ArticleStatus
 	ProviderType *vo.ProviderType
 	Link         *vo.Link
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	DeletedAt    *time.Time
+	Publications []Publication
+	LinkPreview  *LinkPreview
+	Tags         []vo.Tag
+	// AuthorID は記事の所有者である執筆者(未設定の場合は編集者だけが変更できる)
+	AuthorID *uint64
+	// WorkspaceID は記事が属するワークスペース(永続化時に決まり、変更できない)
+	WorkspaceID uint64
+	// ApprovedAt はレビューで承認された日時(下書きに戻すと取り消される)
+	ApprovedAt *time.Time
+	// PublishedAt は最初に公開された日時(公開状態でなくなっても保持する)
+	PublishedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   *time.Time
+
+	// reviewRequired は公開にレビューでの承認が必要か(ワークスペースの設定で決まり、永続化しない)
+	reviewRequired bool
+	// bodyMaxSize は本文の最大サイズ(バイト)で、0以下の場合は既定値を使う(設定で決まり、永続化しない)
+	bodyMaxSize int
+	events      []ArticleEvent
 }
 
 // ArticleOption は記事作成時のオプション設定用
 type ArticleOption func(*Article) error
 
+// WithBody は本文を指定する
+// 最大サイズ(WithBodyMaxSize)を反映するため、検証はオプションの適用後に行う
 func WithBody(body *string) ArticleOption {
 	return func(a *Article) error {
-		b, err := vo.NewArticleBody(body)
-		if err != nil {
-			return fmt.Errorf("invalid body for article option: %w", err)
-		}
-		a.Body = b
+		a.Body = vo.ReconstituteArticleBody(body)
+		return nil
+	}
+}
+
+// WithBodyMaxSize は本文の最大サイズ(バイト)を指定する
+// 0以下の場合は既定値を使う
+func WithBodyMaxSize(size int) ArticleOption {
+	return func(a *Article) error {
+		a.bodyMaxSize = size
 		return nil
 	}
 }
@@ -56,6 +93,49 @@ func WithProviderType(providerType *string) ArticleOption {
 	}
 }
 
+// WithSlug はスラッグを指定する
+// 指定しない場合(nilまたは空文字)はタイトルから生成する
+func WithSlug(slug *string) ArticleOption {
+	return func(a *Article) error {
+		if slug == nil || *slug == "" {
+			return nil
+		}
+		s, err := vo.NewSlug(*slug)
+		if err != nil {
+			return fmt.Errorf("invalid slug for article option: %w", err)
+		}
+		a.Slug = s
+		return nil
+	}
+}
+
+func WithAuthorID(authorID *uint64) ArticleOption {
+	return func(a *Article) error {
+		a.AuthorID = authorID
+		return nil
+	}
+}
+
+func WithTags(tags []string) ArticleOption {
+	return func(a *Article) error {
+		t, err := parseTags(tags)
+		if err != nil {
+			return fmt.Errorf("invalid tags for article option: %w", err)
+		}
+		a.Tags = t
+		return nil
+	}
+}
+
+// WithReviewRequired は公開にレビューでの承認を必要にする
+// 承認が必要な場合、下書き以外のステータスでは作成できない
+func WithReviewRequired(required bool) ArticleOption {
+	return func(a *Article) error {
+		a.reviewRequired = required
+		return nil
+	}
+}
+
 // NewArticle は新しい記事を作成する
 func NewArticle(
 	title string,
@@ -74,6 +154,7 @@ func NewArticle(
 
 	article := &Article{
 		Title:     artTitle,
+		Slug:      vo.GenerateSlug(artTitle.String()),
 		Status:    artStatus,
 		CreatedAt: now,
 		UpdatedAt: now,
@@ -85,14 +166,37 @@ func NewArticle(
 			return nil, fmt.Errorf("failed to apply article option: %w", err)
 		}
 	}
+	if article.Body != nil {
+		value := article.Body.String()
+		if article.Body, err = vo.NewArticleBody(&value, article.bodyMaxSize); err != nil {
+			return nil, fmt.Errorf("invalid article body: %w", err)
+		}
+	}
+	if article.Status.IsInReview() {
+		return nil, fmt.Errorf("create the article as a draft and submit it for review: %w", ErrInvalidStatusTransition)
+	}
+	if article.reviewRequired && article.Status.IsPublic() {
+		return nil, fmt.Errorf("article status %s: %w", article.Status, ErrReviewRequired)
+	}
+	if err := article.syncCanonicalPublication(); err != nil {
+		return nil, fmt.Errorf("failed to create canonical publication: %w", err)
+	}
+
+	article.recordEvent(ArticleEventCreated, now)
+	if article.Status.IsPublished() {
+		article.PublishedAt = &now
+		article.recordEvent(ArticleEventPublished, now)
+	}
 
 	return article, nil
 }
 
 // ReconstituteArticle は永続化層から読み込んだデータから記事を再構築する
+// providerType / link には正規の投稿先の値を渡し、Publicationsは呼び出し側で設定する
 func ReconstituteArticle(
 	id uint64,
 	title string,
+	slug string,
 	status string,
 	body *string,
 	providerType *string,
@@ -101,19 +205,15 @@ func ReconstituteArticle(
 	updatedAt time.Time,
 	deletedAt *time.Time,
 ) (*Article, error) {
-	artTitle, err := vo.NewArticleTitle(title)
+	// タイトルと本文は作成・変更時に検証済みのため、規則が変わっても読み込めるよう検証しない
+	artTitle := vo.ReconstituteArticleTitle(title)
+
+	artSlug, err := vo.NewSlug(slug)
 	if err != nil {
-		return nil, fmt.Errorf("failed to reconstitute article title: %w", err)
+		return nil, fmt.Errorf("failed to reconstitute article slug: %w", err)
 	}
 
-	var artBody *vo.ArticleBody
-	if body != nil {
-		ab, err := vo.NewArticleBody(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to reconstitute article body: %w", err)
-		}
-		artBody = ab
-	}
+	artBody := vo.ReconstituteArticleBody(body)
 
 	artStatus := vo.ArticleStatus(status)
 	if !artStatus.IsValid() {
@@ -141,6 +241,7 @@ func ReconstituteArticle(
 	article := &Article{
 		ID:           id,
 		Title:        artTitle,
+		Slug:         artSlug,
 		Body:         artBody,
 		Status:       artStatus,
 		ProviderType: provType,
@@ -159,9 +260,7 @@ func (a *Article) Publish() error {
 	if a.Status.IsPublished() {
 		return fmt.Errorf("article is already published")
 	}
-	a.Status = vo.ArticleStatusPublished
-	a.UpdatedAt = time.Now()
-	return nil
+	return a.ChangeStatus(vo.ArticleStatusPublished)
 }
 
 // Draft は記事を下書き状態に変更する
@@ -169,11 +268,145 @@ func (a *Article) Draft() error {
 	if a.Status.IsDraft() {
 		return fmt.Errorf("article is already in draft status")
 	}
-	a.Status = vo.ArticleStatusDraft
+	return a.ChangeStatus(vo.ArticleStatusDraft)
+}
+
+// Schedule は記事を公開予約状態に変更する
+func (a *Article) Schedule() error {
+	if a.Status.IsScheduled() {
+		return fmt.Errorf("article is already scheduled")
+	}
+	return a.ChangeStatus(vo.ArticleStatusScheduled)
+}
+
+// Unlist は記事を限定公開状態に変更する
+func (a *Article) Unlist() error {
+	if a.Status.IsUnlisted() {
+		return fmt.Errorf("article is already unlisted")
+	}
+	return a.ChangeStatus(vo.ArticleStatusUnlisted)
+}
+
+// Archive は記事をアーカイブする
+func (a *Article) Archive() error {
+	if a.Status.IsArchived() {
+		return fmt.Errorf("article is already archived")
+	}
+	return a.ChangeStatus(vo.ArticleStatusArchived)
+}
+
+// ChangeStatus は遷移表(vo.ArticleStatus.CanTransitionTo)に従って記事のステータスを変更する
+// 許可されていない遷移はErrInvalidStatusTransitionを返し、同じステータスへの変更は何もしない
+// レビューへの提出と、レビュー中から下書きへの変更はSubmitForReview / ReturnToDraftで行う
+// 公開状態になった場合はpublished、公開状態でなくなった場合はunpublishedのイベントを記録する
+func (a *Article) ChangeStatus(next vo.ArticleStatus) error {
+	if err := a.checkStatusTransition(next); err != nil {
+		return err
+	}
+	if next == a.Status {
+		return nil
+	}
+	previous := a.Status
+	a.setStatus(next)
 	a.UpdatedAt = time.Now()
+	a.recordStatusEvent(previous, a.UpdatedAt)
 	return nil
 }
 
+// checkStatusTransition は現在のステータスからnextへ変更できるかを確認する
+func (a *Article) checkStatusTransition(next vo.ArticleStatus) error {
+	if !next.IsValid() {
+		return fmt.Errorf("invalid article status: %s", next)
+	}
+	if next == a.Status {
+		return nil
+	}
+	if !a.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%s to %s: %w", a.Status, next, ErrInvalidStatusTransition)
+	}
+	if next.IsInReview() {
+		return fmt.Errorf("%s to %s: submit the article for review: %w", a.Status, next, ErrInvalidStatusTransition)
+	}
+	if a.Status.IsInReview() && next.IsDraft() {
+		return fmt.Errorf("withdraw or reject the review to return the article to draft: %w", ErrArticleInReview)
+	}
+	if a.reviewRequired && next.IsPublic() && !a.Status.IsPublic() && a.ApprovedAt == nil {
+		return fmt.Errorf("%s to %s: %w", a.Status, next, ErrReviewRequired)
+	}
+	return nil
+}
+
+// setStatus はステータスを変更し、下書きに戻った場合はレビューでの承認を取り消す
+// 初めて公開された場合は公開日時を記録する
+func (a *Article) setStatus(next vo.ArticleStatus) {
+	a.Status = next
+	if next.IsDraft() {
+		a.ApprovedAt = nil
+	}
+	if next.IsPublished() && a.PublishedAt == nil {
+		now := time.Now()
+		a.PublishedAt = &now
+	}
+}
+
+// RequireReview は公開にレビューでの承認を必要にする
+// 既に公開されている記事の公開状態の間の変更には影響しない
+func (a *Article) RequireReview() {
+	a.reviewRequired = true
+}
+
+// LimitBodySize は本文を変更するときの最大サイズ(バイト)を設定する
+// 0以下の場合は既定値を使う。保存済みの本文には影響しない
+func (a *Article) LimitBodySize(size int) {
+	a.bodyMaxSize = size
+}
+
+// SubmitForReview は下書きの記事をレビューに提出する
+func (a *Article) SubmitForReview() error {
+	if !a.Status.IsDraft() {
+		return fmt.Errorf("only a draft can be submitted for review, article is %s: %w", a.Status, ErrInvalidStatusTransition)
+	}
+	a.Status = vo.ArticleStatusInReview
+	a.ApprovedAt = nil
+	a.UpdatedAt = time.Now()
+	a.recordEvent(ArticleEventUpdated, a.UpdatedAt)
+	return nil
+}
+
+// ApproveReview はレビュー中の記事を承認し、公開できるようにする
+func (a *Article) ApproveReview(at time.Time) error {
+	if !a.Status.IsInReview() {
+		return fmt.Errorf("article is %s, not in review: %w", a.Status, ErrInvalidStatusTransition)
+	}
+	a.ApprovedAt = &at
+	a.UpdatedAt = at
+	a.recordEvent(ArticleEventUpdated, a.UpdatedAt)
+	return nil
+}
+
+// ReturnToDraft はレビュー中の記事を差し戻しまたは取り下げで下書きに戻す
+func (a *Article) ReturnToDraft() error {
+	if !a.Status.IsInReview() {
+		return fmt.Errorf("article is %s, not in review: %w", a.Status, ErrInvalidStatusTransition)
+	}
+	a.setStatus(vo.ArticleStatusDraft)
+	a.UpdatedAt = time.Now()
+	a.recordEvent(ArticleEventUpdated, a.UpdatedAt)
+	return nil
+}
+
+// recordStatusEvent はpreviousから現在のステータスへの変化に応じたイベントを記録する
+func (a *Article) recordStatusEvent(previous vo.ArticleStatus, at time.Time) {
+	if previous == a.Status {
+		return
+	}
+	if a.Status.IsPublished() {
+		a.recordEvent(ArticleEventPublished, at)
+	} else if previous.IsPublished() {
+		a.recordEvent(ArticleEventUnpublished, at)
+	}
+}
+
 // SoftDelete は記事を論理削除する
 func (a *Article) SoftDelete() error {
 	if a.DeletedAt != nil {
@@ -182,6 +415,7 @@ func (a *Article) SoftDelete() error {
 	now := time.Now()
 	a.DeletedAt = &now
 	a.UpdatedAt = now
+	a.recordEvent(ArticleEventDeleted, now)
 	return nil
 }
 
@@ -192,21 +426,74 @@ func (a *Article) Restore() error {
 	}
 	a.DeletedAt = nil
 	a.UpdatedAt = time.Now()
+	a.recordEvent(ArticleEventRestored, a.UpdatedAt)
+	return nil
+}
+
+// AssignAuthor は記事の所有者を変更する(nilの場合は所有者なし)
+func (a *Article) AssignAuthor(authorID *uint64) {
+	if sameAuthor(a.AuthorID, authorID) {
+		return
+	}
+	a.AuthorID = authorID
+	a.UpdatedAt = time.Now()
+	a.recordEvent(ArticleEventUpdated, a.UpdatedAt)
+}
+
+// IsOwnedBy は執筆者が記事の所有者かを判定する
+func (a *Article) IsOwnedBy(authorID uint64) bool {
+	return a.AuthorID != nil && *a.AuthorID == authorID
+}
+
+func sameAuthor(x, y *uint64) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return *x == *y
+}
+
+// ChangeSlug は記事のスラッグを変更する
+// 変更前のスラッグの履歴はリポジトリが保持する
+func (a *Article) ChangeSlug(slug string) error {
+	newSlug, err := vo.NewSlug(slug)
+	if err != nil {
+		return fmt.Errorf("failed to change slug: %w", err)
+	}
+	if newSlug == a.Slug {
+		return nil
+	}
+	a.Slug = newSlug
+	a.UpdatedAt = time.Now()
+	a.recordEvent(ArticleEventUpdated, a.UpdatedAt)
 	return nil
 }
 
 // ChangeProvider は記事のプロバイダを変更する
 // 公開済みの記事は変更不可
 func (a *Article) ChangeProvider(newProviderType *vo.ProviderType) error {
+	if err := a.changeProvider(newProviderType); err != nil {
+		return err
+	}
+	a.UpdatedAt = time.Now()
+	a.recordEvent(ArticleEventUpdated, a.UpdatedAt)
+	return nil
+}
+
+// changeProvider はイベントを記録せずにプロバイダを変更する
+// Updateは変更全体で一つのイベントを記録するため、こちらを使う
+func (a *Article) changeProvider(newProviderType *vo.ProviderType) error {
 	if a.Status.IsPublished() {
 		return fmt.Errorf("cannot change provider for a published article")
 	}
 	a.ProviderType = newProviderType
-	a.UpdatedAt = time.Now()
+	if err := a.syncCanonicalPublication(); err != nil {
+		return fmt.Errorf("failed to change provider: %w", err)
+	}
 	return nil
 }
 
 // Update は記事の属性を更新する
+// レビュー中の記事のタイトルと本文はレビューの対象のため変更できない
 func (a *Article) Update(
 	title *string,
 	body *string,
@@ -214,6 +501,10 @@ func (a *Article) Update(
 	providerType *string,
 	link *string,
 ) error {
+	previousStatus := a.Status
+	if a.Status.IsInReview() && a.changesContent(title, body) {
+		return fmt.Errorf("withdraw the review to change the title or body: %w", ErrArticleInReview)
+	}
 	if title != nil {
 		newTitle, err := vo.NewArticleTitle(*title)
 		if err != nil {
@@ -222,7 +513,7 @@ func (a *Article) Update(
 		a.Title = newTitle
 	}
 	if body != nil {
-		newBody, err := vo.NewArticleBody(body)
+		newBody, err := vo.NewArticleBody(body, a.bodyMaxSize)
 		if err != nil {
 			return fmt.Errorf("failed to update body: %w", err)
 		}
@@ -235,14 +526,17 @@ func (a *Article) Update(
 		if !newStatus.IsValid() {
 			return fmt.Errorf("invalid status provided for update: %s", *status)
 		}
-		a.Status = newStatus
+		if err := a.checkStatusTransition(newStatus); err != nil {
+			return fmt.Errorf("failed to update status: %w", err)
+		}
+		a.setStatus(newStatus)
 	}
 	if providerType != nil {
 		newProvider, err := vo.NewProviderType(providerType)
 		if err != nil {
 			return fmt.Errorf("failed to change provider: %w", err)
 		}
-		if err := a.ChangeProvider(newProvider); err != nil {
+		if err := a.changeProvider(newProvider); err != nil {
 			return fmt.Errorf("failed to change provider: %w", err)
 		}
 	} else {
@@ -257,7 +551,232 @@ func (a *Article) Update(
 	} else {
 		a.Link = nil
 	}
+	if err := a.syncCanonicalPublication(); err != nil {
+		return fmt.Errorf("failed to update canonical publication: %w", err)
+	}
+
+	a.UpdatedAt = time.Now()
+	a.recordEvent(ArticleEventUpdated, a.UpdatedAt)
+	a.recordStatusEvent(previousStatus, a.UpdatedAt)
+	return nil
+}
 
+// changesContent はUpdateに渡されたタイトルと本文が現在の値と異なるかを判定する
+func (a *Article) changesContent(title *string, body *string) bool {
+	if title != nil && *title != a.Title.String() {
+		return true
+	}
+	if (body == nil) != (a.Body == nil) {
+		return true
+	}
+	return body != nil && *body != a.Body.String()
+}
+
+// Publication は指定IDの投稿先を返す
+func (a *Article) Publication(id uint64) (Publication, bool) {
+	for _, p := range a.Publications {
+		if p.ID == id {
+			return p, true
+		}
+	}
+	return Publication{}, false
+}
+
+// AddPublication は投稿先を追加する
+// 最初の投稿先は正規の投稿先になる。同じ投稿先が既にある場合はエラー
+func (a *Article) AddPublication(providerType *string, link *string, externalID *string, publishedAt *time.Time) error {
+	p, err := NewPublication(providerType, link, externalID, publishedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add publication: %w", err)
+	}
+	for i := range a.Publications {
+		if a.Publications[i].sameTarget(p) {
+			return fmt.Errorf("failed to add publication %s: %w", describeTarget(p), ErrPublicationDuplicated)
+		}
+	}
+	p.Canonical = len(a.Publications) == 0
+	a.Publications = append(a.Publications, *p)
+	a.mirrorCanonicalPublication()
+	a.UpdatedAt = time.Now()
+	a.recordEvent(ArticleEventUpdated, a.UpdatedAt)
+	return nil
+}
+
+// RemovePublication は投稿先を削除する
+// 正規の投稿先を削除した場合は残りの先頭を正規に繰り上げる
+func (a *Article) RemovePublication(id uint64) error {
+	idx := a.publicationIndex(id)
+	if idx < 0 {
+		return fmt.Errorf("publication %d is not found in article %d", id, a.ID)
+	}
+	a.removePublicationAt(idx)
+	a.UpdatedAt = time.Now()
+	a.recordEvent(ArticleEventUpdated, a.UpdatedAt)
+	return nil
+}
+
+// SetCanonicalPublication は指定の投稿先を正規の投稿先にする
+// 公開済みの記事でプロバイダが変わる場合はChangeProviderと同様に変更不可
+func (a *Article) SetCanonicalPublication(id uint64) error {
+	idx := a.publicationIndex(id)
+	if idx < 0 {
+		return fmt.Errorf("publication %d is not found in article %d", id, a.ID)
+	}
+	if a.Publications[idx].Canonical {
+		return nil
+	}
+	if a.Status.IsPublished() && a.Publications[idx].ProviderType.String() != a.ProviderType.String() {
+		return fmt.Errorf("cannot change provider for a published article")
+	}
+	for i := range a.Publications {
+		a.Publications[i].Canonical = i == idx
+	}
+	a.mirrorCanonicalPublication()
 	a.UpdatedAt = time.Now()
+	a.recordEvent(ArticleEventUpdated, a.UpdatedAt)
 	return nil
 }
+
+// syncCanonicalPublication は記事のプロバイダとリンクを正規の投稿先へ反映する
+// 両方が未設定の場合は正規の投稿先を削除する
+func (a *Article) syncCanonicalPublication() error {
+	idx := -1
+	for i := range a.Publications {
+		if a.Publications[i].Canonical {
+			idx = i
+			break
+		}
+	}
+	if a.ProviderType == nil && a.Link == nil {
+		if idx >= 0 {
+			a.removePublicationAt(idx)
+		}
+		return nil
+	}
+
+	target := Publication{ProviderType: a.ProviderType, Link: a.Link}
+	for i := range a.Publications {
+		if i != idx && a.Publications[i].sameTarget(&target) {
+			return fmt.Errorf("canonical publication %s: %w", describeTarget(&target), ErrPublicationDuplicated)
+		}
+	}
+	if idx < 0 {
+		target.Canonical = true
+		target.SyncStatus = vo.SyncStatusSynced
+		if target.Link == nil {
+			target.SyncStatus = vo.SyncStatusPending
+		}
+		a.Publications = append(a.Publications, target)
+		return nil
+	}
+
+	p := &a.Publications[idx]
+	if p.Link.String() != a.Link.String() {
+		p.SyncStatus = vo.SyncStatusSynced
+		if a.Link == nil {
+			p.SyncStatus = vo.SyncStatusPending
+		}
+	}
+	p.ProviderType = a.ProviderType
+	p.Link = a.Link
+	return nil
+}
+
+// removePublicationAt は投稿先を削除し、正規の投稿先がなくなった場合は先頭を繰り上げる
+func (a *Article) removePublicationAt(idx int) {
+	a.Publications = append(a.Publications[:idx:idx], a.Publications[idx+1:]...)
+	hasCanonical := false
+	for _, p := range a.Publications {
+		hasCanonical = hasCanonical || p.Canonical
+	}
+	if !hasCanonical && len(a.Publications) > 0 {
+		a.Publications[0].Canonical = true
+	}
+	a.mirrorCanonicalPublication()
+}
+
+// mirrorCanonicalPublication は正規の投稿先の値を記事のプロバイダとリンクへ反映する
+func (a *Article) mirrorCanonicalPublication() {
+	a.ProviderType = nil
+	a.Link = nil
+	for _, p := range a.Publications {
+		if p.Canonical {
+			a.ProviderType = p.ProviderType
+			a.Link = p.Link
+			return
+		}
+	}
+}
+
+func (a *Article) publicationIndex(id uint64) int {
+	for i := range a.Publications {
+		if a.Publications[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddTags は記事にタグを追加する
+// 既に付与されているタグは無視する
+func (a *Article) AddTags(tags ...string) error {
+	newTags, err := parseTags(tags)
+	if err != nil {
+		return fmt.Errorf("failed to add tags: %w", err)
+	}
+	added := false
+	for _, t := range newTags {
+		if a.HasTag(t) {
+			continue
+		}
+		a.Tags = append(a.Tags, t)
+		added = true
+	}
+	if added {
+		a.UpdatedAt = time.Now()
+		a.recordEvent(ArticleEventUpdated, a.UpdatedAt)
+	}
+	return nil
+}
+
+// HasTag は指定のタグが付与されているかを判定する
+func (a *Article) HasTag(tag vo.Tag) bool {
+	for _, t := range a.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Metadata は本文から導出したメタデータを返す
+func (a *Article) Metadata() vo.ArticleMetadata {
+	return vo.NewArticleMetadata(a.Body)
+}
+
+// TagStrings はタグを文字列で返す
+func (a *Article) TagStrings() []string {
+	tags := make([]string, 0, len(a.Tags))
+	for _, t := range a.Tags {
+		tags = append(tags, t.String())
+	}
+	return tags
+}
+
+// parseTags はタグを検証し、重複を除いて返す
+func parseTags(values []string) ([]vo.Tag, error) {
+	tags := make([]vo.Tag, 0, len(values))
+	seen := make(map[vo.Tag]bool)
+	for _, v := range values {
+		t, err := vo.NewTag(v)
+		if err != nil {
+			return nil, err
+		}
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		tags = append(tags, t)
+	}
+	return tags, nil
+}
@@ -0,0 +1,61 @@
+package entity
+
+import "time"
+
+// ArticleEventType は記事に関するドメインイベントの種類を表す
+type ArticleEventType string
+
+const (
+	ArticleEventCreated     ArticleEventType = "article.created"
+	ArticleEventUpdated     ArticleEventType = "article.updated"
+	ArticleEventPublished   ArticleEventType = "article.published"
+	ArticleEventUnpublished ArticleEventType = "article.unpublished"
+	ArticleEventDeleted     ArticleEventType = "article.deleted"
+	ArticleEventRestored    ArticleEventType = "article.restored"
+)
+
+var AllArticleEventTypes = []ArticleEventType{
+	ArticleEventCreated,
+	ArticleEventUpdated,
+	ArticleEventPublished,
+	ArticleEventUnpublished,
+	ArticleEventDeleted,
+	ArticleEventRestored,
+}
+
+func (t ArticleEventType) IsValid() bool {
+	for _, et := range AllArticleEventTypes {
+		if t == et {
+			return true
+		}
+	}
+	return false
+}
+
+func (t ArticleEventType) String() string {
+	return string(t)
+}
+
+// ArticleEvent は記事の状態変化を表すドメインイベント
+// 永続化時にリポジトリが取り出し、アウトボックスへ記録する
+type ArticleEvent struct {
+	Type       ArticleEventType
+	OccurredAt time.Time
+}
+
+// recordEvent はドメインイベントを記録する
+func (a *Article) recordEvent(eventType ArticleEventType, occurredAt time.Time) {
+	a.events = append(a.events, ArticleEvent{Type: eventType, OccurredAt: occurredAt})
+}
+
+// Events は未送出のドメインイベントを返す
+func (a *Article) Events() []ArticleEvent {
+	return append([]ArticleEvent(nil), a.events...)
+}
+
+// PullEvents は未送出のドメインイベントを取り出し、記録をクリアする
+func (a *Article) PullEvents() []ArticleEvent {
+	events := a.events
+	a.events = nil
+	return events
+}
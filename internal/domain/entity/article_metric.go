@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+// ArticleMetric は投稿先から収集したエンゲージメント指標のスナップショット
+// 時系列で蓄積し、期間内の増分を集計に使う
+type ArticleMetric struct {
+	ID            uint64
+	ArticleID     uint64
+	PublicationID uint64
+	ProviderType  vo.ProviderType
+	// Likes はQiitaのいいね数・Zennのいいね数
+	Likes int
+	// Stocks はQiitaのストック数
+	Stocks int
+	// Bookmarks はZennのブックマーク数
+	Bookmarks   int
+	CollectedAt time.Time
+}
+
+// NewArticleMetric は指標のスナップショットを作成する
+func NewArticleMetric(
+	articleID uint64,
+	publicationID uint64,
+	providerType vo.ProviderType,
+	likes, stocks, bookmarks int,
+	collectedAt time.Time,
+) (*ArticleMetric, error) {
+	if !providerType.IsValid() {
+		return nil, fmt.Errorf("invalid provider type for article metric: %s", providerType)
+	}
+	if likes < 0 || stocks < 0 || bookmarks < 0 {
+		return nil, fmt.Errorf("article metric counters must not be negative: likes=%d stocks=%d bookmarks=%d", likes, stocks, bookmarks)
+	}
+	return &ArticleMetric{
+		ArticleID:     articleID,
+		PublicationID: publicationID,
+		ProviderType:  providerType,
+		Likes:         likes,
+		Stocks:        stocks,
+		Bookmarks:     bookmarks,
+		CollectedAt:   collectedAt,
+	}, nil
+}
+
+// Total は全指標の合計
+func (m *ArticleMetric) Total() int {
+	return m.Likes + m.Stocks + m.Bookmarks
+}
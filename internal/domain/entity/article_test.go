@@ -17,6 +17,15 @@ import (
 // 	return &v
 // }
 
+// newArticleIn は指定のステータスの記事を永続化層から読み込んだ状態で作る
+func newArticleIn(t *testing.T, status vo.ArticleStatus) *entity.Article {
+	t.Helper()
+	now := time.Now()
+	article, err := entity.ReconstituteArticle(1, "T", "t", string(status), nil, nil, nil, now, now, nil)
+	require.NoError(t, err)
+	return article
+}
+
 // --- テストケース ---
 
 func TestNewArticle(t *testing.T) {
@@ -74,6 +83,34 @@ func TestNewArticle(t *testing.T) {
 	})
 }
 
+func TestReconstituteArticle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("現在の規則に反する保存済みのタイトルと本文もそのまま読み込める", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		title := "Ｔｉｔｌｅ  \u202Eevil"
+		body := strings.Repeat("a", vo.DefaultMaxArticleBodySize+1)
+
+		article, err := entity.ReconstituteArticle(1, title, "t", string(vo.ArticleStatusDraft), &body, nil, nil, now, now, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, title, article.Title.String())
+		assert.Equal(t, body, article.Body.String())
+	})
+
+	t.Run("読み込んだ記事のタイトルの変更は検証する", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleIn(t, vo.ArticleStatusDraft)
+
+		invalidTitle := "bad\u202Etitle"
+
+		err := article.Update(&invalidTitle, nil, nil, nil, nil)
+
+		assert.Error(t, err)
+	})
+}
+
 func TestArticle_Update(t *testing.T) {
 	t.Parallel()
 
@@ -140,6 +177,21 @@ func TestArticle_Publish(t *testing.T) {
 		err := art.Publish()
 		assert.Error(t, err)
 	})
+
+	t.Run("最初に公開された日時を再公開しても保持する", func(t *testing.T) {
+		t.Parallel()
+		art, err := entity.NewArticle("T", string(vo.ArticleStatusDraft))
+		require.NoError(t, err)
+		assert.Nil(t, art.PublishedAt)
+
+		require.NoError(t, art.Publish())
+		require.NotNil(t, art.PublishedAt)
+		first := *art.PublishedAt
+
+		require.NoError(t, art.Draft())
+		require.NoError(t, art.Publish())
+		assert.Equal(t, first, *art.PublishedAt)
+	})
 }
 
 func TestArticle_Draft(t *testing.T) {
@@ -166,6 +218,180 @@ func TestArticle_Draft(t *testing.T) {
 	})
 }
 
+func TestArticle_ChangeStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("全ての組み合わせで遷移表に従う", func(t *testing.T) {
+		t.Parallel()
+		for _, from := range vo.AllArticleStatuses {
+			for _, to := range vo.AllArticleStatuses {
+				article := newArticleIn(t, from)
+
+				err := article.ChangeStatus(to)
+				switch {
+				case from == to:
+					require.NoError(t, err, "%s -> %s", from, to)
+					assert.Empty(t, article.PullEvents(), "%s -> %s", from, to)
+				case to.IsInReview() || (from.IsInReview() && to.IsDraft()):
+					// レビューへの提出とレビュー中からの差し戻しはレビューの操作でだけ行う
+					require.Error(t, err, "%s -> %s", from, to)
+					assert.Equal(t, from, article.Status, "%s -> %s", from, to)
+				case from.CanTransitionTo(to):
+					require.NoError(t, err, "%s -> %s", from, to)
+					assert.Equal(t, to, article.Status, "%s -> %s", from, to)
+				default:
+					require.ErrorIs(t, err, entity.ErrInvalidStatusTransition, "%s -> %s", from, to)
+					assert.Equal(t, from, article.Status, "%s -> %s", from, to)
+					assert.Empty(t, article.PullEvents(), "%s -> %s", from, to)
+				}
+			}
+		}
+	})
+
+	t.Run("公開状態に出入りする遷移だけがイベントを記録する", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			from, to vo.ArticleStatus
+			want     []entity.ArticleEventType
+		}{
+			{vo.ArticleStatusDraft, vo.ArticleStatusPublished, []entity.ArticleEventType{entity.ArticleEventPublished}},
+			{vo.ArticleStatusScheduled, vo.ArticleStatusPublished, []entity.ArticleEventType{entity.ArticleEventPublished}},
+			{vo.ArticleStatusUnlisted, vo.ArticleStatusPublished, []entity.ArticleEventType{entity.ArticleEventPublished}},
+			{vo.ArticleStatusPublished, vo.ArticleStatusArchived, []entity.ArticleEventType{entity.ArticleEventUnpublished}},
+			{vo.ArticleStatusPublished, vo.ArticleStatusUnlisted, []entity.ArticleEventType{entity.ArticleEventUnpublished}},
+			{vo.ArticleStatusDraft, vo.ArticleStatusArchived, nil},
+			{vo.ArticleStatusArchived, vo.ArticleStatusDraft, nil},
+		}
+		for _, tt := range tests {
+			article, err := entity.NewArticle("T", string(tt.from))
+			require.NoError(t, err)
+			article.PullEvents()
+
+			require.NoError(t, article.ChangeStatus(tt.to))
+			var got []entity.ArticleEventType
+			for _, e := range article.PullEvents() {
+				got = append(got, e.Type)
+			}
+			assert.Equal(t, tt.want, got, "%s -> %s", tt.from, tt.to)
+		}
+	})
+
+	t.Run("アーカイブした記事は下書きを経由すれば再公開できる", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("T", string(vo.ArticleStatusPublished))
+		require.NoError(t, err)
+
+		require.NoError(t, article.Archive())
+		assert.ErrorIs(t, article.Publish(), entity.ErrInvalidStatusTransition)
+		require.NoError(t, article.Draft())
+		require.NoError(t, article.Publish())
+		assert.True(t, article.Status.IsPublished())
+	})
+
+	t.Run("Updateでも遷移表に従う", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("T", string(vo.ArticleStatusArchived))
+		require.NoError(t, err)
+
+		status := string(vo.ArticleStatusPublished)
+		err = article.Update(nil, nil, &status, nil, nil)
+		assert.ErrorIs(t, err, entity.ErrInvalidStatusTransition)
+		assert.True(t, article.Status.IsArchived())
+	})
+
+	t.Run("既に同じステータスの場合は名前付きの変更メソッドがエラーを返す", func(t *testing.T) {
+		t.Parallel()
+		for status, change := range map[vo.ArticleStatus]func(*entity.Article) error{
+			vo.ArticleStatusScheduled: (*entity.Article).Schedule,
+			vo.ArticleStatusUnlisted:  (*entity.Article).Unlist,
+			vo.ArticleStatusArchived:  (*entity.Article).Archive,
+		} {
+			article, err := entity.NewArticle("T", string(status))
+			require.NoError(t, err)
+			assert.Error(t, change(article), status)
+		}
+	})
+}
+
+func TestArticle_Review(t *testing.T) {
+	t.Parallel()
+
+	t.Run("承認された記事だけを公開できる", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("T", string(vo.ArticleStatusDraft), entity.WithReviewRequired(true))
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, article.Publish(), entity.ErrReviewRequired)
+		require.NoError(t, article.SubmitForReview())
+		assert.ErrorIs(t, article.Publish(), entity.ErrReviewRequired)
+		require.NoError(t, article.ApproveReview(time.Now()))
+		require.NoError(t, article.Publish())
+		assert.True(t, article.Status.IsPublished())
+	})
+
+	t.Run("レビューが不要なら承認なしに公開できる", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("T", string(vo.ArticleStatusDraft))
+		require.NoError(t, err)
+		require.NoError(t, article.SubmitForReview())
+		require.NoError(t, article.Publish())
+	})
+
+	t.Run("レビューが必要なら公開状態では作成できない", func(t *testing.T) {
+		t.Parallel()
+		_, err := entity.NewArticle("T", string(vo.ArticleStatusPublished), entity.WithReviewRequired(true))
+		assert.ErrorIs(t, err, entity.ErrReviewRequired)
+		_, err = entity.NewArticle("T", string(vo.ArticleStatusInReview))
+		assert.ErrorIs(t, err, entity.ErrInvalidStatusTransition)
+	})
+
+	t.Run("下書きに戻すと承認が取り消される", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleIn(t, vo.ArticleStatusDraft)
+		article.RequireReview()
+		require.NoError(t, article.SubmitForReview())
+		require.NoError(t, article.ApproveReview(time.Now()))
+		require.NoError(t, article.ChangeStatus(vo.ArticleStatusScheduled))
+		require.NoError(t, article.Draft())
+
+		assert.Nil(t, article.ApprovedAt)
+		assert.ErrorIs(t, article.ChangeStatus(vo.ArticleStatusScheduled), entity.ErrReviewRequired)
+	})
+
+	t.Run("公開中の記事の公開状態の間の変更には承認は不要", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleIn(t, vo.ArticleStatusPublished)
+		article.RequireReview()
+		require.NoError(t, article.Unlist())
+		require.NoError(t, article.Publish())
+	})
+
+	t.Run("レビュー中はタイトルと本文を変更できない", func(t *testing.T) {
+		t.Parallel()
+		body := "本文"
+		article, err := entity.NewArticle("T", string(vo.ArticleStatusDraft), entity.WithBody(&body))
+		require.NoError(t, err)
+		require.NoError(t, article.SubmitForReview())
+
+		title, changed := "T", "変更後"
+		assert.ErrorIs(t, article.Update(&title, &changed, nil, nil, nil), entity.ErrArticleInReview)
+		assert.ErrorIs(t, article.Draft(), entity.ErrArticleInReview)
+		require.NoError(t, article.Update(&title, &body, nil, nil, nil))
+
+		require.NoError(t, article.ReturnToDraft())
+		assert.True(t, article.Status.IsDraft())
+		require.NoError(t, article.Update(&title, &changed, nil, nil, nil))
+	})
+
+	t.Run("下書き以外は提出できない", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleIn(t, vo.ArticleStatusPublished)
+		assert.ErrorIs(t, article.SubmitForReview(), entity.ErrInvalidStatusTransition)
+		assert.ErrorIs(t, article.ApproveReview(time.Now()), entity.ErrInvalidStatusTransition)
+		assert.ErrorIs(t, article.ReturnToDraft(), entity.ErrInvalidStatusTransition)
+	})
+}
+
 func TestArticle_SoftDelete_And_Restore(t *testing.T) {
 	t.Parallel()
 	baseArticle, _ := entity.NewArticle("T", string(vo.ArticleStatusDraft))
@@ -222,6 +448,9 @@ func TestArticle_ChangeProvider(t *testing.T) {
 		require.NotNil(t, article.ProviderType)
 		assert.Equal(t, vo.ProviderTypeQiita, *article.ProviderType)
 		assert.True(t, article.UpdatedAt.After(originalUpdatedAt))
+		events := article.Events()
+		require.NotEmpty(t, events)
+		assert.Equal(t, entity.ArticleEventUpdated, events[len(events)-1].Type)
 	})
 
 	t.Run("公開済み記事のプロバイダ変更はエラー", func(t *testing.T) {
@@ -232,3 +461,329 @@ func TestArticle_ChangeProvider(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestArticle_Events(t *testing.T) {
+	t.Parallel()
+
+	eventTypes := func(events []entity.ArticleEvent) []entity.ArticleEventType {
+		var types []entity.ArticleEventType
+		for _, e := range events {
+			types = append(types, e.Type)
+		}
+		return types
+	}
+
+	t.Run("作成時にcreatedイベントが記録される", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("T", string(vo.ArticleStatusDraft))
+		require.NoError(t, err)
+		assert.Equal(t, []entity.ArticleEventType{entity.ArticleEventCreated}, eventTypes(article.Events()))
+	})
+
+	t.Run("公開状態で作成するとpublishedイベントも記録される", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("T", string(vo.ArticleStatusPublished))
+		require.NoError(t, err)
+		assert.Equal(t,
+			[]entity.ArticleEventType{entity.ArticleEventCreated, entity.ArticleEventPublished},
+			eventTypes(article.Events()),
+		)
+	})
+
+	t.Run("PullEventsで取り出すと記録がクリアされる", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("T", string(vo.ArticleStatusDraft))
+		require.NoError(t, err)
+		assert.Len(t, article.PullEvents(), 1)
+		assert.Empty(t, article.Events())
+	})
+
+	t.Run("状態変化ごとにイベントが記録される", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("T", string(vo.ArticleStatusDraft))
+		require.NoError(t, err)
+		article.PullEvents()
+
+		require.NoError(t, article.Publish())
+		require.NoError(t, article.Draft())
+		require.NoError(t, article.SoftDelete())
+		require.NoError(t, article.Restore())
+
+		assert.Equal(t, []entity.ArticleEventType{
+			entity.ArticleEventPublished,
+			entity.ArticleEventUnpublished,
+			entity.ArticleEventDeleted,
+			entity.ArticleEventRestored,
+		}, eventTypes(article.PullEvents()))
+	})
+
+	t.Run("Updateでステータスが公開に変わるとpublishedイベントも記録される", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("T", string(vo.ArticleStatusDraft))
+		require.NoError(t, err)
+		article.PullEvents()
+
+		status := string(vo.ArticleStatusPublished)
+		require.NoError(t, article.Update(nil, nil, &status, nil, nil))
+
+		assert.Equal(t,
+			[]entity.ArticleEventType{entity.ArticleEventUpdated, entity.ArticleEventPublished},
+			eventTypes(article.PullEvents()),
+		)
+	})
+}
+
+func TestArticle_Tags(t *testing.T) {
+	t.Parallel()
+
+	t.Run("作成時にタグを正規化して重複を除く", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("T", string(vo.ArticleStatusDraft), entity.WithTags([]string{"Go", "go", "設計"}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"go", "設計"}, article.TagStrings())
+	})
+
+	t.Run("無効なタグの場合はエラー", func(t *testing.T) {
+		t.Parallel()
+		_, err := entity.NewArticle("T", string(vo.ArticleStatusDraft), entity.WithTags([]string{"go lang"}))
+		assert.Error(t, err)
+	})
+
+	t.Run("AddTagsは未付与のタグのみ追加する", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("T", string(vo.ArticleStatusDraft), entity.WithTags([]string{"go"}))
+		require.NoError(t, err)
+		article.PullEvents()
+
+		require.NoError(t, article.AddTags("GO", "rust"))
+
+		assert.Equal(t, []string{"go", "rust"}, article.TagStrings())
+		assert.Len(t, article.PullEvents(), 1)
+	})
+
+	t.Run("AddTagsで追加がなければイベントを記録しない", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("T", string(vo.ArticleStatusDraft), entity.WithTags([]string{"go"}))
+		require.NoError(t, err)
+		article.PullEvents()
+
+		require.NoError(t, article.AddTags("go"))
+
+		assert.Empty(t, article.PullEvents())
+	})
+}
+
+func TestArticle_Slug(t *testing.T) {
+	t.Parallel()
+
+	t.Run("指定がなければタイトルから生成する", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("Hello World", string(vo.ArticleStatusDraft))
+		require.NoError(t, err)
+		assert.Equal(t, vo.Slug("hello-world"), article.Slug)
+	})
+
+	t.Run("指定したスラッグを使う", func(t *testing.T) {
+		t.Parallel()
+		slug := "custom-slug"
+		article, err := entity.NewArticle("Hello World", string(vo.ArticleStatusDraft), entity.WithSlug(&slug))
+		require.NoError(t, err)
+		assert.Equal(t, vo.Slug("custom-slug"), article.Slug)
+	})
+
+	t.Run("無効なスラッグの場合はエラー", func(t *testing.T) {
+		t.Parallel()
+		slug := "Invalid Slug"
+		_, err := entity.NewArticle("Hello World", string(vo.ArticleStatusDraft), entity.WithSlug(&slug))
+		assert.Error(t, err)
+	})
+
+	t.Run("ChangeSlugは変更があればイベントを記録する", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("Hello World", string(vo.ArticleStatusDraft))
+		require.NoError(t, err)
+		article.PullEvents()
+
+		require.NoError(t, article.ChangeSlug("hello-world"))
+		assert.Empty(t, article.PullEvents())
+
+		require.NoError(t, article.ChangeSlug("hello-go"))
+		assert.Equal(t, vo.Slug("hello-go"), article.Slug)
+		events := article.PullEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, entity.ArticleEventUpdated, events[0].Type)
+	})
+
+	t.Run("ChangeSlugで無効なスラッグの場合はエラー", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("Hello World", string(vo.ArticleStatusDraft))
+		require.NoError(t, err)
+
+		assert.Error(t, article.ChangeSlug(""))
+		assert.Equal(t, vo.Slug("hello-world"), article.Slug)
+	})
+}
+
+func TestArticle_Publications(t *testing.T) {
+	t.Parallel()
+
+	newArticleWithLink := func(t *testing.T) *entity.Article {
+		t.Helper()
+		provider := string(vo.ProviderTypeZenn)
+		link := "https://zenn.dev/example/articles/a"
+		article, err := entity.NewArticle("Hello World", string(vo.ArticleStatusDraft),
+			entity.WithProviderType(&provider),
+			entity.WithLink(&link),
+		)
+		require.NoError(t, err)
+		article.Publications[0].ID = 1
+		article.PullEvents()
+		return article
+	}
+
+	t.Run("プロバイダとリンクを指定すると正規の投稿先を作成する", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleWithLink(t)
+
+		require.Len(t, article.Publications, 1)
+		p := article.Publications[0]
+		assert.True(t, p.Canonical)
+		assert.Equal(t, "zenn", p.ProviderType.String())
+		assert.Equal(t, "https://zenn.dev/example/articles/a", p.Link.String())
+		assert.Equal(t, vo.SyncStatusSynced, p.SyncStatus)
+	})
+
+	t.Run("プロバイダとリンクがなければ投稿先を作成しない", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("Hello World", string(vo.ArticleStatusDraft))
+		require.NoError(t, err)
+		assert.Empty(t, article.Publications)
+	})
+
+	t.Run("AddPublicationはクロスポストを追加し正規の投稿先は変えない", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleWithLink(t)
+		provider := string(vo.ProviderTypeQiita)
+		link := "https://qiita.com/example/items/a"
+		externalID := "a"
+
+		require.NoError(t, article.AddPublication(&provider, &link, &externalID, nil))
+
+		require.Len(t, article.Publications, 2)
+		assert.False(t, article.Publications[1].Canonical)
+		assert.Equal(t, "a", *article.Publications[1].ExternalID)
+		assert.Equal(t, "zenn", article.ProviderType.String())
+		events := article.PullEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, entity.ArticleEventUpdated, events[0].Type)
+	})
+
+	t.Run("最初の投稿先は正規の投稿先になる", func(t *testing.T) {
+		t.Parallel()
+		article, err := entity.NewArticle("Hello World", string(vo.ArticleStatusDraft))
+		require.NoError(t, err)
+		provider := string(vo.ProviderTypeNote)
+
+		require.NoError(t, article.AddPublication(&provider, nil, nil, nil))
+
+		require.Len(t, article.Publications, 1)
+		assert.True(t, article.Publications[0].Canonical)
+		assert.Equal(t, vo.SyncStatusPending, article.Publications[0].SyncStatus)
+		assert.Equal(t, "note", article.ProviderType.String())
+		assert.Nil(t, article.Link)
+	})
+
+	t.Run("同じリンクの投稿先は追加できない", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleWithLink(t)
+		link := "https://zenn.dev/example/articles/a"
+
+		assert.Error(t, article.AddPublication(nil, &link, nil, nil))
+		assert.Len(t, article.Publications, 1)
+	})
+
+	t.Run("プロバイダとリンクのどちらもない投稿先はエラー", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleWithLink(t)
+		assert.Error(t, article.AddPublication(nil, nil, nil, nil))
+	})
+
+	t.Run("正規の投稿先を削除すると次の投稿先が繰り上がる", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleWithLink(t)
+		provider := string(vo.ProviderTypeQiita)
+		link := "https://qiita.com/example/items/a"
+		require.NoError(t, article.AddPublication(&provider, &link, nil, nil))
+		article.Publications[1].ID = 2
+
+		require.NoError(t, article.RemovePublication(1))
+
+		require.Len(t, article.Publications, 1)
+		assert.True(t, article.Publications[0].Canonical)
+		assert.Equal(t, "qiita", article.ProviderType.String())
+		assert.Equal(t, "https://qiita.com/example/items/a", article.Link.String())
+	})
+
+	t.Run("存在しない投稿先の削除はエラー", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleWithLink(t)
+		assert.Error(t, article.RemovePublication(99))
+	})
+
+	t.Run("SetCanonicalPublicationで正規の投稿先を切り替える", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleWithLink(t)
+		provider := string(vo.ProviderTypeQiita)
+		link := "https://qiita.com/example/items/a"
+		require.NoError(t, article.AddPublication(&provider, &link, nil, nil))
+		article.Publications[1].ID = 2
+
+		require.NoError(t, article.SetCanonicalPublication(2))
+
+		assert.False(t, article.Publications[0].Canonical)
+		assert.True(t, article.Publications[1].Canonical)
+		assert.Equal(t, "qiita", article.ProviderType.String())
+		assert.Equal(t, "https://qiita.com/example/items/a", article.Link.String())
+	})
+
+	t.Run("公開済みの記事でプロバイダが変わる切り替えはエラー", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleWithLink(t)
+		provider := string(vo.ProviderTypeQiita)
+		require.NoError(t, article.AddPublication(&provider, nil, nil, nil))
+		article.Publications[1].ID = 2
+		require.NoError(t, article.Publish())
+
+		assert.Error(t, article.SetCanonicalPublication(2))
+		assert.True(t, article.Publications[0].Canonical)
+	})
+
+	t.Run("Updateは正規の投稿先を更新しクロスポストは残す", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleWithLink(t)
+		qiita := string(vo.ProviderTypeQiita)
+		qiitaLink := "https://qiita.com/example/items/a"
+		require.NoError(t, article.AddPublication(&qiita, &qiitaLink, nil, nil))
+
+		zenn := string(vo.ProviderTypeZenn)
+		newLink := "https://zenn.dev/example/articles/b"
+		title := "Hello World"
+		require.NoError(t, article.Update(&title, nil, nil, &zenn, &newLink))
+
+		require.Len(t, article.Publications, 2)
+		assert.Equal(t, uint64(1), article.Publications[0].ID)
+		assert.Equal(t, newLink, article.Publications[0].Link.String())
+		assert.Equal(t, qiitaLink, article.Publications[1].Link.String())
+	})
+
+	t.Run("Updateでクロスポストと同じリンクにするとエラー", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleWithLink(t)
+		qiita := string(vo.ProviderTypeQiita)
+		qiitaLink := "https://qiita.com/example/items/a"
+		require.NoError(t, article.AddPublication(&qiita, &qiitaLink, nil, nil))
+
+		title := "Hello World"
+		assert.Error(t, article.Update(&title, nil, nil, &qiita, &qiitaLink))
+	})
+}
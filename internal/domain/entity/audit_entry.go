@@ -0,0 +1,172 @@
+package entity
+
+import (
+	"reflect"
+	"time"
+)
+
+// AuditAction は監査ログに記録する記事の操作の種類
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionPublish AuditAction = "publish"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionRestore AuditAction = "restore"
+	// AuditActionPurge はゴミ箱の記事の保持期間を過ぎた完全な削除
+	AuditActionPurge AuditAction = "purge"
+)
+
+var AllAuditActions = []AuditAction{
+	AuditActionCreate,
+	AuditActionUpdate,
+	AuditActionPublish,
+	AuditActionDelete,
+	AuditActionRestore,
+	AuditActionPurge,
+}
+
+func (a AuditAction) IsValid() bool {
+	for _, v := range AllAuditActions {
+		if a == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (a AuditAction) String() string {
+	return string(a)
+}
+
+// AuditActor は操作した呼び出し元
+// 認証が無効な場合や、バックグラウンドの処理による操作ではNameだけが設定される
+type AuditActor struct {
+	Name string
+	// Subject はベアラートークンの"sub"クレーム
+	Subject string
+	// KeyID は認証に使われたAPIキー
+	KeyID *uint64
+	// AuthorID は呼び出し元が執筆者として振る舞う場合の執筆者
+	AuthorID *uint64
+}
+
+// FieldChange は1つのフィールドの変更前後の値(作成では変更前、完全な削除では変更後の値はnil)
+type FieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditEntry は記事の操作の監査ログの1件
+// 追記のみで、記録後に変更・削除されない
+type AuditEntry struct {
+	ID        uint64
+	ArticleID uint64
+	Action    AuditAction
+	Actor     AuditActor
+	// Changes は変更されたフィールドごとの変更前後の値
+	Changes   map[string]FieldChange
+	RequestID string
+	IP        string
+	CreatedAt time.Time
+}
+
+// ArticleSnapshot は監査ログで比較する記事のフィールドの値
+// 値はJSONにそのまま書き出せる形(文字列・数値・文字列のスライス・nil)で保持する
+type ArticleSnapshot map[string]any
+
+// SnapshotArticle は記事の現在のフィールドの値を取り出す
+// 記事を変更する前に呼び出し、変更後の値と比較する
+func SnapshotArticle(a *Article) ArticleSnapshot {
+	if a == nil {
+		return nil
+	}
+	publications := make([]string, 0, len(a.Publications))
+	for _, p := range a.Publications {
+		v := p.ProviderType.String() + " " + p.Link.String()
+		if p.Canonical {
+			v += " (canonical)"
+		}
+		publications = append(publications, v)
+	}
+	return ArticleSnapshot{
+		"title":         a.Title.String(),
+		"slug":          a.Slug.String(),
+		"body":          a.Body.String(),
+		"status":        a.Status.String(),
+		"provider_type": a.ProviderType.String(),
+		"link":          a.Link.String(),
+		"publications":  publications,
+		"tags":          a.TagStrings(),
+		"author_id":     uintValue(a.AuthorID),
+		"approved_at":   timeValue(a.ApprovedAt),
+		"deleted_at":    timeValue(a.DeletedAt),
+	}
+}
+
+// NewAuditEntry は変更前後のスナップショットの差分から監査ログを作成する
+// 作成ではbefore、完全な削除ではafterがnilでもよい
+// 呼び出し元とリクエストの情報は記録するときに設定する
+func NewAuditEntry(articleID uint64, action AuditAction, before, after ArticleSnapshot) *AuditEntry {
+	return &AuditEntry{
+		ArticleID: articleID,
+		Action:    action,
+		Changes:   diffSnapshots(before, after),
+		CreatedAt: time.Now(),
+	}
+}
+
+// HasChanges は記録する変更があるかを判定する
+func (e *AuditEntry) HasChanges() bool {
+	return len(e.Changes) > 0
+}
+
+// diffSnapshots は値が異なるフィールドの変更前後の値を返す
+func diffSnapshots(before, after ArticleSnapshot) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+	add := func(k string) {
+		if _, ok := changes[k]; ok {
+			return
+		}
+		b, a := before[k], after[k]
+		if reflect.DeepEqual(b, a) || (isEmptyValue(b) && isEmptyValue(a)) {
+			return
+		}
+		changes[k] = FieldChange{Before: b, After: a}
+	}
+	for k := range before {
+		add(k)
+	}
+	for k := range after {
+		add(k)
+	}
+	return changes
+}
+
+// isEmptyValue は値なしとして扱う値(nil・空文字列・空のスライス)かを判定する
+func isEmptyValue(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []string:
+		return len(v) == 0
+	}
+	return false
+}
+
+func uintValue(v *uint64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+func timeValue(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,53 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+func TestNewAuditEntry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("作成では値のあるフィールドだけを記録", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleIn(t, vo.ArticleStatusDraft)
+
+		entry := entity.NewAuditEntry(article.ID, entity.AuditActionCreate, nil, entity.SnapshotArticle(article))
+
+		assert.Equal(t, entity.FieldChange{Before: nil, After: "T"}, entry.Changes["title"])
+		assert.Equal(t, entity.FieldChange{Before: nil, After: "draft"}, entry.Changes["status"])
+		assert.NotContains(t, entry.Changes, "body")
+		assert.NotContains(t, entry.Changes, "tags")
+		assert.NotContains(t, entry.Changes, "deleted_at")
+	})
+
+	t.Run("更新では変更されたフィールドだけを記録", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleIn(t, vo.ArticleStatusDraft)
+		before := entity.SnapshotArticle(article)
+
+		title, body := "新しいタイトル", "本文"
+		require.NoError(t, article.Update(&title, &body, nil, nil, nil))
+		require.NoError(t, article.AddTags("go"))
+		entry := entity.NewAuditEntry(article.ID, entity.AuditActionUpdate, before, entity.SnapshotArticle(article))
+
+		assert.Equal(t, map[string]entity.FieldChange{
+			"title": {Before: "T", After: "新しいタイトル"},
+			"body":  {Before: "", After: "本文"},
+			"tags":  {Before: []string{}, After: []string{"go"}},
+		}, entry.Changes)
+	})
+
+	t.Run("変更がなければ記録しない", func(t *testing.T) {
+		t.Parallel()
+		article := newArticleIn(t, vo.ArticleStatusDraft)
+
+		entry := entity.NewAuditEntry(article.ID, entity.AuditActionUpdate, entity.SnapshotArticle(article), entity.SnapshotArticle(article))
+
+		assert.False(t, entry.HasChanges())
+	})
+}
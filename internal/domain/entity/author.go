@@ -0,0 +1,154 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+// 表示名の最大文字数
+const maxAuthorDisplayNameLength = 100
+
+// プロバイダのユーザー名として受け付ける文字列
+var providerUsernamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// プロバイダごとのプロフィールURL
+var providerProfileURLs = map[vo.ProviderType]string{
+	vo.ProviderTypeQiita: "https://qiita.com/%s",
+	vo.ProviderTypeZenn:  "https://zenn.dev/%s",
+	vo.ProviderTypeNote:  "https://note.com/%s",
+}
+
+// Author は記事の執筆者を表す集約
+// Subjectは執筆者として認証するIdPのsubjectで、設定されている場合は一意
+type Author struct {
+	ID                uint64
+	DisplayName       string
+	ProviderUsernames map[vo.ProviderType]string
+	Subject           *string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// NewAuthor は新しい執筆者を作成する
+func NewAuthor(displayName string, providerUsernames map[string]string, subject *string) (*Author, error) {
+	a := &Author{}
+	if err := a.Update(&displayName, providerUsernames, subject); err != nil {
+		return nil, err
+	}
+	a.CreatedAt = a.UpdatedAt
+	return a, nil
+}
+
+// ReconstituteAuthor は永続化層から読み込んだデータから執筆者を再構築する
+func ReconstituteAuthor(
+	id uint64,
+	displayName string,
+	providerUsernames map[string]string,
+	subject *string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) (*Author, error) {
+	usernames, err := parseProviderUsernames(providerUsernames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstitute author: %w", err)
+	}
+	return &Author{
+		ID:                id,
+		DisplayName:       displayName,
+		ProviderUsernames: usernames,
+		Subject:           subject,
+		CreatedAt:         createdAt,
+		UpdatedAt:         updatedAt,
+	}, nil
+}
+
+// Update は執筆者の属性を更新する
+// nilの項目は変更せず、providerUsernamesの空文字のユーザー名はそのプロバイダの設定を削除する
+// subjectに空文字を渡すとsubjectの設定を削除する
+func (a *Author) Update(displayName *string, providerUsernames map[string]string, subject *string) error {
+	if displayName != nil {
+		name := strings.TrimSpace(*displayName)
+		if name == "" {
+			return fmt.Errorf("author display name must not be empty")
+		}
+		if len([]rune(name)) > maxAuthorDisplayNameLength {
+			return fmt.Errorf("author display name must be at most %d characters", maxAuthorDisplayNameLength)
+		}
+		a.DisplayName = name
+	}
+	if providerUsernames != nil {
+		merged := make(map[string]string, len(a.ProviderUsernames)+len(providerUsernames))
+		for p, u := range a.ProviderUsernames {
+			merged[p.String()] = u
+		}
+		for p, u := range providerUsernames {
+			if u == "" {
+				delete(merged, p)
+				continue
+			}
+			merged[p] = u
+		}
+		usernames, err := parseProviderUsernames(merged)
+		if err != nil {
+			return err
+		}
+		a.ProviderUsernames = usernames
+	}
+	if subject != nil {
+		if s := strings.TrimSpace(*subject); s != "" {
+			a.Subject = &s
+		} else {
+			a.Subject = nil
+		}
+	}
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+// ProfileURL はプロバイダ上の執筆者のプロフィールURLを返す
+func (a *Author) ProfileURL(provider vo.ProviderType) (string, bool) {
+	username, ok := a.ProviderUsernames[provider]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf(providerProfileURLs[provider], username), true
+}
+
+// ProviderUsernameStrings はプロバイダのユーザー名を文字列のキーで返す
+func (a *Author) ProviderUsernameStrings() map[string]string {
+	usernames := make(map[string]string, len(a.ProviderUsernames))
+	for p, u := range a.ProviderUsernames {
+		usernames[p.String()] = u
+	}
+	return usernames
+}
+
+// Providers はユーザー名が設定されているプロバイダを名前順で返す
+func (a *Author) Providers() []vo.ProviderType {
+	providers := make([]vo.ProviderType, 0, len(a.ProviderUsernames))
+	for p := range a.ProviderUsernames {
+		providers = append(providers, p)
+	}
+	sort.Slice(providers, func(i, j int) bool { return providers[i] < providers[j] })
+	return providers
+}
+
+func parseProviderUsernames(values map[string]string) (map[vo.ProviderType]string, error) {
+	usernames := make(map[vo.ProviderType]string, len(values))
+	for provider, username := range values {
+		p, err := vo.NewProviderType(&provider)
+		if err != nil || p == nil {
+			return nil, fmt.Errorf("invalid provider for username: %q", provider)
+		}
+		if !providerUsernamePattern.MatchString(username) {
+			return nil, fmt.Errorf("invalid %s username: %q", provider, username)
+		}
+		usernames[*p] = username
+	}
+	return usernames, nil
+}
@@ -0,0 +1,61 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+func TestNewAuthor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		display   string
+		usernames map[string]string
+		wantErr   bool
+	}{
+		{name: "有効な値で作成成功", display: "Alice", usernames: map[string]string{"qiita": "alice", "zenn": "alice_dev"}},
+		{name: "ユーザー名なしで作成成功", display: "Alice"},
+		{name: "表示名が空白のみはエラー", display: "  ", wantErr: true},
+		{name: "未知のプロバイダはエラー", display: "Alice", usernames: map[string]string{"medium": "alice"}, wantErr: true},
+		{name: "ユーザー名に使えない文字はエラー", display: "Alice", usernames: map[string]string{"note": "alice/../x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			a, err := entity.NewAuthor(tt.display, tt.usernames, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, a)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, a.ProviderUsernames, len(tt.usernames))
+		})
+	}
+}
+
+func TestAuthor_Update(t *testing.T) {
+	t.Parallel()
+
+	subject := "idp|123"
+	a, err := entity.NewAuthor("Alice", map[string]string{"qiita": "alice", "zenn": "alice"}, &subject)
+	require.NoError(t, err)
+
+	empty := ""
+	require.NoError(t, a.Update(nil, map[string]string{"zenn": "", "note": "alice_note"}, &empty))
+
+	assert.Equal(t, "Alice", a.DisplayName)
+	assert.Equal(t, []vo.ProviderType{vo.ProviderTypeNote, vo.ProviderTypeQiita}, a.Providers())
+	assert.Nil(t, a.Subject)
+	url, ok := a.ProfileURL(vo.ProviderTypeNote)
+	assert.True(t, ok)
+	assert.Equal(t, "https://note.com/alice_note", url)
+	_, ok = a.ProfileURL(vo.ProviderTypeZenn)
+	assert.False(t, ok)
+}
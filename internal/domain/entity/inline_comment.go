@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+// ErrCommentIsReply はスレッドの先頭でないコメントに返信・解決・範囲の操作をしようとした場合に返される
+var ErrCommentIsReply = errors.New("comment is a reply")
+
+// InlineComment は記事本文の範囲に付けるコメント
+// スレッドの先頭のコメントが本文の範囲(Anchor)と解決状態を持ち、返信はParentIDで先頭のコメントを指す
+type InlineComment struct {
+	ID        uint64
+	ArticleID uint64
+	ParentID  *uint64
+	// Anchor はコメントを付けた本文の範囲(返信ではnil)
+	Anchor *vo.TextAnchor
+	// Outdated は本文の変更で引用した範囲が見つからなくなったか
+	Outdated  bool
+	Commenter ReviewActor
+	Body      string
+	// ResolvedBy / ResolvedAt はスレッドを解決した呼び出し元と日時
+	ResolvedBy *ReviewActor
+	ResolvedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewInlineComment は本文の範囲にコメントを付け、スレッドを始める
+func NewInlineComment(articleID uint64, anchor vo.TextAnchor, by ReviewActor, body string) (*InlineComment, error) {
+	body, err := validCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &InlineComment{
+		ArticleID: articleID,
+		Anchor:    &anchor,
+		Commenter: by,
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsReply はスレッドへの返信かを判定する
+func (c *InlineComment) IsReply() bool {
+	return c.ParentID != nil
+}
+
+// Reply はスレッドに返信する
+func (c *InlineComment) Reply(by ReviewActor, body string) (*InlineComment, error) {
+	if c.IsReply() {
+		return nil, fmt.Errorf("comment %d: reply to the first comment of the thread: %w", c.ID, ErrCommentIsReply)
+	}
+	body, err := validCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	parentID := c.ID
+	return &InlineComment{
+		ArticleID: c.ArticleID,
+		ParentID:  &parentID,
+		Commenter: by,
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Edit はコメントの本文を変更する
+func (c *InlineComment) Edit(body string) error {
+	body, err := validCommentBody(body)
+	if err != nil {
+		return err
+	}
+	c.Body = body
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// Resolve はスレッドを解決済みにする(解決済みの場合は何もしない)
+func (c *InlineComment) Resolve(by ReviewActor) error {
+	if c.IsReply() {
+		return fmt.Errorf("comment %d: resolve the first comment of the thread: %w", c.ID, ErrCommentIsReply)
+	}
+	if c.ResolvedAt != nil {
+		return nil
+	}
+	now := time.Now()
+	c.ResolvedBy = &by
+	c.ResolvedAt = &now
+	c.UpdatedAt = now
+	return nil
+}
+
+// Unresolve はスレッドを未解決に戻す
+func (c *InlineComment) Unresolve() error {
+	if c.IsReply() {
+		return fmt.Errorf("comment %d: unresolve the first comment of the thread: %w", c.ID, ErrCommentIsReply)
+	}
+	c.ResolvedBy = nil
+	c.ResolvedAt = nil
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// Reanchor は変更後の本文に合わせて範囲を探し直し、変更があったかを返す
+// 引用が見つからない場合は範囲をそのまま残してOutdatedにし、後の変更で引用が戻れば再び範囲を合わせる
+func (c *InlineComment) Reanchor(body string) bool {
+	if c.Anchor == nil {
+		return false
+	}
+	anchor, ok := c.Anchor.Reanchor(body)
+	if !ok {
+		if c.Outdated {
+			return false
+		}
+		c.Outdated = true
+		c.UpdatedAt = time.Now()
+		return true
+	}
+	if anchor == *c.Anchor && !c.Outdated {
+		return false
+	}
+	c.Anchor = &anchor
+	c.Outdated = false
+	c.UpdatedAt = time.Now()
+	return true
+}
@@ -0,0 +1,68 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+func newInlineComment(t *testing.T, body string, start, end int) *entity.InlineComment {
+	t.Helper()
+	anchor, err := vo.NewTextAnchor(body, start, end, "")
+	require.NoError(t, err)
+	c, err := entity.NewInlineComment(1, anchor, reviewer, "ここは言い換えたい")
+	require.NoError(t, err)
+	c.ID = 10
+	return c
+}
+
+func TestInlineComment_Thread(t *testing.T) {
+	t.Parallel()
+
+	c := newInlineComment(t, "はじめに。本題。", 5, 7)
+
+	reply, err := c.Reply(submitter, "直しました")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), *reply.ParentID)
+	assert.Nil(t, reply.Anchor)
+
+	reply.ID = 11
+	_, err = reply.Reply(reviewer, "返信への返信")
+	assert.ErrorIs(t, err, entity.ErrCommentIsReply)
+	assert.ErrorIs(t, reply.Resolve(reviewer), entity.ErrCommentIsReply)
+
+	require.NoError(t, c.Resolve(reviewer))
+	assert.NotNil(t, c.ResolvedAt)
+	assert.Equal(t, "bob", c.ResolvedBy.Name)
+	require.NoError(t, c.Unresolve())
+	assert.Nil(t, c.ResolvedAt)
+	assert.Nil(t, c.ResolvedBy)
+
+	assert.Error(t, c.Edit(" "))
+	require.NoError(t, c.Edit("やはりこのままで"))
+	assert.Equal(t, "やはりこのままで", c.Body)
+}
+
+func TestInlineComment_Reanchor(t *testing.T) {
+	t.Parallel()
+
+	c := newInlineComment(t, "はじめに。本題。", 5, 7)
+
+	assert.False(t, c.Reanchor("はじめに。本題。"), "本文が変わらなければ変更なし")
+
+	require.True(t, c.Reanchor("前書き。はじめに。本題。"))
+	assert.Equal(t, 9, c.Anchor.Start)
+	assert.False(t, c.Outdated)
+
+	require.True(t, c.Reanchor("前書き。はじめに。"))
+	assert.True(t, c.Outdated, "引用が消えると古くなる")
+	assert.Equal(t, 9, c.Anchor.Start, "範囲は残す")
+	assert.False(t, c.Reanchor("前書き。はじめに。!"), "古いままなら変更なし")
+
+	require.True(t, c.Reanchor("本題。"))
+	assert.False(t, c.Outdated, "引用が戻れば範囲を合わせ直す")
+	assert.Equal(t, 0, c.Anchor.Start)
+}
@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// LinkSource はチェック対象のURLがどこに書かれていたかを表す
+type LinkSource string
+
+const (
+	// LinkSourcePublication は投稿先のリンク
+	LinkSourcePublication LinkSource = "publication"
+	// LinkSourceBody は本文中のURL
+	LinkSourceBody LinkSource = "body"
+)
+
+func (s LinkSource) String() string {
+	return string(s)
+}
+
+// LinkCheckStatus はリンクチェックの結果を表す
+type LinkCheckStatus string
+
+const (
+	LinkCheckOK     LinkCheckStatus = "ok"
+	LinkCheckBroken LinkCheckStatus = "broken"
+	// LinkCheckSkipped はrobots.txtで拒否されているなど、チェックしなかったことを表す
+	LinkCheckSkipped LinkCheckStatus = "skipped"
+)
+
+func (s LinkCheckStatus) String() string {
+	return string(s)
+}
+
+// LinkCheck は記事中の1つのURLに対する最新のチェック結果を表すエンティティ
+type LinkCheck struct {
+	ID         uint64
+	ArticleID  uint64
+	URL        string
+	Source     LinkSource
+	Status     LinkCheckStatus
+	StatusCode *int
+	// Redirects は最終的なURLに至るまでのリダイレクト先(順番どおり)
+	Redirects []string
+	LastError *string
+	CheckedAt time.Time
+}
+
+// NewLinkCheck はHTTPの応答からチェック結果を作成する
+// 応答がない(errMsgが空でない)か、最終的なステータスコードが400以上の場合はリンク切れとする
+func NewLinkCheck(articleID uint64, url string, source LinkSource, statusCode int, redirects []string, errMsg string, checkedAt time.Time) (*LinkCheck, error) {
+	if url == "" {
+		return nil, fmt.Errorf("link check requires a url")
+	}
+	c := &LinkCheck{
+		ArticleID: articleID,
+		URL:       url,
+		Source:    source,
+		Status:    LinkCheckOK,
+		Redirects: redirects,
+		CheckedAt: checkedAt,
+	}
+	if c.Redirects == nil {
+		c.Redirects = []string{}
+	}
+	if statusCode > 0 {
+		c.StatusCode = &statusCode
+	}
+	if errMsg != "" {
+		c.LastError = &errMsg
+	}
+	if errMsg != "" || statusCode >= 400 {
+		c.Status = LinkCheckBroken
+	}
+	return c, nil
+}
+
+// NewSkippedLinkCheck はチェックを見送ったURLの結果を作成する
+func NewSkippedLinkCheck(articleID uint64, url string, source LinkSource, reason string, checkedAt time.Time) *LinkCheck {
+	return &LinkCheck{
+		ArticleID: articleID,
+		URL:       url,
+		Source:    source,
+		Status:    LinkCheckSkipped,
+		Redirects: []string{},
+		LastError: &reason,
+		CheckedAt: checkedAt,
+	}
+}
+
+// IsBroken はリンク切れかを判定する
+func (c *LinkCheck) IsBroken() bool {
+	return c.Status == LinkCheckBroken
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// OGPの各項目の最大文字数(超えた分は切り詰める)
+const (
+	maxOpenGraphTitleLength       = 300
+	maxOpenGraphDescriptionLength = 1000
+	maxOpenGraphURLLength         = 2048
+)
+
+// OpenGraph はリンク先ページのOGP(og:title / og:description / og:image / og:site_name)
+type OpenGraph struct {
+	Title       string
+	Description string
+	Image       string
+	SiteName    string
+}
+
+// IsEmpty はOGPの項目が1つも取得できていないかを判定する
+func (o OpenGraph) IsEmpty() bool {
+	return o == OpenGraph{}
+}
+
+// normalized は各項目を最大文字数に切り詰める
+// 長すぎる画像URLは途中で切ると壊れるため空にする
+func (o OpenGraph) normalized() OpenGraph {
+	o.Title = truncateRunes(o.Title, maxOpenGraphTitleLength)
+	o.Description = truncateRunes(o.Description, maxOpenGraphDescriptionLength)
+	o.SiteName = truncateRunes(o.SiteName, maxOpenGraphTitleLength)
+	if len(o.Image) > maxOpenGraphURLLength {
+		o.Image = ""
+	}
+	return o
+}
+
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// LinkPreviewStatus は最後のOGP取得の結果を表す
+type LinkPreviewStatus string
+
+const (
+	LinkPreviewFetched LinkPreviewStatus = "fetched"
+	LinkPreviewFailed  LinkPreviewStatus = "failed"
+)
+
+func (s LinkPreviewStatus) String() string {
+	return string(s)
+}
+
+// LinkPreview は記事のリンク先のOGPを保存したエンティティ
+// 同じURLを持つ記事の間で共有する
+type LinkPreview struct {
+	ID        uint64
+	URL       string
+	OpenGraph OpenGraph
+	Status    LinkPreviewStatus
+	LastError *string
+	// FetchedAt は最後に取得を試みた日時
+	FetchedAt time.Time
+}
+
+// NewLinkPreview はまだ取得していないURLのプレビューを作成する
+func NewLinkPreview(url string) (*LinkPreview, error) {
+	if url == "" {
+		return nil, fmt.Errorf("link preview requires a url")
+	}
+	return &LinkPreview{URL: url}, nil
+}
+
+// Fetched は取得したOGPを記録する
+func (p *LinkPreview) Fetched(og OpenGraph, at time.Time) {
+	p.OpenGraph = og.normalized()
+	p.Status = LinkPreviewFetched
+	p.LastError = nil
+	p.FetchedAt = at
+}
+
+// Failed は取得の失敗を記録する
+// 以前に取得したOGPは次に取得できるまで残す
+func (p *LinkPreview) Failed(errMsg string, at time.Time) {
+	p.Status = LinkPreviewFailed
+	p.LastError = &errMsg
+	p.FetchedAt = at
+}
@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+// ErrPublicationDuplicated は記事に同じ投稿先が既に登録されている場合に返される
+var ErrPublicationDuplicated = errors.New("publication is already registered")
+
+// Publication は記事の投稿先(クロスポストを含む)を表す
+// 記事ごとに1件だけ正規(canonical)の投稿先を持つ
+type Publication struct {
+	ID           uint64
+	ProviderType *vo.ProviderType
+	Link         *vo.Link
+	ExternalID   *string
+	PublishedAt  *time.Time
+	SyncStatus   vo.SyncStatus
+	Canonical    bool
+}
+
+// NewPublication は投稿先を作成する
+// プロバイダとリンクの少なくとも一方が必要。リンクがない場合は反映待ちとする
+func NewPublication(providerType *string, link *string, externalID *string, publishedAt *time.Time) (*Publication, error) {
+	pt, err := vo.NewProviderType(providerType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid provider type for publication: %w", err)
+	}
+	l, err := vo.NewLink(link)
+	if err != nil {
+		return nil, fmt.Errorf("invalid link for publication: %w", err)
+	}
+	if pt == nil && l == nil {
+		return nil, fmt.Errorf("publication requires a provider type or a link")
+	}
+	if externalID != nil && *externalID == "" {
+		externalID = nil
+	}
+
+	status := vo.SyncStatusSynced
+	if l == nil {
+		status = vo.SyncStatusPending
+	}
+	return &Publication{
+		ProviderType: pt,
+		Link:         l,
+		ExternalID:   externalID,
+		PublishedAt:  publishedAt,
+		SyncStatus:   status,
+	}, nil
+}
+
+// ReconstitutePublication は永続化層から読み込んだデータから投稿先を再構築する
+func ReconstitutePublication(
+	id uint64,
+	providerType *string,
+	link *string,
+	externalID *string,
+	publishedAt *time.Time,
+	syncStatus string,
+	canonical bool,
+) (*Publication, error) {
+	p, err := NewPublication(providerType, link, externalID, publishedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstitute publication: %w", err)
+	}
+	status, err := vo.NewSyncStatus(syncStatus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstitute publication: %w", err)
+	}
+	p.ID = id
+	p.SyncStatus = status
+	p.Canonical = canonical
+	return p, nil
+}
+
+// sameTarget は同じ投稿先を指しているかを判定する
+// リンクがあればリンクで、なければプロバイダで比較する
+func (p *Publication) sameTarget(other *Publication) bool {
+	if p.Link != nil || other.Link != nil {
+		return p.Link.String() == other.Link.String()
+	}
+	return p.ProviderType.String() == other.ProviderType.String()
+}
+
+// describeTarget はエラーメッセージ用に投稿先を表す文字列を返す
+func describeTarget(p *Publication) string {
+	if p.Link != nil {
+		return fmt.Sprintf("link %q", p.Link.String())
+	}
+	return fmt.Sprintf("provider %q", p.ProviderType.String())
+}
@@ -0,0 +1,159 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+// レビュー・インラインのコメントの最大文字数
+const maxCommentLength = 10000
+
+// ErrReviewClosed は判断済みのレビュー依頼を承認・差し戻し・取り下げしようとした場合に返される
+var ErrReviewClosed = errors.New("review request is closed")
+
+// ReviewActor はレビューを提出・判断・コメントした呼び出し元
+type ReviewActor struct {
+	// Name は呼び出し元の表示名(APIキーの名前やトークンの表示名)
+	Name string
+	// AuthorID は呼び出し元が執筆者として振る舞う場合の執筆者
+	AuthorID *uint64
+}
+
+// ReviewComment はレビュー依頼のコメント
+// ParentIDが設定されたコメントは、そのコメントから始まるスレッドへの返信を表す
+type ReviewComment struct {
+	ID        uint64
+	ParentID  *uint64
+	Commenter ReviewActor
+	Body      string
+	CreatedAt time.Time
+}
+
+// ReviewRequest は記事の公開前のレビュー依頼
+// 記事ごとに判断待ちの依頼は1件までで、依頼の状態は記事のステータスと合わせて変更する
+type ReviewRequest struct {
+	ID          uint64
+	ArticleID   uint64
+	Status      vo.ReviewStatus
+	SubmittedBy ReviewActor
+	// DecidedBy / DecidedAt は承認・差し戻し・取り下げした呼び出し元と日時
+	DecidedBy *ReviewActor
+	DecidedAt *time.Time
+	Comments  []ReviewComment
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewReviewRequest は記事のレビュー依頼を作成する
+// commentが空でなければ最初のコメントとして追加する
+func NewReviewRequest(articleID uint64, submittedBy ReviewActor, comment string) (*ReviewRequest, error) {
+	now := time.Now()
+	r := &ReviewRequest{
+		ArticleID:   articleID,
+		Status:      vo.ReviewStatusPending,
+		SubmittedBy: submittedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if strings.TrimSpace(comment) != "" {
+		if _, err := r.AddComment(submittedBy, comment, nil); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Approve はレビュー依頼を承認する
+func (r *ReviewRequest) Approve(by ReviewActor, comment string) error {
+	if r.Status != vo.ReviewStatusPending {
+		return fmt.Errorf("review request %d is %s: %w", r.ID, r.Status, ErrReviewClosed)
+	}
+	return r.decide(vo.ReviewStatusApproved, by, comment)
+}
+
+// RequestChanges は修正を求めてレビュー依頼を差し戻す
+// 何を直すべきかを伝えるため、コメントは必須
+func (r *ReviewRequest) RequestChanges(by ReviewActor, comment string) error {
+	if r.Status != vo.ReviewStatusPending {
+		return fmt.Errorf("review request %d is %s: %w", r.ID, r.Status, ErrReviewClosed)
+	}
+	if strings.TrimSpace(comment) == "" {
+		return fmt.Errorf("a comment is required to request changes")
+	}
+	return r.decide(vo.ReviewStatusChangesRequested, by, comment)
+}
+
+// Withdraw はレビュー依頼を取り下げる
+// 承認済みの依頼も、記事を公開する前であれば取り下げて修正できる
+func (r *ReviewRequest) Withdraw(by ReviewActor) error {
+	if !r.Status.IsOpen() {
+		return fmt.Errorf("review request %d is %s: %w", r.ID, r.Status, ErrReviewClosed)
+	}
+	return r.decide(vo.ReviewStatusWithdrawn, by, "")
+}
+
+func (r *ReviewRequest) decide(status vo.ReviewStatus, by ReviewActor, comment string) error {
+	if strings.TrimSpace(comment) != "" {
+		if _, err := r.AddComment(by, comment, nil); err != nil {
+			return err
+		}
+	}
+	now := time.Now()
+	r.Status = status
+	r.DecidedBy = &by
+	r.DecidedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// AddComment はコメントを追加する
+// parentIDを指定した場合は、この依頼のスレッドの先頭のコメントへの返信になる
+func (r *ReviewRequest) AddComment(by ReviewActor, body string, parentID *uint64) (*ReviewComment, error) {
+	body, err := validCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+	if parentID != nil {
+		parent, ok := r.comment(*parentID)
+		if !ok {
+			return nil, fmt.Errorf("comment %d is not found in review request %d", *parentID, r.ID)
+		}
+		if parent.ParentID != nil {
+			return nil, fmt.Errorf("comment %d is a reply; reply to the first comment of the thread", *parentID)
+		}
+	}
+	now := time.Now()
+	r.Comments = append(r.Comments, ReviewComment{
+		ParentID:  parentID,
+		Commenter: by,
+		Body:      body,
+		CreatedAt: now,
+	})
+	r.UpdatedAt = now
+	return &r.Comments[len(r.Comments)-1], nil
+}
+
+func (r *ReviewRequest) comment(id uint64) (ReviewComment, bool) {
+	for _, c := range r.Comments {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	return ReviewComment{}, false
+}
+
+// validCommentBody は前後の空白を除いたコメントの本文を検証する
+func validCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", fmt.Errorf("comment must not be empty")
+	}
+	if len([]rune(body)) > maxCommentLength {
+		return "", fmt.Errorf("comment must be at most %d characters", maxCommentLength)
+	}
+	return body, nil
+}
@@ -0,0 +1,125 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+var (
+	submitter = entity.ReviewActor{Name: "alice"}
+	reviewer  = entity.ReviewActor{Name: "bob"}
+)
+
+func TestNewReviewRequest(t *testing.T) {
+	t.Parallel()
+
+	r, err := entity.NewReviewRequest(1, submitter, "  確認をお願いします  ")
+	require.NoError(t, err)
+	assert.Equal(t, vo.ReviewStatusPending, r.Status)
+	require.Len(t, r.Comments, 1)
+	assert.Equal(t, "確認をお願いします", r.Comments[0].Body)
+
+	r, err = entity.NewReviewRequest(1, submitter, "")
+	require.NoError(t, err)
+	assert.Empty(t, r.Comments)
+}
+
+func TestReviewRequest_Decide(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		decide     func(*entity.ReviewRequest) error
+		wantStatus vo.ReviewStatus
+		wantErr    bool
+	}{
+		{
+			name:       "承認する",
+			decide:     func(r *entity.ReviewRequest) error { return r.Approve(reviewer, "") },
+			wantStatus: vo.ReviewStatusApproved,
+		},
+		{
+			name: "コメントを付けて差し戻す",
+			decide: func(r *entity.ReviewRequest) error {
+				return r.RequestChanges(reviewer, "見出しを直してください")
+			},
+			wantStatus: vo.ReviewStatusChangesRequested,
+		},
+		{
+			name:    "コメントなしでは差し戻せない",
+			decide:  func(r *entity.ReviewRequest) error { return r.RequestChanges(reviewer, " ") },
+			wantErr: true,
+		},
+		{
+			name:       "取り下げる",
+			decide:     func(r *entity.ReviewRequest) error { return r.Withdraw(submitter) },
+			wantStatus: vo.ReviewStatusWithdrawn,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, err := entity.NewReviewRequest(1, submitter, "")
+			require.NoError(t, err)
+
+			err = tt.decide(r)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, vo.ReviewStatusPending, r.Status)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, r.Status)
+			assert.NotNil(t, r.DecidedAt)
+		})
+	}
+
+	t.Run("判断済みの依頼は判断し直せない", func(t *testing.T) {
+		t.Parallel()
+		r, err := entity.NewReviewRequest(1, submitter, "")
+		require.NoError(t, err)
+		require.NoError(t, r.RequestChanges(reviewer, "直してください"))
+
+		assert.ErrorIs(t, r.Approve(reviewer, ""), entity.ErrReviewClosed)
+		assert.ErrorIs(t, r.Withdraw(submitter), entity.ErrReviewClosed)
+	})
+
+	t.Run("承認済みの依頼は取り下げられる", func(t *testing.T) {
+		t.Parallel()
+		r, err := entity.NewReviewRequest(1, submitter, "")
+		require.NoError(t, err)
+		require.NoError(t, r.Approve(reviewer, ""))
+
+		assert.ErrorIs(t, r.RequestChanges(reviewer, "やはり直してください"), entity.ErrReviewClosed)
+		require.NoError(t, r.Withdraw(submitter))
+	})
+}
+
+func TestReviewRequest_AddComment(t *testing.T) {
+	t.Parallel()
+
+	r, err := entity.NewReviewRequest(1, submitter, "確認をお願いします")
+	require.NoError(t, err)
+	r.Comments[0].ID = 10
+
+	root := uint64(10)
+	reply, err := r.AddComment(reviewer, "了解です", &root)
+	require.NoError(t, err)
+	assert.Equal(t, &root, reply.ParentID)
+	r.Comments[1].ID = 11
+
+	reply2 := uint64(11)
+	_, err = r.AddComment(submitter, "返信への返信", &reply2)
+	assert.Error(t, err, "返信には返信できない")
+
+	unknown := uint64(99)
+	_, err = r.AddComment(submitter, "コメント", &unknown)
+	assert.Error(t, err)
+
+	_, err = r.AddComment(submitter, "", nil)
+	assert.Error(t, err)
+}
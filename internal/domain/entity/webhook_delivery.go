@@ -0,0 +1,86 @@
+package entity
+
+import "time"
+
+// WebhookDeliveryStatus はWebhook配信の状態を表す
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+func (s WebhookDeliveryStatus) String() string {
+	return string(s)
+}
+
+// WebhookDelivery は1件のWebhook配信とその結果を表すエンティティ
+// 配信ログとして保持され、再送(Replay)の元にもなる
+type WebhookDelivery struct {
+	ID             uint64
+	SubscriptionID uint64
+	EventType      ArticleEventType
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	ResponseStatus *int
+	LastError      *string
+	NextAttemptAt  time.Time
+	ReplayOf       *uint64
+	// MessageID は配信の元になったアウトボックスのメッセージ(再送などメッセージ以外から作成した場合はnil)
+	MessageID   *uint64
+	CreatedAt   time.Time
+	DeliveredAt *time.Time
+}
+
+// NewWebhookDelivery は配信待ちのWebhook配信を作成する
+func NewWebhookDelivery(subscriptionID uint64, eventType ArticleEventType, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		SubscriptionID: subscriptionID,
+		EventType:      eventType,
+		Payload:        payload,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+}
+
+// RecordSuccess は配信成功を記録する
+func (d *WebhookDelivery) RecordSuccess(statusCode int, at time.Time) {
+	d.Attempts++
+	d.Status = WebhookDeliverySucceeded
+	d.ResponseStatus = &statusCode
+	d.LastError = nil
+	d.DeliveredAt = &at
+}
+
+// RecordFailure は配信失敗を記録する
+// nextAttemptAtがnilの場合は再試行せず失敗として確定する
+func (d *WebhookDelivery) RecordFailure(statusCode *int, errMsg string, nextAttemptAt *time.Time) {
+	d.Attempts++
+	d.ResponseStatus = statusCode
+	d.LastError = &errMsg
+	if nextAttemptAt == nil {
+		d.Status = WebhookDeliveryFailed
+		return
+	}
+	d.NextAttemptAt = *nextAttemptAt
+}
+
+// NewWebhookDeliveryForMessage はアウトボックスのメッセージから配信待ちのWebhook配信を作成する
+// 同じメッセージと購読の配信は1件だけ保存される
+func NewWebhookDeliveryForMessage(messageID, subscriptionID uint64, eventType ArticleEventType, payload []byte) *WebhookDelivery {
+	d := NewWebhookDelivery(subscriptionID, eventType, payload)
+	d.MessageID = &messageID
+	return d
+}
+
+// Replay は同じペイロードで新たな配信を作成する
+func (d *WebhookDelivery) Replay() *WebhookDelivery {
+	replay := NewWebhookDelivery(d.SubscriptionID, d.EventType, d.Payload)
+	originalID := d.ID
+	replay.ReplayOf = &originalID
+	return replay
+}
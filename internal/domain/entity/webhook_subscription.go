@@ -0,0 +1,170 @@
+package entity
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Webhook署名用シークレットの最小文字数
+const MinWebhookSecretLength = 16
+
+// WebhookSubscription は記事イベントの通知先を表すエンティティ
+type WebhookSubscription struct {
+	ID         uint64
+	URL        string
+	Secret     string
+	EventTypes []ArticleEventType
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewWebhookSubscription は新しいWebhook購読を作成する
+func NewWebhookSubscription(rawURL string, secret string, eventTypes []string) (*WebhookSubscription, error) {
+	if err := validateWebhookURL(rawURL); err != nil {
+		return nil, err
+	}
+	if err := validateWebhookSecret(secret); err != nil {
+		return nil, err
+	}
+	types, err := parseArticleEventTypes(eventTypes)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &WebhookSubscription{
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: types,
+		Active:     true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// ReconstituteWebhookSubscription は永続化層から読み込んだデータからWebhook購読を再構築する
+func ReconstituteWebhookSubscription(
+	id uint64,
+	rawURL string,
+	secret string,
+	eventTypes []string,
+	active bool,
+	createdAt time.Time,
+	updatedAt time.Time,
+) (*WebhookSubscription, error) {
+	types, err := parseArticleEventTypes(eventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstitute webhook subscription: %w", err)
+	}
+	return &WebhookSubscription{
+		ID:         id,
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: types,
+		Active:     active,
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
+	}, nil
+}
+
+// Update はWebhook購読の属性を更新する
+// nilの項目は変更しない
+func (s *WebhookSubscription) Update(rawURL *string, secret *string, eventTypes []string, active *bool) error {
+	if rawURL != nil {
+		if err := validateWebhookURL(*rawURL); err != nil {
+			return err
+		}
+	}
+	if secret != nil {
+		if err := validateWebhookSecret(*secret); err != nil {
+			return err
+		}
+	}
+	var types []ArticleEventType
+	if eventTypes != nil {
+		t, err := parseArticleEventTypes(eventTypes)
+		if err != nil {
+			return err
+		}
+		types = t
+	}
+
+	if rawURL != nil {
+		s.URL = *rawURL
+	}
+	if secret != nil {
+		s.Secret = *secret
+	}
+	if types != nil {
+		s.EventTypes = types
+	}
+	if active != nil {
+		s.Active = *active
+	}
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// Subscribes は指定のイベントを購読しているかを判定する
+func (s *WebhookSubscription) Subscribes(eventType ArticleEventType) bool {
+	if !s.Active {
+		return false
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// EventTypeStrings は購読イベントを文字列で返す
+func (s *WebhookSubscription) EventTypeStrings() []string {
+	types := make([]string, 0, len(s.EventTypes))
+	for _, t := range s.EventTypes {
+		types = append(types, t.String())
+	}
+	return types
+}
+
+func validateWebhookURL(rawURL string) error {
+	u, err := url.ParseRequestURI(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("webhook url must use http or https: %s", rawURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("webhook url must have a host: %s", rawURL)
+	}
+	return nil
+}
+
+func validateWebhookSecret(secret string) error {
+	if len(secret) < MinWebhookSecretLength {
+		return fmt.Errorf("webhook secret must be at least %d characters", MinWebhookSecretLength)
+	}
+	return nil
+}
+
+func parseArticleEventTypes(values []string) ([]ArticleEventType, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("at least one event type is required")
+	}
+	types := make([]ArticleEventType, 0, len(values))
+	seen := make(map[ArticleEventType]bool)
+	for _, v := range values {
+		t := ArticleEventType(v)
+		if !t.IsValid() {
+			return nil, fmt.Errorf("invalid event type: %s", v)
+		}
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		types = append(types, t)
+	}
+	return types, nil
+}
@@ -0,0 +1,150 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+)
+
+const validSecret = "0123456789abcdef"
+
+func TestNewWebhookSubscription(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		url        string
+		secret     string
+		eventTypes []string
+		wantErr    bool
+	}{
+		{name: "有効な値で作成成功", url: "https://example.com/hook", secret: validSecret, eventTypes: []string{"article.published"}},
+		{name: "http以外のスキームはエラー", url: "ftp://example.com/hook", secret: validSecret, eventTypes: []string{"article.published"}, wantErr: true},
+		{name: "相対URLはエラー", url: "/hook", secret: validSecret, eventTypes: []string{"article.published"}, wantErr: true},
+		{name: "シークレットが短い場合はエラー", url: "https://example.com/hook", secret: "short", eventTypes: []string{"article.published"}, wantErr: true},
+		{name: "イベント未指定はエラー", url: "https://example.com/hook", secret: validSecret, eventTypes: nil, wantErr: true},
+		{name: "未知のイベントはエラー", url: "https://example.com/hook", secret: validSecret, eventTypes: []string{"article.unknown"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sub, err := entity.NewWebhookSubscription(tt.url, tt.secret, tt.eventTypes)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, sub)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, sub.Active)
+			assert.Equal(t, tt.url, sub.URL)
+		})
+	}
+
+	t.Run("重複したイベントはまとめられる", func(t *testing.T) {
+		t.Parallel()
+		sub, err := entity.NewWebhookSubscription("https://example.com/hook", validSecret, []string{"article.published", "article.published"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"article.published"}, sub.EventTypeStrings())
+	})
+}
+
+func TestWebhookSubscription_Update(t *testing.T) {
+	t.Parallel()
+
+	t.Run("指定した項目のみ更新される", func(t *testing.T) {
+		t.Parallel()
+		sub, err := entity.NewWebhookSubscription("https://example.com/hook", validSecret, []string{"article.published"})
+		require.NoError(t, err)
+
+		active := false
+		err = sub.Update(nil, nil, []string{"article.deleted"}, &active)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/hook", sub.URL)
+		assert.Equal(t, []string{"article.deleted"}, sub.EventTypeStrings())
+		assert.False(t, sub.Active)
+	})
+
+	t.Run("無効な値の場合は何も変更しない", func(t *testing.T) {
+		t.Parallel()
+		sub, err := entity.NewWebhookSubscription("https://example.com/hook", validSecret, []string{"article.published"})
+		require.NoError(t, err)
+
+		invalidURL := "not a url"
+		err = sub.Update(&invalidURL, nil, []string{"article.deleted"}, nil)
+
+		assert.Error(t, err)
+		assert.Equal(t, "https://example.com/hook", sub.URL)
+		assert.Equal(t, []string{"article.published"}, sub.EventTypeStrings())
+	})
+}
+
+func TestWebhookSubscription_Subscribes(t *testing.T) {
+	t.Parallel()
+
+	sub, err := entity.NewWebhookSubscription("https://example.com/hook", validSecret, []string{"article.published"})
+	require.NoError(t, err)
+
+	assert.True(t, sub.Subscribes(entity.ArticleEventPublished))
+	assert.False(t, sub.Subscribes(entity.ArticleEventDeleted))
+
+	sub.Active = false
+	assert.False(t, sub.Subscribes(entity.ArticleEventPublished))
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("成功を記録する", func(t *testing.T) {
+		t.Parallel()
+		d := entity.NewWebhookDelivery(1, entity.ArticleEventPublished, []byte(`{}`))
+		d.RecordSuccess(200, time.Now())
+
+		assert.Equal(t, entity.WebhookDeliverySucceeded, d.Status)
+		assert.Equal(t, 1, d.Attempts)
+		require.NotNil(t, d.ResponseStatus)
+		assert.Equal(t, 200, *d.ResponseStatus)
+		assert.NotNil(t, d.DeliveredAt)
+	})
+
+	t.Run("再試行ありの失敗は配信待ちのまま", func(t *testing.T) {
+		t.Parallel()
+		d := entity.NewWebhookDelivery(1, entity.ArticleEventPublished, []byte(`{}`))
+		next := time.Now().Add(time.Minute)
+		status := 500
+		d.RecordFailure(&status, "server error", &next)
+
+		assert.Equal(t, entity.WebhookDeliveryPending, d.Status)
+		assert.Equal(t, next, d.NextAttemptAt)
+		require.NotNil(t, d.LastError)
+		assert.Equal(t, "server error", *d.LastError)
+	})
+
+	t.Run("再試行なしの失敗は失敗として確定する", func(t *testing.T) {
+		t.Parallel()
+		d := entity.NewWebhookDelivery(1, entity.ArticleEventPublished, []byte(`{}`))
+		d.RecordFailure(nil, "connection refused", nil)
+
+		assert.Equal(t, entity.WebhookDeliveryFailed, d.Status)
+		assert.Nil(t, d.ResponseStatus)
+	})
+
+	t.Run("Replayは同じペイロードの新しい配信を作る", func(t *testing.T) {
+		t.Parallel()
+		d := entity.NewWebhookDelivery(1, entity.ArticleEventPublished, []byte(`{"a":1}`))
+		d.ID = 10
+		d.RecordFailure(nil, "error", nil)
+
+		replay := d.Replay()
+
+		assert.Equal(t, uint64(0), replay.ID)
+		assert.Equal(t, entity.WebhookDeliveryPending, replay.Status)
+		assert.Equal(t, d.Payload, replay.Payload)
+		require.NotNil(t, replay.ReplayOf)
+		assert.Equal(t, uint64(10), *replay.ReplayOf)
+	})
+}
@@ -0,0 +1,164 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+// 既定のワークスペース(マイグレーションで作成され、テナントを指定しない要求が利用する)
+const (
+	DefaultWorkspaceID   uint64 = 1
+	DefaultWorkspaceSlug        = "default"
+)
+
+// ワークスペース名の最大文字数
+const maxWorkspaceNameLength = 100
+
+// スラッグはサブドメインにも使うため、DNSのラベルとして有効な文字列に限る
+var workspaceSlugPattern = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// WorkspaceSettings はワークスペースごとの表示設定
+// 空の項目はサーバー全体の設定を利用する
+type WorkspaceSettings struct {
+	FeedTitle       string `json:"feed_title,omitempty"`
+	FeedDescription string `json:"feed_description,omitempty"`
+	SiteURL         string `json:"site_url,omitempty"`
+	// RequireReview は記事の公開にレビューでの承認を必要にする
+	RequireReview bool `json:"require_review,omitempty"`
+}
+
+// Workspace は記事などのデータを分離するテナントを表す集約
+// ProviderCredentialsはプロバイダのAPIを呼び出すためのアクセストークンで、平文で保持する
+type Workspace struct {
+	ID                  uint64
+	Slug                string
+	Name                string
+	Settings            WorkspaceSettings
+	ProviderCredentials map[vo.ProviderType]string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// NewWorkspace は新しいワークスペースを作成する
+func NewWorkspace(slug, name string) (*Workspace, error) {
+	slug = strings.TrimSpace(slug)
+	if !workspaceSlugPattern.MatchString(slug) {
+		return nil, fmt.Errorf("workspace slug must consist of lowercase letters, digits and hyphens: %q", slug)
+	}
+	w := &Workspace{Slug: slug, ProviderCredentials: map[vo.ProviderType]string{}}
+	if err := w.Update(&name, nil, nil); err != nil {
+		return nil, err
+	}
+	w.CreatedAt = w.UpdatedAt
+	return w, nil
+}
+
+// ReconstituteWorkspace は永続化層から読み込んだデータからワークスペースを再構築する
+func ReconstituteWorkspace(
+	id uint64,
+	slug string,
+	name string,
+	settings WorkspaceSettings,
+	providerCredentials map[string]string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) (*Workspace, error) {
+	credentials, err := parseProviderCredentials(providerCredentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstitute workspace: %w", err)
+	}
+	return &Workspace{
+		ID:                  id,
+		Slug:                slug,
+		Name:                name,
+		Settings:            settings,
+		ProviderCredentials: credentials,
+		CreatedAt:           createdAt,
+		UpdatedAt:           updatedAt,
+	}, nil
+}
+
+// Update はワークスペースの属性を更新する
+// nilの項目は変更せず、credentialsの空文字のトークンはそのプロバイダの設定を削除する
+func (w *Workspace) Update(name *string, settings *WorkspaceSettings, credentials map[string]string) error {
+	if name != nil {
+		n := strings.TrimSpace(*name)
+		if n == "" {
+			return fmt.Errorf("workspace name must not be empty")
+		}
+		if len([]rune(n)) > maxWorkspaceNameLength {
+			return fmt.Errorf("workspace name must be at most %d characters", maxWorkspaceNameLength)
+		}
+		w.Name = n
+	}
+	if settings != nil {
+		w.Settings = WorkspaceSettings{
+			FeedTitle:       strings.TrimSpace(settings.FeedTitle),
+			FeedDescription: strings.TrimSpace(settings.FeedDescription),
+			SiteURL:         strings.TrimRight(strings.TrimSpace(settings.SiteURL), "/"),
+			RequireReview:   settings.RequireReview,
+		}
+	}
+	if credentials != nil {
+		merged := w.ProviderCredentialStrings()
+		for p, token := range credentials {
+			if token == "" {
+				delete(merged, p)
+				continue
+			}
+			merged[p] = token
+		}
+		parsed, err := parseProviderCredentials(merged)
+		if err != nil {
+			return err
+		}
+		w.ProviderCredentials = parsed
+	}
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+// Credential はプロバイダのアクセストークンを返す
+func (w *Workspace) Credential(provider vo.ProviderType) (string, bool) {
+	token, ok := w.ProviderCredentials[provider]
+	return token, ok
+}
+
+// ProviderCredentialStrings はアクセストークンを文字列のキーで返す
+func (w *Workspace) ProviderCredentialStrings() map[string]string {
+	credentials := make(map[string]string, len(w.ProviderCredentials))
+	for p, token := range w.ProviderCredentials {
+		credentials[p.String()] = token
+	}
+	return credentials
+}
+
+// CredentialProviders はアクセストークンが設定されているプロバイダを名前順で返す
+func (w *Workspace) CredentialProviders() []vo.ProviderType {
+	providers := make([]vo.ProviderType, 0, len(w.ProviderCredentials))
+	for p := range w.ProviderCredentials {
+		providers = append(providers, p)
+	}
+	sort.Slice(providers, func(i, j int) bool { return providers[i] < providers[j] })
+	return providers
+}
+
+func parseProviderCredentials(values map[string]string) (map[vo.ProviderType]string, error) {
+	credentials := make(map[vo.ProviderType]string, len(values))
+	for provider, token := range values {
+		p, err := vo.NewProviderType(&provider)
+		if err != nil || p == nil {
+			return nil, fmt.Errorf("invalid provider for credential: %q", provider)
+		}
+		if strings.TrimSpace(token) != token || token == "" {
+			return nil, fmt.Errorf("invalid %s credential", provider)
+		}
+		credentials[*p] = token
+	}
+	return credentials, nil
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+)
+
+// ErrAPIKeyNotFound は対象のAPIキーが存在しない場合に返される
+var ErrAPIKeyNotFound = fmt.Errorf("api key %w", ErrNotFound)
+
+// APIKeyRepository はAPIキーの永続化を担うリポジトリインターフェース
+type APIKeyRepository interface {
+	// FindAll は失効済みを含む全てのAPIキーを作成日時の新しい順に返す
+	FindAll(ctx context.Context) ([]*entity.APIKey, error)
+	FindByID(ctx context.Context, id uint64) (*entity.APIKey, error)
+	// FindByPrefix は認証に使うため、全てのワークスペースを対象にしたコンテキストでも呼び出される
+	FindByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error)
+	Create(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error)
+	Update(ctx context.Context, key *entity.APIKey) error
+	// TouchLastUsed は最終利用日時を更新する
+	TouchLastUsed(ctx context.Context, id uint64, at time.Time) error
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+// MetricTarget は指標を収集する投稿先
+type MetricTarget struct {
+	ArticleID     uint64
+	PublicationID uint64
+	ProviderType  vo.ProviderType
+	Link          *string
+	ExternalID    *string
+	// WorkspaceID は記事が属するワークスペースで、プロバイダのアクセストークンの選択に使う
+	WorkspaceID uint64
+}
+
+// MetricGrowth は期間内の指標の増分を記事ごとに投稿先を合算したもの
+type MetricGrowth struct {
+	ArticleID uint64
+	Title     string
+	Likes     int
+	Stocks    int
+	Bookmarks int
+	Total     int
+}
+
+// ArticleMetricRepository はエンゲージメント指標の時系列の永続化を担うリポジトリインターフェース
+type ArticleMetricRepository interface {
+	// FindTargets は未削除の記事の投稿先のうち、リンクか外部IDを持つものを返す
+	FindTargets(ctx context.Context) ([]MetricTarget, error)
+	Save(ctx context.Context, metrics []*entity.ArticleMetric) error
+	// FindByArticleID は期間内のスナップショットを古い順に返す
+	FindByArticleID(ctx context.Context, articleID uint64, from, to time.Time) ([]*entity.ArticleMetric, error)
+	// FindGrowth は期間内の増分が大きい順に最大limit件を返す
+	// sortByはlikes / stocks / bookmarks / totalのいずれかで、publishedOnlyの場合は公開済みの記事だけを対象にする
+	FindGrowth(ctx context.Context, from, to time.Time, sortBy string, limit int, publishedOnly bool) ([]MetricGrowth, error)
+}
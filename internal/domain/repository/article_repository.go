@@ -2,27 +2,120 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
 )
 
+// ErrArticleNotFound は対象の記事が存在しない場合に返される
+var ErrArticleNotFound = fmt.Errorf("article %w", ErrNotFound)
+
+// ErrPublicationNotFound は記事に対象の投稿先が存在しない場合に返される
+var ErrPublicationNotFound = fmt.Errorf("publication %w", ErrNotFound)
+
+// ErrArticleSlugConflict は他の記事が既に同じスラッグを使用している場合に返される
+var ErrArticleSlugConflict = errors.New("article slug already exists")
+
+// ErrArticleLinkConflict は他の未削除の記事が既に正規化後に同じリンクを使用している場合に返される
+var ErrArticleLinkConflict = errors.New("article link already exists")
+
 // ArticleRepository は記事の永続化を担うリポジトリインターフェース
 type ArticleRepository interface {
 	FindAll(ctx context.Context) ([]*entity.Article, error)
 	FindByID(ctx context.Context, id uint64) (*entity.Article, error)
+	// FindByIDForUpdate は更新を前提に記事を行ロックして取得する
+	FindByIDForUpdate(ctx context.Context, id uint64) (*entity.Article, error)
 	FindByCriteria(ctx context.Context, criteria ArticleQueryCriteria) ([]*entity.Article, int, error)
+	FindBySlug(ctx context.Context, slug string) (*entity.Article, error)
+	// FindCurrentSlug は過去に使われていたスラッグから記事の現在のスラッグを返す
+	FindCurrentSlug(ctx context.Context, previousSlug string) (string, error)
+	// SlugExists は論理削除済みを含め、いずれかの記事が現在そのスラッグを使用しているか、
+	// exceptArticleID以外の記事が過去に使用していたかを返す(記事は自身の以前のスラッグに戻せる)
+	SlugExists(ctx context.Context, slug string, exceptArticleID uint64) (bool, error)
+	// FindIDByNormalizedLink は正規化したリンク(vo.Link.Normalized)が一致する未削除の記事のIDを返す
+	FindIDByNormalizedLink(ctx context.Context, normalizedLink string) (uint64, error)
 	Create(ctx context.Context, article *entity.Article) (*entity.Article, error)
 	Update(ctx context.Context, article *entity.Article) error
-	Delete(ctx context.Context, id uint64) error
+	// SoftDelete は記事を論理削除する(ゴミ箱に移す)
+	// 論理削除した記事は一覧や参照の対象外になり、復元するか保持期間を過ぎて完全に削除されるまで残る
+	SoftDelete(ctx context.Context, id uint64) error
+	// FindDeletedByIDForUpdate は論理削除済みの記事を復元を前提に行ロックして取得する
+	FindDeletedByIDForUpdate(ctx context.Context, id uint64) (*entity.Article, error)
+	// FindDeletedBefore は指定日時より前に論理削除された記事のうち、IDがafterIDより大きいものをID順に最大limit件返す
+	FindDeletedBefore(ctx context.Context, before time.Time, afterID uint64, limit int) ([]*entity.Article, error)
+	// FindAfterID は論理削除済みを含む記事のうち、IDがafterIDより大きいものをID順に最大limit件返す
+	FindAfterID(ctx context.Context, afterID uint64, limit int) ([]*entity.Article, error)
+	// UpdateMetadata は本文から導出した値(文字数・読了時間)だけを保存し、値が変わったかを返す
+	// 記事の内容の変更ではないため、更新日時は変えずドメインイベントも記録しない
+	UpdateMetadata(ctx context.Context, article *entity.Article) (bool, error)
+	// Purge は指定日時より前に論理削除された記事を、タグや投稿先などとともに完全に削除する
+	// 記事が存在しないか、削除されていない(または指定日時以降に削除された)場合はErrArticleNotFoundを返す
+	Purge(ctx context.Context, id uint64, deletedBefore time.Time) error
 }
 
 // ArticleQueryCriteria は記事検索の条件を表す
 type ArticleQueryCriteria struct {
-	Status         *string
-	ProviderType   *string
+	Status       *string
+	ProviderType *string
+	Tag          *string
+	// PublishedOn はクロスポストを含むいずれかの投稿先のプロバイダで絞り込む
+	// ProviderTypeは正規の投稿先のプロバイダのみを対象とする
+	PublishedOn *string
+	// HasBrokenLinks はリンクチェックでリンク切れが見つかったかどうかで絞り込む
+	HasBrokenLinks *bool
+	// HasLink は正規の投稿先にリンクがあるかどうかで絞り込む
+	HasLink *bool
+	// MinReadingTime / MaxReadingTime は読了時間(分)の範囲で絞り込む
+	MinReadingTime *int
+	MaxReadingTime *int
+	// AuthorID は記事の所有者である執筆者で絞り込む
+	AuthorID       *uint64
 	SortBy         *string
 	SortOrder      *string
 	Page           int
 	Limit          int
 	IncludeDeleted bool
+	// DeletedOnly は論理削除済みの記事だけに絞り込む(ゴミ箱の一覧)
+	DeletedOnly bool
+}
+
+// ArticleLastModified は公開記事のID・スラッグと最終更新日時
+type ArticleLastModified struct {
+	ID        uint64
+	Slug      string
+	UpdatedAt time.Time
+}
+
+// SimilarTitlePair はタイトルが似ている未削除の記事の組(ArticleID < OtherID)
+type SimilarTitlePair struct {
+	ArticleID  uint64
+	Title      string
+	OtherID    uint64
+	OtherTitle string
+	// Similarity はトライグラムによるタイトルの類似度(0〜1)
+	Similarity float64
+}
+
+// SimilarTitleFinder はタイトルが似ている記事の組を探すインターフェース
+type SimilarTitleFinder interface {
+	// FindSimilarTitles は類似度がthreshold以上の組を類似度の高い順に最大limit件返す
+	// publishedOnlyの場合は公開済みの記事どうしの組だけを対象にする
+	FindSimilarTitles(ctx context.Context, threshold float64, limit int, publishedOnly bool) ([]SimilarTitlePair, error)
+}
+
+// ArticleChangeTracker は記事の変更を検知するための時刻を返すインターフェース
+type ArticleChangeTracker interface {
+	// LatestChangeAt は論理削除済みを含む記事の更新日時・削除日時のうち最も新しいものを返す
+	// 非公開への変更や削除でも戻らないため、条件付きリクエストの判定に使える。記事がない場合はゼロ値
+	LatestChangeAt(ctx context.Context) (time.Time, error)
+}
+
+// PublishedArticleReader は公開済み・未削除の記事をメモリに載せずに読み出すインターフェース
+type PublishedArticleReader interface {
+	CountPublished(ctx context.Context) (int, error)
+	// StreamPublished はID昇順でoffsetから最大limit件をfnへ1件ずつ渡す
+	// fnがエラーを返した場合は読み出しを中断してそのエラーを返す
+	StreamPublished(ctx context.Context, offset, limit int, fn func(ArticleLastModified) error) error
 }
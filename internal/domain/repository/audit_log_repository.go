@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+)
+
+// AuditLogQueryCriteria は監査ログの検索の条件を表す
+type AuditLogQueryCriteria struct {
+	ArticleID *uint64
+	Action    *entity.AuditAction
+	// Actor は呼び出し元の名前またはベアラートークンの"sub"クレームで絞り込む
+	Actor *string
+	// From / To は記録日時の範囲 [From, To) で絞り込む
+	From *time.Time
+	To   *time.Time
+	// AfterID はこのIDより後に記録されたログだけを返す(ページングのカーソル)
+	AfterID uint64
+	Limit   int
+}
+
+// AuditLogRepository は記事の操作の監査ログの永続化を担うリポジトリインターフェース
+// ログは追記のみで、更新・削除の操作は持たない
+type AuditLogRepository interface {
+	// Append はログを記録する
+	// 記事の変更と同じトランザクションの中で呼び出す
+	Append(ctx context.Context, entry *entity.AuditEntry) error
+	// Find は条件に一致するログを記録順(ID順)に返す
+	Find(ctx context.Context, criteria AuditLogQueryCriteria) ([]*entity.AuditEntry, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+)
+
+// ErrAuthorNotFound は対象の執筆者が存在しない場合に返される
+var ErrAuthorNotFound = fmt.Errorf("author %w", ErrNotFound)
+
+// ErrAuthorSubjectConflict は他の執筆者が既に同じsubjectを使用している場合に返される
+var ErrAuthorSubjectConflict = errors.New("author subject already exists")
+
+// AuthorRepository は執筆者の永続化を担うリポジトリインターフェース
+type AuthorRepository interface {
+	// FindAll は全ての執筆者をID順に返す
+	FindAll(ctx context.Context) ([]*entity.Author, error)
+	FindByID(ctx context.Context, id uint64) (*entity.Author, error)
+	// FindBySubject はIdPのsubjectに対応する執筆者を返す
+	FindBySubject(ctx context.Context, subject string) (*entity.Author, error)
+	Create(ctx context.Context, author *entity.Author) (*entity.Author, error)
+	Update(ctx context.Context, author *entity.Author) error
+}
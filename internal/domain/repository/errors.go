@@ -0,0 +1,7 @@
+package repository
+
+import "errors"
+
+// ErrNotFound は対象のレコードが存在しない場合に返される
+// 個別のエラーはこれをラップしており、errors.Isで判定できる
+var ErrNotFound = errors.New("not found")
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+)
+
+// ErrInlineCommentNotFound は対象のインラインコメントが存在しない場合に返される
+var ErrInlineCommentNotFound = fmt.Errorf("inline comment %w", ErrNotFound)
+
+// InlineCommentRepository は記事本文のインラインコメントの永続化を担うリポジトリインターフェース
+type InlineCommentRepository interface {
+	// FindByArticleID は記事のコメントを返信も含めて作成順に返す
+	FindByArticleID(ctx context.Context, articleID uint64) ([]*entity.InlineComment, error)
+	FindByID(ctx context.Context, id uint64) (*entity.InlineComment, error)
+	Create(ctx context.Context, comment *entity.InlineComment) (*entity.InlineComment, error)
+	// Update は本文・範囲・解決状態を更新する
+	Update(ctx context.Context, comment *entity.InlineComment) error
+	// Delete はコメントを削除する(スレッドの先頭のコメントの場合は返信も削除される)
+	Delete(ctx context.Context, id uint64) error
+}
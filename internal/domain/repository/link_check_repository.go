@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+)
+
+// LinkCheckRepository はリンクチェック結果の永続化を担うリポジトリインターフェース
+// 記事ごとに最新の結果のみを保持する
+type LinkCheckRepository interface {
+	// ReplaceForArticle は記事のチェック結果を置き換える。記事から消えたURLの結果は削除する
+	ReplaceForArticle(ctx context.Context, articleID uint64, checks []*entity.LinkCheck) error
+	FindByArticleID(ctx context.Context, articleID uint64) ([]*entity.LinkCheck, error)
+	// FindBroken は未削除の記事のリンク切れを記事ID・URL順に返す
+	FindBroken(ctx context.Context) ([]*entity.LinkCheck, error)
+}
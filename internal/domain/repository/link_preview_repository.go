@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+)
+
+// ErrLinkPreviewNotFound はURLのプレビューをまだ取得していない場合に返される
+var ErrLinkPreviewNotFound = fmt.Errorf("link preview %w", ErrNotFound)
+
+// LinkPreviewRepository はリンク先のOGPの永続化を担うリポジトリインターフェース
+type LinkPreviewRepository interface {
+	FindByURL(ctx context.Context, url string) (*entity.LinkPreview, error)
+	// FindRefreshTargets は未削除の記事のLinkのうち、未取得のものと取得し直す時期を過ぎたものを返す
+	// 取得に成功したものはfetchedBefore、失敗したものはfailedBeforeより前に取得したものが対象
+	// 未取得のURLは保存前(IDが0)のプレビューとして返す
+	FindRefreshTargets(ctx context.Context, fetchedBefore, failedBefore time.Time, limit int) ([]*entity.LinkPreview, error)
+	// Save はURLごとにプレビューを登録または更新する
+	Save(ctx context.Context, preview *entity.LinkPreview) error
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+// ErrReviewRequestNotFound は対象のレビュー依頼が存在しない場合に返される
+var ErrReviewRequestNotFound = fmt.Errorf("review request %w", ErrNotFound)
+
+// ErrReviewRequestConflict は記事に判断待ちのレビュー依頼が既にある場合に返される
+var ErrReviewRequestConflict = errors.New("article already has a pending review request")
+
+// ReviewRequestRepository はレビュー依頼とそのコメントの永続化を担うリポジトリインターフェース
+// 依頼の状態は記事のステータスと合わせて変更するため、呼び出し側は記事をロックしたトランザクションの中で操作する
+type ReviewRequestRepository interface {
+	// FindOpenByArticleID は記事の開いている(判断待ちまたは承認済みの)レビュー依頼のうち最新のものを返す
+	// 承認済みの依頼は記事の公開後も残るため、記事がレビュー中の場合にだけ現在の依頼を表す
+	FindOpenByArticleID(ctx context.Context, articleID uint64) (*entity.ReviewRequest, error)
+	// FindLatestByArticleID は記事の最新のレビュー依頼を状態によらず返す
+	FindLatestByArticleID(ctx context.Context, articleID uint64) (*entity.ReviewRequest, error)
+	// FindByArticleID は記事のレビュー依頼を新しい順に返す
+	FindByArticleID(ctx context.Context, articleID uint64) ([]*entity.ReviewRequest, error)
+	// FindByStatus は指定の状態のレビュー依頼を古い順に返す(レビュアーの待ち行列)
+	FindByStatus(ctx context.Context, status vo.ReviewStatus) ([]*entity.ReviewRequest, error)
+	Create(ctx context.Context, request *entity.ReviewRequest) (*entity.ReviewRequest, error)
+	// Update は依頼の状態を更新し、IDのないコメントを追加する(既存のコメントは変更しない)
+	Update(ctx context.Context, request *entity.ReviewRequest) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "context"
+
+// TxManager は複数リポジトリにまたがる処理を1つのトランザクションで実行する
+// fnに渡されるコンテキストを各リポジトリへ引き渡すことで、同一トランザクションが利用される
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// WithinSavepoint はトランザクション内でfnをセーブポイントで囲んで実行する
+	// fnがエラーを返した場合はfnの変更だけを取り消し、トランザクションは続けて利用できる
+	// トランザクション外ではWithinTxと同じ
+	WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error
+}
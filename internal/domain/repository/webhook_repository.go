@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+)
+
+// ErrWebhookSubscriptionNotFound は対象のWebhook購読が存在しない場合に返される
+var ErrWebhookSubscriptionNotFound = fmt.Errorf("webhook subscription %w", ErrNotFound)
+
+// ErrWebhookDeliveryNotFound は対象のWebhook配信が存在しない場合に返される
+var ErrWebhookDeliveryNotFound = fmt.Errorf("webhook delivery %w", ErrNotFound)
+
+// WebhookSubscriptionRepository はWebhook購読の永続化を担うリポジトリインターフェース
+type WebhookSubscriptionRepository interface {
+	FindAll(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	FindByID(ctx context.Context, id uint64) (*entity.WebhookSubscription, error)
+	// FindActiveByEventType は指定イベントを購読している有効な購読を返す
+	FindActiveByEventType(ctx context.Context, eventType entity.ArticleEventType) ([]*entity.WebhookSubscription, error)
+	Create(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *entity.WebhookSubscription) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+// WebhookDeliveryRepository はWebhook配信ログの永続化を担うリポジトリインターフェース
+type WebhookDeliveryRepository interface {
+	FindByID(ctx context.Context, id uint64) (*entity.WebhookDelivery, error)
+	// FindBySubscriptionID は購読ごとの配信ログを新しい順に返す
+	FindBySubscriptionID(ctx context.Context, subscriptionID uint64, limit int) ([]*entity.WebhookDelivery, error)
+	// FindDue は配信予定時刻を過ぎた配信待ちのものを確保して古い順に返す
+	// 確保したものは確保の期間が過ぎるまで他の呼び出しでは返さない(配信中に停止した場合は期間後に再び返す)
+	FindDue(ctx context.Context, limit int) ([]*entity.WebhookDelivery, error)
+	Create(ctx context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error)
+	// CreateAll は配信をまとめて1つのトランザクションで作成する
+	// 同じメッセージと購読の配信が既にあるものは作成しない
+	CreateAll(ctx context.Context, deliveries []*entity.WebhookDelivery) error
+	Update(ctx context.Context, delivery *entity.WebhookDelivery) error
+}
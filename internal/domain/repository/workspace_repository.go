@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+)
+
+// ErrWorkspaceNotFound は対象のワークスペースが存在しない場合に返される
+var ErrWorkspaceNotFound = fmt.Errorf("workspace %w", ErrNotFound)
+
+// ErrWorkspaceSlugConflict は他のワークスペースが既に同じスラッグを使用している場合に返される
+var ErrWorkspaceSlugConflict = errors.New("workspace slug already exists")
+
+// ErrWorkspaceRequired はワークスペースごとのデータをワークスペースを指定せずに操作しようとした場合に返される
+// 絞り込みの指定漏れで他のワークスペースのデータを読み書きしないよう、リポジトリは操作を拒否する
+var ErrWorkspaceRequired = errors.New("workspace is not specified")
+
+// WorkspaceRepository はワークスペースの永続化を担うリポジトリインターフェース
+// ワークスペース自体はコンテキストのワークスペースに関係なく操作できる
+type WorkspaceRepository interface {
+	// FindAll は全てのワークスペースをID順に返す
+	FindAll(ctx context.Context) ([]*entity.Workspace, error)
+	FindByID(ctx context.Context, id uint64) (*entity.Workspace, error)
+	FindBySlug(ctx context.Context, slug string) (*entity.Workspace, error)
+	Create(ctx context.Context, workspace *entity.Workspace) (*entity.Workspace, error)
+	Update(ctx context.Context, workspace *entity.Workspace) error
+}
+
+// workspaceScope はリポジトリが操作するワークスペースの範囲
+type workspaceScope struct {
+	id  uint64
+	all bool
+}
+
+type workspaceScopeKey struct{}
+
+// WithWorkspace はリポジトリの操作をワークスペースidのデータに限定したコンテキストを返す
+// ワークスペースごとのデータを持つリポジトリは、コンテキストのワークスペースで全ての操作を絞り込む
+func WithWorkspace(ctx context.Context, id uint64) context.Context {
+	return context.WithValue(ctx, workspaceScopeKey{}, workspaceScope{id: id})
+}
+
+// WithAllWorkspaces は全てのワークスペースのデータを操作するコンテキストを返す
+// ワークスペースをまたいで処理するワーカーなど、明示的に必要な場合だけ利用する
+func WithAllWorkspaces(ctx context.Context) context.Context {
+	return context.WithValue(ctx, workspaceScopeKey{}, workspaceScope{all: true})
+}
+
+// WorkspaceFrom はコンテキストのワークスペースを返す
+// allは全てのワークスペースを操作する場合にtrueで、どちらも指定されていない場合はokがfalseになる
+func WorkspaceFrom(ctx context.Context) (id uint64, all bool, ok bool) {
+	s, ok := ctx.Value(workspaceScopeKey{}).(workspaceScope)
+	if !ok || (s.id == 0 && !s.all) {
+		return 0, false, false
+	}
+	return s.id, s.all, true
+}
@@ -1,21 +1,54 @@
 package vo
 
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
 // ArticleBody は記事本文を表すValue Object
 type ArticleBody string
 
+// 本文の最大サイズ(バイト)の既定値
+const DefaultMaxArticleBodySize = 1 << 20
+
 // NewArticleBody は記事本文を作成する
 // 空文字列は値なしとして扱う(必須ではない)
-func NewArticleBody(value *string) (*ArticleBody, error) {
+// maxSizeは本文の最大サイズ(バイト)で、0以下の場合は既定値を使う
+// PostgreSQLのTEXTに保存できないため、不正なUTF-8とNUL文字は拒否する
+func NewArticleBody(value *string, maxSize int) (*ArticleBody, error) {
 	if value == nil {
 		return nil, nil
 	}
 	if *value == "" {
 		return nil, nil
 	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxArticleBodySize
+	}
+	if len(*value) > maxSize {
+		return nil, fmt.Errorf("article body exceeds maximum size of %d bytes", maxSize)
+	}
+	if !utf8.ValidString(*value) {
+		return nil, fmt.Errorf("article body must be valid UTF-8")
+	}
+	if strings.ContainsRune(*value, 0) {
+		return nil, fmt.Errorf("article body cannot contain NUL characters")
+	}
 	body := ArticleBody(*value)
 	return &body, nil
 }
 
+// ReconstituteArticleBody は保存済みの本文を検証せずに復元する
+// 最大サイズを小さくしても既存の記事を読み込めるよう、検証はNewArticleBodyで作成・変更するときだけ行う
+func ReconstituteArticleBody(value *string) *ArticleBody {
+	if value == nil || *value == "" {
+		return nil
+	}
+	body := ArticleBody(*value)
+	return &body
+}
+
 func (b *ArticleBody) String() string {
 	if b == nil {
 		return ""
@@ -1,7 +1,10 @@
 package vo_test
 
 import (
+	"strings"
 	"testing"
+	"testing/quick"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,15 +24,18 @@ func TestNewArticleBody(t *testing.T) {
 			name:  "有効な本文で作成成功",
 			value: func() *string { s := "This is a valid body."; return &s }(),
 			want: func() *vo.ArticleBody {
-				b, _ := vo.NewArticleBody(func() *string { s := "This is a valid body."; return &s }())
+				b, _ := vo.NewArticleBody(func() *string { s := "This is a valid body."; return &s }(), 0)
 				return b
 			}(),
 			assertion: assert.NoError,
 		},
 		{
-			name:      "空文字列の本文で作成成功",
-			value:     func() *string { s := ""; return &s }(),
-			want:      func() *vo.ArticleBody { b, _ := vo.NewArticleBody(func() *string { s := ""; return &s }()); return b }(),
+			name:  "空文字列の本文で作成成功",
+			value: func() *string { s := ""; return &s }(),
+			want: func() *vo.ArticleBody {
+				b, _ := vo.NewArticleBody(func() *string { s := ""; return &s }(), 0)
+				return b
+			}(),
 			assertion: assert.NoError,
 		},
 		{
@@ -38,12 +44,30 @@ func TestNewArticleBody(t *testing.T) {
 			want:      nil,
 			assertion: assert.NoError,
 		},
+		{
+			name:      "不正なUTF-8の場合はエラー",
+			value:     func() *string { s := "abc\xff"; return &s }(),
+			want:      nil,
+			assertion: assert.Error,
+		},
+		{
+			name:      "NUL文字を含む場合はエラー",
+			value:     func() *string { s := "abc\x00def"; return &s }(),
+			want:      nil,
+			assertion: assert.Error,
+		},
+		{
+			name:      "既定の最大サイズを超える場合はエラー",
+			value:     func() *string { s := strings.Repeat("a", vo.DefaultMaxArticleBodySize+1); return &s }(),
+			want:      nil,
+			assertion: assert.Error,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := vo.NewArticleBody(tt.value)
+			got, err := vo.NewArticleBody(tt.value, 0)
 			tt.assertion(t, err)
 			assert.Equal(t, tt.want, got)
 		})
@@ -56,7 +80,7 @@ func TestArticleBody_String(t *testing.T) {
 	t.Run("有効な本文の場合", func(t *testing.T) {
 		t.Parallel()
 		bodyValue := "My Test Body"
-		body, err := vo.NewArticleBody(&bodyValue)
+		body, err := vo.NewArticleBody(&bodyValue, 0)
 		require.NoError(t, err)
 		assert.Equal(t, bodyValue, body.String())
 	})
@@ -67,3 +91,49 @@ func TestArticleBody_String(t *testing.T) {
 		assert.Equal(t, "", body.String())
 	})
 }
+
+func TestNewArticleBody_MaxSize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("日本語は3バイトとして数える", func(t *testing.T) {
+		t.Parallel()
+		ok := "あいう"
+		_, err := vo.NewArticleBody(&ok, 9)
+		assert.NoError(t, err)
+
+		tooLarge := "あいうa"
+		_, err = vo.NewArticleBody(&tooLarge, 9)
+		assert.Error(t, err)
+	})
+
+	t.Run("0以下は既定値を使う", func(t *testing.T) {
+		t.Parallel()
+		atLimit := strings.Repeat("a", vo.DefaultMaxArticleBodySize)
+		_, err := vo.NewArticleBody(&atLimit, 0)
+		assert.NoError(t, err)
+
+		tooLarge := atLimit + "a"
+		_, err = vo.NewArticleBody(&tooLarge, -1)
+		assert.Error(t, err)
+	})
+}
+
+// 本文の生成規則に対するプロパティベーステスト
+func TestNewArticleBody_Properties(t *testing.T) {
+	t.Parallel()
+
+	property := func(value string) bool {
+		body, err := vo.NewArticleBody(&value, 0)
+		valid := utf8.ValidString(value) && !strings.ContainsRune(value, 0) && len(value) <= vo.DefaultMaxArticleBodySize
+		switch {
+		case value == "":
+			return err == nil && body == nil
+		case valid:
+			// 有効な本文は加工せずそのまま保持する
+			return err == nil && body.String() == value
+		default:
+			return err != nil && body == nil
+		}
+	}
+	require.NoError(t, quick.Check(property, &quick.Config{MaxCount: 2000}))
+}
@@ -3,11 +3,23 @@ package vo
 import (
 	"fmt"
 	"net/url"
+	"strings"
 )
 
 // Link は記事の外部リンクを表すValue Object
 type Link string
 
+// トラッキング用のクエリパラメータ(utm_*は接頭辞で判定する)
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"yclid":   true,
+	"msclkid": true,
+	"igshid":  true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+}
+
 func NewLink(value *string) (*Link, error) {
 	if value == nil {
 		return nil, nil
@@ -39,3 +51,42 @@ func (l *Link) String() string {
 	}
 	return string(*l)
 }
+
+// Normalized は同じページを指すリンクを同一視するための正規化した値を返す
+// httpはhttpsに揃え、ホストを小文字にして既定のポート・フラグメント・末尾のスラッシュ・
+// トラッキング用のクエリパラメータを取り除き、残りのクエリはキーの順に並べる
+func (l *Link) Normalized() string {
+	if l == nil {
+		return ""
+	}
+	u, err := url.Parse(string(*l))
+	if err != nil || u.Host == "" {
+		return string(*l)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if scheme == "http" {
+		scheme = "https"
+	}
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	query := u.Query()
+	for key := range query {
+		if trackingParams[strings.ToLower(key)] || strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+
+	normalized := url.URL{
+		Scheme:   scheme,
+		User:     u.User,
+		Host:     host,
+		Path:     strings.TrimRight(u.Path, "/"),
+		RawPath:  strings.TrimRight(u.RawPath, "/"),
+		RawQuery: query.Encode(),
+	}
+	return normalized.String()
+}
@@ -65,3 +65,43 @@ func TestLink_String(t *testing.T) {
 
 	assert.Equal(t, linkValue, link.String())
 }
+
+func TestLink_Normalized(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"正規化済みのURLはそのまま", "https://example.com/path", "https://example.com/path"},
+		{"httpはhttpsに揃える", "http://example.com/path", "https://example.com/path"},
+		{"スキームとホストは小文字にし、パスの大文字小文字は保つ", "HTTPS://Example.COM/Path", "https://example.com/Path"},
+		{"既定のポートを取り除く", "https://example.com:443/path", "https://example.com/path"},
+		{"既定以外のポートは残す", "https://example.com:8443/path", "https://example.com:8443/path"},
+		{"末尾のスラッシュを取り除く", "https://example.com/path/", "https://example.com/path"},
+		{"ルートの末尾のスラッシュを取り除く", "https://example.com/", "https://example.com"},
+		{"フラグメントを取り除く", "https://example.com/path#section", "https://example.com/path"},
+		{
+			"トラッキング用のパラメータを取り除き、残りをキーの順に並べる",
+			"https://example.com/path?utm_source=x&b=2&UTM_Medium=y&fbclid=z&a=1",
+			"https://example.com/path?a=1&b=2",
+		},
+		{"トラッキング用のパラメータだけの場合はクエリごと取り除く", "https://example.com/path?utm_source=x", "https://example.com/path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			link, err := vo.NewLink(&tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, link.Normalized())
+		})
+	}
+
+	t.Run("nilの場合は空文字列", func(t *testing.T) {
+		t.Parallel()
+		var link *vo.Link
+		assert.Equal(t, "", link.Normalized())
+	})
+}
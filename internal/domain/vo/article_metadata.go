@@ -0,0 +1,181 @@
+package vo
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	// 抜粋の最大文字数
+	MaxExcerptLength = 120
+	// 日本語・中国語・韓国語の1分あたりの読了文字数
+	cjkCharsPerMinute = 500
+	// 英語などの1分あたりの読了単語数
+	wordsPerMinute = 200
+)
+
+// Heading は本文の見出し
+type Heading struct {
+	Level int
+	Text  string
+}
+
+// ArticleMetadata は記事本文から導出するメタデータを表すValue Object
+type ArticleMetadata struct {
+	// Excerpt はMarkdown記法を除去した先頭MaxExcerptLength文字の抜粋
+	Excerpt string
+	// CharCount は空白を除いたプレーンテキストの文字数(ルーン数)
+	CharCount int
+	// ReadingTimeMinutes はCJK文字と単語それぞれの読了速度から見積もった読了時間(分)
+	ReadingTimeMinutes int
+	// Headings は見出しの一覧(目次)
+	Headings []Heading
+	// Links は本文中のhttp(s)リンク(重複なし、出現順)
+	Links []string
+}
+
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.+?)(?:\s+#+)?\s*$`)
+	embedLinePattern   = regexp.MustCompile(`^@\[[a-z0-9_-]+\]\((\S+)\)$`)
+	imagePattern       = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]*)[^)]*\)`)
+	inlineLinkPattern  = regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]*)[^)]*\)`)
+	autoLinkPattern    = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+	bareURLPattern     = regexp.MustCompile(`https?://[^\s<>()\[\]"']+`)
+	htmlTagPattern     = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	footnoteDefPattern = regexp.MustCompile(`^\[\^[^\]]+\]:\s*`)
+	footnoteRefPattern = regexp.MustCompile(`\[\^[^\]]+\]`)
+	listMarkerPattern  = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s+)?`)
+	tableRulePattern   = regexp.MustCompile(`^\|?\s*:?-{3,}:?\s*(?:\|\s*:?-{3,}:?\s*)*\|?$`)
+	emphasisReplacer   = strings.NewReplacer("**", "", "__", "", "~~", "", "`", "", "*", "", "|", " ")
+)
+
+// NewArticleMetadata は本文からメタデータを導出する
+// コードブロック、数式ブロック、独自記法の区切り行は本文テキストとして数えない
+func NewArticleMetadata(body *ArticleBody) ArticleMetadata {
+	meta := ArticleMetadata{Headings: []Heading{}, Links: []string{}}
+	if body == nil {
+		return meta
+	}
+
+	var plain strings.Builder
+	seenLinks := map[string]bool{}
+	addLink := func(link string) {
+		link = strings.TrimRight(link, ".,;:!?")
+		if !strings.HasPrefix(link, "http://") && !strings.HasPrefix(link, "https://") {
+			return
+		}
+		if !seenLinks[link] {
+			seenLinks[link] = true
+			meta.Links = append(meta.Links, link)
+		}
+	}
+
+	var fence CodeFence
+	inMath := false
+	for _, line := range strings.Split(strings.ReplaceAll(body.String(), "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if fence.Inside() {
+			fence.Close(trimmed)
+			continue
+		}
+		if inMath {
+			inMath = trimmed != "$$"
+			continue
+		}
+		switch {
+		case fence.Open(trimmed):
+			continue
+		case trimmed == "$$":
+			inMath = true
+			continue
+		case strings.HasPrefix(trimmed, ":::"), tableRulePattern.MatchString(trimmed):
+			continue
+		}
+
+		if m := embedLinePattern.FindStringSubmatch(trimmed); m != nil {
+			addLink(m[1])
+			continue
+		}
+		// 画像は本文中のリンクとして扱わない
+		for _, m := range inlineLinkPattern.FindAllStringSubmatch(imagePattern.ReplaceAllString(trimmed, "$1"), -1) {
+			addLink(m[2])
+		}
+		for _, m := range autoLinkPattern.FindAllStringSubmatch(trimmed, -1) {
+			addLink(m[1])
+		}
+		text := stripInlineMarkdown(trimmed)
+		for _, u := range bareURLPattern.FindAllString(text, -1) {
+			addLink(u)
+		}
+
+		if m := headingPattern.FindStringSubmatch(trimmed); m != nil {
+			heading := stripInlineMarkdown(m[2])
+			meta.Headings = append(meta.Headings, Heading{Level: len(m[1]), Text: heading})
+			text = heading
+		}
+		if text != "" {
+			plain.WriteString(text)
+			plain.WriteString(" ")
+		}
+	}
+
+	text := strings.Join(strings.Fields(plain.String()), " ")
+	meta.Excerpt = truncateRunes(text, MaxExcerptLength)
+
+	var cjk, words int
+	inWord := false
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			inWord = false
+			continue
+		case isCJK(r):
+			cjk++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				words++
+			}
+			inWord = true
+		default:
+			inWord = false
+		}
+		meta.CharCount++
+	}
+	if cjk > 0 || words > 0 {
+		minutes := float64(cjk)/cjkCharsPerMinute + float64(words)/wordsPerMinute
+		meta.ReadingTimeMinutes = max(1, int(math.Ceil(minutes)))
+	}
+	return meta
+}
+
+// stripInlineMarkdown は1行分のMarkdown記法を取り除いたテキストを返す
+func stripInlineMarkdown(line string) string {
+	line = strings.TrimLeft(line, "> ")
+	line = strings.TrimLeft(line, "#")
+	line = footnoteDefPattern.ReplaceAllString(strings.TrimSpace(line), "")
+	line = listMarkerPattern.ReplaceAllString(line, "")
+	line = imagePattern.ReplaceAllString(line, "$1")
+	line = inlineLinkPattern.ReplaceAllString(line, "$1")
+	line = autoLinkPattern.ReplaceAllString(line, "$1")
+	line = footnoteRefPattern.ReplaceAllString(line, "")
+	line = htmlTagPattern.ReplaceAllString(line, "")
+	line = emphasisReplacer.Replace(line)
+	return strings.TrimSpace(line)
+}
+
+// isCJK は漢字・ひらがな・カタカナ・ハングルかを判定する
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) || r == 'ー'
+}
+
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:limit])) + "…"
+}
@@ -0,0 +1,99 @@
+package vo_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+func TestNewArticleMetadata(t *testing.T) {
+	t.Parallel()
+
+	body := func(s string) *vo.ArticleBody {
+		b := vo.ArticleBody(s)
+		return &b
+	}
+
+	t.Run("本文がない場合はゼロ値", func(t *testing.T) {
+		t.Parallel()
+		got := vo.NewArticleMetadata(nil)
+
+		assert.Equal(t, "", got.Excerpt)
+		assert.Equal(t, 0, got.CharCount)
+		assert.Equal(t, 0, got.ReadingTimeMinutes)
+		assert.Empty(t, got.Headings)
+		assert.Empty(t, got.Links)
+	})
+
+	t.Run("Markdown記法を除去した抜粋を返す", func(t *testing.T) {
+		t.Parallel()
+		got := vo.NewArticleMetadata(body("# はじめに\n\nこれは**重要**な[記事](https://example.com)です。\n\n```go\nfmt.Println(\"code\")\n```\n\n- `inline` コード\n"))
+
+		assert.Equal(t, "はじめに これは重要な記事です。 inline コード", got.Excerpt)
+	})
+
+	t.Run("文字数はバイトではなくルーンで数え空白を除く", func(t *testing.T) {
+		t.Parallel()
+		got := vo.NewArticleMetadata(body("日本語の 本文"))
+
+		assert.Equal(t, 6, got.CharCount)
+	})
+
+	t.Run("抜粋は最大文字数で切り詰める", func(t *testing.T) {
+		t.Parallel()
+		got := vo.NewArticleMetadata(body(strings.Repeat("あ", vo.MaxExcerptLength+10)))
+
+		assert.Equal(t, strings.Repeat("あ", vo.MaxExcerptLength)+"…", got.Excerpt)
+		assert.Equal(t, vo.MaxExcerptLength+10, got.CharCount)
+	})
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "短い本文は1分", body: "こんにちは", want: 1},
+		{name: "CJKは500文字で1分", body: strings.Repeat("漢", 1000), want: 2},
+		{name: "CJKは端数を切り上げる", body: strings.Repeat("か", 1001), want: 3},
+		{name: "英語は200語で1分", body: strings.Repeat("word ", 400), want: 2},
+		{name: "混在する場合は合算する", body: strings.Repeat("漢", 500) + " " + strings.Repeat("word ", 200), want: 2},
+		{name: "コードブロックのみは0分", body: "```\ncode\n```", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run("読了時間: "+tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, vo.NewArticleMetadata(body(tt.body)).ReadingTimeMinutes)
+		})
+	}
+
+	t.Run("見出しを目次として抽出する", func(t *testing.T) {
+		t.Parallel()
+		got := vo.NewArticleMetadata(body("# 概要\n本文\n## 手順 `1` ##\n```\n# コメント\n```\n###見出しではない\n### [リンク](https://example.com)\n"))
+
+		assert.Equal(t, []vo.Heading{
+			{Level: 1, Text: "概要"},
+			{Level: 2, Text: "手順 1"},
+			{Level: 3, Text: "リンク"},
+		}, got.Headings)
+	})
+
+	t.Run("外部リンクを重複なく出現順に抽出する", func(t *testing.T) {
+		t.Parallel()
+		got := vo.NewArticleMetadata(body(
+			"[a](https://a.example.com) と <https://b.example.com> と https://c.example.com/path.\n" +
+				"![画像](https://img.example.com/x.png)\n" +
+				"[相対](/articles/1) [再掲](https://a.example.com)\n" +
+				"@[card](https://d.example.com)\n" +
+				"```\nhttps://code.example.com\n```\n",
+		))
+
+		assert.Equal(t, []string{
+			"https://a.example.com",
+			"https://b.example.com",
+			"https://c.example.com/path",
+			"https://d.example.com",
+		}, got.Links)
+	})
+}
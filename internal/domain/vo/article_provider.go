@@ -8,11 +8,13 @@ type ProviderType string
 const (
 	ProviderTypeQiita ProviderType = "qiita"
 	ProviderTypeZenn  ProviderType = "zenn"
+	ProviderTypeNote  ProviderType = "note"
 )
 
 var AllProviderTypes = []ProviderType{
 	ProviderTypeQiita,
 	ProviderTypeZenn,
+	ProviderTypeNote,
 }
 
 func NewProviderType(value *string) (*ProviderType, error) {
@@ -53,7 +55,10 @@ func (pt *ProviderType) IsManual() bool {
 	if pt == nil {
 		return false
 	}
-	switch pt {
+	switch *pt {
+	case ProviderTypeNote:
+		// noteは公開APIがないため手動で管理する
+		return true
 	default:
 		return false
 	}
@@ -68,6 +73,8 @@ func (pt *ProviderType) DisplayName() string {
 		return "Qiita"
 	case ProviderTypeZenn:
 		return "Zenn"
+	case ProviderTypeNote:
+		return "note"
 	default:
 		return fmt.Sprintf("Unknown Provider (%s)", pt)
 	}
@@ -80,6 +80,7 @@ func TestProviderType_IsValid(t *testing.T) {
 	}{
 		{name: "Qiitaは有効", pt: vo.ProviderTypeQiita, want: true},
 		{name: "Zennは有効", pt: vo.ProviderTypeZenn, want: true},
+		{name: "noteは有効", pt: vo.ProviderTypeNote, want: true},
 		{name: "無効な値はfalse", pt: vo.ProviderType("invalid"), want: false},
 	}
 
@@ -93,11 +94,14 @@ func TestProviderType_IsValid(t *testing.T) {
 
 func TestProviderType_IsManual(t *testing.T) {
 	t.Parallel()
-	// 現在の実装では常にfalseが返ることをテスト
+	// API連携可能なプロバイダはfalse
 	qiita := vo.ProviderTypeQiita
 	zenn := vo.ProviderTypeZenn
 	assert.False(t, qiita.IsManual())
 	assert.False(t, zenn.IsManual())
+	// noteは公開APIがないため手動管理
+	note := vo.ProviderTypeNote
+	assert.True(t, note.IsManual())
 }
 
 func TestProviderType_DisplayName(t *testing.T) {
@@ -110,6 +114,7 @@ func TestProviderType_DisplayName(t *testing.T) {
 	}{
 		{name: "Qiitaの表示名", pt: vo.ProviderTypeQiita, want: "Qiita"},
 		{name: "Zennの表示名", pt: vo.ProviderTypeZenn, want: "Zenn"},
+		{name: "noteの表示名", pt: vo.ProviderTypeNote, want: "note"},
 		{name: "不明なプロバイダの表示名", pt: "unknown", want: fmt.Sprintf("Unknown Provider (%s)", "unknown")},
 	}
 
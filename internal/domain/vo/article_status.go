@@ -4,13 +4,35 @@ package vo
 type ArticleStatus string
 
 const (
-	ArticleStatusDraft     ArticleStatus = "draft"
+	ArticleStatusDraft ArticleStatus = "draft"
+	// ArticleStatusInReview はレビューに提出され、承認または差し戻しを待っている
+	ArticleStatusInReview  ArticleStatus = "in_review"
+	ArticleStatusScheduled ArticleStatus = "scheduled"
 	ArticleStatusPublished ArticleStatus = "published"
+	// ArticleStatusUnlisted はURLを知っていれば閲覧できるが、一覧・フィード・サイトマップには載せない
+	ArticleStatusUnlisted ArticleStatus = "unlisted"
+	ArticleStatusArchived ArticleStatus = "archived"
 )
 
 var AllArticleStatuses = []ArticleStatus{
 	ArticleStatusDraft,
+	ArticleStatusInReview,
+	ArticleStatusScheduled,
 	ArticleStatusPublished,
+	ArticleStatusUnlisted,
+	ArticleStatusArchived,
+}
+
+// articleStatusTransitions はステータスごとに遷移できる先を表す
+// アーカイブした記事は下書きに戻してからでないと公開できない
+// レビュー中の記事は承認されれば公開でき、差し戻しと取り下げで下書きに戻る
+var articleStatusTransitions = map[ArticleStatus][]ArticleStatus{
+	ArticleStatusDraft:     {ArticleStatusInReview, ArticleStatusScheduled, ArticleStatusPublished, ArticleStatusUnlisted, ArticleStatusArchived},
+	ArticleStatusInReview:  {ArticleStatusDraft, ArticleStatusScheduled, ArticleStatusPublished, ArticleStatusUnlisted},
+	ArticleStatusScheduled: {ArticleStatusDraft, ArticleStatusPublished},
+	ArticleStatusPublished: {ArticleStatusDraft, ArticleStatusUnlisted, ArticleStatusArchived},
+	ArticleStatusUnlisted:  {ArticleStatusDraft, ArticleStatusPublished, ArticleStatusArchived},
+	ArticleStatusArchived:  {ArticleStatusDraft},
 }
 
 func (as ArticleStatus) IsValid() bool {
@@ -22,14 +44,47 @@ func (as ArticleStatus) IsValid() bool {
 	return false
 }
 
+// CanTransitionTo は現在のステータスからnextへ遷移できるかを判定する
+// 同じステータスへの遷移は含まない
+func (as ArticleStatus) CanTransitionTo(next ArticleStatus) bool {
+	for _, s := range articleStatusTransitions[as] {
+		if s == next {
+			return true
+		}
+	}
+	return false
+}
+
 func (as ArticleStatus) IsDraft() bool {
 	return as == ArticleStatusDraft
 }
 
+func (as ArticleStatus) IsInReview() bool {
+	return as == ArticleStatusInReview
+}
+
+// IsPublic は記事が読者に公開される(または公開が予約された)ステータスかを判定する
+// レビューが必要なワークスペースでは、承認なしにこれらのステータスへ遷移できない
+func (as ArticleStatus) IsPublic() bool {
+	return as == ArticleStatusPublished || as == ArticleStatusScheduled || as == ArticleStatusUnlisted
+}
+
+func (as ArticleStatus) IsScheduled() bool {
+	return as == ArticleStatusScheduled
+}
+
 func (as ArticleStatus) IsPublished() bool {
 	return as == ArticleStatusPublished
 }
 
+func (as ArticleStatus) IsUnlisted() bool {
+	return as == ArticleStatusUnlisted
+}
+
+func (as ArticleStatus) IsArchived() bool {
+	return as == ArticleStatusArchived
+}
+
 func (as ArticleStatus) String() string {
 	return string(as)
 }
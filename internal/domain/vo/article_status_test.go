@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
 )
 
@@ -17,6 +18,10 @@ func TestArticleStatus_IsValid(t *testing.T) {
 	}{
 		{name: "Draftは有効", as: vo.ArticleStatusDraft, want: true},
 		{name: "Publishedは有効", as: vo.ArticleStatusPublished, want: true},
+		{name: "Scheduledは有効", as: vo.ArticleStatusScheduled, want: true},
+		{name: "InReviewは有効", as: vo.ArticleStatusInReview, want: true},
+		{name: "Unlistedは有効", as: vo.ArticleStatusUnlisted, want: true},
+		{name: "Archivedは有効", as: vo.ArticleStatusArchived, want: true},
 		{name: "無効な値はfalse", as: vo.ArticleStatus("invalid_status"), want: false},
 	}
 
@@ -28,6 +33,47 @@ func TestArticleStatus_IsValid(t *testing.T) {
 	}
 }
 
+func TestArticleStatus_CanTransitionTo(t *testing.T) {
+	t.Parallel()
+
+	// 遷移表の全ての組み合わせ(行: 遷移元, 列: 遷移先)
+	allowed := map[vo.ArticleStatus]map[vo.ArticleStatus]bool{
+		vo.ArticleStatusDraft: {
+			vo.ArticleStatusDraft: false, vo.ArticleStatusInReview: true, vo.ArticleStatusScheduled: true, vo.ArticleStatusPublished: true, vo.ArticleStatusUnlisted: true, vo.ArticleStatusArchived: true,
+		},
+		vo.ArticleStatusInReview: {
+			vo.ArticleStatusDraft: true, vo.ArticleStatusInReview: false, vo.ArticleStatusScheduled: true, vo.ArticleStatusPublished: true, vo.ArticleStatusUnlisted: true, vo.ArticleStatusArchived: false,
+		},
+		vo.ArticleStatusScheduled: {
+			vo.ArticleStatusDraft: true, vo.ArticleStatusInReview: false, vo.ArticleStatusScheduled: false, vo.ArticleStatusPublished: true, vo.ArticleStatusUnlisted: false, vo.ArticleStatusArchived: false,
+		},
+		vo.ArticleStatusPublished: {
+			vo.ArticleStatusDraft: true, vo.ArticleStatusInReview: false, vo.ArticleStatusScheduled: false, vo.ArticleStatusPublished: false, vo.ArticleStatusUnlisted: true, vo.ArticleStatusArchived: true,
+		},
+		vo.ArticleStatusUnlisted: {
+			vo.ArticleStatusDraft: true, vo.ArticleStatusInReview: false, vo.ArticleStatusScheduled: false, vo.ArticleStatusPublished: true, vo.ArticleStatusUnlisted: false, vo.ArticleStatusArchived: true,
+		},
+		vo.ArticleStatusArchived: {
+			vo.ArticleStatusDraft: true, vo.ArticleStatusInReview: false, vo.ArticleStatusScheduled: false, vo.ArticleStatusPublished: false, vo.ArticleStatusUnlisted: false, vo.ArticleStatusArchived: false,
+		},
+	}
+	require.Len(t, allowed, len(vo.AllArticleStatuses))
+
+	for _, from := range vo.AllArticleStatuses {
+		for _, to := range vo.AllArticleStatuses {
+			want, ok := allowed[from][to]
+			require.True(t, ok, "%s -> %s is missing from the table", from, to)
+			assert.Equal(t, want, from.CanTransitionTo(to), "%s -> %s", from, to)
+		}
+	}
+
+	t.Run("無効なステータスとの間は遷移できない", func(t *testing.T) {
+		t.Parallel()
+		assert.False(t, vo.ArticleStatusDraft.CanTransitionTo(vo.ArticleStatus("invalid_status")))
+		assert.False(t, vo.ArticleStatus("invalid_status").CanTransitionTo(vo.ArticleStatusDraft))
+	})
+}
+
 func TestArticleStatus_IsDraft(t *testing.T) {
 	t.Parallel()
 
@@ -42,9 +88,24 @@ func TestArticleStatus_IsPublished(t *testing.T) {
 	assert.False(t, vo.ArticleStatusDraft.IsPublished())
 }
 
+func TestArticleStatus_IsPublic(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, vo.ArticleStatusPublished.IsPublic())
+	assert.True(t, vo.ArticleStatusScheduled.IsPublic())
+	assert.True(t, vo.ArticleStatusUnlisted.IsPublic())
+	assert.False(t, vo.ArticleStatusDraft.IsPublic())
+	assert.False(t, vo.ArticleStatusInReview.IsPublic())
+	assert.False(t, vo.ArticleStatusArchived.IsPublic())
+}
+
 func TestArticleStatus_String(t *testing.T) {
 	t.Parallel()
 
 	assert.Equal(t, "draft", vo.ArticleStatusDraft.String())
 	assert.Equal(t, "published", vo.ArticleStatusPublished.String())
+	assert.Equal(t, "scheduled", vo.ArticleStatusScheduled.String())
+	assert.Equal(t, "unlisted", vo.ArticleStatusUnlisted.String())
+	assert.Equal(t, "archived", vo.ArticleStatusArchived.String())
+	assert.Equal(t, "in_review", vo.ArticleStatusInReview.String())
 }
@@ -0,0 +1,33 @@
+package vo
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tag は記事に付与するタグを表すValue Object
+// 大文字小文字の揺れを避けるため小文字に正規化する
+type Tag string
+
+// タグの最大文字数制限
+const MaxTagLength = 50
+
+func NewTag(value string) (Tag, error) {
+	v := strings.ToLower(strings.TrimSpace(value))
+	if v == "" {
+		return "", fmt.Errorf("tag cannot be empty")
+	}
+	if utf8.RuneCountInString(v) > MaxTagLength {
+		return "", fmt.Errorf("tag exceeds maximum length of %d characters", MaxTagLength)
+	}
+	if strings.IndexFunc(v, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) >= 0 {
+		return "", fmt.Errorf("tag cannot contain whitespace or commas: %s", value)
+	}
+	return Tag(v), nil
+}
+
+func (t Tag) String() string {
+	return string(t)
+}
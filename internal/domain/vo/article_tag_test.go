@@ -0,0 +1,38 @@
+package vo_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+func TestNewTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		value     string
+		want      vo.Tag
+		assertion assert.ErrorAssertionFunc
+	}{
+		{name: "有効なタグで作成成功", value: "go", want: vo.Tag("go"), assertion: assert.NoError},
+		{name: "前後の空白を除去し小文字化する", value: "  Go ", want: vo.Tag("go"), assertion: assert.NoError},
+		{name: "日本語のタグで作成成功", value: "設計", want: vo.Tag("設計"), assertion: assert.NoError},
+		{name: "ちょうど50文字のタグで作成成功", value: strings.Repeat("あ", vo.MaxTagLength), want: vo.Tag(strings.Repeat("あ", vo.MaxTagLength)), assertion: assert.NoError},
+		{name: "空文字列の場合はエラー", value: " ", want: "", assertion: assert.Error},
+		{name: "50文字を超える場合はエラー", value: strings.Repeat("a", vo.MaxTagLength+1), want: "", assertion: assert.Error},
+		{name: "空白を含む場合はエラー", value: "go lang", want: "", assertion: assert.Error},
+		{name: "カンマを含む場合はエラー", value: "go,rust", want: "", assertion: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := vo.NewTag(tt.value)
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,21 +1,59 @@
 package vo
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
 
 // ArticleTitle は記事タイトルを表すValue Object
 type ArticleTitle string
 
 // タイトルの最大文字数制限
+// DBのVARCHAR(100)と同じくコードポイント(ルーン)単位で数える
 const MaxArticleTitleLength = 100
 
+// NewArticleTitle はタイトルをNFCに正規化し、前後の空白を除去して連続する空白を1つの半角スペースにまとめる
+// 制御文字や双方向テキストの制御文字を含む場合はエラーとする
 func NewArticleTitle(value string) (ArticleTitle, error) {
-	if len(value) == 0 {
+	if !utf8.ValidString(value) {
+		return "", fmt.Errorf("article title must be valid UTF-8")
+	}
+	for _, r := range value {
+		if isForbiddenTitleRune(r) {
+			return "", fmt.Errorf("article title contains a control character: %U", r)
+		}
+	}
+
+	normalized := strings.Join(strings.Fields(norm.NFC.String(value)), " ")
+	if len(normalized) == 0 {
 		return "", fmt.Errorf("article title cannot be empty")
 	}
-	if len(value) > MaxArticleTitleLength {
+	if utf8.RuneCountInString(normalized) > MaxArticleTitleLength {
 		return "", fmt.Errorf("article title exceeds maximum length of %d characters", MaxArticleTitleLength)
 	}
-	return ArticleTitle(value), nil
+	return ArticleTitle(normalized), nil
+}
+
+// isForbiddenTitleRune は空白以外の制御文字と双方向テキストの制御文字かを判定する
+// 空白類(タブ・改行を含む)は拒否せず半角スペースにまとめる
+func isForbiddenTitleRune(r rune) bool {
+	if unicode.IsSpace(r) {
+		return false
+	}
+	if unicode.IsControl(r) {
+		return true
+	}
+	return (r >= '\u202A' && r <= '\u202E') || (r >= '\u2066' && r <= '\u2069')
+}
+
+// ReconstituteArticleTitle は保存済みのタイトルを検証・正規化せずに復元する
+// 規則を後から厳しくしても既存の記事を読み込めるよう、検証はNewArticleTitleで作成・変更するときだけ行う
+func ReconstituteArticleTitle(value string) ArticleTitle {
+	return ArticleTitle(value)
 }
 
 func (t ArticleTitle) String() string {
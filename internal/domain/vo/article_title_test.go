@@ -3,10 +3,13 @@ package vo_test
 import (
 	"strings"
 	"testing"
+	"testing/quick"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"golang.org/x/text/unicode/norm"
 )
 
 func TestNewArticleTitle(t *testing.T) {
@@ -42,6 +45,60 @@ func TestNewArticleTitle(t *testing.T) {
 			want:      "",
 			assertion: assert.Error,
 		},
+		{
+			name:      "日本語はバイト数ではなく文字数で数える",
+			value:     strings.Repeat("あ", vo.MaxArticleTitleLength),
+			want:      vo.ArticleTitle(strings.Repeat("あ", vo.MaxArticleTitleLength)),
+			assertion: assert.NoError,
+		},
+		{
+			name:      "日本語で100文字を超える場合はエラー",
+			value:     strings.Repeat("あ", vo.MaxArticleTitleLength+1),
+			want:      "",
+			assertion: assert.Error,
+		},
+		{
+			name:      "NFCに正規化する",
+			value:     "か\u3099いき\u3099",
+			want:      vo.ArticleTitle("がいぎ"),
+			assertion: assert.NoError,
+		},
+		{
+			name:      "前後の空白を除去し連続する空白をまとめる",
+			value:     " \tGo　と\n\n  Rust  ",
+			want:      vo.ArticleTitle("Go と Rust"),
+			assertion: assert.NoError,
+		},
+		{
+			name:      "空白のみの場合はエラー",
+			value:     " 　\t",
+			want:      "",
+			assertion: assert.Error,
+		},
+		{
+			name:      "制御文字を含む場合はエラー",
+			value:     "title\x00",
+			want:      "",
+			assertion: assert.Error,
+		},
+		{
+			name:      "双方向テキストの制御文字を含む場合はエラー",
+			value:     "abc\u202Edef",
+			want:      "",
+			assertion: assert.Error,
+		},
+		{
+			name:      "不正なUTF-8の場合はエラー",
+			value:     "abc\xff",
+			want:      "",
+			assertion: assert.Error,
+		},
+		{
+			name:      "絵文字のZWJシーケンスは許可する",
+			value:     "家族👨\u200D👩\u200D👧",
+			want:      vo.ArticleTitle("家族👨\u200D👩\u200D👧"),
+			assertion: assert.NoError,
+		},
 	}
 
 	for _, tt := range tests {
@@ -63,3 +120,55 @@ func TestArticleTitle_String(t *testing.T) {
 
 	assert.Equal(t, titleValue, title.String())
 }
+
+// タイトルの生成規則に対するプロパティベーステスト
+func TestNewArticleTitle_Properties(t *testing.T) {
+	t.Parallel()
+
+	t.Run("生成に成功したタイトルは正規化済みで上限以内", func(t *testing.T) {
+		t.Parallel()
+		property := func(value string) bool {
+			title, err := vo.NewArticleTitle(value)
+			if err != nil {
+				return true
+			}
+			s := title.String()
+			return s != "" &&
+				norm.NFC.IsNormalString(s) &&
+				s == strings.TrimSpace(s) &&
+				!strings.Contains(s, "  ") &&
+				utf8.RuneCountInString(s) <= vo.MaxArticleTitleLength
+		}
+		require.NoError(t, quick.Check(property, &quick.Config{MaxCount: 2000}))
+	})
+
+	t.Run("生成は冪等", func(t *testing.T) {
+		t.Parallel()
+		property := func(value string) bool {
+			title, err := vo.NewArticleTitle(value)
+			if err != nil {
+				return true
+			}
+			again, err := vo.NewArticleTitle(title.String())
+			return err == nil && again == title
+		}
+		require.NoError(t, quick.Check(property, &quick.Config{MaxCount: 2000}))
+	})
+
+	t.Run("上限以内の日本語タイトルは常に生成できる", func(t *testing.T) {
+		t.Parallel()
+		kana := []rune("あいうえおかきくけこアイウエオ漢字記事日本語")
+		property := func(seed []uint8) bool {
+			runes := make([]rune, 0, vo.MaxArticleTitleLength)
+			for i := 0; i < len(seed) && i < vo.MaxArticleTitleLength; i++ {
+				runes = append(runes, kana[int(seed[i])%len(kana)])
+			}
+			if len(runes) == 0 {
+				return true
+			}
+			title, err := vo.NewArticleTitle(string(runes))
+			return err == nil && title.String() == string(runes)
+		}
+		require.NoError(t, quick.Check(property, &quick.Config{MaxCount: 500}))
+	})
+}
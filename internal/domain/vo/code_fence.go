@@ -0,0 +1,37 @@
+package vo
+
+import "strings"
+
+// CodeFence はMarkdownの本文を1行ずつ読む際に、コードフェンス(``` / ~~~)の内側かを追跡する
+// 閉じるフェンスは開いたものと同じ文字で、同じ数以上並んだ行とする
+// 本文のメタデータの導出とレンダリングの前処理で、コードブロックの扱いを揃えるために使う
+type CodeFence struct {
+	marker string
+}
+
+// Inside はコードフェンスの内側かを返す
+func (f *CodeFence) Inside() bool {
+	return f.marker != ""
+}
+
+// Open は前後の空白を除いた行がコードフェンスの開始であれば内側に入り、trueを返す
+func (f *CodeFence) Open(trimmed string) bool {
+	if !strings.HasPrefix(trimmed, "```") && !strings.HasPrefix(trimmed, "~~~") {
+		return false
+	}
+	n := 3
+	for n < len(trimmed) && trimmed[n] == trimmed[0] {
+		n++
+	}
+	f.marker = trimmed[:n]
+	return true
+}
+
+// Close は前後の空白を除いた行が開いているコードフェンスの終了であれば外側に出て、trueを返す
+func (f *CodeFence) Close(trimmed string) bool {
+	if !f.Inside() || !strings.HasPrefix(trimmed, f.marker) || strings.Trim(trimmed, f.marker[:1]) != "" {
+		return false
+	}
+	f.marker = ""
+	return true
+}
@@ -0,0 +1,55 @@
+package vo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+func TestCodeFence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		lines      []string
+		wantInside []bool
+	}{
+		{
+			name:       "同じ文字のフェンスで閉じる",
+			lines:      []string{"```go", "~~~", "```"},
+			wantInside: []bool{true, true, false},
+		},
+		{
+			name:       "開いたものより短いフェンスでは閉じない",
+			lines:      []string{"````", "```", "````"},
+			wantInside: []bool{true, true, false},
+		},
+		{
+			name:       "言語指定のある行は閉じるフェンスではない",
+			lines:      []string{"~~~", "~~~ruby", "~~~~"},
+			wantInside: []bool{true, true, false},
+		},
+		{
+			name:       "フェンスでない行では開かない",
+			lines:      []string{"``", "本文"},
+			wantInside: []bool{false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var fence vo.CodeFence
+			for i, line := range tt.lines {
+				if fence.Inside() {
+					fence.Close(line)
+				} else {
+					fence.Open(line)
+				}
+				assert.Equal(t, tt.wantInside[i], fence.Inside(), "line %d: %q", i, line)
+			}
+		})
+	}
+}
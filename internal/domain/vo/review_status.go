@@ -0,0 +1,50 @@
+package vo
+
+import "fmt"
+
+// ReviewStatus はレビュー依頼の状態を表すValue Object
+type ReviewStatus string
+
+const (
+	// ReviewStatusPending はレビュアーの判断待ち
+	ReviewStatusPending ReviewStatus = "pending"
+	// ReviewStatusApproved は承認され、記事を公開できる
+	ReviewStatusApproved ReviewStatus = "approved"
+	// ReviewStatusChangesRequested は修正を求めて差し戻された
+	ReviewStatusChangesRequested ReviewStatus = "changes_requested"
+	// ReviewStatusWithdrawn は提出した側が取り下げた
+	ReviewStatusWithdrawn ReviewStatus = "withdrawn"
+)
+
+var AllReviewStatuses = []ReviewStatus{
+	ReviewStatusPending,
+	ReviewStatusApproved,
+	ReviewStatusChangesRequested,
+	ReviewStatusWithdrawn,
+}
+
+func NewReviewStatus(value string) (ReviewStatus, error) {
+	s := ReviewStatus(value)
+	if !s.IsValid() {
+		return "", fmt.Errorf("invalid review status: %s", value)
+	}
+	return s, nil
+}
+
+func (s ReviewStatus) IsValid() bool {
+	for _, v := range AllReviewStatuses {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen はレビュー依頼が記事のレビュー中の状態に対応しているか(判断待ちまたは承認済み)を判定する
+func (s ReviewStatus) IsOpen() bool {
+	return s == ReviewStatusPending || s == ReviewStatusApproved
+}
+
+func (s ReviewStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,49 @@
+package vo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+func TestNewReviewStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    vo.ReviewStatus
+		wantErr bool
+	}{
+		{name: "pendingは有効", value: "pending", want: vo.ReviewStatusPending},
+		{name: "approvedは有効", value: "approved", want: vo.ReviewStatusApproved},
+		{name: "changes_requestedは有効", value: "changes_requested", want: vo.ReviewStatusChangesRequested},
+		{name: "withdrawnは有効", value: "withdrawn", want: vo.ReviewStatusWithdrawn},
+		{name: "空文字はエラー", value: "", wantErr: true},
+		{name: "無効な値はエラー", value: "rejected", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := vo.NewReviewStatus(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReviewStatus_IsOpen(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, vo.ReviewStatusPending.IsOpen())
+	assert.True(t, vo.ReviewStatusApproved.IsOpen())
+	assert.False(t, vo.ReviewStatusChangesRequested.IsOpen())
+	assert.False(t, vo.ReviewStatusWithdrawn.IsOpen())
+}
@@ -0,0 +1,55 @@
+package vo
+
+import "fmt"
+
+// Scope はAPIキーに許可された操作の範囲を表すValue Object
+type Scope string
+
+const (
+	// ScopeArticlesRead は記事の参照を許可する
+	ScopeArticlesRead Scope = "articles:read"
+	// ScopeArticlesWrite は記事の作成と更新を許可する(参照を含む)
+	ScopeArticlesWrite Scope = "articles:write"
+	// ScopeArticlesAdmin は記事の削除と管理用の操作を許可する(作成・更新・参照を含む)
+	ScopeArticlesAdmin Scope = "articles:admin"
+)
+
+var AllScopes = []Scope{
+	ScopeArticlesRead,
+	ScopeArticlesWrite,
+	ScopeArticlesAdmin,
+}
+
+// scopeLevels は上位のスコープが下位のスコープを含むための順序
+var scopeLevels = map[Scope]int{
+	ScopeArticlesRead:  1,
+	ScopeArticlesWrite: 2,
+	ScopeArticlesAdmin: 3,
+}
+
+func NewScope(value string) (Scope, error) {
+	s := Scope(value)
+	if !s.IsValid() {
+		return "", fmt.Errorf("invalid scope: %s", value)
+	}
+	return s, nil
+}
+
+func (s Scope) IsValid() bool {
+	_, ok := scopeLevels[s]
+	return ok
+}
+
+// Includes はこのスコープがotherの操作を許可するかを判定する
+func (s Scope) Includes(other Scope) bool {
+	level, ok := scopeLevels[s]
+	if !ok {
+		return false
+	}
+	required, ok := scopeLevels[other]
+	return ok && level >= required
+}
+
+func (s Scope) String() string {
+	return string(s)
+}
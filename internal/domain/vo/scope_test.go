@@ -0,0 +1,47 @@
+package vo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+func TestNewScope(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range vo.AllScopes {
+		got, err := vo.NewScope(s.String())
+		require.NoError(t, err)
+		assert.Equal(t, s, got)
+	}
+
+	_, err := vo.NewScope("articles:delete")
+	assert.ErrorContains(t, err, "invalid scope")
+}
+
+func TestScope_Includes(t *testing.T) {
+	t.Parallel()
+
+	// 行: 持っているスコープ, 列: 必要なスコープ
+	want := map[vo.Scope]map[vo.Scope]bool{
+		vo.ScopeArticlesRead: {
+			vo.ScopeArticlesRead: true, vo.ScopeArticlesWrite: false, vo.ScopeArticlesAdmin: false,
+		},
+		vo.ScopeArticlesWrite: {
+			vo.ScopeArticlesRead: true, vo.ScopeArticlesWrite: true, vo.ScopeArticlesAdmin: false,
+		},
+		vo.ScopeArticlesAdmin: {
+			vo.ScopeArticlesRead: true, vo.ScopeArticlesWrite: true, vo.ScopeArticlesAdmin: true,
+		},
+	}
+
+	for have, row := range want {
+		for required, ok := range row {
+			assert.Equal(t, ok, have.Includes(required), "%s includes %s", have, required)
+		}
+	}
+	assert.False(t, vo.Scope("unknown").Includes(vo.ScopeArticlesRead))
+	assert.False(t, vo.ScopeArticlesAdmin.Includes(vo.Scope("unknown")))
+}
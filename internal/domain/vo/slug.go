@@ -0,0 +1,175 @@
+package vo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Slug は記事のURLに使う識別子を表すValue Object
+type Slug string
+
+// スラッグの最大文字数
+const MaxSlugLength = 100
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// NewSlug は英小文字・数字とハイフン区切りのスラッグを作成する
+func NewSlug(value string) (Slug, error) {
+	if value == "" {
+		return "", fmt.Errorf("slug cannot be empty")
+	}
+	if len(value) > MaxSlugLength {
+		return "", fmt.Errorf("slug exceeds maximum length of %d characters", MaxSlugLength)
+	}
+	if !slugPattern.MatchString(value) {
+		return "", fmt.Errorf("slug must consist of lowercase letters, digits and single hyphens: %q", value)
+	}
+	return Slug(value), nil
+}
+
+// GenerateSlug はタイトルからスラッグを生成する
+// かなはヘボン式でローマ字にし、ローマ字にできない文字は区切りとして扱う
+// 漢字を含む場合や英数字が残らない場合は、読みを失った紛らわしいスラッグにならないよう
+// タイトルのハッシュから "article-xxxxxxxx" を生成する
+func GenerateSlug(title string) Slug {
+	var b strings.Builder
+	pendingHyphen, droppedHan := false, false
+	write := func(s string) {
+		if pendingHyphen && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		pendingHyphen = false
+		b.WriteString(s)
+	}
+
+	runes := []rune(norm.NFKC.String(title))
+	for i := 0; i < len(runes); i++ {
+		r := unicode.ToLower(runes[i])
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			write(string(r))
+		case isKana(r):
+			romaji, consumed := romanizeKana(runes[i:])
+			write(romaji)
+			i += consumed - 1
+		case r == '\'' || r == '’':
+			// don't → dont
+		case unicode.Is(unicode.Han, r):
+			droppedHan = true
+		default:
+			pendingHyphen = true
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > MaxSlugLength {
+		slug = strings.TrimRight(slug[:MaxSlugLength], "-")
+	}
+	if slug == "" || droppedHan {
+		sum := sha256.Sum256([]byte(title))
+		slug = "article-" + hex.EncodeToString(sum[:4])
+	}
+	return Slug(slug)
+}
+
+// WithSuffix は重複を避けるために "-n" を付与したスラッグを返す
+// 最大文字数を超える場合は元のスラッグを切り詰める
+func (s Slug) WithSuffix(n int) Slug {
+	suffix := fmt.Sprintf("-%d", n)
+	base := string(s)
+	if len(base)+len(suffix) > MaxSlugLength {
+		base = strings.TrimRight(base[:MaxSlugLength-len(suffix)], "-")
+	}
+	return Slug(base + suffix)
+}
+
+func (s Slug) String() string {
+	return string(s)
+}
+
+func isKana(r rune) bool {
+	return unicode.In(r, unicode.Hiragana, unicode.Katakana) || r == 'ー'
+}
+
+// toHiragana はカタカナをひらがなに変換する
+func toHiragana(r rune) rune {
+	if r >= 'ァ' && r <= 'ヶ' {
+		return r - ('ァ' - 'ぁ')
+	}
+	return r
+}
+
+// romanizeKana は先頭のかなをローマ字にし、消費したルーン数を返す
+func romanizeKana(runes []rune) (string, int) {
+	r := toHiragana(runes[0])
+	switch r {
+	case 'ー':
+		// 長音は表記しない
+		return "", 1
+	case 'っ':
+		// 促音は次の音の子音を重ねる
+		if len(runes) > 1 {
+			next, consumed := romanizeKana(runes[1:])
+			if next != "" && !strings.ContainsRune("aiueon", rune(next[0])) {
+				if strings.HasPrefix(next, "ch") {
+					return "t" + next, consumed + 1
+				}
+				return next[:1] + next, consumed + 1
+			}
+		}
+		return "", 1
+	}
+	if len(runes) > 1 {
+		if romaji, ok := kanaDigraphs[string([]rune{r, toHiragana(runes[1])})]; ok {
+			return romaji, 2
+		}
+	}
+	if romaji, ok := kanaRomaji[r]; ok {
+		return romaji, 1
+	}
+	return "", 1
+}
+
+var kanaRomaji = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+	'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo", 'ゎ': "wa", 'ゔ': "vu",
+}
+
+var kanaDigraphs = map[string]string{
+	"きゃ": "kya", "きゅ": "kyu", "きょ": "kyo",
+	"しゃ": "sha", "しゅ": "shu", "しょ": "sho", "しぇ": "she",
+	"ちゃ": "cha", "ちゅ": "chu", "ちょ": "cho", "ちぇ": "che",
+	"にゃ": "nya", "にゅ": "nyu", "にょ": "nyo",
+	"ひゃ": "hya", "ひゅ": "hyu", "ひょ": "hyo",
+	"みゃ": "mya", "みゅ": "myu", "みょ": "myo",
+	"りゃ": "rya", "りゅ": "ryu", "りょ": "ryo",
+	"ぎゃ": "gya", "ぎゅ": "gyu", "ぎょ": "gyo",
+	"じゃ": "ja", "じゅ": "ju", "じょ": "jo", "じぇ": "je",
+	"びゃ": "bya", "びゅ": "byu", "びょ": "byo",
+	"ぴゃ": "pya", "ぴゅ": "pyu", "ぴょ": "pyo",
+	"ふぁ": "fa", "ふぃ": "fi", "ふぇ": "fe", "ふぉ": "fo",
+	"てぃ": "ti", "でぃ": "di", "とぅ": "tu", "どぅ": "du",
+	"うぃ": "wi", "うぇ": "we", "うぉ": "wo",
+	"ゔぁ": "va", "ゔぃ": "vi", "ゔぇ": "ve", "ゔぉ": "vo",
+}
@@ -0,0 +1,88 @@
+package vo_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+func TestNewSlug(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		value     string
+		want      vo.Slug
+		assertion assert.ErrorAssertionFunc
+	}{
+		{name: "英小文字と数字とハイフン", value: "go-1-22-release", want: "go-1-22-release", assertion: assert.NoError},
+		{name: "空文字列はエラー", value: "", want: "", assertion: assert.Error},
+		{name: "大文字はエラー", value: "Go", want: "", assertion: assert.Error},
+		{name: "先頭のハイフンはエラー", value: "-go", want: "", assertion: assert.Error},
+		{name: "連続するハイフンはエラー", value: "go--lang", want: "", assertion: assert.Error},
+		{name: "日本語はエラー", value: "記事", want: "", assertion: assert.Error},
+		{name: "最大文字数を超える場合はエラー", value: strings.Repeat("a", vo.MaxSlugLength+1), want: "", assertion: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := vo.NewSlug(tt.value)
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGenerateSlug(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		title string
+		want  vo.Slug
+	}{
+		{name: "英語のタイトル", title: "Hello, World! Go 1.22", want: "hello-world-go-1-22"},
+		{name: "アポストロフィは詰める", title: "Don't Panic", want: "dont-panic"},
+		{name: "ひらがなをローマ字にする", title: "はじめての ごー", want: "hajimeteno-go"},
+		{name: "カタカナと拗音", title: "キャッシュ", want: "kyasshu"},
+		{name: "促音と長音", title: "ピッチャー", want: "pitcha"},
+		{name: "全角英数字は半角にする", title: "ＧＯ　ＴＯＵＲ", want: "go-tour"},
+		{name: "漢字を含む場合はハッシュから生成する", title: "Go言語でテスト", want: "article-2f10eae0"},
+		{name: "漢字を除くと別の語になる場合もハッシュから生成する", title: "設計の話", want: "article-220d9b4f"},
+		{name: "英数字が残らない場合はハッシュから生成する", title: "記事一覧", want: "article-8f8089c6"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := vo.GenerateSlug(tt.title)
+			assert.Equal(t, tt.want, got)
+
+			_, err := vo.NewSlug(got.String())
+			assert.NoError(t, err, "生成したスラッグは常に有効")
+		})
+	}
+
+	t.Run("最大文字数で切り詰める", func(t *testing.T) {
+		t.Parallel()
+		got := vo.GenerateSlug(strings.Repeat("ab ", 60))
+
+		assert.LessOrEqual(t, len(got), vo.MaxSlugLength)
+		_, err := vo.NewSlug(got.String())
+		assert.NoError(t, err)
+	})
+}
+
+func TestSlug_WithSuffix(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, vo.Slug("go-2"), vo.Slug("go").WithSuffix(2))
+
+	long := vo.Slug(strings.Repeat("a", vo.MaxSlugLength))
+	got := long.WithSuffix(12)
+	assert.Len(t, got.String(), vo.MaxSlugLength)
+	assert.True(t, strings.HasSuffix(got.String(), "-12"))
+}
@@ -0,0 +1,42 @@
+package vo
+
+import "fmt"
+
+// SyncStatus は投稿先プロバイダとの同期状態を表すValue Object
+type SyncStatus string
+
+const (
+	// SyncStatusPending は投稿先への反映待ち
+	SyncStatusPending SyncStatus = "pending"
+	// SyncStatusSynced は投稿先の内容と一致している
+	SyncStatusSynced SyncStatus = "synced"
+	// SyncStatusFailed は投稿先との同期に失敗した
+	SyncStatusFailed SyncStatus = "failed"
+)
+
+var AllSyncStatuses = []SyncStatus{
+	SyncStatusPending,
+	SyncStatusSynced,
+	SyncStatusFailed,
+}
+
+func NewSyncStatus(value string) (SyncStatus, error) {
+	s := SyncStatus(value)
+	if !s.IsValid() {
+		return "", fmt.Errorf("invalid sync status: %s", value)
+	}
+	return s, nil
+}
+
+func (s SyncStatus) IsValid() bool {
+	for _, v := range AllSyncStatuses {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (s SyncStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,39 @@
+package vo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+func TestNewSyncStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    vo.SyncStatus
+		wantErr bool
+	}{
+		{name: "pendingは有効", value: "pending", want: vo.SyncStatusPending},
+		{name: "syncedは有効", value: "synced", want: vo.SyncStatusSynced},
+		{name: "failedは有効", value: "failed", want: vo.SyncStatusFailed},
+		{name: "空文字はエラー", value: "", wantErr: true},
+		{name: "無効な値はエラー", value: "unknown", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := vo.NewSyncStatus(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
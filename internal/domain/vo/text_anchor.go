@@ -0,0 +1,113 @@
+package vo
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// 引用できる範囲の最大文字数
+	MaxTextAnchorLength = 2000
+	// 範囲を探し直す際に照合する前後の文脈の文字数
+	textAnchorContextLength = 32
+)
+
+// TextAnchor は記事本文の範囲を表すValue Object
+// Start / Endは本文の先頭からの文字(ルーン)単位のオフセットで、範囲は[Start, End)
+// Quoteは範囲の文字列、Prefix / Suffixはその前後の文脈で、本文が変更された後に範囲を探し直すために使う
+type TextAnchor struct {
+	Start  int
+	End    int
+	Quote  string
+	Prefix string
+	Suffix string
+}
+
+// NewTextAnchor は本文の[start, end)の範囲を表すアンカーを作成する
+// quoteを指定した場合は範囲の文字列と一致しなければならない(古い本文に対する範囲の指定を拒否するため)
+func NewTextAnchor(body string, start, end int, quote string) (TextAnchor, error) {
+	if start < 0 || end <= start {
+		return TextAnchor{}, fmt.Errorf("invalid text range [%d, %d)", start, end)
+	}
+	if end-start > MaxTextAnchorLength {
+		return TextAnchor{}, fmt.Errorf("text range must be at most %d characters", MaxTextAnchorLength)
+	}
+	runes := []rune(body)
+	if end > len(runes) {
+		return TextAnchor{}, fmt.Errorf("text range [%d, %d) exceeds the body of %d characters", start, end, len(runes))
+	}
+	if quote != "" && quote != string(runes[start:end]) {
+		return TextAnchor{}, fmt.Errorf("quote does not match the body at [%d, %d)", start, end)
+	}
+	return anchorAt(runes, start, end), nil
+}
+
+func anchorAt(runes []rune, start, end int) TextAnchor {
+	return TextAnchor{
+		Start:  start,
+		End:    end,
+		Quote:  string(runes[start:end]),
+		Prefix: string(runes[max(0, start-textAnchorContextLength):start]),
+		Suffix: string(runes[end:min(len(runes), end+textAnchorContextLength)]),
+	}
+}
+
+// Reanchor は変更後の本文でのアンカーを返す
+// 本文中の引用の出現位置のうち、前後の文脈が最も一致するもの(同じ場合は元の位置に近いもの)に移す
+// 本文から引用が見つからない場合は元のアンカーとfalseを返す
+func (a TextAnchor) Reanchor(body string) (TextAnchor, bool) {
+	if a.Quote == "" {
+		return a, false
+	}
+	runes := []rune(body)
+	length := utf8.RuneCountInString(a.Quote)
+	best, bestScore, found := 0, 0, false
+	for offset, pos := 0, 0; ; {
+		i := strings.Index(body[offset:], a.Quote)
+		if i < 0 {
+			break
+		}
+		pos += utf8.RuneCountInString(body[offset : offset+i])
+		score := commonSuffixLength(a.Prefix, string(runes[max(0, pos-textAnchorContextLength):pos])) +
+			commonPrefixLength(a.Suffix, string(runes[pos+length:min(len(runes), pos+length+textAnchorContextLength)]))
+		if !found || score > bestScore || (score == bestScore && distance(pos, a.Start) < distance(best, a.Start)) {
+			best, bestScore, found = pos, score, true
+		}
+		// 重なった出現も探せるよう、次は1文字先から探す
+		_, size := utf8.DecodeRuneInString(body[offset+i:])
+		offset += i + size
+		pos++
+	}
+	if !found {
+		return a, false
+	}
+	return anchorAt(runes, best, best+length), true
+}
+
+// commonPrefixLength はxとyの先頭から一致する文字数を返す
+func commonPrefixLength(x, y string) int {
+	xs, ys := []rune(x), []rune(y)
+	n := 0
+	for n < len(xs) && n < len(ys) && xs[n] == ys[n] {
+		n++
+	}
+	return n
+}
+
+// commonSuffixLength はxとyの末尾から一致する文字数を返す
+func commonSuffixLength(x, y string) int {
+	xs, ys := []rune(x), []rune(y)
+	n := 0
+	for n < len(xs) && n < len(ys) && xs[len(xs)-1-n] == ys[len(ys)-1-n] {
+		n++
+	}
+	return n
+}
+
+func distance(x, y int) int {
+	if x > y {
+		return x - y
+	}
+	return y - x
+}
@@ -0,0 +1,98 @@
+package vo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+func TestNewTextAnchor(t *testing.T) {
+	t.Parallel()
+
+	const body = "Goの並行処理について"
+
+	tests := []struct {
+		name       string
+		start, end int
+		quote      string
+		want       vo.TextAnchor
+		wantErr    bool
+	}{
+		{name: "文字単位の範囲で作成する", start: 3, end: 7, want: vo.TextAnchor{Start: 3, End: 7, Quote: "並行処理", Prefix: "Goの", Suffix: "について"}},
+		{name: "一致する引用を指定できる", start: 0, end: 2, quote: "Go", want: vo.TextAnchor{Start: 0, End: 2, Quote: "Go", Suffix: "の並行処理について"}},
+		{name: "引用が一致しない場合はエラー", start: 0, end: 2, quote: "Rust", wantErr: true},
+		{name: "空の範囲はエラー", start: 3, end: 3, wantErr: true},
+		{name: "負のオフセットはエラー", start: -1, end: 2, wantErr: true},
+		{name: "本文を超える範囲はエラー", start: 3, end: 20, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := vo.NewTextAnchor(body, tt.start, tt.end, tt.quote)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTextAnchor_Reanchor(t *testing.T) {
+	t.Parallel()
+
+	anchor, err := vo.NewTextAnchor("前置き。並行処理の話。並行処理の続き。", 11, 15, "")
+	require.NoError(t, err)
+	require.Equal(t, "並行処理", anchor.Quote)
+
+	tests := []struct {
+		name   string
+		body   string
+		want   [2]int
+		wantOK bool
+	}{
+		{
+			name:   "同じ位置に引用が残っていれば変わらない",
+			body:   "前置き。並行処理の話。並行処理の続きを書き足した。",
+			want:   [2]int{11, 15},
+			wantOK: true,
+		},
+		{
+			name:   "前に文章が追加されると後ろにずれる",
+			body:   "はじめに。前置き。並行処理の話。並行処理の続き。",
+			want:   [2]int{16, 20},
+			wantOK: true,
+		},
+		{
+			name:   "複数の出現のうち前後の文脈が一致するものを選ぶ",
+			body:   "並行処理の話。並行処理の続き。",
+			want:   [2]int{7, 11},
+			wantOK: true,
+		},
+		{
+			name:   "文脈が同じ程度なら元の位置に近いものを選ぶ",
+			body:   "並行処理。並行処理。並行処理。",
+			want:   [2]int{10, 14},
+			wantOK: true,
+		},
+		{
+			name: "引用が消えた場合は見つからない",
+			body: "前置き。並列処理の話。",
+			want: [2]int{11, 15},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := anchor.Reanchor(tt.body)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, [2]int{got.Start, got.End})
+			assert.Equal(t, anchor.Quote, got.Quote)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+)
+
+// ArticleHandler は記事のHTTPハンドラ
+type ArticleHandler struct {
+	uc    *article.ArticleUsecase
+	views map[string]http.HandlerFunc
+}
+
+func NewArticleHandler(uc *article.ArticleUsecase) *ArticleHandler {
+	h := &ArticleHandler{uc: uc}
+	h.views = map[string]http.HandlerFunc{"rendered": h.rendered}
+	return h
+}
+
+// AddView は GET /articles/{id}/{name} のサブリソースを追加する
+// 他のハンドラが記事配下のルートを持つ場合もRegisterと同じ理由でここから振り分ける
+func (h *ArticleHandler) AddView(name string, fn http.HandlerFunc) {
+	h.views[name] = fn
+}
+
+// Register はルーティングを登録する
+// /articles/{id}/rendered は /articles/by-slug/{slug} とパターンが競合するため、
+// サブリソースをまとめて受けてから振り分ける
+func (h *ArticleHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /articles/{id}", h.get)
+	mux.HandleFunc("GET /articles/{id}/{view}", h.view)
+	mux.HandleFunc("GET /articles/by-slug/{slug}", h.getBySlug)
+	mux.HandleFunc("POST /articles/{id}/publications", h.addPublication)
+	mux.HandleFunc("DELETE /articles/{id}/publications/{publicationID}", h.removePublication)
+	mux.HandleFunc("PUT /articles/{id}/publications/{publicationID}/canonical", h.setCanonicalPublication)
+	mux.HandleFunc("POST /articles/bulk", h.bulk)
+}
+
+func (h *ArticleHandler) view(w http.ResponseWriter, r *http.Request) {
+	fn, ok := h.views[r.PathValue("view")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	fn(w, r)
+}
+
+func (h *ArticleHandler) get(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.FindArticleByID(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+// rendered は本文をサニタイズ済みのHTMLフラグメントとして返す
+func (h *ArticleHandler) rendered(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.FindArticleByID(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(output.BodyHTML))
+}
+
+// getBySlug はスラッグで記事を取得する
+// 過去のスラッグの場合は現在のスラッグへ301でリダイレクトする
+func (h *ArticleHandler) getBySlug(w http.ResponseWriter, r *http.Request) {
+	output, err := h.uc.FindArticleBySlug(r.Context(), r.PathValue("slug"))
+	var moved *article.SlugMovedError
+	if errors.As(err, &moved) {
+		http.Redirect(w, r, "/articles/by-slug/"+url.PathEscape(moved.Slug), http.StatusMovedPermanently)
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+// addPublication はクロスポストなどの投稿先を追加する
+func (h *ArticleHandler) addPublication(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var input article.AddPublicationInput
+	if err := decodeJSON(r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.AddPublication(r.Context(), id, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, output)
+}
+
+func (h *ArticleHandler) removePublication(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	publicationID, err := pathID(r, "publicationID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := h.uc.RemovePublication(r.Context(), id, publicationID); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setCanonicalPublication は投稿先を正規の投稿先にする
+func (h *ArticleHandler) setCanonicalPublication(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	publicationID, err := pathID(r, "publicationID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.SetCanonicalPublication(r.Context(), id, publicationID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+// bulk は指定したIDまたは絞り込みの条件に一致する記事にまとめて操作を適用し、記事ごとの結果を返す
+// 一部の記事に適用できなくても200を返す(dry_runを指定すると変更せずに結果だけを返す)
+func (h *ArticleHandler) bulk(w http.ResponseWriter, r *http.Request) {
+	var input article.BulkInput
+	if err := decodeJSON(r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.BulkApply(r.Context(), input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+)
+
+// slugArticleRepository は現在のスラッグと履歴を固定で持つ
+type slugArticleRepository struct {
+	repository.ArticleRepository
+	article *entity.Article
+	history map[string]string
+}
+
+func (s *slugArticleRepository) FindByID(_ context.Context, id uint64) (*entity.Article, error) {
+	if id != s.article.ID {
+		return nil, repository.ErrArticleNotFound
+	}
+	return s.article, nil
+}
+
+func (s *slugArticleRepository) FindBySlug(_ context.Context, slug string) (*entity.Article, error) {
+	if slug != s.article.Slug.String() {
+		return nil, repository.ErrArticleNotFound
+	}
+	return s.article, nil
+}
+
+func (s *slugArticleRepository) FindCurrentSlug(_ context.Context, previousSlug string) (string, error) {
+	if current, ok := s.history[previousSlug]; ok {
+		return current, nil
+	}
+	return "", repository.ErrArticleNotFound
+}
+
+// FindIDByNormalizedLink は自身の記事のリンクだけを登録済みとして扱う
+func (s *slugArticleRepository) FindIDByNormalizedLink(_ context.Context, normalizedLink string) (uint64, error) {
+	if s.article.Link == nil || normalizedLink != s.article.Link.Normalized() {
+		return 0, repository.ErrArticleNotFound
+	}
+	return s.article.ID, nil
+}
+
+func (s *slugArticleRepository) FindByIDForUpdate(ctx context.Context, id uint64) (*entity.Article, error) {
+	return s.FindByID(ctx, id)
+}
+
+// Update は保存時に採番される投稿先のIDを模倣する
+func (s *slugArticleRepository) Update(_ context.Context, a *entity.Article) error {
+	for i := range a.Publications {
+		if a.Publications[i].ID == 0 {
+			a.Publications[i].ID = uint64(100 + i)
+		}
+	}
+	return nil
+}
+
+// passthroughTxManager はトランザクションを張らずにfnをそのまま実行する
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (passthroughTxManager) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func newArticleTestMux(t *testing.T) *http.ServeMux {
+	t.Helper()
+	body := "# 見出し"
+	a, err := entity.NewArticle("Hello World", "published", entity.WithBody(&body))
+	require.NoError(t, err)
+	a.ID = 1
+
+	repo := &slugArticleRepository{article: a, history: map[string]string{"old-hello": "hello-world"}}
+	renderer := stubRenderer(func(body string) (string, error) { return "<h1>見出し</h1>", nil })
+	mux := http.NewServeMux()
+	NewArticleHandler(article.NewArticleUsecase(repo, passthroughTxManager{}, article.WithBodyRenderer(renderer))).Register(mux)
+	return mux
+}
+
+type stubRenderer func(body string) (string, error)
+
+func (f stubRenderer) Render(body string) (string, error) {
+	return f(body)
+}
+
+func TestArticleHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		path         string
+		wantStatus   int
+		wantLocation string
+		wantBody     string
+	}{
+		{name: "IDで取得する", path: "/articles/1", wantStatus: http.StatusOK, wantBody: `"slug":"hello-world"`},
+		{name: "レンダリング済みのHTMLを返す", path: "/articles/1/rendered", wantStatus: http.StatusOK, wantBody: "<h1>見出し</h1>"},
+		{name: "未知のサブリソースは404", path: "/articles/1/unknown", wantStatus: http.StatusNotFound},
+		{name: "スラッグで取得する", path: "/articles/by-slug/hello-world", wantStatus: http.StatusOK, wantBody: `"body_html":"\u003ch1\u003e見出し\u003c/h1\u003e"`},
+		{name: "過去のスラッグは301で現在のスラッグへ", path: "/articles/by-slug/old-hello", wantStatus: http.StatusMovedPermanently, wantLocation: "/articles/by-slug/hello-world"},
+		{name: "存在しないスラッグは404", path: "/articles/by-slug/unknown", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			newArticleTestMux(t).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantLocation, rec.Header().Get("Location"))
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestArticleHandler_Publications(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "投稿先を追加すると201",
+			method:     http.MethodPost,
+			path:       "/articles/1/publications",
+			body:       `{"provider_type":"qiita","link":"https://qiita.com/example/items/a"}`,
+			wantStatus: http.StatusCreated,
+			wantBody:   `"canonical":true`,
+		},
+		{
+			name:       "プロバイダもリンクもない投稿先は400",
+			method:     http.MethodPost,
+			path:       "/articles/1/publications",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "存在しない記事への追加は404",
+			method:     http.MethodPost,
+			path:       "/articles/2/publications",
+			body:       `{"provider_type":"qiita"}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "存在しない投稿先の削除は404",
+			method:     http.MethodDelete,
+			path:       "/articles/1/publications/99",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "不正な投稿先IDは400",
+			method:     http.MethodPut,
+			path:       "/articles/1/publications/abc/canonical",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			newArticleTestMux(t).ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/audit"
+)
+
+// AuditHandler は監査ログのHTTPハンドラ
+type AuditHandler struct {
+	uc *audit.AuditUsecase
+}
+
+func NewAuditHandler(uc *audit.AuditUsecase) *AuditHandler {
+	return &AuditHandler{uc: uc}
+}
+
+// Register はルーティングを登録する
+func (h *AuditHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /audit-log", h.query)
+	mux.HandleFunc("GET /audit-log/export", h.export)
+}
+
+// query は条件に一致するログを古い順に1ページ分返す(次のページはnext_cursorをafterに指定する)
+func (h *AuditHandler) query(w http.ResponseWriter, r *http.Request) {
+	input, err := auditQueryParams(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.Query(r.Context(), input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+// export は条件に一致するログを全てJSON Lines形式で返す
+// 書き出しを始めた後に失敗した場合はステータスを変更できないため、ログに記録して打ち切る
+func (h *AuditHandler) export(w http.ResponseWriter, r *http.Request) {
+	input, err := auditQueryParams(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	out := &exportWriter{w: w}
+	if err := h.uc.Export(r.Context(), input, out); err != nil {
+		if !out.started {
+			writeError(w, err)
+			return
+		}
+		log.Printf("failed to export audit log: %v", err)
+		return
+	}
+	if !out.started {
+		out.start()
+	}
+}
+
+// exportWriter は最初の書き込みでJSON Linesのヘッダを送る
+// 権限の確認などで書き込む前に失敗した場合は、通常のエラーのレスポンスを返せる
+type exportWriter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (e *exportWriter) start() {
+	e.started = true
+	e.w.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+	e.w.Header().Set("Content-Disposition", `attachment; filename="audit-log.jsonl"`)
+	e.w.WriteHeader(http.StatusOK)
+}
+
+func (e *exportWriter) Write(p []byte) (int, error) {
+	if !e.started {
+		e.start()
+	}
+	return e.w.Write(p)
+}
+
+// auditQueryParams はクエリの絞り込みの条件を取り出す
+func auditQueryParams(r *http.Request) (audit.QueryInput, error) {
+	var input audit.QueryInput
+	var err error
+	q := r.URL.Query()
+	if input.From, input.To, err = periodParams(r); err != nil {
+		return input, err
+	}
+	if v := q.Get("article_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return input, fmt.Errorf("%w: invalid article_id: %q", apperr.ErrInvalidInput, v)
+		}
+		input.ArticleID = &id
+	}
+	if v := q.Get("action"); v != "" {
+		input.Action = &v
+	}
+	if v := q.Get("actor"); v != "" {
+		input.Actor = &v
+	}
+	if v := q.Get("after"); v != "" {
+		if input.After, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return input, fmt.Errorf("%w: invalid after: %q", apperr.ErrInvalidInput, v)
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		if input.Limit, err = strconv.Atoi(v); err != nil {
+			return input, fmt.Errorf("%w: invalid limit: %q", apperr.ErrInvalidInput, v)
+		}
+	}
+	return input, nil
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+// APIキーを受け付けるヘッダ(Authorization: Bearer を優先する)
+const apiKeyHeader = "X-API-Key"
+
+// pathScope はパスのプレフィックスごとに必要なスコープ
+type pathScope struct {
+	prefix string
+	scope  vo.Scope
+}
+
+// AuthMiddleware はAPIキーまたはBearerトークンを検証し、呼び出し元をcontext.Contextに載せる
+// 公開パス以外は認証が必須で、参照系のメソッドはarticles:read、
+// それ以外はarticles:writeを要求する(WithPathScopeで上書きできる)
+type AuthMiddleware struct {
+	authenticator auth.Authenticator
+	publicPaths   []string
+	pathScopes    []pathScope
+}
+
+// AuthOption はAuthMiddlewareの設定を変更する
+type AuthOption func(*AuthMiddleware)
+
+// WithPublicPaths はAPIキーなしで受け付けるパスを指定する
+// "/"で終わるパスはプレフィックスとして扱い、それ以外は完全一致で判定する
+// ただし"/"だけはルートのみに一致する
+func WithPublicPaths(paths ...string) AuthOption {
+	return func(m *AuthMiddleware) {
+		m.publicPaths = append(m.publicPaths, paths...)
+	}
+}
+
+// WithPathScope はプレフィックスに一致するパスに必要なスコープを指定する
+func WithPathScope(prefix string, scope vo.Scope) AuthOption {
+	return func(m *AuthMiddleware) {
+		m.pathScopes = append(m.pathScopes, pathScope{prefix: prefix, scope: scope})
+	}
+}
+
+func NewAuthMiddleware(authenticator auth.Authenticator, opts ...AuthOption) *AuthMiddleware {
+	m := &AuthMiddleware{authenticator: authenticator}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Wrap はnextの前に認証を挟む
+// 不正なAPIキーやトークンは公開パスであっても401にする
+// 呼び出し元がワークスペースに紐づく場合は、そのワークスペースでリクエストを処理する
+func (m *AuthMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		credential := credentialFrom(r)
+		if credential == "" {
+			if m.isPublic(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			writeError(w, fmt.Errorf("%w: api key or bearer token is required", apperr.ErrUnauthenticated))
+			return
+		}
+
+		principal, err := m.authenticator.Authenticate(r.Context(), credential)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		ctx, err := bindWorkspace(r.Context(), principal)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		ctx = auth.WithPrincipal(ctx, principal)
+		if !m.isPublic(r.URL.Path) {
+			if err := auth.Require(ctx, m.requiredScope(r)); err != nil {
+				writeError(w, err)
+				return
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (m *AuthMiddleware) isPublic(path string) bool {
+	for _, p := range m.publicPaths {
+		if path == p || (p != "/" && strings.HasSuffix(p, "/") && strings.HasPrefix(path, p)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *AuthMiddleware) requiredScope(r *http.Request) vo.Scope {
+	for _, ps := range m.pathScopes {
+		if strings.HasPrefix(r.URL.Path, ps.prefix) {
+			return ps.scope
+		}
+	}
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return vo.ScopeArticlesRead
+	}
+	return vo.ScopeArticlesWrite
+}
+
+// credentialFrom はAuthorization: Bearer またはX-API-KeyヘッダからAPIキーまたはトークンを取り出す
+func credentialFrom(r *http.Request) string {
+	if scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return strings.TrimSpace(r.Header.Get(apiKeyHeader))
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+// stubAuthenticator はキーごとに決められた呼び出し元を返す
+type stubAuthenticator map[string]auth.Principal
+
+func (s stubAuthenticator) Authenticate(_ context.Context, rawKey string) (*auth.Principal, error) {
+	p, ok := s[rawKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown api key", apperr.ErrUnauthenticated)
+	}
+	p.Name = rawKey
+	return &p, nil
+}
+
+func newAuthTestServer(t *testing.T) http.Handler {
+	t.Helper()
+	a, err := entity.NewArticle("Hello World", "published")
+	require.NoError(t, err)
+	a.ID = 1
+	owner := uint64(10)
+	a.AuthorID = &owner
+
+	mux := http.NewServeMux()
+	uc := article.NewArticleUsecase(&slugArticleRepository{article: a}, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+	NewArticleHandler(uc).Register(mux)
+	mux.HandleFunc("GET /up", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /webhooks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, []string{})
+	})
+
+	return NewAuthMiddleware(
+		stubAuthenticator{
+			"reader": {Scopes: []vo.Scope{vo.ScopeArticlesRead}},
+			"writer": {Scopes: []vo.Scope{vo.ScopeArticlesWrite}, AuthorID: owner},
+			"other":  {Scopes: []vo.Scope{vo.ScopeArticlesWrite}, AuthorID: owner + 1},
+			"admin":  {Scopes: []vo.Scope{vo.ScopeArticlesAdmin}},
+		},
+		WithPublicPaths("/", "/up", "/sitemaps/"),
+		WithPathScope("/webhooks", vo.ScopeArticlesAdmin),
+	).Wrap(mux)
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		header     string
+		key        string
+		body       string
+		wantStatus int
+	}{
+		{name: "公開パスはキーなしで受け付ける", method: http.MethodGet, path: "/up", wantStatus: http.StatusOK},
+		{name: "公開パスのプレフィックスに一致すればキーなしで受け付ける", method: http.MethodGet, path: "/sitemaps/unknown.xml", wantStatus: http.StatusNotFound},
+		{name: "キーがなければ401", method: http.MethodGet, path: "/articles/1", wantStatus: http.StatusUnauthorized},
+		{name: "未知のキーは公開パスでも401", method: http.MethodGet, path: "/up", header: "X-API-Key", key: "unknown", wantStatus: http.StatusUnauthorized},
+		{name: "Bearerトークンで参照できる", method: http.MethodGet, path: "/articles/1", header: "Authorization", key: "Bearer reader", wantStatus: http.StatusOK},
+		{name: "X-API-Keyヘッダで参照できる", method: http.MethodGet, path: "/articles/1", header: "X-API-Key", key: "reader", wantStatus: http.StatusOK},
+		{
+			name: "参照だけのキーで更新すると403", method: http.MethodPost, path: "/articles/1/publications",
+			header: "X-API-Key", key: "reader", body: `{"provider_type":"qiita"}`, wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "書き込みのキーで更新できる", method: http.MethodPost, path: "/articles/1/publications",
+			header: "X-API-Key", key: "writer", body: `{"provider_type":"qiita"}`, wantStatus: http.StatusCreated,
+		},
+		{
+			name: "他の執筆者の記事は書き込みのキーでも403", method: http.MethodPost, path: "/articles/1/publications",
+			header: "X-API-Key", key: "other", body: `{"provider_type":"qiita"}`, wantStatus: http.StatusForbidden,
+		},
+		{name: "管理用のパスは書き込みのキーでは403", method: http.MethodGet, path: "/webhooks", header: "X-API-Key", key: "writer", wantStatus: http.StatusForbidden},
+		{name: "管理用のパスは管理者のキーで利用できる", method: http.MethodGet, path: "/webhooks", header: "X-API-Key", key: "admin", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.key)
+			}
+			rec := httptest.NewRecorder()
+			newAuthTestServer(t).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
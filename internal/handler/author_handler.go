@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/author"
+)
+
+// AuthorHandler は執筆者とそのプロフィールのHTTPハンドラ
+type AuthorHandler struct {
+	uc *author.AuthorUsecase
+}
+
+func NewAuthorHandler(uc *author.AuthorUsecase) *AuthorHandler {
+	return &AuthorHandler{uc: uc}
+}
+
+// Register はルーティングを登録する
+func (h *AuthorHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /authors", h.list)
+	mux.HandleFunc("POST /authors", h.create)
+	mux.HandleFunc("GET /authors/{id}", h.profile)
+	mux.HandleFunc("PATCH /authors/{id}", h.update)
+}
+
+func (h *AuthorHandler) list(w http.ResponseWriter, r *http.Request) {
+	outputs, err := h.uc.ListAuthors(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, outputs)
+}
+
+func (h *AuthorHandler) create(w http.ResponseWriter, r *http.Request) {
+	var input author.CreateAuthorInput
+	if err := decodeJSON(r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.CreateAuthor(r.Context(), input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, output)
+}
+
+// profile は執筆者と公開済みの記事をpage / limitでページングして返す
+func (h *AuthorHandler) profile(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var input author.ProfileInput
+	for name, dst := range map[string]*int{"page": &input.Page, "limit": &input.Limit} {
+		v := r.URL.Query().Get(name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, fmt.Errorf("%w: invalid %s: %q", apperr.ErrInvalidInput, name, v))
+			return
+		}
+		*dst = n
+	}
+	output, err := h.uc.FindProfile(r.Context(), id, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *AuthorHandler) update(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var input author.UpdateAuthorInput
+	if err := decodeJSON(r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.UpdateAuthor(r.Context(), id, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/comment"
+)
+
+// CommentHandler は記事本文のインラインコメントのHTTPハンドラ
+type CommentHandler struct {
+	uc *comment.CommentUsecase
+}
+
+func NewCommentHandler(uc *comment.CommentUsecase) *CommentHandler {
+	return &CommentHandler{uc: uc}
+}
+
+// Register はルーティングを登録する
+// コメントの一覧 GET /articles/{id}/comments は記事ハンドラのサブリソースとして登録する
+func (h *CommentHandler) Register(mux *http.ServeMux, articles *ArticleHandler) {
+	mux.HandleFunc("POST /articles/{id}/comments", h.create)
+	mux.HandleFunc("PATCH /articles/{id}/comments/{commentID}", h.update)
+	mux.HandleFunc("DELETE /articles/{id}/comments/{commentID}", h.delete)
+	mux.HandleFunc("POST /articles/{id}/comments/{commentID}/replies", h.reply)
+	mux.HandleFunc("POST /articles/{id}/comments/{commentID}/resolve", h.resolution(h.uc.Resolve))
+	mux.HandleFunc("POST /articles/{id}/comments/{commentID}/unresolve", h.resolution(h.uc.Unresolve))
+	articles.AddView("comments", h.list)
+}
+
+// list はスレッドの一覧を返す(解決済みのスレッドは?resolved=trueの場合だけ含める)
+func (h *CommentHandler) list(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var input comment.ListCommentsInput
+	if v := r.URL.Query().Get("resolved"); v != "" {
+		if input.IncludeResolved, err = strconv.ParseBool(v); err != nil {
+			writeError(w, fmt.Errorf("%w: invalid resolved: %q", apperr.ErrInvalidInput, v))
+			return
+		}
+	}
+	output, err := h.uc.List(r.Context(), id, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *CommentHandler) create(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var input comment.CreateCommentInput
+	if err := decodeJSON(r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.Create(r.Context(), id, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, output)
+}
+
+func (h *CommentHandler) reply(w http.ResponseWriter, r *http.Request) {
+	id, commentID, err := commentPath(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var input comment.ReplyInput
+	if err := decodeJSON(r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.Reply(r.Context(), id, commentID, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, output)
+}
+
+func (h *CommentHandler) update(w http.ResponseWriter, r *http.Request) {
+	id, commentID, err := commentPath(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var input comment.UpdateCommentInput
+	if err := decodeJSON(r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.Update(r.Context(), id, commentID, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *CommentHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id, commentID, err := commentPath(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := h.uc.Delete(r.Context(), id, commentID); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// resolution はスレッドを解決・未解決に戻すハンドラを返す
+func (h *CommentHandler) resolution(fn func(ctx context.Context, articleID, commentID uint64) (*comment.CommentOutput, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, commentID, err := commentPath(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		output, err := fn(r.Context(), id, commentID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, output)
+	}
+}
+
+// commentPath はパスから記事とコメントのIDを取り出す
+func commentPath(r *http.Request) (uint64, uint64, error) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		return 0, 0, err
+	}
+	commentID, err := pathID(r, "commentID")
+	if err != nil {
+		return 0, 0, err
+	}
+	return id, commentID, nil
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/duplicate"
+)
+
+// DuplicateHandler は重複している可能性がある記事のHTTPハンドラ
+type DuplicateHandler struct {
+	uc *duplicate.DuplicateUsecase
+}
+
+func NewDuplicateHandler(uc *duplicate.DuplicateUsecase) *DuplicateHandler {
+	return &DuplicateHandler{uc: uc}
+}
+
+// Register はルーティングを登録する
+func (h *DuplicateHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /duplicates/titles", h.similarTitles)
+}
+
+// similarTitles はタイトルが似ている記事の組を類似度の高い順に返す
+func (h *DuplicateHandler) similarTitles(w http.ResponseWriter, r *http.Request) {
+	var input duplicate.SimilarTitlesInput
+	if v := r.URL.Query().Get("threshold"); v != "" {
+		threshold, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			writeError(w, fmt.Errorf("%w: invalid threshold: %q", apperr.ErrInvalidInput, v))
+			return
+		}
+		input.Threshold = &threshold
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, fmt.Errorf("%w: invalid limit: %q", apperr.ErrInvalidInput, v))
+			return
+		}
+		input.Limit = limit
+	}
+	output, err := h.uc.SimilarTitles(r.Context(), input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/feed"
+)
+
+// FeedMeta はフィード全体のメタ情報
+type FeedMeta struct {
+	Title       string
+	Description string
+	Author      string
+	// SiteURL はフィードの提供元サイトのURL(末尾スラッシュなし)
+	SiteURL string
+}
+
+// --- RSS 2.0 ---
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	AtomLink      rssAtom   `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssAtom struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title      string   `xml:"title"`
+	Link       string   `xml:"link"`
+	GUID       rssGUID  `xml:"guid"`
+	PubDate    string   `xml:"pubDate"`
+	Categories []string `xml:"category"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+func renderRSS(meta FeedMeta, selfURL string, out *feed.FeedOutput) ([]byte, error) {
+	channel := rssChannel{
+		Title:       meta.Title,
+		Link:        meta.SiteURL,
+		Description: meta.Description,
+		AtomLink:    rssAtom{Href: selfURL, Rel: "self", Type: "application/rss+xml"},
+		Items:       make([]rssItem, 0, len(out.Items)),
+	}
+	if !out.LastModified.IsZero() {
+		channel.LastBuildDate = out.LastModified.UTC().Format(time.RFC1123Z)
+	}
+	for _, item := range out.Items {
+		channel.Items = append(channel.Items, rssItem{
+			Title:      item.Title,
+			Link:       item.Link,
+			GUID:       rssGUID{IsPermaLink: true, Value: item.Link},
+			PubDate:    item.PublishedAt.UTC().Format(time.RFC1123Z),
+			Categories: item.Categories,
+		})
+	}
+	return marshalXML(rssFeed{Version: "2.0", AtomNS: "http://www.w3.org/2005/Atom", Channel: channel})
+}
+
+// --- Atom ---
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+func renderAtom(meta FeedMeta, selfURL string, out *feed.FeedOutput) ([]byte, error) {
+	updated := out.LastModified
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+	f := atomFeed{
+		Title:   meta.Title,
+		ID:      selfURL,
+		Updated: updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: selfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: meta.SiteURL, Rel: "alternate"},
+		},
+		Author:  atomAuthor{Name: meta.Author},
+		Entries: make([]atomEntry, 0, len(out.Items)),
+	}
+	for _, item := range out.Items {
+		categories := make([]atomCategory, 0, len(item.Categories))
+		for _, c := range item.Categories {
+			categories = append(categories, atomCategory{Term: c})
+		}
+		f.Entries = append(f.Entries, atomEntry{
+			Title:      item.Title,
+			ID:         item.Link,
+			Link:       atomLink{Href: item.Link, Rel: "alternate"},
+			Published:  item.PublishedAt.UTC().Format(time.RFC3339),
+			Updated:    item.UpdatedAt.UTC().Format(time.RFC3339),
+			Categories: categories,
+		})
+	}
+	return marshalXML(f)
+}
+
+// --- JSON Feed 1.1 ---
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	FeedURL     string         `json:"feed_url"`
+	Description string         `json:"description,omitempty"`
+	Authors     []jsonAuthor   `json:"authors,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedItem struct {
+	ID            string   `json:"id"`
+	URL           string   `json:"url"`
+	Title         string   `json:"title"`
+	ContentText   string   `json:"content_text"`
+	DatePublished string   `json:"date_published"`
+	DateModified  string   `json:"date_modified"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+func renderJSONFeed(meta FeedMeta, selfURL string, out *feed.FeedOutput) ([]byte, error) {
+	f := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       meta.Title,
+		HomePageURL: meta.SiteURL,
+		FeedURL:     selfURL,
+		Description: meta.Description,
+		Items:       make([]jsonFeedItem, 0, len(out.Items)),
+	}
+	if meta.Author != "" {
+		f.Authors = []jsonAuthor{{Name: meta.Author}}
+	}
+	for _, item := range out.Items {
+		f.Items = append(f.Items, jsonFeedItem{
+			ID:            item.Link,
+			URL:           item.Link,
+			Title:         item.Title,
+			ContentText:   item.Title,
+			DatePublished: item.PublishedAt.UTC().Format(time.RFC3339),
+			DateModified:  item.UpdatedAt.UTC().Format(time.RFC3339),
+			Tags:          item.Categories,
+		})
+	}
+	b, err := json.Marshal(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode json feed: %w", err)
+	}
+	return b, nil
+}
+
+func marshalXML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to encode xml feed: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// feedFormat はフィード形式ごとのレンダラとContent-Type
+type feedFormat struct {
+	contentType string
+	render      func(meta FeedMeta, selfURL string, out *feed.FeedOutput) ([]byte, error)
+}
+
+var (
+	rssFormat  = feedFormat{contentType: "application/rss+xml; charset=utf-8", render: renderRSS}
+	atomFormat = feedFormat{contentType: "application/atom+xml; charset=utf-8", render: renderAtom}
+	jsonFormat = feedFormat{contentType: "application/feed+json; charset=utf-8", render: renderJSONFeed}
+)
+
+// lastModifiedHeader はLast-Modifiedヘッダー用に秒単位へ切り捨てた時刻
+func lastModifiedHeader(t time.Time) string {
+	return t.UTC().Truncate(time.Second).Format(http.TimeFormat)
+}
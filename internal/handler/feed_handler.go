@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/feed"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/workspace"
+)
+
+// FeedSettingsSource はリクエストのワークスペースのフィードの設定を返す
+type FeedSettingsSource interface {
+	Settings(ctx context.Context) (workspace.SettingsOutput, error)
+}
+
+// FeedHandler は公開記事のRSS 2.0 / Atom / JSON FeedのHTTPハンドラ
+type FeedHandler struct {
+	uc       *feed.FeedUsecase
+	meta     FeedMeta
+	settings FeedSettingsSource
+}
+
+// FeedOption はFeedHandlerの設定を変更する
+type FeedOption func(*FeedHandler)
+
+// WithFeedSettings はワークスペースごとの設定で、空でないタイトル・説明・サイトのURLを上書きする
+func WithFeedSettings(source FeedSettingsSource) FeedOption {
+	return func(h *FeedHandler) {
+		h.settings = source
+	}
+}
+
+func NewFeedHandler(uc *feed.FeedUsecase, meta FeedMeta, opts ...FeedOption) *FeedHandler {
+	meta.SiteURL = strings.TrimRight(meta.SiteURL, "/")
+	h := &FeedHandler{uc: uc, meta: meta}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Register はルーティングを登録する
+func (h *FeedHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /feed.xml", h.serve(rssFormat))
+	mux.HandleFunc("GET /atom.xml", h.serve(atomFormat))
+	mux.HandleFunc("GET /feed.json", h.serve(jsonFormat))
+}
+
+func (h *FeedHandler) serve(format feedFormat) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		input := feed.FeedInput{}
+		if v := r.URL.Query().Get("provider"); v != "" {
+			input.ProviderType = &v
+		}
+		if v := r.URL.Query().Get("tag"); v != "" {
+			input.Tag = &v
+		}
+
+		out, err := h.uc.BuildFeed(r.Context(), input)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		meta, err := h.metaFor(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		selfURL := meta.SiteURL + r.URL.RequestURI()
+		body, err := format.render(meta, selfURL, out)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		sum := sha256.Sum256(body)
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		if !out.LastModified.IsZero() {
+			w.Header().Set("Last-Modified", lastModifiedHeader(out.LastModified))
+		}
+
+		if notModified(r, etag, out.LastModified) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", format.contentType)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}
+}
+
+// metaFor はリクエストのワークスペースの設定を反映したメタ情報を返す
+func (h *FeedHandler) metaFor(ctx context.Context) (FeedMeta, error) {
+	meta := h.meta
+	if h.settings == nil {
+		return meta, nil
+	}
+	settings, err := h.settings.Settings(ctx)
+	if err != nil {
+		return FeedMeta{}, err
+	}
+	if settings.FeedTitle != "" {
+		meta.Title = settings.FeedTitle
+	}
+	if settings.FeedDescription != "" {
+		meta.Description = settings.FeedDescription
+	}
+	if settings.SiteURL != "" {
+		meta.SiteURL = settings.SiteURL
+	}
+	return meta, nil
+}
+
+// notModified は条件付きリクエストに対して304を返すべきかを判定する
+// If-None-Matchが指定されている場合はIf-Modified-Sinceより優先する
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		t, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		return !lastModified.UTC().Truncate(time.Second).After(t)
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/feed"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/workspace"
+)
+
+// stubFeedSettings は固定のワークスペースの設定を返す
+type stubFeedSettings workspace.SettingsOutput
+
+func (s stubFeedSettings) Settings(context.Context) (workspace.SettingsOutput, error) {
+	return workspace.SettingsOutput(s), nil
+}
+
+// stubArticleRepository はFindByCriteriaで固定の記事を返す
+type stubArticleRepository struct {
+	repository.ArticleRepository
+	articles []*entity.Article
+}
+
+func (s *stubArticleRepository) FindByCriteria(context.Context, repository.ArticleQueryCriteria) ([]*entity.Article, int, error) {
+	return s.articles, len(s.articles), nil
+}
+
+// LatestChangeAt は記事の更新日時のうち最も新しいものを返す
+func (s *stubArticleRepository) LatestChangeAt(context.Context) (time.Time, error) {
+	var latest time.Time
+	for _, a := range s.articles {
+		if a.UpdatedAt.After(latest) {
+			latest = a.UpdatedAt
+		}
+	}
+	return latest, nil
+}
+
+func newFeedTestMux(t *testing.T) *http.ServeMux {
+	t.Helper()
+	zenn := vo.ProviderTypeZenn
+	link := vo.Link("https://zenn.dev/articles/1")
+	updated := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := &stubArticleRepository{articles: []*entity.Article{
+		{ID: 1, Title: "記事 & <タイトル>", ProviderType: &zenn, Link: &link, CreatedAt: updated, UpdatedAt: updated},
+	}}
+	mux := http.NewServeMux()
+	NewFeedHandler(feed.NewFeedUsecase(repo, repo, 10), FeedMeta{Title: "Hub", Author: "me", SiteURL: "https://example.com/"}).Register(mux)
+	return mux
+}
+
+func TestFeedHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RSS 2.0を返す", func(t *testing.T) {
+		t.Parallel()
+		rec := httptest.NewRecorder()
+		newFeedTestMux(t).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/feed.xml", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "application/rss+xml")
+		assert.Equal(t, "Sat, 01 Mar 2025 12:00:00 GMT", rec.Header().Get("Last-Modified"))
+		assert.NotEmpty(t, rec.Header().Get("ETag"))
+		assert.Contains(t, rec.Body.String(), `<rss version="2.0"`)
+		assert.Contains(t, rec.Body.String(), "記事 &amp; &lt;タイトル&gt;")
+		assert.Contains(t, rec.Body.String(), "<category>Zenn</category>")
+		assert.Contains(t, rec.Body.String(), `href="https://example.com/feed.xml"`)
+	})
+
+	t.Run("Atomを返す", func(t *testing.T) {
+		t.Parallel()
+		rec := httptest.NewRecorder()
+		newFeedTestMux(t).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/atom.xml", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `<feed xmlns="http://www.w3.org/2005/Atom">`)
+		assert.Contains(t, rec.Body.String(), `<category term="Zenn"></category>`)
+	})
+
+	t.Run("JSON Feed 1.1を返す", func(t *testing.T) {
+		t.Parallel()
+		rec := httptest.NewRecorder()
+		newFeedTestMux(t).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/feed.json?provider=zenn", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "https://jsonfeed.org/version/1.1", body["version"])
+		assert.Equal(t, "https://example.com/feed.json?provider=zenn", body["feed_url"])
+		assert.Len(t, body["items"], 1)
+	})
+
+	t.Run("ETagが一致すれば304を返す", func(t *testing.T) {
+		t.Parallel()
+		mux := newFeedTestMux(t)
+		first := httptest.NewRecorder()
+		mux.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/feed.xml", nil))
+
+		req := httptest.NewRequest(http.MethodGet, "/feed.xml", nil)
+		req.Header.Set("If-None-Match", first.Header().Get("ETag"))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("If-Modified-Sinceが最終更新以降なら304を返す", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, "/feed.xml", nil)
+		req.Header.Set("If-Modified-Since", "Sat, 01 Mar 2025 12:00:00 GMT")
+		rec := httptest.NewRecorder()
+		newFeedTestMux(t).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+	})
+
+	t.Run("If-Modified-Sinceが最終更新より前なら200を返す", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, "/feed.xml", nil)
+		req.Header.Set("If-Modified-Since", "Sat, 01 Mar 2025 11:59:59 GMT")
+		rec := httptest.NewRecorder()
+		newFeedTestMux(t).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("ワークスペースの設定でタイトルとサイトのURLを上書きする", func(t *testing.T) {
+		t.Parallel()
+		mux := http.NewServeMux()
+		NewFeedHandler(
+			feed.NewFeedUsecase(&stubArticleRepository{}, &stubArticleRepository{}, 10),
+			FeedMeta{Title: "Hub", Description: "全体", SiteURL: "https://example.com"},
+			WithFeedSettings(stubFeedSettings{FeedTitle: "Team A", SiteURL: "https://team-a.example.com"}),
+		).Register(mux)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/feed.json", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "Team A", body["title"])
+		assert.Equal(t, "全体", body["description"])
+		assert.Equal(t, "https://team-a.example.com/feed.json", body["feed_url"])
+	})
+
+	t.Run("無効なプロバイダは400を返す", func(t *testing.T) {
+		t.Parallel()
+		rec := httptest.NewRecorder()
+		newFeedTestMux(t).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/feed.xml?provider=unknown", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/linkcheck"
+)
+
+// LinkCheckHandler はリンクチェック結果のHTTPハンドラ
+type LinkCheckHandler struct {
+	uc *linkcheck.LinkCheckUsecase
+}
+
+func NewLinkCheckHandler(uc *linkcheck.LinkCheckUsecase) *LinkCheckHandler {
+	return &LinkCheckHandler{uc: uc}
+}
+
+// Register はルーティングを登録する
+// 記事ごとの結果 GET /articles/{id}/links は記事ハンドラのサブリソースとして登録する
+func (h *LinkCheckHandler) Register(mux *http.ServeMux, articles *ArticleHandler) {
+	mux.HandleFunc("GET /link-checks/report", h.report)
+	articles.AddView("links", h.articleLinks)
+}
+
+func (h *LinkCheckHandler) articleLinks(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.ArticleLinks(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+// report はリンク切れを記事ごとにまとめて返す
+func (h *LinkCheckHandler) report(w http.ResponseWriter, r *http.Request) {
+	output, err := h.uc.Report(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
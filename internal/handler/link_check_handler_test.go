@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/linkcheck"
+)
+
+// stubLinkCheckRepository は記事1のリンク切れを1件だけ返す
+type stubLinkCheckRepository struct {
+	repository.LinkCheckRepository
+}
+
+func (stubLinkCheckRepository) brokenCheck() *entity.LinkCheck {
+	c, _ := entity.NewLinkCheck(1, "https://example.com/gone", entity.LinkSourceBody, 404, nil, "", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	return c
+}
+
+func (s stubLinkCheckRepository) FindByArticleID(_ context.Context, _ uint64) ([]*entity.LinkCheck, error) {
+	return []*entity.LinkCheck{s.brokenCheck()}, nil
+}
+
+func (s stubLinkCheckRepository) FindBroken(_ context.Context) ([]*entity.LinkCheck, error) {
+	return []*entity.LinkCheck{s.brokenCheck()}, nil
+}
+
+func newLinkCheckTestMux(t *testing.T) *http.ServeMux {
+	t.Helper()
+	a, err := entity.NewArticle("Hello World", "published")
+	require.NoError(t, err)
+	a.ID = 1
+	articleRepo := &slugArticleRepository{article: a}
+
+	mux := http.NewServeMux()
+	articleHandler := NewArticleHandler(article.NewArticleUsecase(articleRepo, passthroughTxManager{}))
+	articleHandler.Register(mux)
+	NewLinkCheckHandler(linkcheck.NewLinkCheckUsecase(stubLinkCheckRepository{}, articleRepo, nil)).Register(mux, articleHandler)
+	return mux
+}
+
+func TestLinkCheckHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "記事のリンクのチェック結果を返す", path: "/articles/1/links", wantStatus: http.StatusOK, wantBody: `"url":"https://example.com/gone","source":"body","status":"broken","status_code":404`},
+		{name: "存在しない記事は404", path: "/articles/2/links", wantStatus: http.StatusNotFound},
+		{name: "リンク切れを記事ごとにまとめて返す", path: "/link-checks/report", wantStatus: http.StatusOK, wantBody: `"broken_links":1,"articles":[{"article_id":1,"title":"Hello World"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			newLinkCheckTestMux(t).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
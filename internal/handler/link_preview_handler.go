@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/linkpreview"
+)
+
+// LinkPreviewHandler はリンク先のOGPのHTTPハンドラ
+// 取得したOGPは記事の出力(link_preview)に含まれる
+type LinkPreviewHandler struct {
+	uc *linkpreview.LinkPreviewUsecase
+}
+
+func NewLinkPreviewHandler(uc *linkpreview.LinkPreviewUsecase) *LinkPreviewHandler {
+	return &LinkPreviewHandler{uc: uc}
+}
+
+// Register はルーティングを登録する
+func (h *LinkPreviewHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("POST /articles/{id}/link-preview/refresh", h.refresh)
+}
+
+// refresh は更新方針に関わらずリンク先のOGPを取得し直す
+func (h *LinkPreviewHandler) refresh(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.RefreshArticle(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/metrics"
+)
+
+// MetricsHandler はエンゲージメント指標のHTTPハンドラ
+type MetricsHandler struct {
+	uc *metrics.MetricsUsecase
+}
+
+func NewMetricsHandler(uc *metrics.MetricsUsecase) *MetricsHandler {
+	return &MetricsHandler{uc: uc}
+}
+
+// Register はルーティングを登録する
+// 記事ごとの指標 GET /articles/{id}/metrics は記事ハンドラのサブリソースとして登録する
+func (h *MetricsHandler) Register(mux *http.ServeMux, articles *ArticleHandler) {
+	mux.HandleFunc("GET /metrics/leaderboard", h.leaderboard)
+	articles.AddView("metrics", h.articleMetrics)
+}
+
+func (h *MetricsHandler) articleMetrics(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var input metrics.ArticleMetricsInput
+	if input.From, input.To, err = periodParams(r); err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.ArticleMetrics(r.Context(), id, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+// leaderboard は期間内の指標の伸びが大きい記事の順位を返す
+func (h *MetricsHandler) leaderboard(w http.ResponseWriter, r *http.Request) {
+	var input metrics.LeaderboardInput
+	var err error
+	if input.From, input.To, err = periodParams(r); err != nil {
+		writeError(w, err)
+		return
+	}
+	if v := r.URL.Query().Get("sort"); v != "" {
+		input.SortBy = &v
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, fmt.Errorf("%w: invalid limit: %q", apperr.ErrInvalidInput, v))
+			return
+		}
+		input.Limit = limit
+	}
+	output, err := h.uc.Leaderboard(r.Context(), input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+// periodParams はクエリのfrom / toを取り出す
+// RFC 3339か日付(YYYY-MM-DD)を受け付け、日付のtoはその日の終わりまでを含める
+func periodParams(r *http.Request) (*time.Time, *time.Time, error) {
+	from, err := timeParam(r, "from", false)
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := timeParam(r, "to", true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+func timeParam(r *http.Request, name string, endOfDay bool) (*time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid %s: %q", apperr.ErrInvalidInput, name, v)
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
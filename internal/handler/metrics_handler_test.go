@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/metrics"
+)
+
+// stubArticleMetricRepository は固定のスナップショットと増分を返す
+type stubArticleMetricRepository struct {
+	repository.ArticleMetricRepository
+	gotFrom, gotTo time.Time
+}
+
+func (s *stubArticleMetricRepository) FindByArticleID(_ context.Context, articleID uint64, from, to time.Time) ([]*entity.ArticleMetric, error) {
+	s.gotFrom, s.gotTo = from, to
+	return []*entity.ArticleMetric{
+		{ArticleID: articleID, PublicationID: 10, ProviderType: vo.ProviderTypeQiita, Likes: 3, CollectedAt: from},
+		{ArticleID: articleID, PublicationID: 10, ProviderType: vo.ProviderTypeQiita, Likes: 8, CollectedAt: to},
+	}, nil
+}
+
+func (s *stubArticleMetricRepository) FindGrowth(_ context.Context, _, _ time.Time, _ string, _ int, _ bool) ([]repository.MetricGrowth, error) {
+	return []repository.MetricGrowth{{ArticleID: 1, Title: "Hello World", Likes: 5, Total: 5}}, nil
+}
+
+func newMetricsTestMux(t *testing.T, repo *stubArticleMetricRepository) *http.ServeMux {
+	t.Helper()
+	a, err := entity.NewArticle("Hello World", "published")
+	require.NoError(t, err)
+	a.ID = 1
+	articleRepo := &slugArticleRepository{article: a}
+
+	mux := http.NewServeMux()
+	articleHandler := NewArticleHandler(article.NewArticleUsecase(articleRepo, passthroughTxManager{}))
+	articleHandler.Register(mux)
+	NewMetricsHandler(metrics.NewMetricsUsecase(repo, articleRepo)).Register(mux, articleHandler)
+	return mux
+}
+
+func TestMetricsHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "記事の指標の増分を返す", path: "/articles/1/metrics?from=2025-03-01&to=2025-03-31", wantStatus: http.StatusOK, wantBody: `"growth":{"likes":5,"stocks":0,"bookmarks":0,"total":5}`},
+		{name: "存在しない記事は404", path: "/articles/2/metrics", wantStatus: http.StatusNotFound},
+		{name: "不正な日付は400", path: "/articles/1/metrics?from=yesterday", wantStatus: http.StatusBadRequest},
+		{name: "記事の他のサブリソースも引き続き振り分ける", path: "/articles/1/unknown", wantStatus: http.StatusNotFound},
+		{name: "増分の順位を返す", path: "/metrics/leaderboard?sort=likes&limit=10", wantStatus: http.StatusOK, wantBody: `"rank":1,"article_id":1,"title":"Hello World"`},
+		{name: "未知の並び替えキーは400", path: "/metrics/leaderboard?sort=views", wantStatus: http.StatusBadRequest},
+		{name: "数値でない件数は400", path: "/metrics/leaderboard?limit=ten", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			newMetricsTestMux(t, &stubArticleMetricRepository{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+
+	t.Run("日付のみのtoはその日の終わりまでを含める", func(t *testing.T) {
+		t.Parallel()
+		repo := &stubArticleMetricRepository{}
+		rec := httptest.NewRecorder()
+		newMetricsTestMux(t, repo).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/articles/1/metrics?from=2025-03-01&to=2025-03-31", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), repo.gotFrom)
+		assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond), repo.gotTo)
+	})
+}
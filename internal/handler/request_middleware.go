@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/audit"
+)
+
+// リクエストIDを受け取り、返すヘッダ
+const requestIDHeader = "X-Request-ID"
+
+// 受け付けるリクエストIDの最大長(超える場合は新しく採番する)
+const maxRequestIDLength = 128
+
+// RequestMiddleware はリクエストIDとクライアントのIPアドレスをcontext.Contextに載せる
+// リクエストIDはX-Request-IDヘッダの値を引き継ぎ、なければ採番してレスポンスのヘッダで返す
+// 監査ログはこれらをリクエストの情報として記録する
+type RequestMiddleware struct {
+	trustForwardedFor bool
+}
+
+// RequestOption はRequestMiddlewareの設定を変更する
+type RequestOption func(*RequestMiddleware)
+
+// WithTrustedForwardedFor はクライアントのIPアドレスをX-Forwarded-Forヘッダの先頭から取る
+// ヘッダは呼び出し元が自由に設定できるため、ヘッダを上書きするリバースプロキシの背後でのみ使う
+func WithTrustedForwardedFor() RequestOption {
+	return func(m *RequestMiddleware) {
+		m.trustForwardedFor = true
+	}
+}
+
+func NewRequestMiddleware(opts ...RequestOption) *RequestMiddleware {
+	m := &RequestMiddleware{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Wrap はnextの前にリクエストの情報の設定を挟む
+func (m *RequestMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSpace(r.Header.Get(requestIDHeader))
+		if id == "" || len(id) > maxRequestIDLength {
+			id = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, id)
+		ctx := audit.WithRequest(r.Context(), audit.RequestInfo{ID: id, IP: m.clientIP(r)})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// clientIP はクライアントのIPアドレスを返す
+func (m *RequestMiddleware) clientIP(r *http.Request) string {
+	if m.trustForwardedFor {
+		first, _, _ := strings.Cut(r.Header.Get("X-Forwarded-For"), ",")
+		if ip := net.ParseIP(strings.TrimSpace(first)); ip != nil {
+			return ip.String()
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// newRequestID はランダムな128ビットのリクエストIDを採番する
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
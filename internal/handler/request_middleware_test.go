@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/audit"
+)
+
+func TestRequestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info, _ := audit.RequestFrom(r.Context())
+		_, _ = w.Write([]byte(info.ID + " " + info.IP))
+	})
+
+	tests := []struct {
+		name          string
+		opts          []RequestOption
+		header        map[string]string
+		wantID        string
+		wantIP        string
+		wantGenerated bool
+	}{
+		{
+			name:   "リクエストIDを引き継ぐ",
+			header: map[string]string{"X-Request-ID": "req-1"},
+			wantID: "req-1",
+			wantIP: "192.0.2.1",
+		},
+		{
+			name:          "リクエストIDがなければ採番する",
+			wantIP:        "192.0.2.1",
+			wantGenerated: true,
+		},
+		{
+			name:          "長すぎるリクエストIDは採番し直す",
+			header:        map[string]string{"X-Request-ID": strings.Repeat("a", 129)},
+			wantIP:        "192.0.2.1",
+			wantGenerated: true,
+		},
+		{
+			name:   "既定ではX-Forwarded-Forを信頼しない",
+			header: map[string]string{"X-Request-ID": "req-1", "X-Forwarded-For": "198.51.100.7"},
+			wantID: "req-1",
+			wantIP: "192.0.2.1",
+		},
+		{
+			name:   "信頼する場合はX-Forwarded-Forの先頭を使う",
+			opts:   []RequestOption{WithTrustedForwardedFor()},
+			header: map[string]string{"X-Request-ID": "req-1", "X-Forwarded-For": "198.51.100.7, 10.0.0.1"},
+			wantID: "req-1",
+			wantIP: "198.51.100.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = "192.0.2.1:54321"
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+
+			NewRequestMiddleware(tt.opts...).Wrap(echo).ServeHTTP(rec, req)
+
+			id := rec.Header().Get("X-Request-ID")
+			if tt.wantGenerated {
+				assert.Len(t, id, 32)
+			} else {
+				assert.Equal(t, tt.wantID, id)
+			}
+			assert.Equal(t, id+" "+tt.wantIP, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+)
+
+// errorResponse はエラー時のレスポンスボディ
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// 読み込むリクエストボディの最大サイズ
+const maxRequestBodySize = 1 << 20
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if v == nil {
+		return
+	}
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("failed to encode response: %v", err)
+	}
+}
+
+// writeError はエラーの種類に応じたステータスコードでレスポンスを返す
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, apperr.ErrInvalidInput):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+	case errors.Is(err, apperr.ErrConflict):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, apperr.ErrUnauthenticated):
+		w.Header().Set("WWW-Authenticate", `Bearer realm="article-hub"`)
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: err.Error()})
+	case errors.Is(err, apperr.ErrForbidden):
+		writeJSON(w, http.StatusForbidden, errorResponse{Error: err.Error()})
+	default:
+		log.Printf("internal error: %v", err)
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "internal server error"})
+	}
+}
+
+func decodeJSON(r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxRequestBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: invalid request body: %v", apperr.ErrInvalidInput, err)
+	}
+	return nil
+}
+
+// decodeOptionalJSON は本文が空でなければdecodeJSONで読み込む
+func decodeOptionalJSON(r *http.Request, v any) error {
+	if r.ContentLength == 0 {
+		return nil
+	}
+	return decodeJSON(r, v)
+}
+
+// pathID はパスパラメータからIDを取り出す
+func pathID(r *http.Request, name string) (uint64, error) {
+	id, err := strconv.ParseUint(r.PathValue(name), 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%w: invalid %s: %q", apperr.ErrInvalidInput, name, r.PathValue(name))
+	}
+	return id, nil
+}
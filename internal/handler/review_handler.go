@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/review"
+)
+
+// ReviewHandler は記事のレビューのHTTPハンドラ
+type ReviewHandler struct {
+	uc *review.ReviewUsecase
+}
+
+func NewReviewHandler(uc *review.ReviewUsecase) *ReviewHandler {
+	return &ReviewHandler{uc: uc}
+}
+
+// Register はルーティングを登録する
+// 記事のレビュー履歴 GET /articles/{id}/reviews は記事ハンドラのサブリソースとして登録する
+func (h *ReviewHandler) Register(mux *http.ServeMux, articles *ArticleHandler) {
+	mux.HandleFunc("GET /reviews", h.queue)
+	mux.HandleFunc("POST /articles/{id}/review", h.submit)
+	mux.HandleFunc("POST /articles/{id}/review/approve", h.decision(h.uc.Approve))
+	mux.HandleFunc("POST /articles/{id}/review/reject", h.decision(h.uc.RequestChanges))
+	mux.HandleFunc("POST /articles/{id}/review/withdraw", h.decision(h.uc.Withdraw))
+	mux.HandleFunc("POST /articles/{id}/review/comments", h.comment)
+	articles.AddView("reviews", h.list)
+}
+
+// queue はレビュアーの待ち行列を返す(statusを省略した場合は判断待ちの依頼)
+func (h *ReviewHandler) queue(w http.ResponseWriter, r *http.Request) {
+	output, err := h.uc.Queue(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *ReviewHandler) list(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.ListForArticle(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *ReviewHandler) submit(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var input review.SubmitInput
+	if err := decodeOptionalJSON(r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.Submit(r.Context(), id, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, output)
+}
+
+// decision は承認・差し戻し・取り下げのハンドラを返す
+func (h *ReviewHandler) decision(decide func(ctx context.Context, articleID uint64, input review.DecisionInput) (*review.ReviewOutput, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := pathID(r, "id")
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		var input review.DecisionInput
+		if err := decodeOptionalJSON(r, &input); err != nil {
+			writeError(w, err)
+			return
+		}
+		output, err := decide(r.Context(), id, input)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, output)
+	}
+}
+
+func (h *ReviewHandler) comment(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var input review.CommentInput
+	if err := decodeJSON(r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.Comment(r.Context(), id, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, output)
+}
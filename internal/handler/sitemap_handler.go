@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/sitemap"
+)
+
+const sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// SitemapHandler は公開記事のXMLサイトマップのHTTPハンドラ
+// URL数が上限を超える場合は/sitemap.xmlをサイトマップインデックスとし、/sitemaps/{n}.xmlに分割する
+type SitemapHandler struct {
+	uc   *sitemap.SitemapUsecase
+	site siteResolver
+}
+
+// SitemapOption はSitemapHandlerとRobotsHandlerの設定を変更する
+type SitemapOption func(*siteResolver)
+
+// WithSitemapSettings はワークスペースごとの設定で、空でないサイトのURLを上書きする
+func WithSitemapSettings(source FeedSettingsSource) SitemapOption {
+	return func(s *siteResolver) {
+		s.settings = source
+	}
+}
+
+// siteResolver はリクエストのワークスペースのサイトのURLでURLを組み立てるサイトマップを返す
+type siteResolver struct {
+	settings FeedSettingsSource
+}
+
+func newSiteResolver(opts []SitemapOption) siteResolver {
+	var s siteResolver
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+func (s siteResolver) sitemapFor(ctx context.Context, uc *sitemap.SitemapUsecase) (*sitemap.SitemapUsecase, error) {
+	if s.settings == nil {
+		return uc, nil
+	}
+	settings, err := s.settings.Settings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return uc.ForSite(settings.SiteURL), nil
+}
+
+func NewSitemapHandler(uc *sitemap.SitemapUsecase, opts ...SitemapOption) *SitemapHandler {
+	return &SitemapHandler{uc: uc, site: newSiteResolver(opts)}
+}
+
+// Register はルーティングを登録する
+func (h *SitemapHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /sitemap.xml", h.index)
+	mux.HandleFunc("GET /sitemaps/{file}", h.page)
+}
+
+func (h *SitemapHandler) index(w http.ResponseWriter, r *http.Request) {
+	uc, err := h.site.sitemapFor(r.Context(), h.uc)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	pages, err := uc.PageCount(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if pages == 1 {
+		writeURLSet(w, r, uc, 1)
+		return
+	}
+
+	setSitemapHeaders(w)
+	bw := bufio.NewWriter(w)
+	bw.WriteString(xml.Header)
+	fmt.Fprintf(bw, "<sitemapindex xmlns=%q>\n", sitemapNS)
+	for page := 1; page <= pages; page++ {
+		bw.WriteString("  <sitemap><loc>")
+		xml.EscapeText(bw, []byte(uc.SitemapURL(page)))
+		bw.WriteString("</loc></sitemap>\n")
+	}
+	bw.WriteString("</sitemapindex>\n")
+	if err := bw.Flush(); err != nil {
+		log.Printf("failed to write sitemap index: %v", err)
+	}
+}
+
+func (h *SitemapHandler) page(w http.ResponseWriter, r *http.Request) {
+	name, ok := strings.CutSuffix(r.PathValue("file"), ".xml")
+	page, err := strconv.Atoi(name)
+	if !ok || err != nil || page < 1 {
+		writeError(w, fmt.Errorf("%s: %w", r.PathValue("file"), sitemap.ErrSitemapPageNotFound))
+		return
+	}
+	uc, err := h.site.sitemapFor(r.Context(), h.uc)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	pages, err := uc.PageCount(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if page > pages {
+		writeError(w, fmt.Errorf("%d: %w", page, sitemap.ErrSitemapPageNotFound))
+		return
+	}
+	writeURLSet(w, r, uc, page)
+}
+
+// writeURLSet はリポジトリから読み出した記事をurlsetとして書き出す
+// 読み出しの途中で失敗した場合に途中までのXMLを200で返さないよう、ページ全体をバッファに組み立ててから書き出す
+func writeURLSet(w http.ResponseWriter, r *http.Request, uc *sitemap.SitemapUsecase, page int) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	fmt.Fprintf(&buf, "<urlset xmlns=%q>\n", sitemapNS)
+	err := uc.StreamPage(r.Context(), page, func(u sitemap.URL) error {
+		return writeSitemapURL(&buf, u)
+	})
+	if err != nil {
+		writeError(w, fmt.Errorf("failed to build sitemap page %d: %w", page, err))
+		return
+	}
+	buf.WriteString("</urlset>\n")
+
+	setSitemapHeaders(w)
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("failed to write sitemap page %d: %v", page, err)
+	}
+}
+
+func writeSitemapURL(w *bytes.Buffer, u sitemap.URL) error {
+	w.WriteString("  <url><loc>")
+	if err := xml.EscapeText(w, []byte(u.Loc)); err != nil {
+		return err
+	}
+	w.WriteString("</loc>")
+	if !u.LastModified.IsZero() {
+		fmt.Fprintf(w, "<lastmod>%s</lastmod>", u.LastModified.UTC().Format(time.RFC3339))
+	}
+	_, err := w.WriteString("</url>\n")
+	return err
+}
+
+func setSitemapHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+}
+
+// RobotsHandler は設定から生成したrobots.txtを返すHTTPハンドラ
+type RobotsHandler struct {
+	disallow []string
+	uc       *sitemap.SitemapUsecase
+	site     siteResolver
+}
+
+// NewRobotsHandler はdisallowに列挙したパスのクロールを拒否し、リクエストのワークスペースのサイトマップを参照するrobots.txtを返す
+// disallowが空の場合は全てのパスを許可する
+func NewRobotsHandler(disallow []string, uc *sitemap.SitemapUsecase, opts ...SitemapOption) *RobotsHandler {
+	return &RobotsHandler{disallow: disallow, uc: uc, site: newSiteResolver(opts)}
+}
+
+// Register はルーティングを登録する
+func (h *RobotsHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		uc, err := h.site.sitemapFor(r.Context(), h.uc)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		writeRobots(w, h.disallow, uc.IndexURL())
+	})
+}
+
+func writeRobots(w io.Writer, disallow []string, sitemapURL string) {
+	fmt.Fprintln(w, "User-agent: *")
+	written := false
+	for _, path := range disallow {
+		if path = strings.TrimSpace(path); path != "" {
+			fmt.Fprintf(w, "Disallow: %s\n", path)
+			written = true
+		}
+	}
+	if !written {
+		fmt.Fprintln(w, "Disallow:")
+	}
+	if sitemapURL != "" {
+		fmt.Fprintf(w, "\nSitemap: %s\n", sitemapURL)
+	}
+}
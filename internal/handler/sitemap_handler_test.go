@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/sitemap"
+)
+
+// stubPublishedArticleReader は1からtotalまでのIDの公開記事を返す
+// failAfterを指定すると、その件数を返した後に読み出しに失敗する
+type stubPublishedArticleReader struct {
+	total     int
+	failAfter int
+}
+
+func (s *stubPublishedArticleReader) CountPublished(context.Context) (int, error) {
+	return s.total, nil
+}
+
+func (s *stubPublishedArticleReader) StreamPublished(_ context.Context, offset, limit int, fn func(repository.ArticleLastModified) error) error {
+	for id := offset + 1; id <= s.total && id <= offset+limit; id++ {
+		if s.failAfter > 0 && id > s.failAfter {
+			return errors.New("connection reset")
+		}
+		a := repository.ArticleLastModified{ID: uint64(id), Slug: fmt.Sprintf("article-%d", id), UpdatedAt: time.Date(2025, 1, id, 0, 0, 0, 0, time.UTC)}
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newSitemapTestMux(total, perSitemap int) *http.ServeMux {
+	mux := http.NewServeMux()
+	NewSitemapHandler(sitemap.NewSitemapUsecase(&stubPublishedArticleReader{total: total}, "https://example.com", perSitemap)).Register(mux)
+	return mux
+}
+
+func TestSitemapHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("上限以内ならurlsetを返す", func(t *testing.T) {
+		t.Parallel()
+		rec := httptest.NewRecorder()
+		newSitemapTestMux(2, 10).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "application/xml")
+		assert.Contains(t, rec.Body.String(), `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+		assert.Contains(t, rec.Body.String(), "<url><loc>https://example.com/articles/by-slug/article-2</loc><lastmod>2025-01-02T00:00:00Z</lastmod></url>")
+		assert.Contains(t, rec.Body.String(), "</urlset>")
+	})
+
+	t.Run("上限を超えるとサイトマップインデックスを返す", func(t *testing.T) {
+		t.Parallel()
+		rec := httptest.NewRecorder()
+		newSitemapTestMux(5, 2).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "<sitemapindex")
+		assert.Contains(t, rec.Body.String(), "<loc>https://example.com/sitemaps/3.xml</loc>")
+		assert.NotContains(t, rec.Body.String(), "sitemaps/4.xml")
+	})
+
+	t.Run("分割したページを返す", func(t *testing.T) {
+		t.Parallel()
+		rec := httptest.NewRecorder()
+		newSitemapTestMux(5, 2).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sitemaps/3.xml", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "https://example.com/articles/by-slug/article-5")
+		assert.NotContains(t, rec.Body.String(), "https://example.com/articles/by-slug/article-4<")
+	})
+
+	t.Run("読み出しの途中で失敗した場合は途中までのXMLを返さない", func(t *testing.T) {
+		t.Parallel()
+		mux := http.NewServeMux()
+		reader := &stubPublishedArticleReader{total: 5, failAfter: 2}
+		NewSitemapHandler(sitemap.NewSitemapUsecase(reader, "https://example.com", 10)).Register(mux)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "<urlset")
+	})
+
+	t.Run("ワークスペースのサイトのURLでURLを組み立てる", func(t *testing.T) {
+		t.Parallel()
+		mux := http.NewServeMux()
+		NewSitemapHandler(sitemap.NewSitemapUsecase(&stubPublishedArticleReader{total: 5}, "https://example.com", 2),
+			WithSitemapSettings(stubFeedSettings{SiteURL: "https://team-a.example.com"})).Register(mux)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "<loc>https://team-a.example.com/sitemaps/1.xml</loc>")
+		assert.NotContains(t, rec.Body.String(), "https://example.com")
+	})
+
+	t.Run("存在しないページは404", func(t *testing.T) {
+		t.Parallel()
+		for _, path := range []string{"/sitemaps/4.xml", "/sitemaps/0.xml", "/sitemaps/abc.xml", "/sitemaps/1.txt"} {
+			rec := httptest.NewRecorder()
+			newSitemapTestMux(5, 2).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+			assert.Equal(t, http.StatusNotFound, rec.Code, path)
+		}
+	})
+}
+
+func TestRobotsHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		disallow []string
+		want     string
+	}{
+		{
+			name:     "拒否パスとサイトマップを出力する",
+			disallow: []string{"/webhooks", " /admin "},
+			want:     "User-agent: *\nDisallow: /webhooks\nDisallow: /admin\n\nSitemap: https://example.com/sitemap.xml\n",
+		},
+		{
+			name:     "拒否パスがなければ全て許可する",
+			disallow: nil,
+			want:     "User-agent: *\nDisallow:\n\nSitemap: https://example.com/sitemap.xml\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mux := http.NewServeMux()
+			NewRobotsHandler(tt.disallow, sitemap.NewSitemapUsecase(&stubPublishedArticleReader{}, "https://example.com", 10)).Register(mux)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/robots.txt", nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.want, rec.Body.String())
+		})
+	}
+}
+
+func TestRobotsHandler_WorkspaceSiteURL(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	NewRobotsHandler(nil, sitemap.NewSitemapUsecase(&stubPublishedArticleReader{}, "https://example.com", 10),
+		WithSitemapSettings(stubFeedSettings{SiteURL: "https://team-a.example.com"})).Register(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/robots.txt", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Sitemap: https://team-a.example.com/sitemap.xml\n")
+}
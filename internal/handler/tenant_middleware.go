@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+// ワークスペースを指定するヘッダ
+const workspaceHeader = "X-Workspace"
+
+// WorkspaceResolver はスラッグからワークスペースのIDを解決する
+type WorkspaceResolver interface {
+	ResolveWorkspace(ctx context.Context, slug string) (uint64, error)
+}
+
+// explicitWorkspaceKey はリクエストがワークスペースを明示したかをcontext.Contextに載せるキー
+type explicitWorkspaceKey struct{}
+
+// TenantMiddleware はリクエストのワークスペースを解決し、context.Contextに載せる
+// ワークスペースはX-Workspaceヘッダまたはベースドメインのサブドメインで指定し、
+// 指定がなければ既定のワークスペースになる
+// AuthMiddlewareより外側に置き、トークンやAPIキーに紐づくワークスペースとの整合はAuthMiddlewareで確認する
+type TenantMiddleware struct {
+	resolver   WorkspaceResolver
+	baseDomain string
+}
+
+// TenantOption はTenantMiddlewareの設定を変更する
+type TenantOption func(*TenantMiddleware)
+
+// WithBaseDomain はサブドメインでワークスペースを指定するときのベースドメインを指定する
+// 例えば"hub.example.com"なら"team-a.hub.example.com"はワークスペースteam-aになる
+func WithBaseDomain(domain string) TenantOption {
+	return func(m *TenantMiddleware) {
+		m.baseDomain = strings.ToLower(strings.Trim(domain, "."))
+	}
+}
+
+func NewTenantMiddleware(resolver WorkspaceResolver, opts ...TenantOption) *TenantMiddleware {
+	m := &TenantMiddleware{resolver: resolver}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Wrap はnextの前にワークスペースの解決を挟む
+// ヘッダとサブドメインが異なるワークスペースを指定した場合は400、未知のワークスペースは404にする
+func (m *TenantMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slug, err := m.requestedSlug(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		id := entity.DefaultWorkspaceID
+		if slug != "" {
+			if id, err = m.resolver.ResolveWorkspace(r.Context(), slug); err != nil {
+				writeError(w, err)
+				return
+			}
+		}
+		ctx := repository.WithWorkspace(r.Context(), id)
+		ctx = context.WithValue(ctx, explicitWorkspaceKey{}, slug != "")
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requestedSlug はヘッダまたはサブドメインで指定されたワークスペースのスラッグを返す
+func (m *TenantMiddleware) requestedSlug(r *http.Request) (string, error) {
+	header := strings.ToLower(strings.TrimSpace(r.Header.Get(workspaceHeader)))
+	subdomain := m.subdomain(r.Host)
+	if header != "" && subdomain != "" && header != subdomain {
+		return "", fmt.Errorf("%w: %s header %q does not match subdomain %q", apperr.ErrInvalidInput, workspaceHeader, header, subdomain)
+	}
+	if header != "" {
+		return header, nil
+	}
+	return subdomain, nil
+}
+
+// subdomain はホストがベースドメインの直下のサブドメインであれば、そのラベルを返す
+func (m *TenantMiddleware) subdomain(host string) string {
+	if m.baseDomain == "" {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	label, ok := strings.CutSuffix(strings.ToLower(host), "."+m.baseDomain)
+	if !ok || label == "" || strings.Contains(label, ".") {
+		return ""
+	}
+	return label
+}
+
+// bindWorkspace は呼び出し元とリクエストのワークスペースを突き合わせる
+// ワークスペースに紐づかない呼び出し元(ワークスペースのクレームを持たないトークンなど)は既定のワークスペースでのみ処理し、
+// 他のワークスペースを指定した場合は403にする
+// 紐づく呼び出し元はそのワークスペースで処理し、リクエストが明示したワークスペースと異なる場合は403にする
+func bindWorkspace(ctx context.Context, principal *auth.Principal) (context.Context, error) {
+	id, all, ok := repository.WorkspaceFrom(ctx)
+	requested := ok && !all
+	if principal.WorkspaceID == 0 {
+		if requested && id != entity.DefaultWorkspaceID {
+			return ctx, fmt.Errorf("%w: credential is not bound to workspace %d", apperr.ErrForbidden, id)
+		}
+		if requested {
+			principal.WorkspaceID = id
+		}
+		return ctx, nil
+	}
+	if explicit, _ := ctx.Value(explicitWorkspaceKey{}).(bool); explicit && requested && id != principal.WorkspaceID {
+		return ctx, fmt.Errorf("%w: credential is not valid for workspace %d", apperr.ErrForbidden, id)
+	}
+	return repository.WithWorkspace(ctx, principal.WorkspaceID), nil
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+// stubWorkspaceResolver はスラッグごとに決められたワークスペースのIDを返す
+type stubWorkspaceResolver map[string]uint64
+
+func (s stubWorkspaceResolver) ResolveWorkspace(_ context.Context, slug string) (uint64, error) {
+	id, ok := s[slug]
+	if !ok {
+		return 0, fmt.Errorf("workspace %q: %w", slug, repository.ErrWorkspaceNotFound)
+	}
+	return id, nil
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /workspace-id", func(w http.ResponseWriter, r *http.Request) {
+		id, _, _ := repository.WorkspaceFrom(r.Context())
+		body := strconv.FormatUint(id, 10)
+		if p, ok := auth.PrincipalFrom(r.Context()); ok {
+			body += "/" + strconv.FormatUint(p.WorkspaceID, 10)
+		}
+		_, _ = w.Write([]byte(body))
+	})
+	authMiddleware := NewAuthMiddleware(stubAuthenticator{
+		"unbound": {Scopes: []vo.Scope{vo.ScopeArticlesRead}},
+		"team-a":  {Scopes: []vo.Scope{vo.ScopeArticlesRead}, WorkspaceID: 2},
+		"team-b":  {Scopes: []vo.Scope{vo.ScopeArticlesRead}, WorkspaceID: 3},
+	})
+	server := NewTenantMiddleware(
+		stubWorkspaceResolver{"default": 1, "team-a": 2, "team-b": 3},
+		WithBaseDomain("hub.example.com"),
+	).Wrap(authMiddleware.Wrap(mux))
+
+	tests := []struct {
+		name       string
+		host       string
+		workspace  string
+		key        string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "指定がなければ既定のワークスペース", host: "hub.example.com", key: "unbound", wantStatus: http.StatusOK, wantBody: "1/1"},
+		{name: "ヘッダでワークスペースを指定する", host: "hub.example.com", workspace: "team-a", key: "team-a", wantStatus: http.StatusOK, wantBody: "2/2"},
+		{name: "サブドメインでワークスペースを指定する", host: "team-a.hub.example.com:8080", key: "team-a", wantStatus: http.StatusOK, wantBody: "2/2"},
+		{name: "ワークスペースに紐づかない呼び出し元は既定のワークスペースを明示できる", host: "hub.example.com", workspace: "default", key: "unbound", wantStatus: http.StatusOK, wantBody: "1/1"},
+		{name: "ワークスペースに紐づかない呼び出し元が他のワークスペースを指定すれば403", host: "hub.example.com", workspace: "team-a", key: "unbound", wantStatus: http.StatusForbidden},
+		{name: "ワークスペースに紐づかない呼び出し元がサブドメインで他のワークスペースを指定すれば403", host: "team-a.hub.example.com", key: "unbound", wantStatus: http.StatusForbidden},
+		{name: "ヘッダとサブドメインが異なれば400", host: "team-a.hub.example.com", workspace: "team-b", key: "unbound", wantStatus: http.StatusBadRequest},
+		{name: "未知のワークスペースは404", host: "unknown.hub.example.com", key: "unbound", wantStatus: http.StatusNotFound},
+		{name: "ワークスペースに紐づくキーはそのワークスペースで処理する", host: "hub.example.com", key: "team-b", wantStatus: http.StatusOK, wantBody: "3/3"},
+		{name: "紐づくワークスペースと異なるワークスペースの指定は403", host: "team-a.hub.example.com", key: "team-b", wantStatus: http.StatusForbidden},
+		{name: "紐づくワークスペースを指定できる", host: "hub.example.com", workspace: "team-b", key: "team-b", wantStatus: http.StatusOK, wantBody: "3/3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, "/workspace-id", nil)
+			req.Host = tt.host
+			req.Header.Set("X-API-Key", tt.key)
+			if tt.workspace != "" {
+				req.Header.Set("X-Workspace", tt.workspace)
+			}
+			rec := httptest.NewRecorder()
+
+			server.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
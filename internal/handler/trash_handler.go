@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/trash"
+)
+
+// TrashHandler はゴミ箱(削除済みの記事)のHTTPハンドラ
+type TrashHandler struct {
+	articles *article.ArticleUsecase
+	uc       *trash.TrashUsecase
+}
+
+func NewTrashHandler(articles *article.ArticleUsecase, uc *trash.TrashUsecase) *TrashHandler {
+	return &TrashHandler{articles: articles, uc: uc}
+}
+
+// Register はルーティングを登録する
+func (h *TrashHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /trash", h.list)
+	mux.HandleFunc("POST /trash/purge", h.purge)
+	mux.HandleFunc("POST /articles/{id}/restore", h.restore)
+}
+
+// list は削除済みの記事を新しく削除された順にpage / limitでページングして返す
+func (h *TrashHandler) list(w http.ResponseWriter, r *http.Request) {
+	var input article.ListTrashInput
+	q := r.URL.Query()
+	for name, dst := range map[string]*int{"page": &input.Page, "limit": &input.Limit} {
+		v := q.Get(name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, fmt.Errorf("%w: invalid %s: %q", apperr.ErrInvalidInput, name, v))
+			return
+		}
+		*dst = n
+	}
+	if v := q.Get("author_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			writeError(w, fmt.Errorf("%w: invalid author_id: %q", apperr.ErrInvalidInput, v))
+			return
+		}
+		input.AuthorID = &id
+	}
+	output, err := h.articles.ListTrash(r.Context(), input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+// purge は保持期間を過ぎた記事を完全に削除する
+// 本文に {"dry_run": true} を指定すると削除せずに対象だけを返す
+func (h *TrashHandler) purge(w http.ResponseWriter, r *http.Request) {
+	var input trash.PurgeInput
+	if err := decodeOptionalJSON(r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.Purge(r.Context(), input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+// restore は削除済みの記事をゴミ箱から戻す
+func (h *TrashHandler) restore(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.articles.RestoreArticle(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/webhook"
+)
+
+// WebhookHandler はWebhook購読と配信ログのHTTPハンドラ
+type WebhookHandler struct {
+	uc *webhook.WebhookUsecase
+}
+
+func NewWebhookHandler(uc *webhook.WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{uc: uc}
+}
+
+// Register はルーティングを登録する
+func (h *WebhookHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /webhooks", h.list)
+	mux.HandleFunc("POST /webhooks", h.create)
+	mux.HandleFunc("GET /webhooks/{id}", h.get)
+	mux.HandleFunc("PATCH /webhooks/{id}", h.update)
+	mux.HandleFunc("DELETE /webhooks/{id}", h.delete)
+	mux.HandleFunc("GET /webhooks/{id}/deliveries", h.listDeliveries)
+	mux.HandleFunc("POST /webhooks/{id}/deliveries/{deliveryID}/replay", h.replayDelivery)
+}
+
+func (h *WebhookHandler) list(w http.ResponseWriter, r *http.Request) {
+	outputs, err := h.uc.ListSubscriptions(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, outputs)
+}
+
+func (h *WebhookHandler) create(w http.ResponseWriter, r *http.Request) {
+	var input webhook.CreateSubscriptionInput
+	if err := decodeJSON(r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.CreateSubscription(r.Context(), input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, output)
+}
+
+func (h *WebhookHandler) get(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.FindSubscriptionByID(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *WebhookHandler) update(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var input webhook.UpdateSubscriptionInput
+	if err := decodeJSON(r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.UpdateSubscription(r.Context(), id, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *WebhookHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := h.uc.DeleteSubscription(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) listDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	outputs, err := h.uc.ListDeliveries(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, outputs)
+}
+
+func (h *WebhookHandler) replayDelivery(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	deliveryID, err := pathID(r, "deliveryID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.ReplayDelivery(r.Context(), id, deliveryID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, output)
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/workspace"
+)
+
+// WorkspaceHandler はリクエストのワークスペースの設定のHTTPハンドラ
+type WorkspaceHandler struct {
+	uc *workspace.WorkspaceUsecase
+}
+
+func NewWorkspaceHandler(uc *workspace.WorkspaceUsecase) *WorkspaceHandler {
+	return &WorkspaceHandler{uc: uc}
+}
+
+// Register はルーティングを登録する
+func (h *WorkspaceHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /workspace", h.get)
+	mux.HandleFunc("PATCH /workspace", h.update)
+}
+
+func (h *WorkspaceHandler) get(w http.ResponseWriter, r *http.Request) {
+	output, err := h.uc.Current(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+// update は名前・設定・プロバイダの認証情報を更新する(認証情報はレスポンスに含めない)
+func (h *WorkspaceHandler) update(w http.ResponseWriter, r *http.Request) {
+	var input workspace.UpdateWorkspaceInput
+	if err := decodeJSON(r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+	output, err := h.uc.UpdateCurrent(r.Context(), input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
@@ -0,0 +1,80 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apikey"
+)
+
+// OWASPの推奨値に沿ったArgon2idのパラメータ
+const (
+	defaultMemory      = 19 * 1024
+	defaultIterations  = 2
+	defaultParallelism = 1
+	saltLength         = 16
+	keyLength          = 32
+)
+
+// Argon2idHasher はAPIキーのシークレットをArgon2idでハッシュ化する
+// ハッシュはPHC文字列形式($argon2id$v=19$m=...,t=...,p=...$salt$hash)で保存し、
+// パラメータを変えても既存のハッシュを検証できるようにする
+type Argon2idHasher struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+var _ apikey.Hasher = (*Argon2idHasher)(nil)
+
+func NewArgon2idHasher() *Argon2idHasher {
+	return &Argon2idHasher{
+		memory:      defaultMemory,
+		iterations:  defaultIterations,
+		parallelism: defaultParallelism,
+	}
+}
+
+func (h *Argon2idHasher) Hash(secret string) (string, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	key := argon2.IDKey([]byte(secret), salt, h.iterations, h.memory, h.parallelism, keyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.memory, h.iterations, h.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *Argon2idHasher) Verify(secret, encoded string) (bool, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false, fmt.Errorf("unsupported hash format")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, fmt.Errorf("unsupported argon2 version: %s", parts[2])
+	}
+	var memory, iterations uint32
+	var parallelism uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism); err != nil {
+		return false, fmt.Errorf("invalid argon2 parameters: %w", err)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, fmt.Errorf("invalid salt: %w", err)
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, fmt.Errorf("invalid hash: %w", err)
+	}
+	got := argon2.IDKey([]byte(secret), salt, iterations, memory, parallelism, uint32(len(want)))
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
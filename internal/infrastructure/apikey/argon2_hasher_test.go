@@ -0,0 +1,58 @@
+package apikey_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/apikey"
+)
+
+func TestArgon2idHasher(t *testing.T) {
+	t.Parallel()
+	hasher := apikey.NewArgon2idHasher()
+
+	hash, err := hasher.Hash("s3cret")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=19456,t=2,p=1$"))
+	assert.NotContains(t, hash, "s3cret")
+
+	t.Run("同じシークレットでもソルトが異なる", func(t *testing.T) {
+		t.Parallel()
+		other, err := hasher.Hash("s3cret")
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, other)
+	})
+
+	t.Run("一致するシークレットだけを受け入れる", func(t *testing.T) {
+		t.Parallel()
+		ok, err := hasher.Verify("s3cret", hash)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = hasher.Verify("wrong", hash)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("ハッシュに記録されたパラメータで検証する", func(t *testing.T) {
+		t.Parallel()
+		salt := []byte("saltsaltsaltsalt")
+		key := argon2.IDKey([]byte("s3cret"), salt, 1, 8, 1, 32)
+		legacy := "$argon2id$v=19$m=8,t=1,p=1$" + base64.RawStdEncoding.EncodeToString(salt) + "$" + base64.RawStdEncoding.EncodeToString(key)
+
+		ok, err := hasher.Verify("s3cret", legacy)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("未対応の形式はエラー", func(t *testing.T) {
+		t.Parallel()
+		_, err := hasher.Verify("s3cret", "$2a$10$abcdefghijklmnopqrstuv")
+		assert.ErrorContains(t, err, "unsupported hash format")
+	})
+}
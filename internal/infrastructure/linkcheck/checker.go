@@ -0,0 +1,252 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/netguard"
+	usecase "github.com/umekikazuya/momenture-article-hub/internal/usecase/linkcheck"
+)
+
+const (
+	DefaultUserAgent    = "momenture-article-hub-linkcheck"
+	defaultHostInterval = time.Second
+	defaultMaxRedirects = 10
+	// robots.txtの規則を取得し直すまでの期間
+	defaultRobotsTTL = 24 * time.Hour
+	// 不要になったホストごとの状態を削除する間隔
+	sweepInterval = time.Minute
+	// 読み捨てるレスポンスボディの最大サイズ
+	maxDiscardSize = 64 << 10
+)
+
+var errTooManyRedirects = errors.New("too many redirects")
+
+// HTTPChecker はHEAD(失敗時はGET)でURLの到達性を確認するusecase.Checker
+// 同じホストへのリクエストは一定間隔を空け、robots.txtで拒否されたURLはリクエストしない
+// ホストごとの状態は、間隔を空け終えたものとrobotsTTLを過ぎたものを定期的に削除する
+// リンクは利用者が指定するため、内部のネットワークに届かないnetguard.NewClientのクライアントを使う
+type HTTPChecker struct {
+	client       *http.Client
+	userAgent    string
+	hostInterval time.Duration
+	maxRedirects int
+	robotsTTL    time.Duration
+
+	mu        sync.Mutex
+	nextSlot  map[string]time.Time
+	robots    map[string]*robotsEntry
+	nextSweep time.Time
+}
+
+var _ usecase.Checker = (*HTTPChecker)(nil)
+
+// robotsEntry はホストごとに有効期限までの間1度だけ取得するrobots.txtの規則
+type robotsEntry struct {
+	once    sync.Once
+	rules   robotsRules
+	expires time.Time
+}
+
+// Option はHTTPCheckerの設定
+type Option func(*HTTPChecker)
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *HTTPChecker) {
+		if userAgent != "" {
+			c.userAgent = userAgent
+		}
+	}
+}
+
+// WithHostInterval は同じホストへのリクエストの最小間隔を設定する
+func WithHostInterval(d time.Duration) Option {
+	return func(c *HTTPChecker) {
+		if d >= 0 {
+			c.hostInterval = d
+		}
+	}
+}
+
+func WithMaxRedirects(n int) Option {
+	return func(c *HTTPChecker) {
+		if n > 0 {
+			c.maxRedirects = n
+		}
+	}
+}
+
+// WithRobotsTTL はrobots.txtの規則を取得し直すまでの期間を設定する
+func WithRobotsTTL(d time.Duration) Option {
+	return func(c *HTTPChecker) {
+		if d > 0 {
+			c.robotsTTL = d
+		}
+	}
+}
+
+// NewHTTPChecker はHTTPCheckerを作成する
+// clientがnilの場合は公開アドレスにだけ接続するクライアントを使う
+func NewHTTPChecker(client *http.Client, opts ...Option) *HTTPChecker {
+	if client == nil {
+		client = netguard.NewClient(10 * time.Second)
+	}
+	c := &HTTPChecker{
+		client:       client,
+		userAgent:    DefaultUserAgent,
+		hostInterval: defaultHostInterval,
+		maxRedirects: defaultMaxRedirects,
+		robotsTTL:    defaultRobotsTTL,
+		nextSlot:     make(map[string]time.Time),
+		robots:       make(map[string]*robotsEntry),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *HTTPChecker) Check(ctx context.Context, rawURL string) usecase.Result {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return usecase.Result{Err: fmt.Errorf("unsupported url: %q", rawURL)}
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	if !c.robotsRules(ctx, u).allowed(path) {
+		return usecase.Result{SkipReason: "disallowed by robots.txt"}
+	}
+
+	// HEADに対応していないサーバーがあるため、2xx/3xx以外はGETで確認し直す
+	result := c.request(ctx, http.MethodHead, u)
+	if result.Err == nil && result.StatusCode < 400 {
+		return result
+	}
+	if ctx.Err() != nil {
+		return result
+	}
+	return c.request(ctx, http.MethodGet, u)
+}
+
+// request はリダイレクトを辿ってリクエストし、最終的なステータスコードとリダイレクト先を返す
+func (c *HTTPChecker) request(ctx context.Context, method string, u *url.URL) usecase.Result {
+	if err := c.wait(ctx, u.Host); err != nil {
+		return usecase.Result{Err: err}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return usecase.Result{Err: fmt.Errorf("failed to build request: %w", err)}
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	redirects := []string{}
+	client := *c.client
+	client.CheckRedirect = func(next *http.Request, via []*http.Request) error {
+		if len(via) > c.maxRedirects {
+			return errTooManyRedirects
+		}
+		redirects = append(redirects, next.URL.String())
+		return nil
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return usecase.Result{Redirects: redirects, Err: err}
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDiscardSize))
+	return usecase.Result{StatusCode: resp.StatusCode, Redirects: redirects}
+}
+
+// wait は同じホストへの前回のリクエストからhostInterval経つまで待つ
+func (c *HTTPChecker) wait(ctx context.Context, host string) error {
+	c.mu.Lock()
+	now := time.Now()
+	c.sweep(now)
+	slot := c.nextSlot[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	c.nextSlot[host] = slot.Add(c.hostInterval)
+	c.mu.Unlock()
+
+	d := slot.Sub(now)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// robotsRules はホストのrobots.txtの規則を返す
+// 取得できない場合や4xx/5xxの場合は全て許可として扱う
+func (c *HTTPChecker) robotsRules(ctx context.Context, u *url.URL) robotsRules {
+	key := u.Scheme + "://" + u.Host
+	c.mu.Lock()
+	now := time.Now()
+	c.sweep(now)
+	entry, ok := c.robots[key]
+	if !ok || !now.Before(entry.expires) {
+		entry = &robotsEntry{expires: now.Add(c.robotsTTL)}
+		c.robots[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		if err := c.wait(ctx, u.Host); err != nil {
+			return
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, key+"/robots.txt", nil)
+		if err != nil {
+			return
+		}
+		req.Header.Set("User-Agent", c.userAgent)
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return
+		}
+		entry.rules = parseRobots(io.LimitReader(resp.Body, maxDiscardSize*8), c.userAgent)
+	})
+	return entry.rules
+}
+
+// sweep は間隔を空け終えたホストと期限切れのrobots.txtの規則を削除する
+// 呼び出し側でmuをロックしておく
+func (c *HTTPChecker) sweep(now time.Time) {
+	if now.Before(c.nextSweep) {
+		return
+	}
+	c.nextSweep = now.Add(sweepInterval)
+	for host, slot := range c.nextSlot {
+		if !slot.After(now) {
+			delete(c.nextSlot, host)
+		}
+	}
+	for key, entry := range c.robots {
+		if !now.Before(entry.expires) {
+			delete(c.robots, key)
+		}
+	}
+}
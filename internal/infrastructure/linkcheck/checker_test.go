@@ -0,0 +1,201 @@
+package linkcheck_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/linkcheck"
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/netguard"
+)
+
+const testRobots = `
+User-agent: *
+Disallow: /private
+Allow: /private/public
+Disallow: /*.pdf$
+
+User-agent: other-bot
+Disallow: /
+`
+
+// newFakeSite はrobots.txtといくつかのページを返すローカルサーバーを起動し、受けたリクエストを記録する
+func newFakeSite(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/robots.txt":
+			_, _ = w.Write([]byte(testRobots))
+		case "/ok", "/private/public/page":
+			w.WriteHeader(http.StatusOK)
+		case "/head-not-allowed":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/old":
+			http.Redirect(w, r, "/moved", http.StatusMovedPermanently)
+		case "/moved":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), requests...)
+	}
+}
+
+func TestHTTPChecker_Check(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("HEADで到達できるURLはGETしない", func(t *testing.T) {
+		t.Parallel()
+		srv, requests := newFakeSite(t)
+		checker := linkcheck.NewHTTPChecker(srv.Client(), linkcheck.WithHostInterval(0))
+
+		result := checker.Check(ctx, srv.URL+"/ok")
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Empty(t, result.Redirects)
+		assert.NoError(t, result.Err)
+		assert.Equal(t, []string{"GET /robots.txt", "HEAD /ok"}, requests())
+	})
+
+	t.Run("HEADが失敗した場合はGETで確認し直す", func(t *testing.T) {
+		t.Parallel()
+		srv, requests := newFakeSite(t)
+		checker := linkcheck.NewHTTPChecker(srv.Client(), linkcheck.WithHostInterval(0))
+
+		result := checker.Check(ctx, srv.URL+"/head-not-allowed")
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, []string{"GET /robots.txt", "HEAD /head-not-allowed", "GET /head-not-allowed"}, requests())
+	})
+
+	t.Run("存在しないURLは最終的なステータスコードを返す", func(t *testing.T) {
+		t.Parallel()
+		srv, _ := newFakeSite(t)
+		checker := linkcheck.NewHTTPChecker(srv.Client(), linkcheck.WithHostInterval(0))
+
+		result := checker.Check(ctx, srv.URL+"/gone")
+		assert.Equal(t, http.StatusNotFound, result.StatusCode)
+		assert.NoError(t, result.Err)
+	})
+
+	t.Run("リダイレクト先を順番に記録する", func(t *testing.T) {
+		t.Parallel()
+		srv, _ := newFakeSite(t)
+		checker := linkcheck.NewHTTPChecker(srv.Client(), linkcheck.WithHostInterval(0))
+
+		result := checker.Check(ctx, srv.URL+"/old")
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, []string{srv.URL + "/moved", srv.URL + "/ok"}, result.Redirects)
+	})
+
+	t.Run("リダイレクトが上限を超えるとエラーを返す", func(t *testing.T) {
+		t.Parallel()
+		srv, _ := newFakeSite(t)
+		checker := linkcheck.NewHTTPChecker(srv.Client(), linkcheck.WithHostInterval(0), linkcheck.WithMaxRedirects(3))
+
+		result := checker.Check(ctx, srv.URL+"/loop")
+		assert.Error(t, result.Err)
+		assert.Len(t, result.Redirects, 3)
+	})
+
+	t.Run("robots.txtで拒否されたURLはリクエストせずに見送る", func(t *testing.T) {
+		t.Parallel()
+		srv, requests := newFakeSite(t)
+		checker := linkcheck.NewHTTPChecker(srv.Client(), linkcheck.WithHostInterval(0))
+
+		for _, path := range []string{"/private/page", "/files/doc.pdf"} {
+			result := checker.Check(ctx, srv.URL+path)
+			assert.NotEmpty(t, result.SkipReason, path)
+		}
+		// より長く一致するAllowが優先される
+		result := checker.Check(ctx, srv.URL+"/private/public/page")
+		assert.Empty(t, result.SkipReason)
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		// robots.txtはホストごとに1回だけ取得する
+		assert.Equal(t, []string{"GET /robots.txt", "HEAD /private/public/page"}, requests())
+	})
+
+	t.Run("User-Agentに一致するグループがあればその規則に従う", func(t *testing.T) {
+		t.Parallel()
+		srv, _ := newFakeSite(t)
+		checker := linkcheck.NewHTTPChecker(srv.Client(), linkcheck.WithHostInterval(0), linkcheck.WithUserAgent("Other-Bot/1.0"))
+
+		result := checker.Check(ctx, srv.URL+"/ok")
+		assert.NotEmpty(t, result.SkipReason)
+	})
+
+	t.Run("robots.txtは有効期限が過ぎたら取得し直す", func(t *testing.T) {
+		t.Parallel()
+		srv, requests := newFakeSite(t)
+		ttl := 20 * time.Millisecond
+		checker := linkcheck.NewHTTPChecker(srv.Client(), linkcheck.WithHostInterval(0), linkcheck.WithRobotsTTL(ttl))
+
+		checker.Check(ctx, srv.URL+"/ok")
+		time.Sleep(2 * ttl)
+		checker.Check(ctx, srv.URL+"/ok")
+
+		assert.Equal(t, []string{"GET /robots.txt", "HEAD /ok", "GET /robots.txt", "HEAD /ok"}, requests())
+	})
+
+	t.Run("既定のクライアントは内部のネットワークのURLにリクエストしない", func(t *testing.T) {
+		t.Parallel()
+		srv, requests := newFakeSite(t)
+		checker := linkcheck.NewHTTPChecker(nil, linkcheck.WithHostInterval(0))
+
+		result := checker.Check(ctx, srv.URL+"/ok")
+		assert.ErrorIs(t, result.Err, netguard.ErrNonPublicAddress)
+		assert.Empty(t, requests())
+	})
+
+	t.Run("HTTP以外のURLはエラーを返す", func(t *testing.T) {
+		t.Parallel()
+		checker := linkcheck.NewHTTPChecker(nil)
+
+		result := checker.Check(ctx, "mailto:someone@example.com")
+		assert.Error(t, result.Err)
+	})
+
+	t.Run("同じホストへのリクエストは間隔を空ける", func(t *testing.T) {
+		t.Parallel()
+		srv, requests := newFakeSite(t)
+		interval := 50 * time.Millisecond
+		checker := linkcheck.NewHTTPChecker(srv.Client(), linkcheck.WithHostInterval(interval))
+
+		start := time.Now()
+		var wg sync.WaitGroup
+		for range 2 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				checker.Check(ctx, srv.URL+"/ok")
+			}()
+		}
+		wg.Wait()
+
+		// robots.txtと2回のHEADで3回リクエストするため、少なくとも2間隔待つ
+		require.Len(t, requests(), 3)
+		assert.GreaterOrEqual(t, time.Since(start), 2*interval)
+	})
+}
@@ -0,0 +1,111 @@
+package linkcheck
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// robotsRules はrobots.txtのうち自身に適用されるグループのAllow/Disallow
+type robotsRules struct {
+	allow    []string
+	disallow []string
+}
+
+// parseRobots はrobots.txtを解析し、userAgentに一致するグループ、なければ"*"のグループの規則を返す
+func parseRobots(r io.Reader, userAgent string) robotsRules {
+	userAgent = strings.ToLower(userAgent)
+	groups := map[string]*robotsRules{}
+	var current []string
+	inRules := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// 規則の後に現れたUser-agentは新しいグループの開始
+			if inRules {
+				current = nil
+				inRules = false
+			}
+			agent := strings.ToLower(value)
+			current = append(current, agent)
+			if groups[agent] == nil {
+				groups[agent] = &robotsRules{}
+			}
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				continue
+			}
+			for _, agent := range current {
+				if key == "allow" {
+					groups[agent].allow = append(groups[agent].allow, value)
+				} else {
+					groups[agent].disallow = append(groups[agent].disallow, value)
+				}
+			}
+		}
+	}
+
+	for agent, rules := range groups {
+		if agent != "*" && strings.Contains(userAgent, agent) {
+			return *rules
+		}
+	}
+	if rules, ok := groups["*"]; ok {
+		return *rules
+	}
+	return robotsRules{}
+}
+
+// allowed はパスへのアクセスが許可されているかを判定する
+// 最も長く一致した規則を優先し、同じ長さの場合はAllowを優先する
+func (r robotsRules) allowed(path string) bool {
+	best, allow := -1, true
+	for _, p := range r.allow {
+		if matchRobotsPattern(p, path) && len(p) >= best {
+			best, allow = len(p), true
+		}
+	}
+	for _, p := range r.disallow {
+		if matchRobotsPattern(p, path) && len(p) > best {
+			best, allow = len(p), false
+		}
+	}
+	return allow
+}
+
+// matchRobotsPattern は"*"(任意の文字列)と末尾の"$"(終端)に対応した前方一致
+func matchRobotsPattern(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for i, part := range parts[1:] {
+		// 終端指定の最後の断片はパスの末尾と一致させる
+		idx := strings.Index(rest, part)
+		if anchored && i == len(parts)-2 {
+			idx = strings.LastIndex(rest, part)
+		}
+		if idx < 0 {
+			return false
+		}
+		rest = rest[idx+len(part):]
+	}
+	return !anchored || rest == ""
+}
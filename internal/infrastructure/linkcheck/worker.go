@@ -0,0 +1,43 @@
+package linkcheck
+
+import (
+	"context"
+	"log"
+	"time"
+
+	usecase "github.com/umekikazuya/momenture-article-hub/internal/usecase/linkcheck"
+)
+
+// Worker は記事のリンクを定期的にチェックする
+type Worker struct {
+	uc       *usecase.LinkCheckUsecase
+	interval time.Duration
+}
+
+func NewWorker(uc *usecase.LinkCheckUsecase, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	return &Worker{uc: uc, interval: interval}
+}
+
+// Run は起動直後に1回チェックし、以降はコンテキストがキャンセルされるまで間隔ごとにチェックする
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		out, err := w.uc.Run(ctx)
+		if err != nil {
+			log.Printf("linkcheck worker: %v", err)
+		} else if out.Broken > 0 {
+			log.Printf("linkcheck worker: articles=%d checked=%d broken=%d skipped=%d", out.Articles, out.Checked, out.Broken, out.Skipped)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package markdown
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+)
+
+// CachedRenderer は本文のハッシュをキーに変換結果をLRUでキャッシュする
+// 本文が変わらない限り同じHTMLを返すため、読み取りのたびに変換しない
+type CachedRenderer struct {
+	next     Renderer
+	capacity int
+
+	mu      sync.Mutex
+	ll      *list.List
+	entries map[[sha256.Size]byte]*list.Element
+}
+
+type cacheEntry struct {
+	key  [sha256.Size]byte
+	html string
+}
+
+var _ Renderer = (*CachedRenderer)(nil)
+
+// NewCachedRenderer はcapacity件までの変換結果を保持するCachedRendererを生成する
+func NewCachedRenderer(next Renderer, capacity int) *CachedRenderer {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &CachedRenderer{
+		next:     next,
+		capacity: capacity,
+		ll:       list.New(),
+		entries:  make(map[[sha256.Size]byte]*list.Element),
+	}
+}
+
+func (c *CachedRenderer) Render(body string) (string, error) {
+	key := sha256.Sum256([]byte(body))
+
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		c.ll.MoveToFront(el)
+		html := el.Value.(*cacheEntry).html
+		c.mu.Unlock()
+		return html, nil
+	}
+	c.mu.Unlock()
+
+	html, err := c.next.Render(body)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.ll.MoveToFront(el)
+		return html, nil
+	}
+	c.entries[key] = c.ll.PushFront(&cacheEntry{key: key, html: html})
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+	return html, nil
+}
+
+// Len はキャッシュされている件数を返す
+func (c *CachedRenderer) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
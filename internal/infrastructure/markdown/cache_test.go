@@ -0,0 +1,72 @@
+package markdown_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/markdown"
+)
+
+// countingRenderer は呼び出し回数を記録するRenderer
+type countingRenderer struct {
+	calls map[string]int
+	err   error
+}
+
+func (r *countingRenderer) Render(body string) (string, error) {
+	r.calls[body]++
+	if r.err != nil {
+		return "", r.err
+	}
+	return "<p>" + body + "</p>", nil
+}
+
+func TestCachedRenderer_Render(t *testing.T) {
+	t.Parallel()
+
+	t.Run("同じ本文は再変換しない", func(t *testing.T) {
+		t.Parallel()
+		next := &countingRenderer{calls: map[string]int{}}
+		r := markdown.NewCachedRenderer(next, 10)
+
+		for range 3 {
+			got, err := r.Render("a")
+			require.NoError(t, err)
+			assert.Equal(t, "<p>a</p>", got)
+		}
+		assert.Equal(t, 1, next.calls["a"])
+	})
+
+	t.Run("容量を超えると最も古いものを破棄する", func(t *testing.T) {
+		t.Parallel()
+		next := &countingRenderer{calls: map[string]int{}}
+		r := markdown.NewCachedRenderer(next, 2)
+
+		for _, body := range []string{"a", "b", "a", "c", "a", "b"} {
+			_, err := r.Render(body)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 2, r.Len())
+		assert.Equal(t, 1, next.calls["a"])
+		assert.Equal(t, 2, next.calls["b"])
+		assert.Equal(t, 1, next.calls["c"])
+	})
+
+	t.Run("エラーはキャッシュしない", func(t *testing.T) {
+		t.Parallel()
+		next := &countingRenderer{calls: map[string]int{}, err: errors.New("render error")}
+		r := markdown.NewCachedRenderer(next, 10)
+
+		_, err := r.Render("a")
+		assert.Error(t, err)
+		_, err = r.Render("a")
+		assert.Error(t, err)
+
+		assert.Equal(t, 2, next.calls["a"])
+		assert.Equal(t, 0, r.Len())
+	})
+}
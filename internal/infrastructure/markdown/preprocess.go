@@ -0,0 +1,110 @@
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+var (
+	// :::message alert / :::note warn / ::::details タイトル
+	containerOpenPattern  = regexp.MustCompile(`^(:{3,})\s*(message|note|details)(?:\s+(.*))?$`)
+	containerClosePattern = regexp.MustCompile(`^:{3,}$`)
+	// @[card](https://example.com)
+	embedPattern = regexp.MustCompile(`^@\[([a-z0-9_-]+)\]\((\S+)\)$`)
+)
+
+// preprocess はQiita/Zenn独自記法をCommonMarkで解釈できる形に変換する
+// コードフェンス内は変換しない
+func preprocess(src string) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	out := make([]string, 0, len(lines))
+
+	var fence vo.CodeFence
+	inMath := false
+	var containers []string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if fence.Inside() {
+			out = append(out, line)
+			fence.Close(trimmed)
+			continue
+		}
+		if inMath {
+			if trimmed == "$$" {
+				out = append(out, "```")
+				inMath = false
+				continue
+			}
+			out = append(out, line)
+			continue
+		}
+
+		switch {
+		case fence.Open(trimmed):
+			out = append(out, line)
+		case trimmed == "$$":
+			// ブロック数式はmath言語のコードフェンスとして扱う
+			out = append(out, "```math")
+			inMath = true
+		case containerOpenPattern.MatchString(trimmed):
+			m := containerOpenPattern.FindStringSubmatch(trimmed)
+			open, closeTag := containerHTML(m[2], strings.TrimSpace(m[3]))
+			containers = append(containers, closeTag)
+			out = append(out, "", open, "")
+		case containerClosePattern.MatchString(trimmed) && len(containers) > 0:
+			closeTag := containers[len(containers)-1]
+			containers = containers[:len(containers)-1]
+			out = append(out, "", closeTag, "")
+		case embedPattern.MatchString(trimmed):
+			m := embedPattern.FindStringSubmatch(trimmed)
+			out = append(out, "", embedHTML(m[1], m[2]), "")
+		default:
+			out = append(out, line)
+		}
+	}
+	if inMath {
+		out = append(out, "```")
+	}
+	for i := len(containers) - 1; i >= 0; i-- {
+		out = append(out, "", containers[i], "")
+	}
+	return strings.Join(out, "\n")
+}
+
+// containerHTML は:::ブロックの開始タグと終了タグを返す
+func containerHTML(kind, arg string) (string, string) {
+	switch kind {
+	case "details":
+		return "<details><summary>" + html.EscapeString(arg) + "</summary>", "</details>"
+	case "note":
+		// Qiita: info / warn / alert
+		level := "info"
+		if arg == "warn" || arg == "alert" {
+			level = arg
+		}
+		return `<aside class="note ` + level + `">`, "</aside>"
+	default:
+		// Zenn: message / message alert
+		class := "msg message"
+		if arg == "alert" {
+			class = "msg alert"
+		}
+		return `<aside class="` + class + `">`, "</aside>"
+	}
+}
+
+// embedHTML は@[type](url)形式の埋め込みをリンクカードとして出力する
+// http(s)以外の値はテキストとして扱う
+func embedHTML(kind, value string) string {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return `<div class="embed embed-` + kind + `">` + html.EscapeString(value) + "</div>"
+	}
+	escaped := html.EscapeString(u.String())
+	return `<div class="embed embed-` + kind + `"><a href="` + escaped + `">` + escaped + "</a></div>"
+}
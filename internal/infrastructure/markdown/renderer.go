@@ -0,0 +1,119 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+// Renderer は記事本文のMarkdownをHTMLへ変換する
+type Renderer interface {
+	Render(body string) (string, error)
+}
+
+// GoldmarkRenderer はQiita/Zenn互換のMarkdownをサニタイズ済みHTMLへ変換する
+// 独自記法(:::message, :::note, :::details, @[card](url), $$数式$$, ```lang:filename)に対応する
+// インライン数式($...$)はテキストのまま残し、クライアント側のKaTeX等で描画する前提とする
+type GoldmarkRenderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+}
+
+var _ Renderer = (*GoldmarkRenderer)(nil)
+
+func NewGoldmarkRenderer() *GoldmarkRenderer {
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM, extension.Footnote),
+		goldmark.WithRendererOptions(
+			// 生成したHTMLは後段でサニタイズする
+			html.WithUnsafe(),
+			renderer.WithNodeRenderers(util.Prioritized(&codeBlockRenderer{}, 100)),
+		),
+	)
+	return &GoldmarkRenderer{md: md, policy: newPolicy()}
+}
+
+func (r *GoldmarkRenderer) Render(body string) (string, error) {
+	if strings.TrimSpace(body) == "" {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	if err := r.md.Convert([]byte(preprocess(body)), &buf); err != nil {
+		return "", fmt.Errorf("failed to render markdown: %w", err)
+	}
+	return r.policy.Sanitize(buf.String()), nil
+}
+
+// newPolicy はユーザー投稿向けのポリシーに独自記法の出力で使う要素を加えたもの
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowElements("aside", "details", "summary")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^[a-zA-Z0-9 _-]+$`)).OnElements("aside", "div", "code", "span", "pre")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}
+
+// codeBlockRenderer は```lang:filename形式のファイル名とmath言語のブロック数式を出力する
+type codeBlockRenderer struct{}
+
+func (r *codeBlockRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.render)
+}
+
+func (r *codeBlockRenderer) render(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.FencedCodeBlock)
+
+	var info string
+	if n.Info != nil {
+		info = strings.TrimSpace(string(n.Info.Segment.Value(source)))
+	}
+	if i := strings.IndexAny(info, " \t"); i >= 0 {
+		info = info[:i]
+	}
+	lang, filename, _ := strings.Cut(info, ":")
+
+	var code bytes.Buffer
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		code.Write(line.Value(source))
+	}
+	escaped := util.EscapeHTML(code.Bytes())
+
+	if lang == "math" {
+		_, _ = w.WriteString(`<div class="math math-display">`)
+		_, _ = w.Write(escaped)
+		_, _ = w.WriteString("</div>\n")
+		return ast.WalkSkipChildren, nil
+	}
+
+	_, _ = w.WriteString(`<div class="code-block">`)
+	if filename != "" {
+		_, _ = w.WriteString(`<div class="code-block-filename">`)
+		_, _ = w.Write(util.EscapeHTML([]byte(filename)))
+		_, _ = w.WriteString("</div>")
+	}
+	_, _ = w.WriteString("<pre><code")
+	if lang != "" {
+		_, _ = w.WriteString(` class="language-`)
+		_, _ = w.Write(util.EscapeHTML([]byte(lang)))
+		_, _ = w.WriteString(`"`)
+	}
+	_, _ = w.WriteString(">")
+	_, _ = w.Write(escaped)
+	_, _ = w.WriteString("</code></pre></div>\n")
+	return ast.WalkSkipChildren, nil
+}
@@ -0,0 +1,108 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/markdown"
+)
+
+func TestGoldmarkRenderer_Render(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		body        string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "空の本文は空文字",
+			body:     "  \n",
+			contains: nil,
+		},
+		{
+			name:     "GFMのテーブルとタスクリスト",
+			body:     "| a | b |\n|---|---|\n| 1 | 2 |\n\n- [x] done\n",
+			contains: []string{"<table>", "<td>1</td>", `<input checked="" disabled="" type="checkbox">`},
+		},
+		{
+			name:     "Zennのメッセージブロック",
+			body:     ":::message alert\n**注意**\n:::\n",
+			contains: []string{`<aside class="msg alert">`, "<strong>注意</strong>", "</aside>"},
+		},
+		{
+			name:     "Qiitaのノート",
+			body:     ":::note warn\n警告\n:::\n",
+			contains: []string{`<aside class="note warn">`, "<p>警告</p>"},
+		},
+		{
+			name:     "入れ子のアコーディオン",
+			body:     "::::details 外側\n:::message\n内側\n:::\n::::\n",
+			contains: []string{"<details><summary>外側</summary>", `<aside class="msg message">`, "<p>内側</p>\n</aside>", "</details>"},
+		},
+		{
+			name:     "閉じられていないブロックは末尾で閉じる",
+			body:     ":::message\n本文\n",
+			contains: []string{`<aside class="msg message">`, "</aside>"},
+		},
+		{
+			name:     "リンクカード",
+			body:     "@[card](https://zenn.dev/a?b=1&c=2)\n",
+			contains: []string{`<div class="embed embed-card"><a href="https://zenn.dev/a?b=1&amp;c=2" rel="nofollow noopener" target="_blank">`},
+		},
+		{
+			name:        "http以外の埋め込みはテキストとして扱う",
+			body:        "@[card](javascript:alert(1))\n",
+			contains:    []string{`<div class="embed embed-card">javascript:alert(1)</div>`},
+			notContains: []string{"<a "},
+		},
+		{
+			name:     "ファイル名付きのコードブロック",
+			body:     "```go:main.go\nfmt.Println(\"<x>\")\n```\n",
+			contains: []string{`<div class="code-block-filename">main.go</div>`, `<code class="language-go">fmt.Println(&#34;&lt;x&gt;&#34;)`},
+		},
+		{
+			name:        "コードブロック内の独自記法は変換しない",
+			body:        "```\n:::message\n```\n",
+			contains:    []string{"<pre><code>:::message\n</code></pre>"},
+			notContains: []string{"<aside"},
+		},
+		{
+			name:     "ブロック数式",
+			body:     "$$\na < b\n$$\n",
+			contains: []string{`<div class="math math-display">a &lt; b`},
+		},
+		{
+			name:     "Qiitaのmathコードブロック",
+			body:     "```math\nx^2\n```\n",
+			contains: []string{`<div class="math math-display">x^2`},
+		},
+		{
+			name:        "スクリプトと危険なリンクは除去する",
+			body:        "<script>alert(1)</script>\n\n[x](javascript:alert(1)) <img src=x onerror=alert(1)>\n",
+			notContains: []string{"<script", "javascript:", "onerror"},
+		},
+	}
+
+	r := markdown.NewGoldmarkRenderer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := r.Render(tt.body)
+
+			require.NoError(t, err)
+			if tt.contains == nil && tt.notContains == nil {
+				assert.Empty(t, got)
+			}
+			for _, want := range tt.contains {
+				assert.Contains(t, got, want)
+			}
+			for _, unwanted := range tt.notContains {
+				assert.NotContains(t, got, unwanted)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	usecase "github.com/umekikazuya/momenture-article-hub/internal/usecase/metrics"
+)
+
+// 読み込むレスポンスボディの最大サイズ
+const maxResponseSize = 1 << 20
+
+// getJSON はGETリクエストを送り、JSONレスポンスをvへデコードする
+// 404は記事が存在しないものとしてErrTargetNotSupportedを返す
+func getJSON(ctx context.Context, client *http.Client, endpoint string, header http.Header, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build metrics request: %w", err)
+	}
+	for k, values := range header {
+		for _, value := range values {
+			req.Header.Add(k, value)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "momenture-article-hub-metrics")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+		return fmt.Errorf("%s: %w", endpoint, usecase.ErrTargetNotSupported)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+		return fmt.Errorf("%s responded with status %d", endpoint, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", endpoint, err)
+	}
+	return nil
+}
+
+// identifier は外部IDがあればそれを、なければリンクのパスでmarkerの直後のセグメントを返す
+// 例: https://qiita.com/user/items/abc はmarkerが"items"のときabc
+func identifier(target repository.MetricTarget, marker string) (string, error) {
+	if target.ExternalID != nil && *target.ExternalID != "" {
+		return *target.ExternalID, nil
+	}
+	if target.Link == nil {
+		return "", fmt.Errorf("publication %d has no link: %w", target.PublicationID, usecase.ErrTargetNotSupported)
+	}
+	u, err := url.Parse(*target.Link)
+	if err != nil {
+		return "", fmt.Errorf("publication %d has an invalid link: %w", target.PublicationID, usecase.ErrTargetNotSupported)
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] == marker && segments[i+1] != "" {
+			return segments[i+1], nil
+		}
+	}
+	return "", fmt.Errorf("link %q of publication %d is not an article: %w", *target.Link, target.PublicationID, usecase.ErrTargetNotSupported)
+}
+
+func newClient(client *http.Client) *http.Client {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return client
+}
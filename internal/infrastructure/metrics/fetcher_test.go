@@ -0,0 +1,168 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/metrics"
+	usecase "github.com/umekikazuya/momenture-article-hub/internal/usecase/metrics"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// newFakeProvider はパスごとに固定のJSONを返すローカルサーバーを起動する
+func newFakeProvider(t *testing.T, responses map[string]string, gotAuth *string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if gotAuth != nil {
+			*gotAuth = r.Header.Get("Authorization")
+		}
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if body == "" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// credentialSource はワークスペースIDごとに固定のアクセストークンを返すmetrics.CredentialSource
+type credentialSource map[uint64]map[vo.ProviderType]string
+
+func (s credentialSource) Credential(_ context.Context, workspaceID uint64, provider vo.ProviderType) (string, bool, error) {
+	token, ok := s[workspaceID][provider]
+	return token, ok, nil
+}
+
+func TestQiitaFetcher_Fetch(t *testing.T) {
+	t.Parallel()
+
+	responses := map[string]string{
+		"/api/v2/items/abc123":  `{"id":"abc123","likes_count":12,"stocks_count":7}`,
+		"/api/v2/items/limited": "",
+	}
+
+	t.Run("リンクの記事IDからいいね数とストック数を取得する", func(t *testing.T) {
+		t.Parallel()
+		var gotAuth string
+		srv := newFakeProvider(t, responses, &gotAuth)
+		f := metrics.NewQiitaFetcher(srv.Client(), srv.URL, "secret-token")
+
+		counters, err := f.Fetch(context.Background(), repository.MetricTarget{
+			ProviderType: vo.ProviderTypeQiita,
+			Link:         ptr("https://qiita.com/someone/items/abc123"),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, usecase.Counters{Likes: 12, Stocks: 7}, counters)
+		assert.Equal(t, "Bearer secret-token", gotAuth)
+	})
+
+	t.Run("ワークスペースのアクセストークンを共通のトークンより優先する", func(t *testing.T) {
+		t.Parallel()
+		var gotAuth string
+		srv := newFakeProvider(t, responses, &gotAuth)
+		f := metrics.NewQiitaFetcher(srv.Client(), srv.URL, "shared-token", metrics.WithCredentials(credentialSource{
+			2: {vo.ProviderTypeQiita: "workspace-token"},
+		}))
+
+		_, err := f.Fetch(context.Background(), repository.MetricTarget{ExternalID: ptr("abc123"), WorkspaceID: 2})
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer workspace-token", gotAuth)
+
+		_, err = f.Fetch(context.Background(), repository.MetricTarget{ExternalID: ptr("abc123"), WorkspaceID: 3})
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer shared-token", gotAuth)
+	})
+
+	t.Run("外部IDがあればリンクより優先する", func(t *testing.T) {
+		t.Parallel()
+		srv := newFakeProvider(t, responses, nil)
+		f := metrics.NewQiitaFetcher(srv.Client(), srv.URL, "")
+
+		counters, err := f.Fetch(context.Background(), repository.MetricTarget{
+			Link:       ptr("https://qiita.com/someone/items/other"),
+			ExternalID: ptr("abc123"),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 12, counters.Likes)
+	})
+
+	t.Run("記事を指さないリンクはErrTargetNotSupported", func(t *testing.T) {
+		t.Parallel()
+		srv := newFakeProvider(t, responses, nil)
+		f := metrics.NewQiitaFetcher(srv.Client(), srv.URL, "")
+
+		_, err := f.Fetch(context.Background(), repository.MetricTarget{Link: ptr("https://qiita.com/someone")})
+
+		assert.ErrorIs(t, err, usecase.ErrTargetNotSupported)
+	})
+
+	t.Run("存在しない記事はErrTargetNotSupported", func(t *testing.T) {
+		t.Parallel()
+		srv := newFakeProvider(t, responses, nil)
+		f := metrics.NewQiitaFetcher(srv.Client(), srv.URL, "")
+
+		_, err := f.Fetch(context.Background(), repository.MetricTarget{ExternalID: ptr("missing")})
+
+		assert.ErrorIs(t, err, usecase.ErrTargetNotSupported)
+	})
+
+	t.Run("2xx以外はエラー", func(t *testing.T) {
+		t.Parallel()
+		srv := newFakeProvider(t, responses, nil)
+		f := metrics.NewQiitaFetcher(srv.Client(), srv.URL, "")
+
+		_, err := f.Fetch(context.Background(), repository.MetricTarget{ExternalID: ptr("limited")})
+
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, usecase.ErrTargetNotSupported)
+	})
+}
+
+func TestZennFetcher_Fetch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("スラッグからいいね数とブックマーク数を取得する", func(t *testing.T) {
+		t.Parallel()
+		srv := newFakeProvider(t, map[string]string{
+			"/api/articles/go-generics": `{"article":{"slug":"go-generics","liked_count":42,"bookmarked_count":9}}`,
+		}, nil)
+		f := metrics.NewZennFetcher(srv.Client(), srv.URL)
+
+		counters, err := f.Fetch(context.Background(), repository.MetricTarget{
+			ProviderType: vo.ProviderTypeZenn,
+			Link:         ptr("https://zenn.dev/someone/articles/go-generics"),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, usecase.Counters{Likes: 42, Bookmarks: 9}, counters)
+	})
+
+	t.Run("本の章など記事以外のリンクはErrTargetNotSupported", func(t *testing.T) {
+		t.Parallel()
+		srv := newFakeProvider(t, map[string]string{}, nil)
+		f := metrics.NewZennFetcher(srv.Client(), srv.URL)
+
+		_, err := f.Fetch(context.Background(), repository.MetricTarget{Link: ptr("https://zenn.dev/someone/books/intro")})
+
+		assert.ErrorIs(t, err, usecase.ErrTargetNotSupported)
+	})
+}
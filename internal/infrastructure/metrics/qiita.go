@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	usecase "github.com/umekikazuya/momenture-article-hub/internal/usecase/metrics"
+)
+
+// DefaultQiitaBaseURL はQiita API v2のベースURL
+const DefaultQiitaBaseURL = "https://qiita.com"
+
+// CredentialSource はワークスペースごとのプロバイダのアクセストークンを返す
+type CredentialSource interface {
+	Credential(ctx context.Context, workspaceID uint64, provider vo.ProviderType) (string, bool, error)
+}
+
+// QiitaFetcher はQiita API v2から記事のいいね数とストック数を取得する
+type QiitaFetcher struct {
+	client      *http.Client
+	baseURL     string
+	token       string
+	credentials CredentialSource
+}
+
+var _ usecase.Fetcher = (*QiitaFetcher)(nil)
+
+// QiitaOption はQiitaFetcherの設定を変更する
+type QiitaOption func(*QiitaFetcher)
+
+// WithCredentials は記事のワークスペースに設定されたアクセストークンをtokenより優先して使う
+func WithCredentials(source CredentialSource) QiitaOption {
+	return func(f *QiitaFetcher) {
+		f.credentials = source
+	}
+}
+
+// NewQiitaFetcher はQiitaの取得元を作成する
+// tokenを指定するとAuthorizationヘッダーを付与し、レート制限が緩和される
+func NewQiitaFetcher(client *http.Client, baseURL, token string, opts ...QiitaOption) *QiitaFetcher {
+	if baseURL == "" {
+		baseURL = DefaultQiitaBaseURL
+	}
+	f := &QiitaFetcher{client: newClient(client), baseURL: strings.TrimRight(baseURL, "/"), token: token}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+type qiitaItem struct {
+	LikesCount  int `json:"likes_count"`
+	StocksCount int `json:"stocks_count"`
+}
+
+func (f *QiitaFetcher) Fetch(ctx context.Context, target repository.MetricTarget) (usecase.Counters, error) {
+	id, err := identifier(target, "items")
+	if err != nil {
+		return usecase.Counters{}, err
+	}
+	token, err := f.tokenFor(ctx, target)
+	if err != nil {
+		return usecase.Counters{}, err
+	}
+	header := http.Header{}
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	var item qiitaItem
+	if err := getJSON(ctx, f.client, f.baseURL+"/api/v2/items/"+url.PathEscape(id), header, &item); err != nil {
+		return usecase.Counters{}, err
+	}
+	return usecase.Counters{Likes: item.LikesCount, Stocks: item.StocksCount}, nil
+}
+
+// tokenFor は記事のワークスペースのアクセストークンを返し、設定されていなければ共通のtokenを返す
+func (f *QiitaFetcher) tokenFor(ctx context.Context, target repository.MetricTarget) (string, error) {
+	if f.credentials == nil || target.WorkspaceID == 0 {
+		return f.token, nil
+	}
+	token, ok, err := f.credentials.Credential(ctx, target.WorkspaceID, vo.ProviderTypeQiita)
+	if err != nil {
+		return "", fmt.Errorf("failed to find qiita credential of workspace %d: %w", target.WorkspaceID, err)
+	}
+	if !ok {
+		return f.token, nil
+	}
+	return token, nil
+}
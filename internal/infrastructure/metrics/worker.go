@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"context"
+	"log"
+	"time"
+
+	usecase "github.com/umekikazuya/momenture-article-hub/internal/usecase/metrics"
+)
+
+// Worker は投稿先の指標を定期的に収集する
+type Worker struct {
+	uc       *usecase.MetricsUsecase
+	interval time.Duration
+}
+
+func NewWorker(uc *usecase.MetricsUsecase, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = 6 * time.Hour
+	}
+	return &Worker{uc: uc, interval: interval}
+}
+
+// Run は起動直後に1回収集し、以降はコンテキストがキャンセルされるまで間隔ごとに収集する
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		out, err := w.uc.Collect(ctx)
+		if err != nil {
+			log.Printf("metrics worker: %v", err)
+		} else if out.Failed > 0 {
+			log.Printf("metrics worker: collected=%d skipped=%d failed=%d", out.Collected, out.Skipped, out.Failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
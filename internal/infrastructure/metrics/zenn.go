@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	usecase "github.com/umekikazuya/momenture-article-hub/internal/usecase/metrics"
+)
+
+// DefaultZennBaseURL はZennのベースURL
+const DefaultZennBaseURL = "https://zenn.dev"
+
+// ZennFetcher はZennの記事APIからいいね数とブックマーク数を取得する
+type ZennFetcher struct {
+	client  *http.Client
+	baseURL string
+}
+
+var _ usecase.Fetcher = (*ZennFetcher)(nil)
+
+func NewZennFetcher(client *http.Client, baseURL string) *ZennFetcher {
+	if baseURL == "" {
+		baseURL = DefaultZennBaseURL
+	}
+	return &ZennFetcher{client: newClient(client), baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+type zennArticleResponse struct {
+	Article struct {
+		LikedCount      int `json:"liked_count"`
+		BookmarkedCount int `json:"bookmarked_count"`
+	} `json:"article"`
+}
+
+func (f *ZennFetcher) Fetch(ctx context.Context, target repository.MetricTarget) (usecase.Counters, error) {
+	slug, err := identifier(target, "articles")
+	if err != nil {
+		return usecase.Counters{}, err
+	}
+	var res zennArticleResponse
+	if err := getJSON(ctx, f.client, f.baseURL+"/api/articles/"+url.PathEscape(slug), nil, &res); err != nil {
+		return usecase.Counters{}, err
+	}
+	return usecase.Counters{Likes: res.Article.LikedCount, Bookmarks: res.Article.BookmarkedCount}, nil
+}
@@ -0,0 +1,71 @@
+// Package netguard は外部から指定されたURLへのリクエストが内部のネットワークに届かないようにする
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrNonPublicAddress は接続先が公開アドレスでない場合に返される
+var ErrNonPublicAddress = errors.New("connection to non-public address is not allowed")
+
+// nonPublicPrefixes は標準ライブラリの判定に含まれない、公開されていないアドレスの範囲
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // このネットワーク
+	netip.MustParsePrefix("100.64.0.0/10"),   // キャリアグレードNAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETFプロトコル割り当て
+	netip.MustParsePrefix("192.0.2.0/24"),    // ドキュメント用
+	netip.MustParsePrefix("198.18.0.0/15"),   // ベンチマーク用
+	netip.MustParsePrefix("198.51.100.0/24"), // ドキュメント用
+	netip.MustParsePrefix("203.0.113.0/24"),  // ドキュメント用
+	netip.MustParsePrefix("240.0.0.0/4"),     // 予約済み
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64(内部のIPv4アドレスを埋め込める)
+	netip.MustParsePrefix("2002::/16"),       // 6to4(内部のIPv4アドレスを埋め込める)
+	netip.MustParsePrefix("2001:db8::/32"),   // ドキュメント用
+}
+
+// IsPublic はアドレスがインターネット上の公開アドレスかを返す
+// ループバック、プライベート、リンクローカル(クラウドのメタデータサービスを含む)、マルチキャストなどは公開アドレスではない
+func IsPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// Control は接続先が公開アドレスでない場合に接続を拒否するnet.DialerのControl
+// 名前解決の後のアドレスで判定するため、内部のアドレスを返すホスト名やリダイレクト先にも適用される
+func Control(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, address)
+	}
+	if !IsPublic(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+// NewClient は公開アドレスにだけ接続するHTTPクライアントを作成する
+// プロキシを経由すると接続先を判定できないため、環境変数のプロキシ設定は使わない
+func NewClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   Control,
+	}).DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
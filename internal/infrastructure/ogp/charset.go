@@ -0,0 +1,56 @@
+package ogp
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// decode はContent-Type、BOM、metaタグの順に文字コードを判定してUTF-8に変換する
+// いずれにも宣言がない場合はUTF-8、EUC-JP、Shift_JISの順に不正なバイト列なく読めるものを選ぶ
+func decode(body []byte, contentType string) ([]byte, error) {
+	enc, name, certain := charset.DetermineEncoding(body, contentType)
+	// 宣言がなく判定できなかった場合の既定値はwindows-1252になる
+	if !certain && name == "windows-1252" {
+		enc = guessEncoding(body)
+	}
+	if enc == encoding.Nop {
+		return body, nil
+	}
+	return io.ReadAll(transform.NewReader(bytes.NewReader(body), enc.NewDecoder()))
+}
+
+// guessEncoding は宣言のない本文の文字コードを推測する
+// EUC-JPのバイト列はShift_JISとしても(半角カナとして)読めてしまうため、EUC-JPを先に試す
+func guessEncoding(body []byte) encoding.Encoding {
+	// 上限サイズで切り詰めた末尾の不完全な文字は判定から除く
+	body = trimPartialRune(body)
+	if utf8.Valid(body) {
+		return encoding.Nop
+	}
+	for _, enc := range []encoding.Encoding{japanese.EUCJP, japanese.ShiftJIS} {
+		if decodesCleanly(body, enc) {
+			return enc
+		}
+	}
+	enc, _ := charset.Lookup("windows-1252")
+	return enc
+}
+
+func decodesCleanly(body []byte, enc encoding.Encoding) bool {
+	decoded, err := enc.NewDecoder().Bytes(body)
+	return err == nil && !strings.ContainsRune(string(decoded), utf8.RuneError)
+}
+
+func trimPartialRune(body []byte) []byte {
+	for i := 0; i < 3 && len(body) > 0 && body[len(body)-1] >= 0x80; i++ {
+		body = body[:len(body)-1]
+	}
+	return body
+}
@@ -0,0 +1,108 @@
+package ogp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/netguard"
+	usecase "github.com/umekikazuya/momenture-article-hub/internal/usecase/linkpreview"
+)
+
+const (
+	DefaultUserAgent = "momenture-article-hub-ogp"
+	// OGPはheadにあるため、本文は先頭だけ読めば足りる
+	defaultMaxBytes = 512 << 10
+)
+
+// HTTPFetcher はリンク先のHTMLを取得してOGPを取り出すusecase.Fetcher
+// 応答のサイズはmaxBytesまでに制限し、時間の制限はHTTPクライアントのタイムアウトに従う
+// リンクは利用者が指定するため、内部のネットワークに届かないnetguard.NewClientのクライアントを使う
+type HTTPFetcher struct {
+	client    *http.Client
+	userAgent string
+	maxBytes  int64
+}
+
+var _ usecase.Fetcher = (*HTTPFetcher)(nil)
+
+// Option はHTTPFetcherの設定
+type Option func(*HTTPFetcher)
+
+func WithUserAgent(userAgent string) Option {
+	return func(f *HTTPFetcher) {
+		if userAgent != "" {
+			f.userAgent = userAgent
+		}
+	}
+}
+
+// WithMaxBytes は読み込む応答の最大サイズを設定する
+func WithMaxBytes(n int64) Option {
+	return func(f *HTTPFetcher) {
+		if n > 0 {
+			f.maxBytes = n
+		}
+	}
+}
+
+// NewHTTPFetcher はHTTPFetcherを作成する
+// clientがnilの場合は公開アドレスにだけ接続するクライアントを使う
+func NewHTTPFetcher(client *http.Client, opts ...Option) *HTTPFetcher {
+	if client == nil {
+		client = netguard.NewClient(5 * time.Second)
+	}
+	f := &HTTPFetcher{client: client, userAgent: DefaultUserAgent, maxBytes: defaultMaxBytes}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, rawURL string) (entity.OpenGraph, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return entity.OpenGraph{}, fmt.Errorf("unsupported url: %q", rawURL)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return entity.OpenGraph{}, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return entity.OpenGraph{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return entity.OpenGraph{}, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); contentType != "" && (err != nil || (mediaType != "text/html" && mediaType != "application/xhtml+xml")) {
+		return entity.OpenGraph{}, fmt.Errorf("not an html page: %q", contentType)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBytes))
+	if err != nil {
+		return entity.OpenGraph{}, fmt.Errorf("failed to read response: %w", err)
+	}
+	decoded, err := decode(body, contentType)
+	if err != nil {
+		return entity.OpenGraph{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// リダイレクトされた場合は最終的なURLを相対URLの基準にする
+	og := parse(decoded, resp.Request.URL)
+	if og.IsEmpty() {
+		return entity.OpenGraph{}, fmt.Errorf("no open graph data found")
+	}
+	return og, nil
+}
@@ -0,0 +1,132 @@
+package ogp_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/netguard"
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/ogp"
+)
+
+// newFixtureSite はtestdataのHTMLをContent-Type: text/html(charsetなし)で返すローカルサーバーを起動する
+func newFixtureSite(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pages/{name}", func(w http.ResponseWriter, r *http.Request) {
+		body, err := os.ReadFile(filepath.Join("testdata", r.PathValue("name")+".html"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write(body)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/pages/shift_jis", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<html><head><!-- " + strings.Repeat("a", 4096) + " -->" +
+			`<meta property="og:title" content="上限より後ろ"></head></html>`))
+	})
+	mux.HandleFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("\x89PNG"))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHTTPFetcher_Fetch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	srv := newFixtureSite(t)
+	fetcher := ogp.NewHTTPFetcher(srv.Client())
+
+	t.Run("metaタグで宣言されたShift_JISのページを読み、相対URLの画像を絶対URLにする", func(t *testing.T) {
+		t.Parallel()
+		og, err := fetcher.Fetch(ctx, srv.URL+"/pages/shift_jis")
+		require.NoError(t, err)
+		assert.Equal(t, entity.OpenGraph{
+			Title:       "日本語の記事タイトル",
+			Description: "Shift_JISで書かれたページの説明文です。",
+			Image:       srv.URL + "/images/ogp.png",
+			SiteName:    "サンプルサイト",
+		}, og)
+	})
+
+	t.Run("文字コードの宣言がないEUC-JPのページを推測して読み、説明文をdescriptionで補う", func(t *testing.T) {
+		t.Parallel()
+		og, err := fetcher.Fetch(ctx, srv.URL+"/pages/euc_jp_undeclared")
+		require.NoError(t, err)
+		assert.Equal(t, entity.OpenGraph{
+			Title:       "EUC-JPで書かれた記事タイトル",
+			Description: "宣言がなくても文字コードを推測して読み込みます。",
+			SiteName:    "旧サイト",
+		}, og)
+	})
+
+	t.Run("OGPがない項目はtwitter:*とtitleタグで補い、bodyのmetaタグは読まない", func(t *testing.T) {
+		t.Parallel()
+		og, err := fetcher.Fetch(ctx, srv.URL+"/pages/fallback")
+		require.NoError(t, err)
+		assert.Equal(t, entity.OpenGraph{
+			Title:       "Twitterカードのタイトル",
+			Description: "metaタグの説明文",
+			Image:       "https://cdn.example.com/card.png",
+		}, og)
+	})
+
+	t.Run("リダイレクト先のページのOGPを取得する", func(t *testing.T) {
+		t.Parallel()
+		og, err := fetcher.Fetch(ctx, srv.URL+"/moved")
+		require.NoError(t, err)
+		assert.Equal(t, "日本語の記事タイトル", og.Title)
+		assert.Equal(t, srv.URL+"/images/ogp.png", og.Image)
+	})
+
+	t.Run("上限サイズより後ろは読まない", func(t *testing.T) {
+		t.Parallel()
+		limited := ogp.NewHTTPFetcher(srv.Client(), ogp.WithMaxBytes(1024))
+		_, err := limited.Fetch(ctx, srv.URL+"/large")
+		assert.ErrorContains(t, err, "no open graph data found")
+
+		og, err := fetcher.Fetch(ctx, srv.URL+"/large")
+		require.NoError(t, err)
+		assert.Equal(t, "上限より後ろ", og.Title)
+	})
+
+	t.Run("取得できない場合はエラーを返す", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			url  string
+			want string
+		}{
+			{"OGPがない", srv.URL + "/pages/no_ogp", "no open graph data found"},
+			{"HTMLではない", srv.URL + "/image", "not an html page"},
+			{"404", srv.URL + "/pages/missing", "unexpected status: 404"},
+			{"HTTP(S)ではない", "ftp://example.com/", "unsupported url"},
+		}
+		for _, tt := range tests {
+			_, err := fetcher.Fetch(ctx, tt.url)
+			assert.ErrorContains(t, err, tt.want, tt.name)
+		}
+	})
+
+	t.Run("既定のクライアントは内部のネットワークのページを取得しない", func(t *testing.T) {
+		t.Parallel()
+		_, err := ogp.NewHTTPFetcher(nil).Fetch(ctx, srv.URL+"/pages/shift_jis")
+		assert.ErrorIs(t, err, netguard.ErrNonPublicAddress)
+	})
+}
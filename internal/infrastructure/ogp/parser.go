@@ -0,0 +1,133 @@
+package ogp
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+)
+
+// parse はHTMLのheadからOGPを取り出す
+// OGPがない項目はtwitter:*、<title>、<meta name="description">の順に補う
+// 相対URLの画像はページのURLを基準に絶対URLにする
+func parse(body []byte, pageURL *url.URL) entity.OpenGraph {
+	// fallbackはtwitter:*、documentは<title>と<meta name="description">の値
+	var og, fallback, document entity.OpenGraph
+	z := xhtml.NewTokenizer(bytes.NewReader(body))
+	inTitle := false
+
+	for {
+		switch z.Next() {
+		case xhtml.ErrorToken:
+			return complete(og, fallback, document, pageURL)
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Body:
+				return complete(og, fallback, document, pageURL)
+			case atom.Title:
+				inTitle = true
+			case atom.Meta:
+				if hasAttr {
+					applyMeta(&og, &fallback, &document, metaAttrs(z))
+				}
+			}
+		case xhtml.EndTagToken:
+			name, _ := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Head:
+				return complete(og, fallback, document, pageURL)
+			case atom.Title:
+				inTitle = false
+			}
+		case xhtml.TextToken:
+			if inTitle && document.Title == "" {
+				document.Title = normalizeSpace(string(z.Text()))
+			}
+		}
+	}
+}
+
+func metaAttrs(z *xhtml.Tokenizer) map[string]string {
+	attrs := map[string]string{}
+	for {
+		key, val, more := z.TagAttr()
+		attrs[string(key)] = string(val)
+		if !more {
+			return attrs
+		}
+	}
+}
+
+// applyMeta はmetaタグの値を該当する項目に設定する(最初に現れた値を優先する)
+func applyMeta(og, fallback, document *entity.OpenGraph, attrs map[string]string) {
+	key := strings.ToLower(attrs["property"])
+	if key == "" {
+		key = strings.ToLower(attrs["name"])
+	}
+	value := normalizeSpace(attrs["content"])
+	if value == "" {
+		return
+	}
+
+	var field *string
+	switch key {
+	case "og:title":
+		field = &og.Title
+	case "og:description":
+		field = &og.Description
+	case "og:image", "og:image:url", "og:image:secure_url":
+		field = &og.Image
+	case "og:site_name":
+		field = &og.SiteName
+	case "twitter:title":
+		field = &fallback.Title
+	case "twitter:description":
+		field = &fallback.Description
+	case "description":
+		field = &document.Description
+	case "twitter:image", "twitter:image:src":
+		field = &fallback.Image
+	default:
+		return
+	}
+	if *field == "" {
+		*field = value
+	}
+}
+
+func complete(og, fallback, document entity.OpenGraph, pageURL *url.URL) entity.OpenGraph {
+	og.Title = firstNonEmpty(og.Title, fallback.Title, document.Title)
+	og.Description = firstNonEmpty(og.Description, fallback.Description, document.Description)
+	og.Image = firstNonEmpty(og.Image, fallback.Image)
+	if og.Image != "" {
+		og.Image = resolveURL(pageURL, og.Image)
+	}
+	return og
+}
+
+// resolveURL はHTTP(S)の絶対URLに解決できない画像を空にする
+func resolveURL(base *url.URL, ref string) string {
+	u, err := base.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return u.String()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
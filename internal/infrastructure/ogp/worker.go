@@ -0,0 +1,43 @@
+package ogp
+
+import (
+	"context"
+	"log"
+	"time"
+
+	usecase "github.com/umekikazuya/momenture-article-hub/internal/usecase/linkpreview"
+)
+
+// Worker は記事のリンク先のOGPを定期的に取得し直す
+type Worker struct {
+	uc       *usecase.LinkPreviewUsecase
+	interval time.Duration
+}
+
+func NewWorker(uc *usecase.LinkPreviewUsecase, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &Worker{uc: uc, interval: interval}
+}
+
+// Run は起動直後に1回取得し、以降はコンテキストがキャンセルされるまで間隔ごとに取得する
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		out, err := w.uc.Refresh(ctx)
+		if err != nil {
+			log.Printf("ogp worker: %v", err)
+		} else if out.Failed > 0 {
+			log.Printf("ogp worker: fetched=%d failed=%d", out.Fetched, out.Failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
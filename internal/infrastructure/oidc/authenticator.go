@@ -0,0 +1,148 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+// Authenticator は検証済みのトークンのクレームから呼び出し元を組み立てる
+// rolesClaimのロールをroleScopesでスコープに対応付け、対応付けのないロールはスコープを与えない
+type Authenticator struct {
+	verifier       *Verifier
+	rolesClaim     string
+	roleScopes     map[string]vo.Scope
+	workspaceClaim string
+	workspaces     WorkspaceResolver
+}
+
+// WorkspaceResolver はスラッグからワークスペースのIDを解決する
+type WorkspaceResolver interface {
+	ResolveWorkspace(ctx context.Context, slug string) (uint64, error)
+}
+
+// AuthenticatorOption はAuthenticatorの設定を変更する
+type AuthenticatorOption func(*Authenticator)
+
+// WithWorkspaceClaim はclaimのワークスペースのスラッグで呼び出し元をワークスペースに紐づける
+// クレームがないトークンや未知のワークスペースのトークンは受け付けない
+// 指定しない場合、呼び出し元はリクエストが指定したワークスペースで処理される
+func WithWorkspaceClaim(claim string, resolver WorkspaceResolver) AuthenticatorOption {
+	return func(a *Authenticator) {
+		a.workspaceClaim = claim
+		a.workspaces = resolver
+	}
+}
+
+func NewAuthenticator(verifier *Verifier, rolesClaim string, roleScopes map[string]vo.Scope, opts ...AuthenticatorOption) *Authenticator {
+	a := &Authenticator{verifier: verifier, rolesClaim: rolesClaim, roleScopes: roleScopes}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (*auth.Principal, error) {
+	claims, err := a.verifier.Verify(ctx, token)
+	if errors.Is(err, ErrInvalidToken) {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrUnauthenticated, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	roles := stringsAt(claims.Raw, a.rolesClaim)
+	var scopes []vo.Scope
+	for _, role := range roles {
+		if s, ok := a.roleScopes[role]; ok {
+			scopes = append(scopes, s)
+		}
+	}
+	workspaceID, err := a.workspaceOf(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.Principal{
+		Subject:     claims.Subject,
+		Name:        displayName(claims),
+		Roles:       roles,
+		Scopes:      scopes,
+		WorkspaceID: workspaceID,
+	}, nil
+}
+
+// workspaceOf はトークンが紐づくワークスペースを返す(クレームを使わない場合は0)
+func (a *Authenticator) workspaceOf(ctx context.Context, claims *Claims) (uint64, error) {
+	if a.workspaceClaim == "" {
+		return 0, nil
+	}
+	slugs := stringsAt(claims.Raw, a.workspaceClaim)
+	if len(slugs) != 1 {
+		return 0, fmt.Errorf("%w: token must have exactly one %s claim", apperr.ErrUnauthenticated, a.workspaceClaim)
+	}
+	id, err := a.workspaces.ResolveWorkspace(ctx, slugs[0])
+	if errors.Is(err, repository.ErrNotFound) {
+		return 0, fmt.Errorf("%w: %v", apperr.ErrUnauthenticated, err)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// ParseRoleScopes は"role=scope"形式の対応付けを読み込む
+func ParseRoleScopes(entries []string) (map[string]vo.Scope, error) {
+	m := make(map[string]vo.Scope, len(entries))
+	for _, e := range entries {
+		role, scope, ok := strings.Cut(strings.TrimSpace(e), "=")
+		if !ok || role == "" {
+			return nil, fmt.Errorf("invalid role scope mapping: %q", e)
+		}
+		s, err := vo.NewScope(scope)
+		if err != nil {
+			return nil, fmt.Errorf("invalid role scope mapping %q: %w", e, err)
+		}
+		m[role] = s
+	}
+	return m, nil
+}
+
+// stringsAt は"realm_access.roles"のようなドット区切りのパスにある文字列または文字列の配列を返す
+func stringsAt(raw map[string]any, path string) []string {
+	var v any = raw
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	switch x := v.(type) {
+	case string:
+		return strings.Fields(x)
+	case []any:
+		values := make([]string, 0, len(x))
+		for _, e := range x {
+			if s, ok := e.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func displayName(c *Claims) string {
+	for _, key := range []string{"name", "preferred_username", "email"} {
+		if s, ok := c.Raw[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return c.Subject
+}
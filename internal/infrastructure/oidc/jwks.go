@@ -0,0 +1,252 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// JWKSの応答の最大サイズ
+const maxJWKSBytes = 1 << 20
+
+// errUnknownKey はJWKSにkidの鍵が見つからない場合に返される
+var errUnknownKey = errors.New("unknown signing key")
+
+// jwk はJWKSに含まれる鍵(RSAとP-256のECだけを扱う)
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+// KeySet はファイルまたはURLから読み込んだJWKSをキャッシュする
+// キャッシュはrefreshIntervalごとに読み込み直し、未知のkidのトークンを受けた場合も
+// 読み込み直すことで鍵のローテーションに追従する
+// 読み込みは成否にかかわらずminRefreshIntervalより短い間隔では行わない
+type KeySet struct {
+	load               func(ctx context.Context) ([]byte, error)
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+	now                func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	loadedAt    time.Time
+	attemptedAt time.Time
+}
+
+// KeySetOption はKeySetの設定を変更する
+type KeySetOption func(*KeySet)
+
+// WithRefreshInterval はキャッシュを読み込み直す間隔を指定する
+func WithRefreshInterval(d time.Duration) KeySetOption {
+	return func(s *KeySet) {
+		if d > 0 {
+			s.refreshInterval = d
+		}
+	}
+}
+
+// WithKeySetClock はキャッシュの期限の判定に使う時計を差し替える
+func WithKeySetClock(now func() time.Time) KeySetOption {
+	return func(s *KeySet) {
+		s.now = now
+	}
+}
+
+// NewFileKeySet はファイルからJWKSを読み込むKeySetを作成する
+func NewFileKeySet(path string, opts ...KeySetOption) *KeySet {
+	return newKeySet(func(context.Context) ([]byte, error) {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwks file: %w", err)
+		}
+		return b, nil
+	}, opts...)
+}
+
+// NewURLKeySet はURLからJWKSを取得するKeySetを作成する
+func NewURLKeySet(client *http.Client, url string, opts ...KeySetOption) *KeySet {
+	return newKeySet(func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create jwks request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch jwks: unexpected status: %d", resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, maxJWKSBytes))
+	}, opts...)
+}
+
+func newKeySet(load func(ctx context.Context) ([]byte, error), opts ...KeySetOption) *KeySet {
+	s := &KeySet{
+		load:               load,
+		refreshInterval:    time.Hour,
+		minRefreshInterval: 30 * time.Second,
+		now:                time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Key はkidの公開鍵を返す
+func (s *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.keys == nil || s.now().Sub(s.loadedAt) >= s.refreshInterval {
+		// 読み込みに失敗しても、読み込み済みの鍵があれば使い続ける
+		if err := s.reload(ctx); err != nil && s.keys == nil {
+			return nil, err
+		}
+	}
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+	// 未知のkidはローテーション直後の可能性があるため読み込み直す
+	if err := s.reload(ctx); err != nil {
+		return nil, err
+	}
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: kid %q", errUnknownKey, kid)
+}
+
+// reload はJWKSを読み込み直す
+// 直前の読み込みからminRefreshIntervalが経っていない場合は何もしない
+func (s *KeySet) reload(ctx context.Context) error {
+	now := s.now()
+	if !s.attemptedAt.IsZero() && now.Sub(s.attemptedAt) < s.minRefreshInterval {
+		if s.keys == nil {
+			return fmt.Errorf("jwks is not loaded yet")
+		}
+		return nil
+	}
+	s.attemptedAt = now
+	keys, err := s.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	s.keys = keys
+	s.loadedAt = now
+	return nil
+}
+
+func (s *KeySet) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	b, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var set jwks
+	if err := json.Unmarshal(b, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse jwks: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwk %q: %w", k.Kid, err)
+		}
+		if key != nil {
+			keys[k.Kid] = key
+		}
+	}
+	return keys, nil
+}
+
+// publicKey はJWKを公開鍵に変換する
+// 扱わない種類の鍵はnilを返して読み飛ばす
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid exponent")
+		}
+		if n.BitLen() < 2048 {
+			return nil, fmt.Errorf("rsa key must be at least 2048 bits")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, nil
+		}
+		x, err := decodeFixed(k.X, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x: %w", err)
+		}
+		y, err := decodeFixed(k.Y, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y: %w", err)
+		}
+		// 曲線上の点であることを検証する
+		if _, err := ecdh.P256().NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+			return nil, fmt.Errorf("invalid ec point: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func decodeFixed(s string, size int) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != size {
+		return nil, fmt.Errorf("must be %d bytes", size)
+	}
+	return b, nil
+}
@@ -0,0 +1,222 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// 受け付ける署名アルゴリズム
+const (
+	algRS256 = "RS256"
+	algES256 = "ES256"
+)
+
+// ErrInvalidToken はトークンの形式、署名、クレームのいずれかが不正な場合に返される
+var ErrInvalidToken = errors.New("invalid token")
+
+// KeyProvider はkidの公開鍵を返す
+type KeyProvider interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+// Claims は検証済みのトークンのクレーム
+type Claims struct {
+	Subject string
+	// Raw はペイロードの全てのクレーム
+	Raw map[string]any
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+// Verifier はRS256/ES256で署名されたJWTを検証する
+type Verifier struct {
+	keys     KeyProvider
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+// VerifierOption はVerifierの設定を変更する
+type VerifierOption func(*Verifier)
+
+// WithLeeway はexp / nbf / iatの判定で許容する時刻のずれを指定する
+func WithLeeway(d time.Duration) VerifierOption {
+	return func(v *Verifier) {
+		if d >= 0 {
+			v.leeway = d
+		}
+	}
+}
+
+// WithClock は有効期限の判定に使う時計を差し替える
+func WithClock(now func() time.Time) VerifierOption {
+	return func(v *Verifier) {
+		v.now = now
+	}
+}
+
+func NewVerifier(keys KeyProvider, issuer, audience string, opts ...VerifierOption) *Verifier {
+	v := &Verifier{
+		keys:     keys,
+		issuer:   issuer,
+		audience: audience,
+		leeway:   time.Minute,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Verify は署名とiss / aud / exp / nbf / iatを検証し、クレームを返す
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed jwt", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: invalid header: %v", ErrInvalidToken, err)
+	}
+	if h.Alg != algRS256 && h.Alg != algES256 {
+		return nil, fmt.Errorf("%w: unsupported alg %q", ErrInvalidToken, h.Alg)
+	}
+	key, err := v.keys.Key(ctx, h.Kid)
+	if errors.Is(err, errUnknownKey) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid signature encoding", ErrInvalidToken)
+	}
+	if err := verifySignature(h.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	var raw map[string]any
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, fmt.Errorf("%w: invalid payload: %v", ErrInvalidToken, err)
+	}
+	if err := v.validateClaims(raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	sub, _ := raw["sub"].(string)
+	return &Claims{Subject: sub, Raw: raw}, nil
+}
+
+func (v *Verifier) validateClaims(raw map[string]any) error {
+	if iss, _ := raw["iss"].(string); iss != v.issuer {
+		return fmt.Errorf("unexpected issuer %q", iss)
+	}
+	if !containsAudience(raw["aud"], v.audience) {
+		return fmt.Errorf("audience does not include %q", v.audience)
+	}
+
+	now := v.now()
+	exp, ok := numericDate(raw["exp"])
+	if !ok {
+		return fmt.Errorf("exp is required")
+	}
+	if !now.Before(exp.Add(v.leeway)) {
+		return fmt.Errorf("token has expired")
+	}
+	if nbf, ok := numericDate(raw["nbf"]); ok && now.Add(v.leeway).Before(nbf) {
+		return fmt.Errorf("token is not valid yet")
+	}
+	if iat, ok := numericDate(raw["iat"]); ok && now.Add(v.leeway).Before(iat) {
+		return fmt.Errorf("token is issued in the future")
+	}
+	return nil
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, sig []byte) error {
+	digest := sha256.Sum256([]byte(signingInput))
+	switch alg {
+	case algRS256:
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key type does not match alg %s", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+			return fmt.Errorf("signature verification failed")
+		}
+		return nil
+	case algES256:
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key type does not match alg %s", alg)
+		}
+		// JWSのES256の署名はDERではなくRとSを32バイトずつ連結したもの
+		if len(sig) != 64 {
+			return fmt.Errorf("signature verification failed")
+		}
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(pub, digest[:], r, s) {
+			return fmt.Errorf("signature verification failed")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported alg %q", alg)
+	}
+}
+
+func decodeSegment(seg string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// containsAudience はaudが文字列または配列で、audienceを含むかを判定する
+func containsAudience(aud any, audience string) bool {
+	switch a := aud.(type) {
+	case string:
+		return a == audience
+	case []any:
+		for _, v := range a {
+			if s, ok := v.(string); ok && s == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// numericDate はNumericDate(UNIX秒)のクレームを時刻に変換する
+func numericDate(v any) (time.Time, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*1e9)), true
+}
@@ -0,0 +1,322 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/oidc"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+)
+
+const (
+	testIssuer   = "https://idp.example.com/"
+	testAudience = "article-hub"
+)
+
+var testNow = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// testKey はテスト用に生成した署名鍵
+type testKey struct {
+	kid string
+	alg string
+	key crypto.Signer
+}
+
+func newRSAKey(t *testing.T, kid string) testKey {
+	t.Helper()
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return testKey{kid: kid, alg: "RS256", key: k}
+}
+
+func newECKey(t *testing.T, kid string) testKey {
+	t.Helper()
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return testKey{kid: kid, alg: "ES256", key: k}
+}
+
+func (k testKey) jwk() map[string]string {
+	enc := base64.RawURLEncoding.EncodeToString
+	switch pub := k.key.Public().(type) {
+	case *rsa.PublicKey:
+		return map[string]string{"kty": "RSA", "kid": k.kid, "use": "sig", "n": enc(pub.N.Bytes()), "e": enc(big.NewInt(int64(pub.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		return map[string]string{"kty": "EC", "kid": k.kid, "crv": "P-256", "x": enc(pub.X.FillBytes(make([]byte, 32))), "y": enc(pub.Y.FillBytes(make([]byte, 32)))}
+	}
+	panic("unsupported key")
+}
+
+func jwksJSON(t *testing.T, keys ...testKey) []byte {
+	t.Helper()
+	set := map[string][]map[string]string{"keys": {}}
+	for _, k := range keys {
+		set["keys"] = append(set["keys"], k.jwk())
+	}
+	b, err := json.Marshal(set)
+	require.NoError(t, err)
+	return b
+}
+
+// sign はヘッダのalgとkidを指定してトークンに署名する
+func (k testKey) sign(t *testing.T, alg string, claims map[string]any) string {
+	t.Helper()
+	enc := base64.RawURLEncoding.EncodeToString
+	h, err := json.Marshal(map[string]string{"alg": alg, "kid": k.kid, "typ": "JWT"})
+	require.NoError(t, err)
+	p, err := json.Marshal(claims)
+	require.NoError(t, err)
+	input := enc(h) + "." + enc(p)
+	digest := sha256.Sum256([]byte(input))
+
+	var sig []byte
+	switch key := k.key.(type) {
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		require.NoError(t, err)
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return input + "." + enc(sig)
+}
+
+func validClaims(overrides map[string]any) map[string]any {
+	claims := map[string]any{
+		"iss":   testIssuer,
+		"aud":   testAudience,
+		"sub":   "user-1",
+		"name":  "山田 太郎",
+		"iat":   testNow.Add(-time.Minute).Unix(),
+		"exp":   testNow.Add(2 * time.Hour).Unix(),
+		"roles": []string{"editor"},
+	}
+	for k, v := range overrides {
+		if v == nil {
+			delete(claims, k)
+			continue
+		}
+		claims[k] = v
+	}
+	return claims
+}
+
+func writeJWKSFile(t *testing.T, keys ...testKey) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwksJSON(t, keys...), 0o600))
+	return path
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	rsaKey := newRSAKey(t, "rsa-1")
+	ecKey := newECKey(t, "ec-1")
+	keys := oidc.NewFileKeySet(writeJWKSFile(t, rsaKey, ecKey))
+	verifier := oidc.NewVerifier(keys, testIssuer, testAudience, oidc.WithClock(func() time.Time { return testNow }))
+
+	t.Run("RS256とES256で署名されたトークンを受け入れる", func(t *testing.T) {
+		t.Parallel()
+		for _, k := range []testKey{rsaKey, ecKey} {
+			claims, err := verifier.Verify(ctx, k.sign(t, k.alg, validClaims(nil)))
+			require.NoError(t, err, k.alg)
+			assert.Equal(t, "user-1", claims.Subject)
+		}
+	})
+
+	t.Run("audは配列でもよい", func(t *testing.T) {
+		t.Parallel()
+		_, err := verifier.Verify(ctx, rsaKey.sign(t, "RS256", validClaims(map[string]any{"aud": []string{"other", testAudience}})))
+		assert.NoError(t, err)
+	})
+
+	t.Run("許容するずれの範囲内なら期限切れ直後でも受け入れる", func(t *testing.T) {
+		t.Parallel()
+		_, err := verifier.Verify(ctx, rsaKey.sign(t, "RS256", validClaims(map[string]any{"exp": testNow.Add(-30 * time.Second).Unix()})))
+		assert.NoError(t, err)
+	})
+
+	t.Run("不正なトークンはErrInvalidToken", func(t *testing.T) {
+		t.Parallel()
+		other := newRSAKey(t, "rsa-1")
+		valid := rsaKey.sign(t, "RS256", validClaims(nil))
+		parts := strings.Split(valid, ".")
+		tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"`+testIssuer+`","aud":"`+testAudience+`","sub":"admin","exp":9999999999}`)) + "." + parts[2]
+
+		tests := []struct {
+			name  string
+			token string
+			want  string
+		}{
+			{"発行者が異なる", rsaKey.sign(t, "RS256", validClaims(map[string]any{"iss": "https://evil.example.com/"})), "unexpected issuer"},
+			{"対象者が異なる", rsaKey.sign(t, "RS256", validClaims(map[string]any{"aud": "other"})), "audience"},
+			{"有効期限切れ", rsaKey.sign(t, "RS256", validClaims(map[string]any{"exp": testNow.Add(-2 * time.Minute).Unix()})), "expired"},
+			{"expがない", rsaKey.sign(t, "RS256", validClaims(map[string]any{"exp": nil})), "exp is required"},
+			{"有効期間の開始前", rsaKey.sign(t, "RS256", validClaims(map[string]any{"nbf": testNow.Add(5 * time.Minute).Unix()})), "not valid yet"},
+			{"別の鍵で署名", other.sign(t, "RS256", validClaims(nil)), "signature verification failed"},
+			{"ペイロードの改ざん", tampered, "signature verification failed"},
+			{"algと鍵の種類が一致しない", ecKey.sign(t, "RS256", validClaims(nil)), "key type does not match"},
+			{"未知のkid", newECKey(t, "unknown").sign(t, "ES256", validClaims(nil)), "unknown signing key"},
+			{"alg=none", base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + parts[1] + ".", "unsupported alg"},
+			{"JWTではない", "not-a-jwt", "malformed"},
+		}
+		for _, tt := range tests {
+			_, err := verifier.Verify(ctx, tt.token)
+			assert.ErrorIs(t, err, oidc.ErrInvalidToken, tt.name)
+			assert.ErrorContains(t, err, tt.want, tt.name)
+		}
+	})
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	oldKey := newRSAKey(t, "old")
+	newKey := newECKey(t, "new")
+
+	var mu sync.Mutex
+	served := jwksJSON(t, oldKey)
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		_, _ = w.Write(served)
+	}))
+	t.Cleanup(srv.Close)
+	rotate := func(keys ...testKey) {
+		mu.Lock()
+		defer mu.Unlock()
+		served = jwksJSON(t, keys...)
+	}
+	count := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+
+	now := testNow
+	keys := oidc.NewURLKeySet(srv.Client(), srv.URL, oidc.WithRefreshInterval(time.Hour), oidc.WithKeySetClock(func() time.Time { return now }))
+	verifier := oidc.NewVerifier(keys, testIssuer, testAudience, oidc.WithClock(func() time.Time { return now }))
+	verify := func(k testKey) error {
+		_, err := verifier.Verify(ctx, k.sign(t, k.alg, validClaims(nil)))
+		return err
+	}
+
+	require.NoError(t, verify(oldKey))
+	require.NoError(t, verify(oldKey))
+	assert.Equal(t, 1, count(), "キャッシュした鍵を使う")
+
+	// 鍵がローテーションされた直後は未知のkidでも短い間隔では取得し直さない
+	rotate(oldKey, newKey)
+	assert.ErrorContains(t, verify(newKey), "unknown signing key")
+	assert.Equal(t, 1, count())
+
+	// 最小間隔を過ぎれば未知のkidを受けた時点で取得し直す
+	now = now.Add(time.Minute)
+	require.NoError(t, verify(newKey))
+	assert.Equal(t, 2, count())
+
+	// 古い鍵が取り除かれても、次の定期的な読み込みまではキャッシュの鍵で検証する
+	rotate(newKey)
+	require.NoError(t, verify(oldKey))
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, verify(oldKey))
+	now = now.Add(30 * time.Minute)
+	assert.ErrorContains(t, verify(oldKey), "unknown signing key")
+	assert.Equal(t, 3, count())
+}
+
+// stubWorkspaceResolver はスラッグごとに決められたワークスペースのIDを返す
+type stubWorkspaceResolver map[string]uint64
+
+func (s stubWorkspaceResolver) ResolveWorkspace(_ context.Context, slug string) (uint64, error) {
+	id, ok := s[slug]
+	if !ok {
+		return 0, fmt.Errorf("workspace %q: %w", slug, repository.ErrWorkspaceNotFound)
+	}
+	return id, nil
+}
+
+func TestAuthenticator(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	key := newRSAKey(t, "rsa-1")
+	verifier := oidc.NewVerifier(oidc.NewFileKeySet(writeJWKSFile(t, key)), testIssuer, testAudience, oidc.WithClock(func() time.Time { return testNow }))
+	roleScopes, err := oidc.ParseRoleScopes([]string{"admin=articles:admin", "editor=articles:write", "viewer=articles:read"})
+	require.NoError(t, err)
+
+	t.Run("ロールをスコープに対応付ける", func(t *testing.T) {
+		t.Parallel()
+		a := oidc.NewAuthenticator(verifier, "roles", roleScopes)
+		p, err := a.Authenticate(ctx, key.sign(t, "RS256", validClaims(map[string]any{"roles": []string{"editor", "unknown"}})))
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", p.Subject)
+		assert.Equal(t, "山田 太郎", p.Name)
+		assert.Equal(t, []string{"editor", "unknown"}, p.Roles)
+		assert.Equal(t, []vo.Scope{vo.ScopeArticlesWrite}, p.Scopes)
+	})
+
+	t.Run("入れ子のクレームからロールを読む", func(t *testing.T) {
+		t.Parallel()
+		a := oidc.NewAuthenticator(verifier, "realm_access.roles", roleScopes)
+		p, err := a.Authenticate(ctx, key.sign(t, "RS256", validClaims(map[string]any{
+			"roles":        nil,
+			"realm_access": map[string]any{"roles": []string{"admin"}},
+		})))
+		require.NoError(t, err)
+		assert.Equal(t, []vo.Scope{vo.ScopeArticlesAdmin}, p.Scopes)
+	})
+
+	t.Run("不正なトークンはErrUnauthenticated", func(t *testing.T) {
+		t.Parallel()
+		a := oidc.NewAuthenticator(verifier, "roles", roleScopes)
+		_, err := a.Authenticate(ctx, key.sign(t, "RS256", validClaims(map[string]any{"aud": "other"})))
+		assert.ErrorIs(t, err, apperr.ErrUnauthenticated)
+	})
+
+	t.Run("ワークスペースのクレームで呼び出し元をワークスペースに紐づける", func(t *testing.T) {
+		t.Parallel()
+		a := oidc.NewAuthenticator(verifier, "roles", roleScopes, oidc.WithWorkspaceClaim("workspace", stubWorkspaceResolver{"team-a": 2}))
+		p, err := a.Authenticate(ctx, key.sign(t, "RS256", validClaims(map[string]any{"workspace": "team-a"})))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), p.WorkspaceID)
+
+		_, err = a.Authenticate(ctx, key.sign(t, "RS256", validClaims(map[string]any{"workspace": "unknown"})))
+		assert.ErrorIs(t, err, apperr.ErrUnauthenticated)
+		_, err = a.Authenticate(ctx, key.sign(t, "RS256", validClaims(nil)))
+		assert.ErrorIs(t, err, apperr.ErrUnauthenticated)
+	})
+
+	t.Run("対応付けの形式が不正ならエラー", func(t *testing.T) {
+		t.Parallel()
+		_, err := oidc.ParseRoleScopes([]string{"admin:articles:admin"})
+		assert.Error(t, err)
+		_, err = oidc.ParseRoleScopes([]string{"admin=articles:delete"})
+		assert.Error(t, err)
+	})
+}
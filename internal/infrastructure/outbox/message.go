@@ -0,0 +1,33 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+// Message はアウトボックスに記録された配信待ちのイベント
+type Message struct {
+	ID            uint64
+	AggregateType string
+	AggregateID   uint64
+	EventType     string
+	Payload       []byte
+	Attempts      int
+	CreatedAt     time.Time
+}
+
+// Store はアウトボックスの読み書きを担う
+type Store interface {
+	// FetchPending は配信可能な未送信メッセージを確保してID順に返す
+	// 同一集約については先頭の未送信メッセージのみを返すことで、集約単位の順序を保証する
+	// 確保したメッセージは、一定時間が過ぎるまで他のリレーのFetchPendingでは返さない
+	FetchPending(ctx context.Context, limit int) ([]Message, error)
+	MarkDelivered(ctx context.Context, id uint64) error
+	MarkRetry(ctx context.Context, id uint64, attempts int, nextAttemptAt time.Time, lastError string) error
+	MarkDead(ctx context.Context, id uint64, attempts int, lastError string) error
+}
+
+// Publisher はメッセージを外部へ配信する
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
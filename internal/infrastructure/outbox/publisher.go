@@ -0,0 +1,200 @@
+package outbox
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogPublisher はメッセージをログへ出力するだけのPublisher
+// 開発環境や動作確認向け
+type LogPublisher struct {
+	logger *log.Logger
+}
+
+func NewLogPublisher(logger *log.Logger) *LogPublisher {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogPublisher{logger: logger}
+}
+
+func (p *LogPublisher) Publish(_ context.Context, msg Message) error {
+	p.logger.Printf("outbox: %s %s:%d %s", msg.EventType, msg.AggregateType, msg.AggregateID, msg.Payload)
+	return nil
+}
+
+// WebhookPublisher はメッセージをHTTP POSTで配信するPublisher
+// 2xx以外のレスポンスは配信失敗として扱う
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookPublisher(url string, client *http.Client) *WebhookPublisher {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &WebhookPublisher{url: url, client: client}
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, msg Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(msg.Payload))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Type", msg.EventType)
+	req.Header.Set("X-Event-ID", strconv.FormatUint(msg.ID, 10))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// NATSPublisher はNATS互換サーバーへテキストプロトコルでPUBするPublisher
+// サブジェクトは "<prefix>.<event_type>" となる
+type NATSPublisher struct {
+	addr          string
+	subjectPrefix string
+	timeout       time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+	rw   *bufio.ReadWriter
+}
+
+func NewNATSPublisher(addr string, subjectPrefix string) *NATSPublisher {
+	return &NATSPublisher{
+		addr:          addr,
+		subjectPrefix: subjectPrefix,
+		timeout:       5 * time.Second,
+	}
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, msg Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.connect(ctx); err != nil {
+		return err
+	}
+
+	subject := msg.EventType
+	if p.subjectPrefix != "" {
+		subject = p.subjectPrefix + "." + msg.EventType
+	}
+
+	// PUBの後にPINGを送り、PONGを受け取ることでサーバーが受理したことを確認する
+	deadline := time.Now().Add(p.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = p.conn.SetDeadline(deadline)
+
+	fmt.Fprintf(p.rw, "PUB %s %d\r\n", subject, len(msg.Payload))
+	p.rw.Write(msg.Payload)
+	p.rw.WriteString("\r\nPING\r\n")
+	if err := p.rw.Flush(); err != nil {
+		p.closeLocked()
+		return fmt.Errorf("failed to publish to nats: %w", err)
+	}
+
+	for {
+		line, err := p.rw.ReadString('\n')
+		if err != nil {
+			p.closeLocked()
+			return fmt.Errorf("failed to read nats response: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			p.rw.WriteString("PONG\r\n")
+			_ = p.rw.Flush()
+		case strings.HasPrefix(line, "-ERR"):
+			p.closeLocked()
+			return fmt.Errorf("nats server error: %s", line)
+		}
+	}
+}
+
+// Close はNATSサーバーとの接続を閉じる
+func (p *NATSPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closeLocked()
+}
+
+func (p *NATSPublisher) connect(ctx context.Context) error {
+	if p.conn != nil {
+		return nil
+	}
+	dialer := net.Dialer{Timeout: p.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to nats: %w", err)
+	}
+	p.conn = conn
+	p.rw = bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+
+	_ = conn.SetDeadline(time.Now().Add(p.timeout))
+	// サーバーは接続直後にINFOを送る
+	if _, err := p.rw.ReadString('\n'); err != nil {
+		p.closeLocked()
+		return fmt.Errorf("failed to read nats info: %w", err)
+	}
+	p.rw.WriteString(`CONNECT {"verbose":false,"pedantic":false,"name":"momenture-article-hub"}` + "\r\n")
+	if err := p.rw.Flush(); err != nil {
+		p.closeLocked()
+		return fmt.Errorf("failed to send nats connect: %w", err)
+	}
+	return nil
+}
+
+func (p *NATSPublisher) closeLocked() error {
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn = nil
+	p.rw = nil
+	return err
+}
+
+// MultiPublisher は複数のPublisherへ順に配信する
+// いずれかが失敗した場合はメッセージ全体が再試行されるため、各Publisherは冪等である必要がある
+type MultiPublisher struct {
+	publishers []Publisher
+}
+
+func NewMultiPublisher(publishers ...Publisher) *MultiPublisher {
+	return &MultiPublisher{publishers: publishers}
+}
+
+func (p *MultiPublisher) Publish(ctx context.Context, msg Message) error {
+	for _, pub := range p.publishers {
+		if err := pub.Publish(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
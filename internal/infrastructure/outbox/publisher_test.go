@@ -0,0 +1,97 @@
+package outbox_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/outbox"
+)
+
+func TestWebhookPublisher_Publish(t *testing.T) {
+	t.Parallel()
+
+	t.Run("2xxなら成功", func(t *testing.T) {
+		t.Parallel()
+		var gotBody, gotEvent string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			gotBody = string(b)
+			gotEvent = r.Header.Get("X-Event-Type")
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		p := outbox.NewWebhookPublisher(srv.URL, srv.Client())
+		err := p.Publish(context.Background(), outbox.Message{ID: 1, EventType: "article.created", Payload: []byte(`{"id":1}`)})
+
+		require.NoError(t, err)
+		assert.Equal(t, `{"id":1}`, gotBody)
+		assert.Equal(t, "article.created", gotEvent)
+	})
+
+	t.Run("2xx以外はエラー", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		p := outbox.NewWebhookPublisher(srv.URL, srv.Client())
+		err := p.Publish(context.Background(), outbox.Message{ID: 1, EventType: "article.created", Payload: []byte(`{}`)})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNATSPublisher_Publish(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		_, _ = conn.Write([]byte("INFO {}\r\n"))
+
+		var pub strings.Builder
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(line, "PUB"):
+				pub.WriteString(line)
+				payload, _ := r.ReadString('\n')
+				pub.WriteString(payload)
+			case strings.HasPrefix(line, "PING"):
+				received <- pub.String()
+				_, _ = conn.Write([]byte("PONG\r\n"))
+			}
+		}
+	}()
+
+	p := outbox.NewNATSPublisher(ln.Addr().String(), "articlehub")
+	defer p.Close()
+
+	err = p.Publish(context.Background(), outbox.Message{ID: 1, EventType: "article.published", Payload: []byte(`{"id":1}`)})
+
+	require.NoError(t, err)
+	assert.Equal(t, "PUB articlehub.article.published 8\r\n{\"id\":1}\r\n", <-received)
+}
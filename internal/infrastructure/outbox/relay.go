@@ -0,0 +1,175 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultBatchSize    = 100
+	defaultMaxAttempts  = 10
+	defaultBaseBackoff  = time.Second
+	defaultMaxBackoff   = 10 * time.Minute
+)
+
+// Relay はアウトボックスの未送信メッセージを読み出し、Publisherへ引き渡すワーカー
+// 配信は少なくとも1回(at-least-once)であり、受信側は冪等に処理する必要がある
+type Relay struct {
+	store        Store
+	publisher    Publisher
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+	baseBackoff  time.Duration
+	maxBackoff   time.Duration
+	now          func() time.Time
+}
+
+// RelayOption はRelay作成時のオプション設定用
+type RelayOption func(*Relay)
+
+func WithPollInterval(d time.Duration) RelayOption {
+	return func(r *Relay) {
+		if d > 0 {
+			r.pollInterval = d
+		}
+	}
+}
+
+func WithBatchSize(n int) RelayOption {
+	return func(r *Relay) {
+		if n > 0 {
+			r.batchSize = n
+		}
+	}
+}
+
+func WithMaxAttempts(n int) RelayOption {
+	return func(r *Relay) {
+		if n > 0 {
+			r.maxAttempts = n
+		}
+	}
+}
+
+func WithBackoff(base, max time.Duration) RelayOption {
+	return func(r *Relay) {
+		if base > 0 {
+			r.baseBackoff = base
+		}
+		if max > 0 {
+			r.maxBackoff = max
+		}
+	}
+}
+
+func WithClock(now func() time.Time) RelayOption {
+	return func(r *Relay) {
+		r.now = now
+	}
+}
+
+// NewRelay は新しいRelayを作成する
+func NewRelay(store Store, publisher Publisher, opts ...RelayOption) *Relay {
+	r := &Relay{
+		store:        store,
+		publisher:    publisher,
+		pollInterval: defaultPollInterval,
+		batchSize:    defaultBatchSize,
+		maxAttempts:  defaultMaxAttempts,
+		baseBackoff:  defaultBaseBackoff,
+		maxBackoff:   defaultMaxBackoff,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Run はコンテキストがキャンセルされるまでポーリングを続ける
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := r.ProcessBatch(ctx)
+			if err != nil {
+				log.Printf("outbox relay: %v", err)
+				break
+			}
+			if n < r.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessBatch は1バッチ分のメッセージを配信し、処理した件数を返す
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	messages, err := r.store.FetchPending(ctx, r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch pending messages: %w", err)
+	}
+
+	// 同一集約のメッセージが失敗した場合、後続メッセージは今回のバッチでは配信しない
+	blocked := make(map[string]bool)
+	for _, msg := range messages {
+		key := fmt.Sprintf("%s:%d", msg.AggregateType, msg.AggregateID)
+		if blocked[key] {
+			continue
+		}
+
+		if err := r.publisher.Publish(ctx, msg); err != nil {
+			blocked[key] = true
+			if markErr := r.handleFailure(ctx, msg, err); markErr != nil {
+				return 0, markErr
+			}
+			continue
+		}
+
+		if err := r.store.MarkDelivered(ctx, msg.ID); err != nil {
+			return 0, fmt.Errorf("failed to mark message %d as delivered: %w", msg.ID, err)
+		}
+	}
+	return len(messages), nil
+}
+
+func (r *Relay) handleFailure(ctx context.Context, msg Message, publishErr error) error {
+	attempts := msg.Attempts + 1
+	if attempts >= r.maxAttempts {
+		log.Printf("outbox relay: message %d moved to dead letter after %d attempts: %v", msg.ID, attempts, publishErr)
+		if err := r.store.MarkDead(ctx, msg.ID, attempts, publishErr.Error()); err != nil {
+			return fmt.Errorf("failed to mark message %d as dead: %w", msg.ID, err)
+		}
+		return nil
+	}
+
+	nextAttemptAt := r.now().Add(r.backoff(attempts))
+	if err := r.store.MarkRetry(ctx, msg.ID, attempts, nextAttemptAt, publishErr.Error()); err != nil {
+		return fmt.Errorf("failed to schedule retry for message %d: %w", msg.ID, err)
+	}
+	return nil
+}
+
+// backoff は試行回数に応じた指数バックオフの待機時間を返す
+func (r *Relay) backoff(attempts int) time.Duration {
+	d := r.baseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= r.maxBackoff {
+			return r.maxBackoff
+		}
+	}
+	return d
+}
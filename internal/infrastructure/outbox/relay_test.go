@@ -0,0 +1,132 @@
+package outbox_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/outbox"
+)
+
+type fakeStore struct {
+	pending   []outbox.Message
+	delivered []uint64
+	retried   map[uint64]time.Time
+	dead      []uint64
+}
+
+func (s *fakeStore) FetchPending(_ context.Context, limit int) ([]outbox.Message, error) {
+	if len(s.pending) > limit {
+		return s.pending[:limit], nil
+	}
+	return s.pending, nil
+}
+
+func (s *fakeStore) MarkDelivered(_ context.Context, id uint64) error {
+	s.delivered = append(s.delivered, id)
+	return nil
+}
+
+func (s *fakeStore) MarkRetry(_ context.Context, id uint64, _ int, nextAttemptAt time.Time, _ string) error {
+	if s.retried == nil {
+		s.retried = make(map[uint64]time.Time)
+	}
+	s.retried[id] = nextAttemptAt
+	return nil
+}
+
+func (s *fakeStore) MarkDead(_ context.Context, id uint64, _ int, _ string) error {
+	s.dead = append(s.dead, id)
+	return nil
+}
+
+type fakePublisher struct {
+	failIDs   map[uint64]bool
+	published []uint64
+}
+
+func (p *fakePublisher) Publish(_ context.Context, msg outbox.Message) error {
+	if p.failIDs[msg.ID] {
+		return fmt.Errorf("publish failed")
+	}
+	p.published = append(p.published, msg.ID)
+	return nil
+}
+
+func TestRelay_ProcessBatch(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	t.Run("全メッセージを配信済みにする", func(t *testing.T) {
+		t.Parallel()
+		store := &fakeStore{pending: []outbox.Message{
+			{ID: 1, AggregateType: "article", AggregateID: 1},
+			{ID: 2, AggregateType: "article", AggregateID: 2},
+		}}
+		publisher := &fakePublisher{}
+		relay := outbox.NewRelay(store, publisher, outbox.WithClock(clock))
+
+		n, err := relay.ProcessBatch(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, []uint64{1, 2}, publisher.published)
+		assert.Equal(t, []uint64{1, 2}, store.delivered)
+	})
+
+	t.Run("失敗した集約の後続メッセージは配信しない", func(t *testing.T) {
+		t.Parallel()
+		store := &fakeStore{pending: []outbox.Message{
+			{ID: 1, AggregateType: "article", AggregateID: 1},
+			{ID: 2, AggregateType: "article", AggregateID: 2},
+			{ID: 3, AggregateType: "article", AggregateID: 1},
+		}}
+		publisher := &fakePublisher{failIDs: map[uint64]bool{1: true}}
+		relay := outbox.NewRelay(store, publisher, outbox.WithClock(clock))
+
+		_, err := relay.ProcessBatch(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{2}, publisher.published)
+		assert.Contains(t, store.retried, uint64(1))
+		assert.NotContains(t, store.retried, uint64(3))
+	})
+
+	t.Run("指数バックオフで再試行時刻を設定する", func(t *testing.T) {
+		t.Parallel()
+		store := &fakeStore{pending: []outbox.Message{
+			{ID: 1, AggregateType: "article", AggregateID: 1, Attempts: 2},
+		}}
+		publisher := &fakePublisher{failIDs: map[uint64]bool{1: true}}
+		relay := outbox.NewRelay(store, publisher,
+			outbox.WithClock(clock),
+			outbox.WithBackoff(time.Second, time.Minute),
+		)
+
+		_, err := relay.ProcessBatch(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(4*time.Second), store.retried[1])
+	})
+
+	t.Run("最大試行回数に達したらデッドレターにする", func(t *testing.T) {
+		t.Parallel()
+		store := &fakeStore{pending: []outbox.Message{
+			{ID: 1, AggregateType: "article", AggregateID: 1, Attempts: 2},
+		}}
+		publisher := &fakePublisher{failIDs: map[uint64]bool{1: true}}
+		relay := outbox.NewRelay(store, publisher, outbox.WithClock(clock), outbox.WithMaxAttempts(3))
+
+		_, err := relay.ProcessBatch(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{1}, store.dead)
+		assert.Empty(t, store.retried)
+	})
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+)
+
+// apiKeyModel はapi_keysテーブルのレコードを表す
+type apiKeyModel struct {
+	ID          uint64 `gorm:"primaryKey"`
+	Name        string
+	Prefix      string
+	SecretHash  string
+	Scopes      []string `gorm:"type:jsonb;serializer:json"`
+	AuthorID    *uint64
+	WorkspaceID uint64
+	CreatedAt   time.Time
+	LastUsedAt  *time.Time
+	RevokedAt   *time.Time
+}
+
+func (apiKeyModel) TableName() string {
+	return "api_keys"
+}
+
+func newAPIKeyModel(k *entity.APIKey) *apiKeyModel {
+	return &apiKeyModel{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		SecretHash: k.SecretHash,
+		Scopes:     k.ScopeStrings(),
+		AuthorID:   k.AuthorID,
+		CreatedAt:  k.CreatedAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
+
+func (m *apiKeyModel) toEntity() (*entity.APIKey, error) {
+	k, err := entity.ReconstituteAPIKey(
+		m.ID,
+		m.Name,
+		m.Prefix,
+		m.SecretHash,
+		m.Scopes,
+		m.CreatedAt,
+		m.LastUsedAt,
+		m.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	k.AuthorID = m.AuthorID
+	k.WorkspaceID = m.WorkspaceID
+	return k, nil
+}
+
+// 執筆者への外部キー制約名
+const apiKeyAuthorForeignKey = "api_keys_author_id_fkey"
+
+// APIKeyRepository はrepository.APIKeyRepositoryのPostgreSQL実装
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+var _ repository.APIKeyRepository = (*APIKeyRepository)(nil)
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (r *APIKeyRepository) FindAll(ctx context.Context) ([]*entity.APIKey, error) {
+	var models []apiKeyModel
+	if err := conn(ctx, r.db).Order("created_at DESC, id DESC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find api keys: %w", err)
+	}
+	keys := make([]*entity.APIKey, 0, len(models))
+	for i := range models {
+		k, err := models[i].toEntity()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepository) FindByID(ctx context.Context, id uint64) (*entity.APIKey, error) {
+	var m apiKeyModel
+	err := conn(ctx, r.db).Where("id = ?", id).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("api key %d: %w", id, repository.ErrAPIKeyNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api key %d: %w", id, err)
+	}
+	return m.toEntity()
+}
+
+func (r *APIKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	var m apiKeyModel
+	err := conn(ctx, r.db).Where("prefix = ?", prefix).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("api key %q: %w", prefix, repository.ErrAPIKeyNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api key %q: %w", prefix, err)
+	}
+	return m.toEntity()
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error) {
+	m := newAPIKeyModel(key)
+	if err := conn(ctx, r.db).Create(m).Error; err != nil {
+		if isForeignKeyViolation(err, apiKeyAuthorForeignKey) {
+			return nil, fmt.Errorf("author %d: %w", *m.AuthorID, repository.ErrAuthorNotFound)
+		}
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+	return m.toEntity()
+}
+
+func (r *APIKeyRepository) Update(ctx context.Context, key *entity.APIKey) error {
+	m := newAPIKeyModel(key)
+	result := conn(ctx, r.db).Model(&apiKeyModel{}).Where("id = ?", m.ID).Select("*").Omit("id", "prefix", "secret_hash", "created_at").Updates(m)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update api key %d: %w", m.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("api key %d: %w", m.ID, repository.ErrAPIKeyNotFound)
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id uint64, at time.Time) error {
+	err := conn(ctx, r.db).Model(&apiKeyModel{}).Where("id = ?", id).Update("last_used_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to touch api key %d: %w", id, err)
+	}
+	return nil
+}
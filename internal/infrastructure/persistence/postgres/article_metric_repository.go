@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+// articleMetricModel はarticle_metricsテーブルのレコードを表す
+type articleMetricModel struct {
+	ID            uint64 `gorm:"primaryKey"`
+	ArticleID     uint64
+	PublicationID uint64
+	ProviderType  string
+	Likes         int
+	Stocks        int
+	Bookmarks     int
+	CollectedAt   time.Time
+}
+
+func (articleMetricModel) TableName() string {
+	return "article_metrics"
+}
+
+func (m *articleMetricModel) toEntity() (*entity.ArticleMetric, error) {
+	metric, err := entity.NewArticleMetric(
+		m.ArticleID,
+		m.PublicationID,
+		vo.ProviderType(m.ProviderType),
+		m.Likes,
+		m.Stocks,
+		m.Bookmarks,
+		m.CollectedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstitute article metric %d: %w", m.ID, err)
+	}
+	metric.ID = m.ID
+	return metric, nil
+}
+
+// metricTargetRow は指標の収集対象の投稿先
+type metricTargetRow struct {
+	ArticleID     uint64
+	PublicationID uint64
+	ProviderType  string
+	Link          *string
+	ExternalID    *string
+	WorkspaceID   uint64
+}
+
+// 増分の並び替えに利用できるカラム
+var metricGrowthSortColumns = map[string]string{
+	"likes":     "likes",
+	"stocks":    "stocks",
+	"bookmarks": "bookmarks",
+	"total":     "total",
+}
+
+// 投稿先ごとに期間内の最初と最後のスナップショットの差を求め、記事ごとに合算する
+const metricGrowthQuery = `
+WITH windowed AS (
+  SELECT article_id, likes, stocks, bookmarks,
+    ROW_NUMBER() OVER (PARTITION BY publication_id ORDER BY collected_at ASC, id ASC) AS first_rank,
+    ROW_NUMBER() OVER (PARTITION BY publication_id ORDER BY collected_at DESC, id DESC) AS last_rank
+  FROM article_metrics
+  WHERE collected_at BETWEEN ? AND ?
+), growth AS (
+  SELECT article_id,
+    SUM(CASE WHEN last_rank = 1 THEN likes ELSE 0 END) - SUM(CASE WHEN first_rank = 1 THEN likes ELSE 0 END) AS likes,
+    SUM(CASE WHEN last_rank = 1 THEN stocks ELSE 0 END) - SUM(CASE WHEN first_rank = 1 THEN stocks ELSE 0 END) AS stocks,
+    SUM(CASE WHEN last_rank = 1 THEN bookmarks ELSE 0 END) - SUM(CASE WHEN first_rank = 1 THEN bookmarks ELSE 0 END) AS bookmarks
+  FROM windowed
+  GROUP BY article_id
+)
+SELECT g.article_id, a.title, g.likes, g.stocks, g.bookmarks, g.likes + g.stocks + g.bookmarks AS total
+FROM growth g
+JOIN articles a ON a.id = g.article_id AND a.deleted_at IS NULL AND %s
+ORDER BY %s DESC, g.article_id ASC
+LIMIT ?`
+
+// ArticleMetricRepository はrepository.ArticleMetricRepositoryのPostgreSQL実装
+type ArticleMetricRepository struct {
+	db *gorm.DB
+}
+
+var _ repository.ArticleMetricRepository = (*ArticleMetricRepository)(nil)
+
+func NewArticleMetricRepository(db *gorm.DB) *ArticleMetricRepository {
+	return &ArticleMetricRepository{db: db}
+}
+
+func (r *ArticleMetricRepository) FindTargets(ctx context.Context) ([]repository.MetricTarget, error) {
+	workspace, workspaceArgs, err := workspaceCondition(ctx, "a")
+	if err != nil {
+		return nil, err
+	}
+	var rows []metricTargetRow
+	err = conn(ctx, r.db).Table("article_publications p").
+		Select("p.article_id, p.id AS publication_id, p.provider_type, p.link, p.external_id, a.workspace_id").
+		Joins("JOIN articles a ON a.id = p.article_id").
+		Where("a.deleted_at IS NULL AND p.provider_type IS NOT NULL AND (p.link IS NOT NULL OR p.external_id IS NOT NULL)").
+		Where(workspace, workspaceArgs...).
+		Order("p.id").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find metric targets: %w", err)
+	}
+	targets := make([]repository.MetricTarget, 0, len(rows))
+	for _, row := range rows {
+		targets = append(targets, repository.MetricTarget{
+			ArticleID:     row.ArticleID,
+			PublicationID: row.PublicationID,
+			ProviderType:  vo.ProviderType(row.ProviderType),
+			Link:          row.Link,
+			ExternalID:    row.ExternalID,
+			WorkspaceID:   row.WorkspaceID,
+		})
+	}
+	return targets, nil
+}
+
+func (r *ArticleMetricRepository) Save(ctx context.Context, metrics []*entity.ArticleMetric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+	models := make([]articleMetricModel, 0, len(metrics))
+	for _, m := range metrics {
+		models = append(models, articleMetricModel{
+			ArticleID:     m.ArticleID,
+			PublicationID: m.PublicationID,
+			ProviderType:  m.ProviderType.String(),
+			Likes:         m.Likes,
+			Stocks:        m.Stocks,
+			Bookmarks:     m.Bookmarks,
+			CollectedAt:   m.CollectedAt,
+		})
+	}
+	if err := conn(ctx, r.db).CreateInBatches(&models, 500).Error; err != nil {
+		return fmt.Errorf("failed to save article metrics: %w", err)
+	}
+	for i := range models {
+		metrics[i].ID = models[i].ID
+	}
+	return nil
+}
+
+func (r *ArticleMetricRepository) FindByArticleID(ctx context.Context, articleID uint64, from, to time.Time) ([]*entity.ArticleMetric, error) {
+	var models []articleMetricModel
+	err := conn(ctx, r.db).
+		Where("article_id = ? AND collected_at BETWEEN ? AND ?", articleID, from, to).
+		Order("collected_at, id").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find metrics of article %d: %w", articleID, err)
+	}
+	metrics := make([]*entity.ArticleMetric, 0, len(models))
+	for i := range models {
+		m, err := models[i].toEntity()
+		if err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics, nil
+}
+
+func (r *ArticleMetricRepository) FindGrowth(ctx context.Context, from, to time.Time, sortBy string, limit int, publishedOnly bool) ([]repository.MetricGrowth, error) {
+	column, ok := metricGrowthSortColumns[sortBy]
+	if !ok {
+		column = metricGrowthSortColumns["total"]
+	}
+	workspace, workspaceArgs, err := workspaceCondition(ctx, "a")
+	if err != nil {
+		return nil, err
+	}
+	if publishedOnly {
+		workspace += " AND a.status = ?"
+		workspaceArgs = append(workspaceArgs, vo.ArticleStatusPublished.String())
+	}
+	args := append(append([]any{from, to}, workspaceArgs...), limit)
+	var rows []repository.MetricGrowth
+	if err := conn(ctx, r.db).Raw(fmt.Sprintf(metricGrowthQuery, workspace, column), args...).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate metric growth: %w", err)
+	}
+	return rows, nil
+}
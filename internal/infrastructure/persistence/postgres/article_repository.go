@@ -0,0 +1,846 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+// articleModel はarticlesテーブルのレコードを表す
+type articleModel struct {
+	ID     uint64 `gorm:"primaryKey"`
+	Title  string
+	Slug   string
+	Body   *string
+	Status string
+	// 本文から導出した値。絞り込みと並び替えのために保持する
+	CharCount          int
+	ReadingTimeMinutes int
+	// 正規の投稿先のリンクを正規化した値。未削除の記事の間で一意
+	NormalizedLink *string
+	AuthorID       *uint64
+	WorkspaceID    uint64
+	ApprovedAt     *time.Time
+	PublishedAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      *time.Time
+}
+
+func (articleModel) TableName() string {
+	return "articles"
+}
+
+// articleTagModel はarticle_tagsテーブルのレコードを表す
+type articleTagModel struct {
+	ArticleID uint64 `gorm:"primaryKey"`
+	Tag       string `gorm:"primaryKey"`
+}
+
+func (articleTagModel) TableName() string {
+	return "article_tags"
+}
+
+// articlePublicationModel はarticle_publicationsテーブルのレコードを表す
+type articlePublicationModel struct {
+	ID           uint64 `gorm:"primaryKey"`
+	ArticleID    uint64
+	ProviderType *string
+	Link         *string
+	ExternalID   *string
+	PublishedAt  *time.Time
+	SyncStatus   string
+	IsCanonical  bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (articlePublicationModel) TableName() string {
+	return "article_publications"
+}
+
+func newArticlePublicationModel(articleID uint64, p *entity.Publication) *articlePublicationModel {
+	m := &articlePublicationModel{
+		ID:          p.ID,
+		ArticleID:   articleID,
+		ExternalID:  p.ExternalID,
+		PublishedAt: p.PublishedAt,
+		SyncStatus:  p.SyncStatus.String(),
+		IsCanonical: p.Canonical,
+	}
+	if p.ProviderType != nil {
+		providerType := p.ProviderType.String()
+		m.ProviderType = &providerType
+	}
+	if p.Link != nil {
+		link := p.Link.String()
+		m.Link = &link
+	}
+	return m
+}
+
+func (m *articlePublicationModel) toEntity() (*entity.Publication, error) {
+	return entity.ReconstitutePublication(
+		m.ID,
+		m.ProviderType,
+		m.Link,
+		m.ExternalID,
+		m.PublishedAt,
+		m.SyncStatus,
+		m.IsCanonical,
+	)
+}
+
+// articleSlugHistoryModel はarticle_slug_historyテーブルのレコードを表す
+type articleSlugHistoryModel struct {
+	WorkspaceID uint64 `gorm:"primaryKey"`
+	Slug        string `gorm:"primaryKey"`
+	ArticleID   uint64
+	CreatedAt   time.Time
+}
+
+func (articleSlugHistoryModel) TableName() string {
+	return "article_slug_history"
+}
+
+// スラッグの一意制約名と正規化したリンクの一意インデックス名
+const (
+	articleSlugUniqueConstraint = "articles_slug_key"
+	articleLinkUniqueIndex      = "idx_articles_normalized_link"
+)
+
+// 所有者の執筆者への外部キー制約名
+const articleAuthorForeignKey = "articles_author_id_fkey"
+
+func newArticleModel(a *entity.Article) *articleModel {
+	meta := a.Metadata()
+	m := &articleModel{
+		ID:                 a.ID,
+		Title:              a.Title.String(),
+		Slug:               a.Slug.String(),
+		Status:             a.Status.String(),
+		CharCount:          meta.CharCount,
+		ReadingTimeMinutes: meta.ReadingTimeMinutes,
+		AuthorID:           a.AuthorID,
+		WorkspaceID:        a.WorkspaceID,
+		ApprovedAt:         a.ApprovedAt,
+		PublishedAt:        a.PublishedAt,
+		CreatedAt:          a.CreatedAt,
+		UpdatedAt:          a.UpdatedAt,
+		DeletedAt:          a.DeletedAt,
+	}
+	if a.Body != nil {
+		body := a.Body.String()
+		m.Body = &body
+	}
+	if a.Link != nil {
+		normalized := a.Link.Normalized()
+		m.NormalizedLink = &normalized
+	}
+	return m
+}
+
+// toEntity は記事と投稿先のレコードからエンティティを再構築する
+// 記事のプロバイダとリンクには正規の投稿先の値を使う
+func (m *articleModel) toEntity(publications []articlePublicationModel) (*entity.Article, error) {
+	var providerType, link *string
+	pubs := make([]entity.Publication, 0, len(publications))
+	for i := range publications {
+		p, err := publications[i].toEntity()
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconstitute publication of article %d: %w", m.ID, err)
+		}
+		if p.Canonical {
+			providerType, link = publications[i].ProviderType, publications[i].Link
+		}
+		pubs = append(pubs, *p)
+	}
+
+	a, err := entity.ReconstituteArticle(
+		m.ID,
+		m.Title,
+		m.Slug,
+		m.Status,
+		m.Body,
+		providerType,
+		link,
+		m.CreatedAt,
+		m.UpdatedAt,
+		m.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	a.Publications = pubs
+	a.AuthorID = m.AuthorID
+	a.WorkspaceID = m.WorkspaceID
+	a.ApprovedAt = m.ApprovedAt
+	a.PublishedAt = m.PublishedAt
+	return a, nil
+}
+
+// 並び替えに利用できるカラム
+var articleSortColumns = map[string]string{
+	"created_at":   "created_at",
+	"published_at": "published_at",
+	"updated_at":   "updated_at",
+	"title":        "title",
+	"reading_time": "reading_time_minutes",
+	"char_count":   "char_count",
+	"deleted_at":   "deleted_at",
+}
+
+// ArticleRepository はrepository.ArticleRepositoryのPostgreSQL実装
+// 記事の変更と同一トランザクションでドメインイベントをアウトボックスへ記録する
+type ArticleRepository struct {
+	db *gorm.DB
+}
+
+var (
+	_ repository.ArticleRepository      = (*ArticleRepository)(nil)
+	_ repository.PublishedArticleReader = (*ArticleRepository)(nil)
+	_ repository.SimilarTitleFinder     = (*ArticleRepository)(nil)
+)
+
+func NewArticleRepository(db *gorm.DB) *ArticleRepository {
+	return &ArticleRepository{db: db}
+}
+
+func (r *ArticleRepository) FindAll(ctx context.Context) ([]*entity.Article, error) {
+	var models []articleModel
+	if err := conn(ctx, r.db).Where("deleted_at IS NULL").Order("id").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find articles: %w", err)
+	}
+	return r.toArticleEntities(ctx, models)
+}
+
+func (r *ArticleRepository) FindByID(ctx context.Context, id uint64) (*entity.Article, error) {
+	return r.findByID(ctx, conn(ctx, r.db), id)
+}
+
+// FindByIDForUpdate は SELECT ... FOR UPDATE で記事を行ロックして取得する
+// トランザクション外で呼び出した場合はロックが即座に解放される
+func (r *ArticleRepository) FindByIDForUpdate(ctx context.Context, id uint64) (*entity.Article, error) {
+	return r.findByID(ctx, conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+// FindDeletedByIDForUpdate は SELECT ... FOR UPDATE で論理削除済みの記事を行ロックして取得する
+func (r *ArticleRepository) FindDeletedByIDForUpdate(ctx context.Context, id uint64) (*entity.Article, error) {
+	var m articleModel
+	err := conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("deleted article %d: %w", id, repository.ErrArticleNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deleted article %d: %w", id, err)
+	}
+	articles, err := r.toArticleEntities(ctx, []articleModel{m})
+	if err != nil {
+		return nil, err
+	}
+	return articles[0], nil
+}
+
+func (r *ArticleRepository) FindDeletedBefore(ctx context.Context, before time.Time, afterID uint64, limit int) ([]*entity.Article, error) {
+	var models []articleModel
+	err := conn(ctx, r.db).Where("deleted_at < ? AND id > ?", before, afterID).Order("id").Limit(limit).Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find articles deleted before %s: %w", before.Format(time.RFC3339), err)
+	}
+	return r.toArticleEntities(ctx, models)
+}
+
+func (r *ArticleRepository) FindAfterID(ctx context.Context, afterID uint64, limit int) ([]*entity.Article, error) {
+	var models []articleModel
+	err := conn(ctx, r.db).Where("id > ?", afterID).Order("id").Limit(limit).Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find articles after %d: %w", afterID, err)
+	}
+	return r.toArticleEntities(ctx, models)
+}
+
+// UpdateMetadata は本文から導出した値が保存済みの値と異なる場合だけ更新する
+func (r *ArticleRepository) UpdateMetadata(ctx context.Context, article *entity.Article) (bool, error) {
+	meta := article.Metadata()
+	result := conn(ctx, r.db).Model(&articleModel{}).
+		Where("id = ? AND (char_count <> ? OR reading_time_minutes <> ?)", article.ID, meta.CharCount, meta.ReadingTimeMinutes).
+		UpdateColumns(map[string]any{"char_count": meta.CharCount, "reading_time_minutes": meta.ReadingTimeMinutes})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update metadata of article %d: %w", article.ID, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Purge は記事を完全に削除する
+// タグ・投稿先・指標・リンクチェック・レビュー・コメントは外部キーのON DELETE CASCADEで削除される
+func (r *ArticleRepository) Purge(ctx context.Context, id uint64, deletedBefore time.Time) error {
+	result := conn(ctx, r.db).Where("id = ? AND deleted_at < ?", id, deletedBefore).Delete(&articleModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to purge article %d: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("deleted article %d: %w", id, repository.ErrArticleNotFound)
+	}
+	return nil
+}
+
+func (r *ArticleRepository) findByID(ctx context.Context, db *gorm.DB, id uint64) (*entity.Article, error) {
+	var m articleModel
+	err := db.Where("id = ? AND deleted_at IS NULL", id).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("article %d: %w", id, repository.ErrArticleNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find article %d: %w", id, err)
+	}
+	articles, err := r.toArticleEntities(ctx, []articleModel{m})
+	if err != nil {
+		return nil, err
+	}
+	return articles[0], nil
+}
+
+func (r *ArticleRepository) FindBySlug(ctx context.Context, slug string) (*entity.Article, error) {
+	var m articleModel
+	err := conn(ctx, r.db).Where("slug = ? AND deleted_at IS NULL", slug).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("article slug %q: %w", slug, repository.ErrArticleNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find article by slug %q: %w", slug, err)
+	}
+	articles, err := r.toArticleEntities(ctx, []articleModel{m})
+	if err != nil {
+		return nil, err
+	}
+	return articles[0], nil
+}
+
+// FindCurrentSlug はスラッグの履歴から未削除の記事の現在のスラッグを返す
+func (r *ArticleRepository) FindCurrentSlug(ctx context.Context, previousSlug string) (string, error) {
+	workspace, workspaceArgs, err := workspaceCondition(ctx, "h")
+	if err != nil {
+		return "", err
+	}
+	var slugs []string
+	err = conn(ctx, r.db).Table("article_slug_history h").
+		Joins("JOIN articles a ON a.id = h.article_id").
+		Where("h.slug = ? AND a.deleted_at IS NULL", previousSlug).
+		Where(workspace, workspaceArgs...).
+		Limit(1).
+		Pluck("a.slug", &slugs).Error
+	if err != nil {
+		return "", fmt.Errorf("failed to find slug history %q: %w", previousSlug, err)
+	}
+	if len(slugs) == 0 {
+		return "", fmt.Errorf("article slug %q: %w", previousSlug, repository.ErrArticleNotFound)
+	}
+	return slugs[0], nil
+}
+
+// SlugExists は記事の現在のスラッグに加えて、他の記事の過去のスラッグの履歴も確認する
+// 履歴にあるスラッグを使うと、以前のURLからのリダイレクト先が別の記事に変わってしまうため
+func (r *ArticleRepository) SlugExists(ctx context.Context, slug string, exceptArticleID uint64) (bool, error) {
+	var count int64
+	if err := conn(ctx, r.db).Model(&articleModel{}).Where("slug = ?", slug).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check article slug %q: %w", slug, err)
+	}
+	if count > 0 {
+		return true, nil
+	}
+	err := conn(ctx, r.db).Model(&articleSlugHistoryModel{}).
+		Where("slug = ? AND article_id <> ?", slug, exceptArticleID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check slug history %q: %w", slug, err)
+	}
+	return count > 0, nil
+}
+
+func (r *ArticleRepository) FindIDByNormalizedLink(ctx context.Context, normalizedLink string) (uint64, error) {
+	var ids []uint64
+	err := conn(ctx, r.db).Model(&articleModel{}).
+		Where("normalized_link = ? AND deleted_at IS NULL", normalizedLink).
+		Limit(1).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to find article by link %q: %w", normalizedLink, err)
+	}
+	if len(ids) == 0 {
+		return 0, fmt.Errorf("article link %q: %w", normalizedLink, repository.ErrArticleNotFound)
+	}
+	return ids[0], nil
+}
+
+// FindSimilarTitles はpg_trgmの%演算子でトライグラムのインデックスを使って候補を絞り込む
+// %の閾値はトランザクション内でthresholdに設定する
+func (r *ArticleRepository) FindSimilarTitles(ctx context.Context, threshold float64, limit int, publishedOnly bool) ([]repository.SimilarTitlePair, error) {
+	workspace, workspaceArgs, err := workspaceCondition(ctx, "a")
+	if err != nil {
+		return nil, err
+	}
+	var pairs []repository.SimilarTitlePair
+	err = withinTx(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT set_config('pg_trgm.similarity_threshold', ?, true)", strconv.FormatFloat(threshold, 'f', -1, 64)).Error; err != nil {
+			return fmt.Errorf("failed to set similarity threshold: %w", err)
+		}
+		query := tx.Table("articles a").
+			Select("a.id AS article_id, a.title, b.id AS other_id, b.title AS other_title, similarity(a.title, b.title) AS similarity").
+			Joins("JOIN articles b ON a.id < b.id AND a.workspace_id = b.workspace_id AND a.title % b.title").
+			Where("a.deleted_at IS NULL AND b.deleted_at IS NULL").
+			Where(workspace, workspaceArgs...)
+		if publishedOnly {
+			query = query.Where("a.status = ? AND b.status = ?", vo.ArticleStatusPublished.String(), vo.ArticleStatusPublished.String())
+		}
+		return query.
+			Order("similarity DESC, a.id, b.id").
+			Limit(limit).
+			Scan(&pairs).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find similar titles: %w", err)
+	}
+	return pairs, nil
+}
+
+func (r *ArticleRepository) FindByCriteria(ctx context.Context, criteria repository.ArticleQueryCriteria) ([]*entity.Article, int, error) {
+	query := conn(ctx, r.db).Model(&articleModel{})
+	switch {
+	case criteria.DeletedOnly:
+		query = query.Where("deleted_at IS NOT NULL")
+	case !criteria.IncludeDeleted:
+		query = query.Where("deleted_at IS NULL")
+	}
+	if criteria.Status != nil {
+		query = query.Where("status = ?", *criteria.Status)
+	}
+	if criteria.ProviderType != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM article_publications p WHERE p.article_id = articles.id AND p.is_canonical AND p.provider_type = ?)", *criteria.ProviderType)
+	}
+	if criteria.PublishedOn != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM article_publications p WHERE p.article_id = articles.id AND p.provider_type = ?)", *criteria.PublishedOn)
+	}
+	if criteria.HasBrokenLinks != nil {
+		brokenLinks := "EXISTS (SELECT 1 FROM link_checks l WHERE l.article_id = articles.id AND l.status = 'broken')"
+		if *criteria.HasBrokenLinks {
+			query = query.Where(brokenLinks)
+		} else {
+			query = query.Where("NOT " + brokenLinks)
+		}
+	}
+	if criteria.HasLink != nil {
+		link := "EXISTS (SELECT 1 FROM article_publications p WHERE p.article_id = articles.id AND p.is_canonical AND p.link IS NOT NULL)"
+		if *criteria.HasLink {
+			query = query.Where(link)
+		} else {
+			query = query.Where("NOT " + link)
+		}
+	}
+	if criteria.Tag != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM article_tags t WHERE t.article_id = articles.id AND t.tag = ?)", *criteria.Tag)
+	}
+	if criteria.MinReadingTime != nil {
+		query = query.Where("reading_time_minutes >= ?", *criteria.MinReadingTime)
+	}
+	if criteria.MaxReadingTime != nil {
+		query = query.Where("reading_time_minutes <= ?", *criteria.MaxReadingTime)
+	}
+	if criteria.AuthorID != nil {
+		query = query.Where("author_id = ?", *criteria.AuthorID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count articles: %w", err)
+	}
+
+	sortColumn := "created_at"
+	if criteria.SortBy != nil {
+		if col, ok := articleSortColumns[*criteria.SortBy]; ok {
+			sortColumn = col
+		}
+	}
+	sortOrder := "DESC"
+	if criteria.SortOrder != nil && *criteria.SortOrder == "asc" {
+		sortOrder = "ASC"
+	}
+	query = query.Order(fmt.Sprintf("%s %s, id %s", sortColumn, sortOrder, sortOrder))
+
+	if criteria.Limit > 0 {
+		page := criteria.Page
+		if page < 1 {
+			page = 1
+		}
+		query = query.Limit(criteria.Limit).Offset((page - 1) * criteria.Limit)
+	}
+
+	var models []articleModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to find articles by criteria: %w", err)
+	}
+	articles, err := r.toArticleEntities(ctx, models)
+	if err != nil {
+		return nil, 0, err
+	}
+	return articles, int(total), nil
+}
+
+// CountPublished は公開済み・未削除の記事数を返す
+func (r *ArticleRepository) CountPublished(ctx context.Context) (int, error) {
+	var total int64
+	err := conn(ctx, r.db).Model(&articleModel{}).
+		Where("status = ? AND deleted_at IS NULL", vo.ArticleStatusPublished.String()).
+		Count(&total).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count published articles: %w", err)
+	}
+	return int(total), nil
+}
+
+// LatestChangeAt は論理削除済みを含む記事のupdated_atとdeleted_atの最大値を返す
+func (r *ArticleRepository) LatestChangeAt(ctx context.Context) (time.Time, error) {
+	var row struct {
+		LatestChangeAt *time.Time
+	}
+	err := withinTx(ctx, r.db, func(tx *gorm.DB) error {
+		return tx.Model(&articleModel{}).
+			Select("GREATEST(MAX(updated_at), MAX(deleted_at)) AS latest_change_at").
+			Scan(&row).Error
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to find latest article change: %w", err)
+	}
+	if row.LatestChangeAt == nil {
+		return time.Time{}, nil
+	}
+	return *row.LatestChangeAt, nil
+}
+
+// StreamPublished は公開済み・未削除の記事をカーソルで1行ずつ読み出す
+// 読み終えるまで行レベルセキュリティの設定を保つため、トランザクション内で読み出す
+func (r *ArticleRepository) StreamPublished(ctx context.Context, offset, limit int, fn func(repository.ArticleLastModified) error) error {
+	return withinTx(ctx, r.db, func(tx *gorm.DB) error {
+		rows, err := tx.Model(&articleModel{}).
+			Select("id", "slug", "updated_at").
+			Where("status = ? AND deleted_at IS NULL", vo.ArticleStatusPublished.String()).
+			Order("id").
+			Offset(offset).
+			Limit(limit).
+			Rows()
+		if err != nil {
+			return fmt.Errorf("failed to stream published articles: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var a repository.ArticleLastModified
+			if err := rows.Scan(&a.ID, &a.Slug, &a.UpdatedAt); err != nil {
+				return fmt.Errorf("failed to scan published article: %w", err)
+			}
+			if err := fn(a); err != nil {
+				return err
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to stream published articles: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *ArticleRepository) Create(ctx context.Context, article *entity.Article) (*entity.Article, error) {
+	m := newArticleModel(article)
+	err := withinTx(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Create(m).Error; err != nil {
+			if isUniqueViolation(err, articleSlugUniqueConstraint) {
+				return fmt.Errorf("slug %q: %w", m.Slug, repository.ErrArticleSlugConflict)
+			}
+			if isUniqueViolation(err, articleLinkUniqueIndex) {
+				return fmt.Errorf("link %q: %w", article.Link.String(), repository.ErrArticleLinkConflict)
+			}
+			if isForeignKeyViolation(err, articleAuthorForeignKey) {
+				return fmt.Errorf("author %d: %w", *m.AuthorID, repository.ErrAuthorNotFound)
+			}
+			return fmt.Errorf("failed to create article: %w", err)
+		}
+		article.ID = m.ID
+		article.WorkspaceID = m.WorkspaceID
+		if err := replaceArticleTags(tx, article); err != nil {
+			return err
+		}
+		if err := syncArticlePublications(tx, article); err != nil {
+			return err
+		}
+		return appendArticleEvents(tx, article, article.PullEvents())
+	})
+	if err != nil {
+		return nil, err
+	}
+	created, err := m.toEntity(nil)
+	if err != nil {
+		return nil, err
+	}
+	created.Tags = article.Tags
+	created.Publications = article.Publications
+	created.ProviderType = article.ProviderType
+	created.Link = article.Link
+	return created, nil
+}
+
+func (r *ArticleRepository) Update(ctx context.Context, article *entity.Article) error {
+	m := newArticleModel(article)
+	return withinTx(ctx, r.db, func(tx *gorm.DB) error {
+		var previous []storedArticleLink
+		err := tx.Model(&articleModel{}).
+			Select("workspace_id, slug, (SELECT p.link FROM article_publications p WHERE p.article_id = articles.id AND p.is_canonical) AS link").
+			Where("id = ?", m.ID).
+			Find(&previous).Error
+		if err != nil {
+			return fmt.Errorf("failed to find slug of article %d: %w", m.ID, err)
+		}
+		if len(previous) == 0 {
+			return fmt.Errorf("article %d: %w", m.ID, repository.ErrArticleNotFound)
+		}
+		if err := keepLegacyDuplicateLink(tx, m, previous[0], article.Link); err != nil {
+			return err
+		}
+
+		result := tx.Model(&articleModel{}).Where("id = ?", m.ID).Select("*").Omit("id", "created_at").Updates(m)
+		if result.Error != nil {
+			if isUniqueViolation(result.Error, articleSlugUniqueConstraint) {
+				return fmt.Errorf("slug %q: %w", m.Slug, repository.ErrArticleSlugConflict)
+			}
+			if isUniqueViolation(result.Error, articleLinkUniqueIndex) {
+				return fmt.Errorf("link %q: %w", article.Link.String(), repository.ErrArticleLinkConflict)
+			}
+			if isForeignKeyViolation(result.Error, articleAuthorForeignKey) {
+				return fmt.Errorf("author %d: %w", *m.AuthorID, repository.ErrAuthorNotFound)
+			}
+			return fmt.Errorf("failed to update article %d: %w", m.ID, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("article %d: %w", m.ID, repository.ErrArticleNotFound)
+		}
+		if previous[0].Slug != m.Slug {
+			if err := recordSlugHistory(tx, m.ID, previous[0].Slug, m.Slug); err != nil {
+				return err
+			}
+		}
+		if err := replaceArticleTags(tx, article); err != nil {
+			return err
+		}
+		if err := syncArticlePublications(tx, article); err != nil {
+			return err
+		}
+		return appendArticleEvents(tx, article, article.PullEvents())
+	})
+}
+
+// storedArticleLink は更新前の記事のスラッグと正規の投稿先のリンク
+type storedArticleLink struct {
+	WorkspaceID uint64
+	Slug        string
+	Link        *string
+}
+
+// keepLegacyDuplicateLink はリンクを変更していない記事が同じワークスペースの他の記事とリンクが重複している場合、
+// 正規化したリンクを保存せずに一意インデックスの対象外のままにする
+// 重複の検出より前からある記事や、マイグレーションの概算と現在の正規化の規則の違いで重複が分かった記事を、
+// リンク以外の変更でも保存できるようにするため。リンクを変更する場合は呼び出し側で重複を確認済み
+func keepLegacyDuplicateLink(tx *gorm.DB, m *articleModel, previous storedArticleLink, link *vo.Link) error {
+	if m.NormalizedLink == nil || previous.Link == nil || *previous.Link != link.String() {
+		return nil
+	}
+	var ids []uint64
+	err := tx.Model(&articleModel{}).
+		Where("workspace_id = ? AND normalized_link = ? AND deleted_at IS NULL AND id <> ?", previous.WorkspaceID, *m.NormalizedLink, m.ID).
+		Limit(1).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return fmt.Errorf("failed to check link of article %d: %w", m.ID, err)
+	}
+	if len(ids) > 0 {
+		m.NormalizedLink = nil
+	}
+	return nil
+}
+
+// SoftDelete は記事を論理削除する
+func (r *ArticleRepository) SoftDelete(ctx context.Context, id uint64) error {
+	return withinTx(ctx, r.db, func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&articleModel{}).
+			Where("id = ? AND deleted_at IS NULL", id).
+			Updates(map[string]any{"deleted_at": now, "updated_at": now})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete article %d: %w", id, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("article %d: %w", id, repository.ErrArticleNotFound)
+		}
+
+		var m articleModel
+		if err := tx.Where("id = ?", id).Take(&m).Error; err != nil {
+			return fmt.Errorf("failed to reload article %d: %w", id, err)
+		}
+		articles, err := r.toArticleEntities(ctx, []articleModel{m})
+		if err != nil {
+			return err
+		}
+		return appendArticleEvents(tx, articles[0], []entity.ArticleEvent{
+			{Type: entity.ArticleEventDeleted, OccurredAt: now},
+		})
+	})
+}
+
+// recordSlugHistory は変更前のスラッグを履歴に残す
+// 同じワークスペースの他の記事の履歴に同じスラッグがある場合は新しい記事へのリダイレクトで上書きし、
+// 以前のスラッグに戻した場合は履歴から取り除く
+func recordSlugHistory(tx *gorm.DB, articleID uint64, previous, current string) error {
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "workspace_id"}, {Name: "slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"article_id", "created_at"}),
+	}).Create(&articleSlugHistoryModel{Slug: previous, ArticleID: articleID, CreatedAt: time.Now()}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record slug history of article %d: %w", articleID, err)
+	}
+	if err := tx.Where("slug = ?", current).Delete(&articleSlugHistoryModel{}).Error; err != nil {
+		return fmt.Errorf("failed to clean up slug history of article %d: %w", articleID, err)
+	}
+	return nil
+}
+
+// toArticleEntities はレコードをエンティティに変換し、タグと投稿先をまとめて読み込む
+func (r *ArticleRepository) toArticleEntities(ctx context.Context, models []articleModel) ([]*entity.Article, error) {
+	articles := make([]*entity.Article, 0, len(models))
+	if len(models) == 0 {
+		return articles, nil
+	}
+
+	ids := make([]uint64, 0, len(models))
+	for i := range models {
+		ids = append(ids, models[i].ID)
+	}
+	var tagModels []articleTagModel
+	if err := conn(ctx, r.db).Where("article_id IN ?", ids).Order("tag").Find(&tagModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to find article tags: %w", err)
+	}
+	tagsByArticle := make(map[uint64][]vo.Tag, len(models))
+	for _, t := range tagModels {
+		tagsByArticle[t.ArticleID] = append(tagsByArticle[t.ArticleID], vo.Tag(t.Tag))
+	}
+	var publicationModels []articlePublicationModel
+	if err := conn(ctx, r.db).Where("article_id IN ?", ids).Order("id").Find(&publicationModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to find article publications: %w", err)
+	}
+	publicationsByArticle := make(map[uint64][]articlePublicationModel, len(models))
+	for _, p := range publicationModels {
+		publicationsByArticle[p.ArticleID] = append(publicationsByArticle[p.ArticleID], p)
+	}
+
+	var links []string
+	for _, p := range publicationModels {
+		if p.IsCanonical && p.Link != nil {
+			links = append(links, *p.Link)
+		}
+	}
+	previewsByURL := make(map[string]*entity.LinkPreview, len(links))
+	if len(links) > 0 {
+		var previewModels []linkPreviewModel
+		if err := conn(ctx, r.db).Where("url IN ?", links).Find(&previewModels).Error; err != nil {
+			return nil, fmt.Errorf("failed to find link previews: %w", err)
+		}
+		for i := range previewModels {
+			previewsByURL[previewModels[i].URL] = previewModels[i].toEntity()
+		}
+	}
+
+	for i := range models {
+		a, err := models[i].toEntity(publicationsByArticle[models[i].ID])
+		if err != nil {
+			return nil, err
+		}
+		a.Tags = tagsByArticle[a.ID]
+		if a.Link != nil {
+			a.LinkPreview = previewsByURL[a.Link.String()]
+		}
+		articles = append(articles, a)
+	}
+	return articles, nil
+}
+
+// replaceArticleTags は記事のタグを現在の状態で置き換える
+func replaceArticleTags(tx *gorm.DB, article *entity.Article) error {
+	if err := tx.Where("article_id = ?", article.ID).Delete(&articleTagModel{}).Error; err != nil {
+		return fmt.Errorf("failed to clear tags of article %d: %w", article.ID, err)
+	}
+	if len(article.Tags) == 0 {
+		return nil
+	}
+	rows := make([]articleTagModel, 0, len(article.Tags))
+	for _, t := range article.Tags {
+		rows = append(rows, articleTagModel{ArticleID: article.ID, Tag: t.String()})
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to save tags of article %d: %w", article.ID, err)
+	}
+	return nil
+}
+
+// syncArticlePublications は記事の投稿先を現在の状態に揃える
+// 既存の投稿先はIDを保ったまま更新し、新しい投稿先には採番したIDを設定する
+func syncArticlePublications(tx *gorm.DB, article *entity.Article) error {
+	keep := make([]uint64, 0, len(article.Publications))
+	for _, p := range article.Publications {
+		if p.ID != 0 {
+			keep = append(keep, p.ID)
+		}
+	}
+	remove := tx.Where("article_id = ?", article.ID)
+	if len(keep) > 0 {
+		remove = remove.Where("id NOT IN ?", keep)
+	}
+	if err := remove.Delete(&articlePublicationModel{}).Error; err != nil {
+		return fmt.Errorf("failed to remove publications of article %d: %w", article.ID, err)
+	}
+	// 正規の投稿先の付け替えで一意インデックスに一時的に違反しないよう先に外す
+	err := tx.Model(&articlePublicationModel{}).
+		Where("article_id = ? AND is_canonical", article.ID).
+		Update("is_canonical", false).Error
+	if err != nil {
+		return fmt.Errorf("failed to reset canonical publication of article %d: %w", article.ID, err)
+	}
+
+	for i := range article.Publications {
+		m := newArticlePublicationModel(article.ID, &article.Publications[i])
+		if m.ID == 0 {
+			if err := tx.Create(m).Error; err != nil {
+				return fmt.Errorf("failed to save publication of article %d: %w", article.ID, err)
+			}
+			article.Publications[i].ID = m.ID
+			continue
+		}
+		m.UpdatedAt = time.Now()
+		result := tx.Model(&articlePublicationModel{}).
+			Where("id = ? AND article_id = ?", m.ID, article.ID).
+			Select("*").Omit("id", "article_id", "created_at").
+			Updates(m)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update publication %d of article %d: %w", m.ID, article.ID, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("publication %d of article %d: %w", m.ID, article.ID, repository.ErrPublicationNotFound)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+)
+
+// auditLogModel はaudit_logテーブルのレコードを表す
+type auditLogModel struct {
+	ID            uint64 `gorm:"primaryKey"`
+	WorkspaceID   uint64
+	ArticleID     uint64
+	Action        string
+	ActorName     string
+	ActorSubject  string
+	ActorKeyID    *uint64
+	ActorAuthorID *uint64
+	Changes       map[string]entity.FieldChange `gorm:"type:jsonb;serializer:json"`
+	RequestID     string
+	IP            string `gorm:"column:ip"`
+	CreatedAt     time.Time
+}
+
+func (auditLogModel) TableName() string {
+	return "audit_log"
+}
+
+func newAuditLogModel(e *entity.AuditEntry) *auditLogModel {
+	changes := e.Changes
+	if changes == nil {
+		changes = map[string]entity.FieldChange{}
+	}
+	return &auditLogModel{
+		ArticleID:     e.ArticleID,
+		Action:        e.Action.String(),
+		ActorName:     e.Actor.Name,
+		ActorSubject:  e.Actor.Subject,
+		ActorKeyID:    e.Actor.KeyID,
+		ActorAuthorID: e.Actor.AuthorID,
+		Changes:       changes,
+		RequestID:     e.RequestID,
+		IP:            e.IP,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+func (m *auditLogModel) toEntity() *entity.AuditEntry {
+	return &entity.AuditEntry{
+		ID:        m.ID,
+		ArticleID: m.ArticleID,
+		Action:    entity.AuditAction(m.Action),
+		Actor: entity.AuditActor{
+			Name:     m.ActorName,
+			Subject:  m.ActorSubject,
+			KeyID:    m.ActorKeyID,
+			AuthorID: m.ActorAuthorID,
+		},
+		Changes:   m.Changes,
+		RequestID: m.RequestID,
+		IP:        m.IP,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// AuditLogRepository はrepository.AuditLogRepositoryのPostgreSQL実装
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+var _ repository.AuditLogRepository = (*AuditLogRepository)(nil)
+
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+func (r *AuditLogRepository) Append(ctx context.Context, entry *entity.AuditEntry) error {
+	m := newAuditLogModel(entry)
+	if err := conn(ctx, r.db).Create(m).Error; err != nil {
+		return fmt.Errorf("failed to append audit log of article %d: %w", entry.ArticleID, err)
+	}
+	entry.ID = m.ID
+	return nil
+}
+
+func (r *AuditLogRepository) Find(ctx context.Context, criteria repository.AuditLogQueryCriteria) ([]*entity.AuditEntry, error) {
+	query := conn(ctx, r.db).Model(&auditLogModel{})
+	if criteria.ArticleID != nil {
+		query = query.Where("article_id = ?", *criteria.ArticleID)
+	}
+	if criteria.Action != nil {
+		query = query.Where("action = ?", criteria.Action.String())
+	}
+	if criteria.Actor != nil {
+		query = query.Where("(actor_name = ? OR actor_subject = ?)", *criteria.Actor, *criteria.Actor)
+	}
+	if criteria.From != nil {
+		query = query.Where("created_at >= ?", *criteria.From)
+	}
+	if criteria.To != nil {
+		query = query.Where("created_at < ?", *criteria.To)
+	}
+	if criteria.AfterID > 0 {
+		query = query.Where("id > ?", criteria.AfterID)
+	}
+	if criteria.Limit > 0 {
+		query = query.Limit(criteria.Limit)
+	}
+
+	var models []auditLogModel
+	if err := query.Order("id").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find audit log: %w", err)
+	}
+	entries := make([]*entity.AuditEntry, 0, len(models))
+	for i := range models {
+		entries = append(entries, models[i].toEntity())
+	}
+	return entries, nil
+}
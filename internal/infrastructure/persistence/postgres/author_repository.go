@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+)
+
+// subjectの一意制約名
+const authorSubjectUniqueConstraint = "authors_subject_key"
+
+// authorModel はauthorsテーブルのレコードを表す
+type authorModel struct {
+	ID                uint64 `gorm:"primaryKey"`
+	DisplayName       string
+	ProviderUsernames map[string]string `gorm:"type:jsonb;serializer:json"`
+	Subject           *string
+	WorkspaceID       uint64
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (authorModel) TableName() string {
+	return "authors"
+}
+
+func newAuthorModel(a *entity.Author) *authorModel {
+	return &authorModel{
+		ID:                a.ID,
+		DisplayName:       a.DisplayName,
+		ProviderUsernames: a.ProviderUsernameStrings(),
+		Subject:           a.Subject,
+		CreatedAt:         a.CreatedAt,
+		UpdatedAt:         a.UpdatedAt,
+	}
+}
+
+func (m *authorModel) toEntity() (*entity.Author, error) {
+	return entity.ReconstituteAuthor(
+		m.ID,
+		m.DisplayName,
+		m.ProviderUsernames,
+		m.Subject,
+		m.CreatedAt,
+		m.UpdatedAt,
+	)
+}
+
+// AuthorRepository はrepository.AuthorRepositoryのPostgreSQL実装
+type AuthorRepository struct {
+	db *gorm.DB
+}
+
+var _ repository.AuthorRepository = (*AuthorRepository)(nil)
+
+func NewAuthorRepository(db *gorm.DB) *AuthorRepository {
+	return &AuthorRepository{db: db}
+}
+
+func (r *AuthorRepository) FindAll(ctx context.Context) ([]*entity.Author, error) {
+	var models []authorModel
+	if err := conn(ctx, r.db).Order("id").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find authors: %w", err)
+	}
+	authors := make([]*entity.Author, 0, len(models))
+	for i := range models {
+		a, err := models[i].toEntity()
+		if err != nil {
+			return nil, err
+		}
+		authors = append(authors, a)
+	}
+	return authors, nil
+}
+
+func (r *AuthorRepository) FindByID(ctx context.Context, id uint64) (*entity.Author, error) {
+	var m authorModel
+	err := conn(ctx, r.db).Where("id = ?", id).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("author %d: %w", id, repository.ErrAuthorNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find author %d: %w", id, err)
+	}
+	return m.toEntity()
+}
+
+func (r *AuthorRepository) FindBySubject(ctx context.Context, subject string) (*entity.Author, error) {
+	var m authorModel
+	err := conn(ctx, r.db).Where("subject = ?", subject).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("author with subject %q: %w", subject, repository.ErrAuthorNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find author with subject %q: %w", subject, err)
+	}
+	return m.toEntity()
+}
+
+func (r *AuthorRepository) Create(ctx context.Context, author *entity.Author) (*entity.Author, error) {
+	m := newAuthorModel(author)
+	if err := conn(ctx, r.db).Create(m).Error; err != nil {
+		if isUniqueViolation(err, authorSubjectUniqueConstraint) {
+			return nil, fmt.Errorf("subject %q: %w", *m.Subject, repository.ErrAuthorSubjectConflict)
+		}
+		return nil, fmt.Errorf("failed to create author: %w", err)
+	}
+	return m.toEntity()
+}
+
+func (r *AuthorRepository) Update(ctx context.Context, author *entity.Author) error {
+	m := newAuthorModel(author)
+	result := conn(ctx, r.db).Model(&authorModel{}).Where("id = ?", m.ID).Select("*").Omit("id", "created_at").Updates(m)
+	if result.Error != nil {
+		if isUniqueViolation(result.Error, authorSubjectUniqueConstraint) {
+			return fmt.Errorf("subject %q: %w", *m.Subject, repository.ErrAuthorSubjectConflict)
+		}
+		return fmt.Errorf("failed to update author %d: %w", m.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("author %d: %w", m.ID, repository.ErrAuthorNotFound)
+	}
+	return nil
+}
@@ -27,6 +27,11 @@ func NewPostgreSQLDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// ワークスペースごとのテーブルへの操作をコンテキストのワークスペースで絞り込む
+	if err := registerWorkspaceScope(db); err != nil {
+		return nil, err
+	}
+
 	// データベース接続プールの設定（オプション）
 	sqlDB, err := db.DB()
 	if err != nil {
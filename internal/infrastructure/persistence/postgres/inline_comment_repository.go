@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+// inlineCommentModel はinline_commentsテーブルのレコードを表す
+type inlineCommentModel struct {
+	ID                 uint64 `gorm:"primaryKey"`
+	WorkspaceID        uint64
+	ArticleID          uint64
+	ParentID           *uint64
+	AnchorStart        *int
+	AnchorEnd          *int
+	AnchorQuote        *string
+	AnchorPrefix       string
+	AnchorSuffix       string
+	Outdated           bool
+	CommenterName      string
+	CommenterAuthorID  *uint64
+	Body               string
+	ResolvedByName     *string
+	ResolvedByAuthorID *uint64
+	ResolvedAt         *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+func (inlineCommentModel) TableName() string {
+	return "inline_comments"
+}
+
+func newInlineCommentModel(c *entity.InlineComment) *inlineCommentModel {
+	m := &inlineCommentModel{
+		ID:                c.ID,
+		ArticleID:         c.ArticleID,
+		ParentID:          c.ParentID,
+		Outdated:          c.Outdated,
+		CommenterName:     c.Commenter.Name,
+		CommenterAuthorID: c.Commenter.AuthorID,
+		Body:              c.Body,
+		ResolvedAt:        c.ResolvedAt,
+		CreatedAt:         c.CreatedAt,
+		UpdatedAt:         c.UpdatedAt,
+	}
+	if a := c.Anchor; a != nil {
+		m.AnchorStart, m.AnchorEnd = &a.Start, &a.End
+		m.AnchorQuote, m.AnchorPrefix, m.AnchorSuffix = &a.Quote, a.Prefix, a.Suffix
+	}
+	if c.ResolvedBy != nil {
+		m.ResolvedByName = &c.ResolvedBy.Name
+		m.ResolvedByAuthorID = c.ResolvedBy.AuthorID
+	}
+	return m
+}
+
+func (m *inlineCommentModel) toEntity() *entity.InlineComment {
+	c := &entity.InlineComment{
+		ID:         m.ID,
+		ArticleID:  m.ArticleID,
+		ParentID:   m.ParentID,
+		Outdated:   m.Outdated,
+		Commenter:  entity.ReviewActor{Name: m.CommenterName, AuthorID: m.CommenterAuthorID},
+		Body:       m.Body,
+		ResolvedAt: m.ResolvedAt,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+	if m.AnchorStart != nil && m.AnchorEnd != nil && m.AnchorQuote != nil {
+		c.Anchor = &vo.TextAnchor{
+			Start:  *m.AnchorStart,
+			End:    *m.AnchorEnd,
+			Quote:  *m.AnchorQuote,
+			Prefix: m.AnchorPrefix,
+			Suffix: m.AnchorSuffix,
+		}
+	}
+	if m.ResolvedByName != nil {
+		c.ResolvedBy = &entity.ReviewActor{Name: *m.ResolvedByName, AuthorID: m.ResolvedByAuthorID}
+	}
+	return c
+}
+
+// InlineCommentRepository はrepository.InlineCommentRepositoryのPostgreSQL実装
+type InlineCommentRepository struct {
+	db *gorm.DB
+}
+
+var _ repository.InlineCommentRepository = (*InlineCommentRepository)(nil)
+
+func NewInlineCommentRepository(db *gorm.DB) *InlineCommentRepository {
+	return &InlineCommentRepository{db: db}
+}
+
+func (r *InlineCommentRepository) FindByArticleID(ctx context.Context, articleID uint64) ([]*entity.InlineComment, error) {
+	var models []inlineCommentModel
+	if err := conn(ctx, r.db).Where("article_id = ?", articleID).Order("id").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find inline comments of article %d: %w", articleID, err)
+	}
+	comments := make([]*entity.InlineComment, 0, len(models))
+	for i := range models {
+		comments = append(comments, models[i].toEntity())
+	}
+	return comments, nil
+}
+
+func (r *InlineCommentRepository) FindByID(ctx context.Context, id uint64) (*entity.InlineComment, error) {
+	var m inlineCommentModel
+	err := conn(ctx, r.db).Where("id = ?", id).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("inline comment %d: %w", id, repository.ErrInlineCommentNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find inline comment %d: %w", id, err)
+	}
+	return m.toEntity(), nil
+}
+
+func (r *InlineCommentRepository) Create(ctx context.Context, comment *entity.InlineComment) (*entity.InlineComment, error) {
+	m := newInlineCommentModel(comment)
+	if err := conn(ctx, r.db).Create(m).Error; err != nil {
+		return nil, fmt.Errorf("failed to create inline comment: %w", err)
+	}
+	comment.ID = m.ID
+	return comment, nil
+}
+
+func (r *InlineCommentRepository) Update(ctx context.Context, comment *entity.InlineComment) error {
+	m := newInlineCommentModel(comment)
+	result := conn(ctx, r.db).Model(&inlineCommentModel{}).Where("id = ?", m.ID).
+		Select("*").Omit("id", "article_id", "parent_id", "commenter_name", "commenter_author_id", "created_at").Updates(m)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update inline comment %d: %w", m.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("inline comment %d: %w", m.ID, repository.ErrInlineCommentNotFound)
+	}
+	return nil
+}
+
+func (r *InlineCommentRepository) Delete(ctx context.Context, id uint64) error {
+	result := conn(ctx, r.db).Where("id = ?", id).Delete(&inlineCommentModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete inline comment %d: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("inline comment %d: %w", id, repository.ErrInlineCommentNotFound)
+	}
+	return nil
+}
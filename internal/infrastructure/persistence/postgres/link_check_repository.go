@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+)
+
+// linkCheckModel はlink_checksテーブルのレコードを表す
+type linkCheckModel struct {
+	ID         uint64 `gorm:"primaryKey"`
+	ArticleID  uint64
+	URL        string
+	Source     string
+	Status     string
+	StatusCode *int
+	Redirects  []byte `gorm:"type:jsonb"`
+	LastError  *string
+	CheckedAt  time.Time
+}
+
+func (linkCheckModel) TableName() string {
+	return "link_checks"
+}
+
+func newLinkCheckModel(c *entity.LinkCheck) (*linkCheckModel, error) {
+	redirects, err := json.Marshal(c.Redirects)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal redirects of %s: %w", c.URL, err)
+	}
+	return &linkCheckModel{
+		ID:         c.ID,
+		ArticleID:  c.ArticleID,
+		URL:        c.URL,
+		Source:     c.Source.String(),
+		Status:     c.Status.String(),
+		StatusCode: c.StatusCode,
+		Redirects:  redirects,
+		LastError:  c.LastError,
+		CheckedAt:  c.CheckedAt,
+	}, nil
+}
+
+func (m *linkCheckModel) toEntity() (*entity.LinkCheck, error) {
+	redirects := []string{}
+	if len(m.Redirects) > 0 {
+		if err := json.Unmarshal(m.Redirects, &redirects); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal redirects of link check %d: %w", m.ID, err)
+		}
+	}
+	return &entity.LinkCheck{
+		ID:         m.ID,
+		ArticleID:  m.ArticleID,
+		URL:        m.URL,
+		Source:     entity.LinkSource(m.Source),
+		Status:     entity.LinkCheckStatus(m.Status),
+		StatusCode: m.StatusCode,
+		Redirects:  redirects,
+		LastError:  m.LastError,
+		CheckedAt:  m.CheckedAt,
+	}, nil
+}
+
+func toLinkCheckEntities(models []linkCheckModel) ([]*entity.LinkCheck, error) {
+	checks := make([]*entity.LinkCheck, 0, len(models))
+	for i := range models {
+		c, err := models[i].toEntity()
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, c)
+	}
+	return checks, nil
+}
+
+// LinkCheckRepository はrepository.LinkCheckRepositoryのPostgreSQL実装
+type LinkCheckRepository struct {
+	db *gorm.DB
+}
+
+var _ repository.LinkCheckRepository = (*LinkCheckRepository)(nil)
+
+func NewLinkCheckRepository(db *gorm.DB) *LinkCheckRepository {
+	return &LinkCheckRepository{db: db}
+}
+
+func (r *LinkCheckRepository) ReplaceForArticle(ctx context.Context, articleID uint64, checks []*entity.LinkCheck) error {
+	models := make([]*linkCheckModel, 0, len(checks))
+	urls := make([]string, 0, len(checks))
+	for _, c := range checks {
+		m, err := newLinkCheckModel(c)
+		if err != nil {
+			return err
+		}
+		m.ArticleID = articleID
+		models = append(models, m)
+		urls = append(urls, c.URL)
+	}
+
+	return withinTx(ctx, r.db, func(tx *gorm.DB) error {
+		// 記事から消えたURLの結果を削除する
+		query := tx.Where("article_id = ?", articleID)
+		if len(urls) > 0 {
+			query = query.Where("url NOT IN ?", urls)
+		}
+		if err := query.Delete(&linkCheckModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete stale link checks of article %d: %w", articleID, err)
+		}
+		if len(models) == 0 {
+			return nil
+		}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "article_id"}, {Name: "url"}},
+			DoUpdates: clause.AssignmentColumns([]string{"source", "status", "status_code", "redirects", "last_error", "checked_at"}),
+		}).Create(&models).Error
+		if err != nil {
+			return fmt.Errorf("failed to save link checks of article %d: %w", articleID, err)
+		}
+		return nil
+	})
+}
+
+func (r *LinkCheckRepository) FindByArticleID(ctx context.Context, articleID uint64) ([]*entity.LinkCheck, error) {
+	var models []linkCheckModel
+	if err := conn(ctx, r.db).Where("article_id = ?", articleID).Order("url").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find link checks of article %d: %w", articleID, err)
+	}
+	return toLinkCheckEntities(models)
+}
+
+func (r *LinkCheckRepository) FindBroken(ctx context.Context) ([]*entity.LinkCheck, error) {
+	var models []linkCheckModel
+	err := conn(ctx, r.db).
+		Joins("JOIN articles a ON a.id = link_checks.article_id AND a.deleted_at IS NULL").
+		Where("link_checks.status = ?", entity.LinkCheckBroken.String()).
+		Order("link_checks.article_id, link_checks.url").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find broken links: %w", err)
+	}
+	return toLinkCheckEntities(models)
+}
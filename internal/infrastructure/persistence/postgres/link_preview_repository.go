@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+)
+
+// linkPreviewModel はlink_previewsテーブルのレコードを表す
+type linkPreviewModel struct {
+	ID          uint64 `gorm:"primaryKey"`
+	URL         string
+	Title       string
+	Description string
+	Image       string
+	SiteName    string
+	Status      string
+	LastError   *string
+	FetchedAt   time.Time
+}
+
+func (linkPreviewModel) TableName() string {
+	return "link_previews"
+}
+
+func newLinkPreviewModel(p *entity.LinkPreview) *linkPreviewModel {
+	return &linkPreviewModel{
+		ID:          p.ID,
+		URL:         p.URL,
+		Title:       p.OpenGraph.Title,
+		Description: p.OpenGraph.Description,
+		Image:       p.OpenGraph.Image,
+		SiteName:    p.OpenGraph.SiteName,
+		Status:      p.Status.String(),
+		LastError:   p.LastError,
+		FetchedAt:   p.FetchedAt,
+	}
+}
+
+func (m *linkPreviewModel) toEntity() *entity.LinkPreview {
+	return &entity.LinkPreview{
+		ID:  m.ID,
+		URL: m.URL,
+		OpenGraph: entity.OpenGraph{
+			Title:       m.Title,
+			Description: m.Description,
+			Image:       m.Image,
+			SiteName:    m.SiteName,
+		},
+		Status:    entity.LinkPreviewStatus(m.Status),
+		LastError: m.LastError,
+		FetchedAt: m.FetchedAt,
+	}
+}
+
+// linkPreviewTargetRow は取得し直す対象のLinkと保存済みのプレビュー(未取得の場合はNULL)
+type linkPreviewTargetRow struct {
+	Link        string
+	ID          *uint64
+	Title       *string
+	Description *string
+	Image       *string
+	SiteName    *string
+	Status      *string
+	LastError   *string
+	FetchedAt   *time.Time
+}
+
+// LinkPreviewRepository はrepository.LinkPreviewRepositoryのPostgreSQL実装
+type LinkPreviewRepository struct {
+	db *gorm.DB
+}
+
+var _ repository.LinkPreviewRepository = (*LinkPreviewRepository)(nil)
+
+func NewLinkPreviewRepository(db *gorm.DB) *LinkPreviewRepository {
+	return &LinkPreviewRepository{db: db}
+}
+
+func (r *LinkPreviewRepository) FindByURL(ctx context.Context, url string) (*entity.LinkPreview, error) {
+	var m linkPreviewModel
+	err := conn(ctx, r.db).Where("url = ?", url).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("link preview %q: %w", url, repository.ErrLinkPreviewNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find link preview %q: %w", url, err)
+	}
+	return m.toEntity(), nil
+}
+
+func (r *LinkPreviewRepository) FindRefreshTargets(ctx context.Context, fetchedBefore, failedBefore time.Time, limit int) ([]*entity.LinkPreview, error) {
+	workspace, workspaceArgs, err := workspaceCondition(ctx, "a")
+	if err != nil {
+		return nil, err
+	}
+	var rows []linkPreviewTargetRow
+	// 未取得のURLを優先し、以降は取得日時の古い順
+	err = conn(ctx, r.db).Table("(SELECT DISTINCT p.link FROM article_publications p JOIN articles a ON a.id = p.article_id WHERE p.is_canonical AND p.link IS NOT NULL AND a.deleted_at IS NULL AND "+workspace+") l", workspaceArgs...).
+		Select("l.link, v.id, v.title, v.description, v.image, v.site_name, v.status, v.last_error, v.fetched_at").
+		Joins("LEFT JOIN link_previews v ON v.url = l.link").
+		Where("v.id IS NULL OR (v.status = ? AND v.fetched_at < ?) OR (v.status = ? AND v.fetched_at < ?)",
+			entity.LinkPreviewFetched.String(), fetchedBefore, entity.LinkPreviewFailed.String(), failedBefore).
+		Order("v.fetched_at ASC NULLS FIRST, l.link").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find link previews to refresh: %w", err)
+	}
+
+	previews := make([]*entity.LinkPreview, 0, len(rows))
+	for _, row := range rows {
+		if row.ID == nil {
+			p, err := entity.NewLinkPreview(row.Link)
+			if err != nil {
+				return nil, err
+			}
+			previews = append(previews, p)
+			continue
+		}
+		m := linkPreviewModel{
+			ID:          *row.ID,
+			URL:         row.Link,
+			Title:       *row.Title,
+			Description: *row.Description,
+			Image:       *row.Image,
+			SiteName:    *row.SiteName,
+			Status:      *row.Status,
+			LastError:   row.LastError,
+			FetchedAt:   *row.FetchedAt,
+		}
+		previews = append(previews, m.toEntity())
+	}
+	return previews, nil
+}
+
+func (r *LinkPreviewRepository) Save(ctx context.Context, preview *entity.LinkPreview) error {
+	m := newLinkPreviewModel(preview)
+	err := conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "url"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "description", "image", "site_name", "status", "last_error", "fetched_at"}),
+	}).Create(m).Error
+	if err != nil {
+		return fmt.Errorf("failed to save link preview %q: %w", preview.URL, err)
+	}
+	preview.ID = m.ID
+	return nil
+}
@@ -0,0 +1,268 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/outbox"
+)
+
+const (
+	outboxStatusPending   = "pending"
+	outboxStatusDelivered = "delivered"
+	outboxStatusDead      = "dead"
+
+	outboxAggregateArticle = "article"
+)
+
+// outboxModel はoutboxテーブルのレコードを表す
+type outboxModel struct {
+	ID            uint64 `gorm:"primaryKey"`
+	AggregateType string
+	AggregateID   uint64
+	EventType     string
+	Payload       []byte `gorm:"type:jsonb"`
+	Status        string
+	Attempts      int
+	LastError     *string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	DeliveredAt   *time.Time
+}
+
+func (outboxModel) TableName() string {
+	return "outbox"
+}
+
+// articleEventPayload はアウトボックスに記録する記事イベントのペイロード
+// WorkspaceIDは配信先をイベントの記事が属するワークスペースに限定するために使う
+type articleEventPayload struct {
+	Event       string     `json:"event"`
+	OccurredAt  time.Time  `json:"occurred_at"`
+	WorkspaceID uint64     `json:"workspace_id"`
+	Article     articleDTO `json:"article"`
+}
+
+type articleDTO struct {
+	ID           uint64           `json:"id"`
+	Title        string           `json:"title"`
+	Slug         string           `json:"slug"`
+	Body         string           `json:"body"`
+	Status       string           `json:"status"`
+	ProviderType string           `json:"provider_type"`
+	Link         string           `json:"link"`
+	Publications []publicationDTO `json:"publications"`
+	Tags         []string         `json:"tags"`
+	Metadata     metadataDTO      `json:"metadata"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	DeletedAt    *time.Time       `json:"deleted_at,omitempty"`
+}
+
+type publicationDTO struct {
+	ID           uint64     `json:"id"`
+	ProviderType string     `json:"provider_type"`
+	Link         string     `json:"link"`
+	ExternalID   *string    `json:"external_id,omitempty"`
+	PublishedAt  *time.Time `json:"published_at,omitempty"`
+	SyncStatus   string     `json:"sync_status"`
+	Canonical    bool       `json:"canonical"`
+}
+
+func newPublicationDTOs(pubs []entity.Publication) []publicationDTO {
+	dtos := make([]publicationDTO, 0, len(pubs))
+	for _, p := range pubs {
+		dtos = append(dtos, publicationDTO{
+			ID:           p.ID,
+			ProviderType: p.ProviderType.String(),
+			Link:         p.Link.String(),
+			ExternalID:   p.ExternalID,
+			PublishedAt:  p.PublishedAt,
+			SyncStatus:   p.SyncStatus.String(),
+			Canonical:    p.Canonical,
+		})
+	}
+	return dtos
+}
+
+type metadataDTO struct {
+	Excerpt            string       `json:"excerpt"`
+	CharCount          int          `json:"char_count"`
+	ReadingTimeMinutes int          `json:"reading_time_minutes"`
+	TOC                []headingDTO `json:"toc"`
+	Links              []string     `json:"links"`
+}
+
+type headingDTO struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+}
+
+func newMetadataDTO(m vo.ArticleMetadata) metadataDTO {
+	toc := make([]headingDTO, 0, len(m.Headings))
+	for _, h := range m.Headings {
+		toc = append(toc, headingDTO{Level: h.Level, Text: h.Text})
+	}
+	return metadataDTO{
+		Excerpt:            m.Excerpt,
+		CharCount:          m.CharCount,
+		ReadingTimeMinutes: m.ReadingTimeMinutes,
+		TOC:                toc,
+		Links:              m.Links,
+	}
+}
+
+// appendArticleEvents は記事のドメインイベントを同一トランザクション内でアウトボックスへ記録する
+func appendArticleEvents(tx *gorm.DB, article *entity.Article, events []entity.ArticleEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	dto := articleDTO{
+		ID:           article.ID,
+		Title:        article.Title.String(),
+		Slug:         article.Slug.String(),
+		Body:         article.Body.String(),
+		Status:       article.Status.String(),
+		ProviderType: article.ProviderType.String(),
+		Link:         article.Link.String(),
+		Publications: newPublicationDTOs(article.Publications),
+		Tags:         article.TagStrings(),
+		Metadata:     newMetadataDTO(article.Metadata()),
+		CreatedAt:    article.CreatedAt,
+		UpdatedAt:    article.UpdatedAt,
+		DeletedAt:    article.DeletedAt,
+	}
+
+	rows := make([]outboxModel, 0, len(events))
+	for _, e := range events {
+		payload, err := json.Marshal(articleEventPayload{
+			Event:       e.Type.String(),
+			OccurredAt:  e.OccurredAt,
+			WorkspaceID: article.WorkspaceID,
+			Article:     dto,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s payload: %w", e.Type, err)
+		}
+		rows = append(rows, outboxModel{
+			AggregateType: outboxAggregateArticle,
+			AggregateID:   article.ID,
+			EventType:     e.Type.String(),
+			Payload:       payload,
+			Status:        outboxStatusPending,
+			NextAttemptAt: e.OccurredAt,
+			CreatedAt:     e.OccurredAt,
+		})
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to append outbox messages: %w", err)
+	}
+	return nil
+}
+
+// 取得したメッセージを他のリレーから確保しておく期間の既定値
+const defaultOutboxClaimLease = 5 * time.Minute
+
+// OutboxStore はoutbox.StoreのPostgreSQL実装
+type OutboxStore struct {
+	db         *gorm.DB
+	claimLease time.Duration
+}
+
+var _ outbox.Store = (*OutboxStore)(nil)
+
+// NewOutboxStore はOutboxStoreを作成する
+// claimLeaseは取得したメッセージを他のリレーから確保しておく期間で、1バッチの配信にかかる時間より長くする
+// 0以下の場合は既定値を使う
+func NewOutboxStore(db *gorm.DB, claimLease time.Duration) *OutboxStore {
+	if claimLease <= 0 {
+		claimLease = defaultOutboxClaimLease
+	}
+	return &OutboxStore{db: db, claimLease: claimLease}
+}
+
+// FetchPending はメッセージの次の配信予定時刻をclaimLease後に進めて確保してから返す
+// 複数のリレーが同時に動いても同じメッセージは取得されず、配信中に停止したリレーのメッセージは期間を過ぎると再び取得される
+// 行ロックはSKIP LOCKEDで避けるため、他のリレーが確保中のメッセージを待たない
+func (s *OutboxStore) FetchPending(ctx context.Context, limit int) ([]outbox.Message, error) {
+	var models []outboxModel
+	// 同一集約でより古い未送信メッセージが残っている場合は対象外とし、集約単位の配信順序を守る
+	// 確保中のメッセージも未送信のため、他のリレーが同一集約の後続メッセージを先に配信することはない
+	err := conn(ctx, s.db).Raw(`
+		UPDATE outbox SET next_attempt_at = NOW() + ? * INTERVAL '1 second'
+		WHERE id IN (
+		  SELECT o.id FROM outbox o
+		  WHERE o.status = ?
+		    AND o.next_attempt_at <= NOW()
+		    AND NOT EXISTS (
+		      SELECT 1 FROM outbox p
+		      WHERE p.status = ?
+		        AND p.aggregate_type = o.aggregate_type
+		        AND p.aggregate_id = o.aggregate_id
+		        AND p.id < o.id
+		    )
+		  ORDER BY o.id
+		  LIMIT ?
+		  FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		s.claimLease.Seconds(), outboxStatusPending, outboxStatusPending, limit,
+	).Scan(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim pending outbox messages: %w", err)
+	}
+	// RETURNINGの順序は保証されないため、ID順に並べ直す
+	slices.SortFunc(models, func(a, b outboxModel) int { return cmp.Compare(a.ID, b.ID) })
+
+	messages := make([]outbox.Message, 0, len(models))
+	for _, m := range models {
+		messages = append(messages, outbox.Message{
+			ID:            m.ID,
+			AggregateType: m.AggregateType,
+			AggregateID:   m.AggregateID,
+			EventType:     m.EventType,
+			Payload:       m.Payload,
+			Attempts:      m.Attempts,
+			CreatedAt:     m.CreatedAt,
+		})
+	}
+	return messages, nil
+}
+
+func (s *OutboxStore) MarkDelivered(ctx context.Context, id uint64) error {
+	return s.update(ctx, id, map[string]any{
+		"status":       outboxStatusDelivered,
+		"delivered_at": time.Now(),
+	})
+}
+
+func (s *OutboxStore) MarkRetry(ctx context.Context, id uint64, attempts int, nextAttemptAt time.Time, lastError string) error {
+	return s.update(ctx, id, map[string]any{
+		"attempts":        attempts,
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      lastError,
+	})
+}
+
+func (s *OutboxStore) MarkDead(ctx context.Context, id uint64, attempts int, lastError string) error {
+	return s.update(ctx, id, map[string]any{
+		"status":     outboxStatusDead,
+		"attempts":   attempts,
+		"last_error": lastError,
+	})
+}
+
+func (s *OutboxStore) update(ctx context.Context, id uint64, values map[string]any) error {
+	if err := conn(ctx, s.db).Model(&outboxModel{}).Where("id = ?", id).Updates(values).Error; err != nil {
+		return fmt.Errorf("failed to update outbox message %d: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
+
+// 記事ごとに判断待ちのレビュー依頼を1件に制限する一意インデックス名
+const reviewRequestPendingUniqueIndex = "review_requests_pending_article_key"
+
+// reviewRequestModel はreview_requestsテーブルのレコードを表す
+type reviewRequestModel struct {
+	ID                  uint64 `gorm:"primaryKey"`
+	WorkspaceID         uint64
+	ArticleID           uint64
+	Status              string
+	SubmittedByName     string
+	SubmittedByAuthorID *uint64
+	DecidedByName       *string
+	DecidedByAuthorID   *uint64
+	DecidedAt           *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func (reviewRequestModel) TableName() string {
+	return "review_requests"
+}
+
+// reviewCommentModel はreview_commentsテーブルのレコードを表す
+type reviewCommentModel struct {
+	ID                uint64 `gorm:"primaryKey"`
+	WorkspaceID       uint64
+	ReviewRequestID   uint64
+	ParentID          *uint64
+	CommenterName     string
+	CommenterAuthorID *uint64
+	Body              string
+	CreatedAt         time.Time
+}
+
+func (reviewCommentModel) TableName() string {
+	return "review_comments"
+}
+
+func newReviewRequestModel(r *entity.ReviewRequest) *reviewRequestModel {
+	m := &reviewRequestModel{
+		ID:                  r.ID,
+		ArticleID:           r.ArticleID,
+		Status:              r.Status.String(),
+		SubmittedByName:     r.SubmittedBy.Name,
+		SubmittedByAuthorID: r.SubmittedBy.AuthorID,
+		DecidedAt:           r.DecidedAt,
+		CreatedAt:           r.CreatedAt,
+		UpdatedAt:           r.UpdatedAt,
+	}
+	if r.DecidedBy != nil {
+		m.DecidedByName = &r.DecidedBy.Name
+		m.DecidedByAuthorID = r.DecidedBy.AuthorID
+	}
+	return m
+}
+
+func (m *reviewRequestModel) toEntity(comments []reviewCommentModel) (*entity.ReviewRequest, error) {
+	status, err := vo.NewReviewStatus(m.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstitute review request %d: %w", m.ID, err)
+	}
+	r := &entity.ReviewRequest{
+		ID:          m.ID,
+		ArticleID:   m.ArticleID,
+		Status:      status,
+		SubmittedBy: entity.ReviewActor{Name: m.SubmittedByName, AuthorID: m.SubmittedByAuthorID},
+		DecidedAt:   m.DecidedAt,
+		Comments:    make([]entity.ReviewComment, 0, len(comments)),
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+	if m.DecidedByName != nil {
+		r.DecidedBy = &entity.ReviewActor{Name: *m.DecidedByName, AuthorID: m.DecidedByAuthorID}
+	}
+	for _, c := range comments {
+		r.Comments = append(r.Comments, entity.ReviewComment{
+			ID:        c.ID,
+			ParentID:  c.ParentID,
+			Commenter: entity.ReviewActor{Name: c.CommenterName, AuthorID: c.CommenterAuthorID},
+			Body:      c.Body,
+			CreatedAt: c.CreatedAt,
+		})
+	}
+	return r, nil
+}
+
+// ReviewRequestRepository はrepository.ReviewRequestRepositoryのPostgreSQL実装
+type ReviewRequestRepository struct {
+	db *gorm.DB
+}
+
+var _ repository.ReviewRequestRepository = (*ReviewRequestRepository)(nil)
+
+func NewReviewRequestRepository(db *gorm.DB) *ReviewRequestRepository {
+	return &ReviewRequestRepository{db: db}
+}
+
+func (r *ReviewRequestRepository) FindOpenByArticleID(ctx context.Context, articleID uint64) (*entity.ReviewRequest, error) {
+	open := []string{vo.ReviewStatusPending.String(), vo.ReviewStatusApproved.String()}
+	return r.findOne(ctx, conn(ctx, r.db).Where("article_id = ? AND status IN ?", articleID, open), articleID)
+}
+
+func (r *ReviewRequestRepository) FindLatestByArticleID(ctx context.Context, articleID uint64) (*entity.ReviewRequest, error) {
+	return r.findOne(ctx, conn(ctx, r.db).Where("article_id = ?", articleID), articleID)
+}
+
+func (r *ReviewRequestRepository) findOne(ctx context.Context, query *gorm.DB, articleID uint64) (*entity.ReviewRequest, error) {
+	var m reviewRequestModel
+	err := query.Order("created_at DESC, id DESC").Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("review request of article %d: %w", articleID, repository.ErrReviewRequestNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find review request of article %d: %w", articleID, err)
+	}
+	requests, err := r.withComments(ctx, []reviewRequestModel{m})
+	if err != nil {
+		return nil, err
+	}
+	return requests[0], nil
+}
+
+func (r *ReviewRequestRepository) FindByArticleID(ctx context.Context, articleID uint64) ([]*entity.ReviewRequest, error) {
+	var models []reviewRequestModel
+	if err := conn(ctx, r.db).Where("article_id = ?", articleID).Order("created_at DESC, id DESC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find review requests of article %d: %w", articleID, err)
+	}
+	return r.withComments(ctx, models)
+}
+
+func (r *ReviewRequestRepository) FindByStatus(ctx context.Context, status vo.ReviewStatus) ([]*entity.ReviewRequest, error) {
+	var models []reviewRequestModel
+	if err := conn(ctx, r.db).Where("status = ?", status.String()).Order("created_at, id").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find %s review requests: %w", status, err)
+	}
+	return r.withComments(ctx, models)
+}
+
+// withComments はレビュー依頼のコメントをまとめて読み込み、エンティティを再構築する
+func (r *ReviewRequestRepository) withComments(ctx context.Context, models []reviewRequestModel) ([]*entity.ReviewRequest, error) {
+	requests := make([]*entity.ReviewRequest, 0, len(models))
+	if len(models) == 0 {
+		return requests, nil
+	}
+	ids := make([]uint64, 0, len(models))
+	for _, m := range models {
+		ids = append(ids, m.ID)
+	}
+	var comments []reviewCommentModel
+	if err := conn(ctx, r.db).Where("review_request_id IN ?", ids).Order("id").Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("failed to find review comments: %w", err)
+	}
+	byRequest := make(map[uint64][]reviewCommentModel, len(models))
+	for _, c := range comments {
+		byRequest[c.ReviewRequestID] = append(byRequest[c.ReviewRequestID], c)
+	}
+	for i := range models {
+		req, err := models[i].toEntity(byRequest[models[i].ID])
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, req)
+	}
+	return requests, nil
+}
+
+func (r *ReviewRequestRepository) Create(ctx context.Context, request *entity.ReviewRequest) (*entity.ReviewRequest, error) {
+	m := newReviewRequestModel(request)
+	err := withinTx(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Create(m).Error; err != nil {
+			if isUniqueViolation(err, reviewRequestPendingUniqueIndex) {
+				return fmt.Errorf("article %d: %w", m.ArticleID, repository.ErrReviewRequestConflict)
+			}
+			return fmt.Errorf("failed to create review request: %w", err)
+		}
+		request.ID = m.ID
+		return insertReviewComments(tx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (r *ReviewRequestRepository) Update(ctx context.Context, request *entity.ReviewRequest) error {
+	m := newReviewRequestModel(request)
+	return withinTx(ctx, r.db, func(tx *gorm.DB) error {
+		result := tx.Model(&reviewRequestModel{}).Where("id = ?", m.ID).Select("*").Omit("id", "article_id", "created_at").Updates(m)
+		if result.Error != nil {
+			if isUniqueViolation(result.Error, reviewRequestPendingUniqueIndex) {
+				return fmt.Errorf("article %d: %w", m.ArticleID, repository.ErrReviewRequestConflict)
+			}
+			return fmt.Errorf("failed to update review request %d: %w", m.ID, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("review request %d: %w", m.ID, repository.ErrReviewRequestNotFound)
+		}
+		return insertReviewComments(tx, request)
+	})
+}
+
+// insertReviewComments はIDのない(未保存の)コメントを追加し、採番されたIDをエンティティに反映する
+func insertReviewComments(tx *gorm.DB, request *entity.ReviewRequest) error {
+	for i := range request.Comments {
+		c := &request.Comments[i]
+		if c.ID != 0 {
+			continue
+		}
+		m := &reviewCommentModel{
+			ReviewRequestID:   request.ID,
+			ParentID:          c.ParentID,
+			CommenterName:     c.Commenter.Name,
+			CommenterAuthorID: c.Commenter.AuthorID,
+			Body:              c.Body,
+			CreatedAt:         c.CreatedAt,
+		}
+		if err := tx.Create(m).Error; err != nil {
+			return fmt.Errorf("failed to add comment to review request %d: %w", request.ID, err)
+		}
+		c.ID = m.ID
+	}
+	return nil
+}
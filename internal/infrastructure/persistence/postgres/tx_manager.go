@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+)
+
+// txKey はコンテキストにトランザクションを格納するためのキー
+type txKey struct{}
+
+// TxManager はrepository.TxManagerのGORM実装
+// トランザクションをコンテキストに格納し、各リポジトリはconnを通じて透過的に利用する
+type TxManager struct {
+	db *gorm.DB
+}
+
+var _ repository.TxManager = (*TxManager)(nil)
+
+func NewTxManager(db *gorm.DB) *TxManager {
+	return &TxManager{db: db}
+}
+
+// WithinTx はfnをトランザクション内で実行する
+// 既にトランザクション内であれば、そのトランザクションに参加する
+// 新たに開始したトランザクションには行レベルセキュリティのためにコンテキストのワークスペースを設定する
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := setWorkspaceConfig(ctx, tx.Statement.ConnPool); err != nil {
+			return err
+		}
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// WithinSavepoint はコンテキストのトランザクション内でfnをセーブポイントで囲んで実行する
+// GORMはトランザクション内で開始したトランザクションをセーブポイントとして扱い、エラーの場合はセーブポイントまで巻き戻す
+func (m *TxManager) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, ok := txFromContext(ctx)
+	if !ok {
+		return m.WithinTx(ctx, fn)
+	}
+	return tx.WithContext(ctx).Transaction(func(sp *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, sp))
+	})
+}
+
+func txFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok
+}
+
+// conn はコンテキストにトランザクションがあればそれを、なければdbを返す
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// withinTx はコンテキストのトランザクションに参加するか、新たにトランザクションを開始してfnを実行する
+func withinTx(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if tx, ok := txFromContext(ctx); ok {
+		return fn(tx.WithContext(ctx))
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := setWorkspaceConfig(ctx, tx.Statement.ConnPool); err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
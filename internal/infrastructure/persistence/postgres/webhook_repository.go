@@ -0,0 +1,326 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+)
+
+// webhookSubscriptionModel はwebhook_subscriptionsテーブルのレコードを表す
+type webhookSubscriptionModel struct {
+	ID          uint64 `gorm:"primaryKey"`
+	URL         string
+	Secret      string
+	EventTypes  []string `gorm:"type:jsonb;serializer:json"`
+	Active      bool
+	WorkspaceID uint64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (webhookSubscriptionModel) TableName() string {
+	return "webhook_subscriptions"
+}
+
+func newWebhookSubscriptionModel(s *entity.WebhookSubscription) *webhookSubscriptionModel {
+	return &webhookSubscriptionModel{
+		ID:         s.ID,
+		URL:        s.URL,
+		Secret:     s.Secret,
+		EventTypes: s.EventTypeStrings(),
+		Active:     s.Active,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+func (m *webhookSubscriptionModel) toEntity() (*entity.WebhookSubscription, error) {
+	return entity.ReconstituteWebhookSubscription(
+		m.ID,
+		m.URL,
+		m.Secret,
+		m.EventTypes,
+		m.Active,
+		m.CreatedAt,
+		m.UpdatedAt,
+	)
+}
+
+// webhookDeliveryModel はwebhook_deliveriesテーブルのレコードを表す
+type webhookDeliveryModel struct {
+	ID             uint64 `gorm:"primaryKey"`
+	SubscriptionID uint64
+	EventType      string
+	Payload        []byte `gorm:"type:jsonb"`
+	Status         string
+	Attempts       int
+	ResponseStatus *int
+	LastError      *string
+	NextAttemptAt  time.Time
+	ReplayOf       *uint64
+	MessageID      *uint64
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+func (webhookDeliveryModel) TableName() string {
+	return "webhook_deliveries"
+}
+
+func newWebhookDeliveryModel(d *entity.WebhookDelivery) *webhookDeliveryModel {
+	return &webhookDeliveryModel{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventType:      d.EventType.String(),
+		Payload:        d.Payload,
+		Status:         d.Status.String(),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		NextAttemptAt:  d.NextAttemptAt,
+		ReplayOf:       d.ReplayOf,
+		MessageID:      d.MessageID,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
+
+func (m *webhookDeliveryModel) toEntity() *entity.WebhookDelivery {
+	return &entity.WebhookDelivery{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		EventType:      entity.ArticleEventType(m.EventType),
+		Payload:        m.Payload,
+		Status:         entity.WebhookDeliveryStatus(m.Status),
+		Attempts:       m.Attempts,
+		ResponseStatus: m.ResponseStatus,
+		LastError:      m.LastError,
+		NextAttemptAt:  m.NextAttemptAt,
+		ReplayOf:       m.ReplayOf,
+		MessageID:      m.MessageID,
+		CreatedAt:      m.CreatedAt,
+		DeliveredAt:    m.DeliveredAt,
+	}
+}
+
+// WebhookSubscriptionRepository はrepository.WebhookSubscriptionRepositoryのPostgreSQL実装
+type WebhookSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+var _ repository.WebhookSubscriptionRepository = (*WebhookSubscriptionRepository)(nil)
+
+func NewWebhookSubscriptionRepository(db *gorm.DB) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{db: db}
+}
+
+func (r *WebhookSubscriptionRepository) FindAll(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	var models []webhookSubscriptionModel
+	if err := conn(ctx, r.db).Order("id").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find webhook subscriptions: %w", err)
+	}
+	return toWebhookSubscriptionEntities(models)
+}
+
+func (r *WebhookSubscriptionRepository) FindByID(ctx context.Context, id uint64) (*entity.WebhookSubscription, error) {
+	var m webhookSubscriptionModel
+	err := conn(ctx, r.db).Where("id = ?", id).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("webhook subscription %d: %w", id, repository.ErrWebhookSubscriptionNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook subscription %d: %w", id, err)
+	}
+	return m.toEntity()
+}
+
+func (r *WebhookSubscriptionRepository) FindActiveByEventType(ctx context.Context, eventType entity.ArticleEventType) ([]*entity.WebhookSubscription, error) {
+	filter, err := json.Marshal([]string{eventType.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build event type filter: %w", err)
+	}
+	var models []webhookSubscriptionModel
+	err = conn(ctx, r.db).
+		Where("active AND event_types @> ?::jsonb", string(filter)).
+		Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook subscriptions for %s: %w", eventType, err)
+	}
+	return toWebhookSubscriptionEntities(models)
+}
+
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	m := newWebhookSubscriptionModel(subscription)
+	if err := conn(ctx, r.db).Create(m).Error; err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return m.toEntity()
+}
+
+func (r *WebhookSubscriptionRepository) Update(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	m := newWebhookSubscriptionModel(subscription)
+	result := conn(ctx, r.db).Model(&webhookSubscriptionModel{}).Where("id = ?", m.ID).Select("*").Omit("id", "created_at").Updates(m)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update webhook subscription %d: %w", m.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook subscription %d: %w", m.ID, repository.ErrWebhookSubscriptionNotFound)
+	}
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id uint64) error {
+	result := conn(ctx, r.db).Where("id = ?", id).Delete(&webhookSubscriptionModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook subscription %d: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook subscription %d: %w", id, repository.ErrWebhookSubscriptionNotFound)
+	}
+	return nil
+}
+
+func toWebhookSubscriptionEntities(models []webhookSubscriptionModel) ([]*entity.WebhookSubscription, error) {
+	subs := make([]*entity.WebhookSubscription, 0, len(models))
+	for i := range models {
+		s, err := models[i].toEntity()
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, s)
+	}
+	return subs, nil
+}
+
+// 取得した配信を他のワーカーから確保しておく期間の既定値
+const defaultWebhookClaimLease = 5 * time.Minute
+
+// WebhookDeliveryRepository はrepository.WebhookDeliveryRepositoryのPostgreSQL実装
+type WebhookDeliveryRepository struct {
+	db         *gorm.DB
+	claimLease time.Duration
+}
+
+var _ repository.WebhookDeliveryRepository = (*WebhookDeliveryRepository)(nil)
+
+// NewWebhookDeliveryRepository はWebhookDeliveryRepositoryを作成する
+// claimLeaseは取得した配信を他のワーカーから確保しておく期間で、1バッチの配信にかかる時間より長くする
+// 0以下の場合は既定値を使う
+func NewWebhookDeliveryRepository(db *gorm.DB, claimLease time.Duration) *WebhookDeliveryRepository {
+	if claimLease <= 0 {
+		claimLease = defaultWebhookClaimLease
+	}
+	return &WebhookDeliveryRepository{db: db, claimLease: claimLease}
+}
+
+func (r *WebhookDeliveryRepository) FindByID(ctx context.Context, id uint64) (*entity.WebhookDelivery, error) {
+	var m webhookDeliveryModel
+	err := conn(ctx, r.db).Where("id = ?", id).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("webhook delivery %d: %w", id, repository.ErrWebhookDeliveryNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook delivery %d: %w", id, err)
+	}
+	return m.toEntity(), nil
+}
+
+func (r *WebhookDeliveryRepository) FindBySubscriptionID(ctx context.Context, subscriptionID uint64, limit int) ([]*entity.WebhookDelivery, error) {
+	var models []webhookDeliveryModel
+	err := conn(ctx, r.db).
+		Where("subscription_id = ?", subscriptionID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook deliveries for subscription %d: %w", subscriptionID, err)
+	}
+	return toWebhookDeliveryEntities(models), nil
+}
+
+// FindDue は配信の次の配信予定時刻をclaimLease後に進めて確保してから返す
+// 複数のワーカーが同時に動いても同じ配信は取得されず、行ロックはSKIP LOCKEDで避けるため他のワーカーを待たない
+// 配信の結果を保存すると、次の配信予定時刻は結果に応じた値に置き換わる
+func (r *WebhookDeliveryRepository) FindDue(ctx context.Context, limit int) ([]*entity.WebhookDelivery, error) {
+	var models []webhookDeliveryModel
+	err := withinTx(ctx, r.db, func(tx *gorm.DB) error {
+		return tx.Raw(`
+			UPDATE webhook_deliveries SET next_attempt_at = NOW() + ? * INTERVAL '1 second'
+			WHERE id IN (
+			  SELECT id FROM webhook_deliveries
+			  WHERE status = ? AND next_attempt_at <= NOW()
+			  ORDER BY id
+			  LIMIT ?
+			  FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *`,
+			r.claimLease.Seconds(), entity.WebhookDeliveryPending.String(), limit,
+		).Scan(&models).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due webhook deliveries: %w", err)
+	}
+	// RETURNINGの順序は保証されないため、ID順に並べ直す
+	slices.SortFunc(models, func(a, b webhookDeliveryModel) int { return cmp.Compare(a.ID, b.ID) })
+	return toWebhookDeliveryEntities(models), nil
+}
+
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	m := newWebhookDeliveryModel(delivery)
+	if err := conn(ctx, r.db).Create(m).Error; err != nil {
+		return nil, fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+	return m.toEntity(), nil
+}
+
+// CreateAll は1つのINSERTで作成し、メッセージと購読の一意制約に違反するものは読み飛ばす
+func (r *WebhookDeliveryRepository) CreateAll(ctx context.Context, deliveries []*entity.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	models := make([]*webhookDeliveryModel, 0, len(deliveries))
+	for _, d := range deliveries {
+		models = append(models, newWebhookDeliveryModel(d))
+	}
+	return withinTx(ctx, r.db, func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "message_id"}, {Name: "subscription_id"}},
+			DoNothing: true,
+		}).Create(&models).Error
+		if err != nil {
+			return fmt.Errorf("failed to create webhook deliveries: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	m := newWebhookDeliveryModel(delivery)
+	result := conn(ctx, r.db).Model(&webhookDeliveryModel{}).Where("id = ?", m.ID).Select("*").Omit("id", "created_at").Updates(m)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update webhook delivery %d: %w", m.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook delivery %d: %w", m.ID, repository.ErrWebhookDeliveryNotFound)
+	}
+	return nil
+}
+
+func toWebhookDeliveryEntities(models []webhookDeliveryModel) []*entity.WebhookDelivery {
+	deliveries := make([]*entity.WebhookDelivery, 0, len(models))
+	for i := range models {
+		deliveries = append(deliveries, models[i].toEntity())
+	}
+	return deliveries
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+)
+
+// スラッグの一意制約名
+const workspaceSlugUniqueConstraint = "workspaces_slug_key"
+
+// workspaceModel はworkspacesテーブルのレコードを表す
+type workspaceModel struct {
+	ID                  uint64 `gorm:"primaryKey"`
+	Slug                string
+	Name                string
+	Settings            entity.WorkspaceSettings `gorm:"type:jsonb;serializer:json"`
+	ProviderCredentials map[string]string        `gorm:"type:jsonb;serializer:json"`
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func (workspaceModel) TableName() string {
+	return "workspaces"
+}
+
+func newWorkspaceModel(w *entity.Workspace) *workspaceModel {
+	return &workspaceModel{
+		ID:                  w.ID,
+		Slug:                w.Slug,
+		Name:                w.Name,
+		Settings:            w.Settings,
+		ProviderCredentials: w.ProviderCredentialStrings(),
+		CreatedAt:           w.CreatedAt,
+		UpdatedAt:           w.UpdatedAt,
+	}
+}
+
+func (m *workspaceModel) toEntity() (*entity.Workspace, error) {
+	return entity.ReconstituteWorkspace(
+		m.ID,
+		m.Slug,
+		m.Name,
+		m.Settings,
+		m.ProviderCredentials,
+		m.CreatedAt,
+		m.UpdatedAt,
+	)
+}
+
+// WorkspaceRepository はrepository.WorkspaceRepositoryのPostgreSQL実装
+type WorkspaceRepository struct {
+	db *gorm.DB
+}
+
+var _ repository.WorkspaceRepository = (*WorkspaceRepository)(nil)
+
+func NewWorkspaceRepository(db *gorm.DB) *WorkspaceRepository {
+	return &WorkspaceRepository{db: db}
+}
+
+func (r *WorkspaceRepository) FindAll(ctx context.Context) ([]*entity.Workspace, error) {
+	var models []workspaceModel
+	if err := conn(ctx, r.db).Order("id").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find workspaces: %w", err)
+	}
+	workspaces := make([]*entity.Workspace, 0, len(models))
+	for i := range models {
+		w, err := models[i].toEntity()
+		if err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, w)
+	}
+	return workspaces, nil
+}
+
+func (r *WorkspaceRepository) FindByID(ctx context.Context, id uint64) (*entity.Workspace, error) {
+	var m workspaceModel
+	err := conn(ctx, r.db).Where("id = ?", id).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("workspace %d: %w", id, repository.ErrWorkspaceNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find workspace %d: %w", id, err)
+	}
+	return m.toEntity()
+}
+
+func (r *WorkspaceRepository) FindBySlug(ctx context.Context, slug string) (*entity.Workspace, error) {
+	var m workspaceModel
+	err := conn(ctx, r.db).Where("slug = ?", slug).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("workspace %q: %w", slug, repository.ErrWorkspaceNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find workspace %q: %w", slug, err)
+	}
+	return m.toEntity()
+}
+
+func (r *WorkspaceRepository) Create(ctx context.Context, workspace *entity.Workspace) (*entity.Workspace, error) {
+	m := newWorkspaceModel(workspace)
+	if err := conn(ctx, r.db).Create(m).Error; err != nil {
+		if isUniqueViolation(err, workspaceSlugUniqueConstraint) {
+			return nil, fmt.Errorf("slug %q: %w", m.Slug, repository.ErrWorkspaceSlugConflict)
+		}
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+	return m.toEntity()
+}
+
+func (r *WorkspaceRepository) Update(ctx context.Context, workspace *entity.Workspace) error {
+	m := newWorkspaceModel(workspace)
+	result := conn(ctx, r.db).Model(&workspaceModel{}).Where("id = ?", m.ID).Select("*").Omit("id", "slug", "created_at").Updates(m)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update workspace %d: %w", m.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("workspace %d: %w", m.ID, repository.ErrWorkspaceNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+)
+
+// workspace_idカラムを持つテーブル
+var workspaceTables = map[string]bool{
+	"articles":              true,
+	"authors":               true,
+	"api_keys":              true,
+	"webhook_subscriptions": true,
+	"article_slug_history":  true,
+	"review_requests":       true,
+	"review_comments":       true,
+	"inline_comments":       true,
+	"audit_log":             true,
+}
+
+// workspaceParent は親のテーブルを通じてワークスペースに属するテーブルの、親のテーブルと参照するカラム
+type workspaceParent struct {
+	table  string
+	column string
+}
+
+var workspaceChildTables = map[string]workspaceParent{
+	"article_tags":         {table: "articles", column: "article_id"},
+	"article_publications": {table: "articles", column: "article_id"},
+	"article_metrics":      {table: "articles", column: "article_id"},
+	"link_checks":          {table: "articles", column: "article_id"},
+	"webhook_deliveries":   {table: "webhook_subscriptions", column: "subscription_id"},
+}
+
+// registerWorkspaceScope はワークスペースごとのテーブルへの操作をコンテキストのワークスペースで絞り込むコールバックを登録する
+// コンテキストにワークスペースがない場合は操作をErrWorkspaceRequiredで失敗させ、作成する行にはワークスペースを設定する
+// 別名を付けたテーブル(Table("articles a"))と生のSQLは対象にならないため、workspaceConditionで明示的に絞り込む
+// 行レベルセキュリティはワークスペースの設定がなければ行を見せないため、トランザクション外の操作もトランザクションで囲んで設定する
+// Rows / Row / Scanは呼び出し元が読み終えるまでトランザクションを閉じられないため、withinTxの中で使う
+func registerWorkspaceScope(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Query().Before("gorm:query").Register("workspace:begin_transaction", beginWorkspaceTransaction); err != nil {
+		return fmt.Errorf("failed to register workspace scope: %w", err)
+	}
+	if err := cb.Query().After("gorm:after_query").Register("workspace:commit_or_rollback_transaction", commitWorkspaceTransaction); err != nil {
+		return fmt.Errorf("failed to register workspace scope: %w", err)
+	}
+	if err := cb.Raw().Before("gorm:raw").Register("workspace:begin_transaction", beginWorkspaceTransaction); err != nil {
+		return fmt.Errorf("failed to register workspace scope: %w", err)
+	}
+	if err := cb.Raw().After("gorm:raw").Register("workspace:commit_or_rollback_transaction", commitWorkspaceTransaction); err != nil {
+		return fmt.Errorf("failed to register workspace scope: %w", err)
+	}
+	// 作成・更新・削除はGORMが開始するトランザクションに設定する
+	if err := cb.Create().After("gorm:begin_transaction").Register("workspace:config", configureWorkspaceTransaction); err != nil {
+		return fmt.Errorf("failed to register workspace scope: %w", err)
+	}
+	if err := cb.Update().After("gorm:begin_transaction").Register("workspace:config", configureWorkspaceTransaction); err != nil {
+		return fmt.Errorf("failed to register workspace scope: %w", err)
+	}
+	if err := cb.Delete().After("gorm:begin_transaction").Register("workspace:config", configureWorkspaceTransaction); err != nil {
+		return fmt.Errorf("failed to register workspace scope: %w", err)
+	}
+	if err := cb.Query().Before("gorm:query").Register("workspace:query", scopeWorkspace); err != nil {
+		return fmt.Errorf("failed to register workspace scope: %w", err)
+	}
+	if err := cb.Row().Before("gorm:row").Register("workspace:row", scopeWorkspace); err != nil {
+		return fmt.Errorf("failed to register workspace scope: %w", err)
+	}
+	if err := cb.Update().Before("gorm:update").Register("workspace:update", scopeWorkspaceUpdate); err != nil {
+		return fmt.Errorf("failed to register workspace scope: %w", err)
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("workspace:delete", scopeWorkspace); err != nil {
+		return fmt.Errorf("failed to register workspace scope: %w", err)
+	}
+	if err := cb.Create().Before("gorm:create").Register("workspace:create", assignWorkspace); err != nil {
+		return fmt.Errorf("failed to register workspace scope: %w", err)
+	}
+	return nil
+}
+
+// workspaceTxKey はbeginWorkspaceTransactionがトランザクションを開始したことを記録するキー
+const workspaceTxKey = "workspace:started_transaction"
+
+// beginWorkspaceTransaction はトランザクション外の参照と生のSQLをトランザクションで囲み、ワークスペースを設定する
+// 既にトランザクション内であれば、開始時に設定済みのため何もしない
+func beginWorkspaceTransaction(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	if _, _, ok := repository.WorkspaceFrom(db.Statement.Context); !ok {
+		return
+	}
+	tx := db.Begin()
+	if errors.Is(tx.Error, gorm.ErrInvalidTransaction) {
+		return
+	}
+	if tx.Error != nil {
+		_ = db.AddError(tx.Error)
+		return
+	}
+	db.Statement.ConnPool = tx.Statement.ConnPool
+	db.InstanceSet(workspaceTxKey, true)
+	if err := setWorkspaceConfig(db.Statement.Context, db.Statement.ConnPool); err != nil {
+		_ = db.AddError(err)
+	}
+}
+
+// commitWorkspaceTransaction はbeginWorkspaceTransactionが開始したトランザクションを終了する
+func commitWorkspaceTransaction(db *gorm.DB) {
+	if _, ok := db.InstanceGet(workspaceTxKey); !ok {
+		return
+	}
+	if db.Error != nil {
+		db.Rollback()
+	} else {
+		db.Commit()
+	}
+	db.Statement.ConnPool = db.ConnPool
+}
+
+// configureWorkspaceTransaction はGORMが作成・更新・削除のために開始したトランザクションにワークスペースを設定する
+func configureWorkspaceTransaction(db *gorm.DB) {
+	if _, ok := db.InstanceGet("gorm:started_transaction"); !ok || db.Error != nil {
+		return
+	}
+	if err := setWorkspaceConfig(db.Statement.Context, db.Statement.ConnPool); err != nil {
+		_ = db.AddError(err)
+	}
+}
+
+// scopeWorkspace は参照・更新・削除の条件にコンテキストのワークスペースを加える
+func scopeWorkspace(db *gorm.DB) {
+	stmt := db.Statement
+	if stmt.SQL.Len() > 0 {
+		return
+	}
+	table := stmt.Table
+	parent, isChild := workspaceChildTables[table]
+	if !workspaceTables[table] && !isChild {
+		return
+	}
+
+	id, all, ok := repository.WorkspaceFrom(stmt.Context)
+	if !ok {
+		_ = db.AddError(fmt.Errorf("%s: %w", table, repository.ErrWorkspaceRequired))
+		return
+	}
+	if all {
+		return
+	}
+	var condition clause.Expression = clause.Eq{Column: clause.Column{Table: table, Name: "workspace_id"}, Value: id}
+	if isChild {
+		condition = clause.Expr{
+			SQL:  fmt.Sprintf("EXISTS (SELECT 1 FROM %s w WHERE w.id = %s.%s AND w.workspace_id = ?)", parent.table, table, parent.column),
+			Vars: []any{id},
+		}
+	}
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{condition}})
+}
+
+// scopeWorkspaceUpdate は更新を絞り込み、ワークスペースは変更できないため更新するカラムからworkspace_idを除く
+func scopeWorkspaceUpdate(db *gorm.DB) {
+	if workspaceTables[db.Statement.Table] {
+		db.Statement.Omits = append(db.Statement.Omits, "workspace_id")
+	}
+	scopeWorkspace(db)
+}
+
+// assignWorkspace は作成する行にコンテキストのワークスペースを設定する
+// 全てのワークスペースを操作するコンテキストでは、どのワークスペースに作成するかが決まらないため失敗させる
+func assignWorkspace(db *gorm.DB) {
+	stmt := db.Statement
+	if stmt.Schema == nil || !workspaceTables[stmt.Table] {
+		return
+	}
+	id, all, ok := repository.WorkspaceFrom(stmt.Context)
+	if !ok || all {
+		_ = db.AddError(fmt.Errorf("%s: %w", stmt.Table, repository.ErrWorkspaceRequired))
+		return
+	}
+	field := stmt.Schema.LookUpField("workspace_id")
+	if field == nil {
+		_ = db.AddError(fmt.Errorf("%s: model has no workspace_id field", stmt.Table))
+		return
+	}
+	switch rv := stmt.ReflectValue; rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := field.Set(stmt.Context, reflect.Indirect(rv.Index(i)), id); err != nil {
+				_ = db.AddError(err)
+				return
+			}
+		}
+	case reflect.Struct:
+		if err := field.Set(stmt.Context, rv, id); err != nil {
+			_ = db.AddError(err)
+		}
+	}
+}
+
+// workspaceCondition は別名aliasのテーブルをコンテキストのワークスペースで絞り込む条件を返す
+// 全てのワークスペースを操作するコンテキストでは常に真の条件を返す
+func workspaceCondition(ctx context.Context, alias string) (string, []any, error) {
+	id, all, ok := repository.WorkspaceFrom(ctx)
+	if !ok {
+		return "", nil, fmt.Errorf("%s: %w", alias, repository.ErrWorkspaceRequired)
+	}
+	if all {
+		return "TRUE", nil, nil
+	}
+	return alias + ".workspace_id = ?", []any{id}, nil
+}
+
+// setWorkspaceConfig はトランザクションの行レベルセキュリティにコンテキストのワークスペースを設定する
+// 設定はトランザクションの終了とともに破棄される
+func setWorkspaceConfig(ctx context.Context, tx gorm.ConnPool) error {
+	id, all, ok := repository.WorkspaceFrom(ctx)
+	var err error
+	switch {
+	case !ok:
+		return nil
+	case all:
+		_, err = tx.ExecContext(ctx, "SELECT set_config('app.all_workspaces', 'on', true)")
+	default:
+		_, err = tx.ExecContext(ctx, "SELECT set_config('app.workspace_id', $1, true)", strconv.FormatUint(id, 10))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set workspace of transaction: %w", err)
+	}
+	return nil
+}
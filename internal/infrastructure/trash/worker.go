@@ -0,0 +1,43 @@
+package trash
+
+import (
+	"context"
+	"log"
+	"time"
+
+	usecase "github.com/umekikazuya/momenture-article-hub/internal/usecase/trash"
+)
+
+// Worker は保持期間を過ぎた削除済みの記事を定期的に完全に削除する
+type Worker struct {
+	uc       *usecase.TrashUsecase
+	interval time.Duration
+}
+
+func NewWorker(uc *usecase.TrashUsecase, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	return &Worker{uc: uc, interval: interval}
+}
+
+// Run は起動直後に1回削除し、以降はコンテキストがキャンセルされるまで間隔ごとに削除する
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := w.uc.Purge(ctx, usecase.PurgeInput{})
+		if err != nil {
+			log.Printf("trash worker: %v", err)
+		} else if report.Purged > 0 {
+			log.Printf("trash worker: purged=%d cutoff=%s", report.Purged, report.Cutoff.Format(time.RFC3339))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
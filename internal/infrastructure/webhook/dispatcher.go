@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/outbox"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+	usecase "github.com/umekikazuya/momenture-article-hub/internal/usecase/webhook"
+)
+
+// articleEventMessage はアウトボックスに記録された記事イベントのペイロード
+// articleのフィールドはFindArticleByIDOutputと同じJSON表現になっている
+type articleEventMessage struct {
+	Event       string                        `json:"event"`
+	OccurredAt  time.Time                     `json:"occurred_at"`
+	WorkspaceID uint64                        `json:"workspace_id"`
+	Article     article.FindArticleByIDOutput `json:"article"`
+}
+
+// Dispatcher はアウトボックスの記事イベントをWebhook配信キューへ振り分けるoutbox.Publisher
+// イベントは記事と同じワークスペースの購読にだけ配信する
+type Dispatcher struct {
+	uc *usecase.WebhookUsecase
+}
+
+var _ outbox.Publisher = (*Dispatcher)(nil)
+
+func NewDispatcher(uc *usecase.WebhookUsecase) *Dispatcher {
+	return &Dispatcher{uc: uc}
+}
+
+func (d *Dispatcher) Publish(ctx context.Context, msg outbox.Message) error {
+	if msg.AggregateType != "article" {
+		return nil
+	}
+	var event articleEventMessage
+	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+		return fmt.Errorf("failed to decode outbox message %d: %w", msg.ID, err)
+	}
+	// ワークスペースの導入前に記録されたイベントは既定のワークスペースに属する
+	workspaceID := event.WorkspaceID
+	if workspaceID == 0 {
+		workspaceID = entity.DefaultWorkspaceID
+	}
+	return d.uc.Dispatch(repository.WithWorkspace(ctx, workspaceID), usecase.DispatchInput{
+		MessageID:  msg.ID,
+		Event:      event.Event,
+		OccurredAt: event.OccurredAt,
+		Article:    event.Article,
+	})
+}
+
+// Worker は配信待ちのWebhookを定期的に送信する
+type Worker struct {
+	uc        *usecase.WebhookUsecase
+	interval  time.Duration
+	batchSize int
+}
+
+func NewWorker(uc *usecase.WebhookUsecase, interval time.Duration, batchSize int) *Worker {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	if batchSize <= 0 {
+		batchSize = 50
+	}
+	return &Worker{uc: uc, interval: interval, batchSize: batchSize}
+}
+
+// Run はコンテキストがキャンセルされるまで配信を続ける
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := w.uc.DeliverDue(ctx, w.batchSize)
+			if err != nil {
+				log.Printf("webhook worker: %v", err)
+				break
+			}
+			if n < w.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/netguard"
+	usecase "github.com/umekikazuya/momenture-article-hub/internal/usecase/webhook"
+)
+
+const (
+	// SignatureHeader はペイロードのHMAC-SHA256署名を格納するヘッダー
+	SignatureHeader = "X-Webhook-Signature-256"
+	// TimestampHeader は署名に含めた送信時刻(UNIX秒)を格納するヘッダー
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// HTTPSender はWebhookをHTTP POSTで送信する
+type HTTPSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+var _ usecase.Sender = (*HTTPSender)(nil)
+
+// NewHTTPSender はHTTPSenderを作成する
+// 送信先のURLはワークスペースの管理者が指定するため、clientがnilの場合は公開アドレスにだけ接続するクライアントを使う
+// リダイレクトは追わず、3xxの応答は配信の失敗として扱う
+func NewHTTPSender(client *http.Client) *HTTPSender {
+	if client == nil {
+		client = netguard.NewClient(10 * time.Second)
+	}
+	c := *client
+	c.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &HTTPSender{client: &c, now: time.Now}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, req usecase.DeliveryRequest) (int, error) {
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "momenture-article-hub-webhook")
+	httpReq.Header.Set(EventHeader, req.EventType)
+	httpReq.Header.Set(DeliveryHeader, strconv.FormatUint(req.DeliveryID, 10))
+	httpReq.Header.Set(TimestampHeader, timestamp)
+	httpReq.Header.Set(SignatureHeader, "sha256="+Sign(req.Secret, timestamp, req.Payload))
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign は "<timestamp>.<payload>" に対するHMAC-SHA256署名を16進文字列で返す
+// 受信側は同じ計算を行い、hmac.Equalで比較することで改ざんとリプレイを検知できる
+func Sign(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,105 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/netguard"
+	"github.com/umekikazuya/momenture-article-hub/internal/infrastructure/webhook"
+	usecase "github.com/umekikazuya/momenture-article-hub/internal/usecase/webhook"
+)
+
+func TestHTTPSender_Send(t *testing.T) {
+	t.Parallel()
+
+	t.Run("HMAC-SHA256署名付きで送信する", func(t *testing.T) {
+		t.Parallel()
+		var gotSignature, gotTimestamp, gotEvent string
+		var gotBody []byte
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotBody, _ = io.ReadAll(r.Body)
+			gotSignature = r.Header.Get(webhook.SignatureHeader)
+			gotTimestamp = r.Header.Get(webhook.TimestampHeader)
+			gotEvent = r.Header.Get(webhook.EventHeader)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		sender := webhook.NewHTTPSender(srv.Client())
+		status, err := sender.Send(context.Background(), usecase.DeliveryRequest{
+			DeliveryID: 1,
+			URL:        srv.URL,
+			Secret:     "0123456789abcdef",
+			EventType:  "article.published",
+			Payload:    []byte(`{"event":"article.published"}`),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "article.published", gotEvent)
+		assert.Equal(t, "sha256="+webhook.Sign("0123456789abcdef", gotTimestamp, gotBody), gotSignature)
+	})
+
+	t.Run("2xx以外はステータスコードとエラーを返す", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer srv.Close()
+
+		sender := webhook.NewHTTPSender(srv.Client())
+		status, err := sender.Send(context.Background(), usecase.DeliveryRequest{URL: srv.URL, Payload: []byte(`{}`)})
+
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusGone, status)
+	})
+
+	t.Run("リダイレクトは追わずに失敗として返す", func(t *testing.T) {
+		t.Parallel()
+		redirected := false
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			redirected = true
+		}))
+		defer target.Close()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+		}))
+		defer srv.Close()
+
+		sender := webhook.NewHTTPSender(srv.Client())
+		status, err := sender.Send(context.Background(), usecase.DeliveryRequest{URL: srv.URL, Payload: []byte(`{}`)})
+
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusTemporaryRedirect, status)
+		assert.False(t, redirected)
+	})
+
+	t.Run("既定のクライアントは公開されていないアドレスに送信しない", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("the request must not reach a loopback address")
+		}))
+		defer srv.Close()
+
+		sender := webhook.NewHTTPSender(nil)
+		_, err := sender.Send(context.Background(), usecase.DeliveryRequest{URL: srv.URL, Payload: []byte(`{}`)})
+
+		assert.ErrorIs(t, err, netguard.ErrNonPublicAddress)
+	})
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		webhook.Sign("secret", "1700000000", []byte("{}")),
+	)
+}
@@ -0,0 +1,201 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+// KeyPrefix marks the plaintext API keys issued by this service
+// so that they can be recognized by secret scanners.
+const KeyPrefix = "mah"
+
+const (
+	prefixBytes = 6
+	secretBytes = 32
+	// touchInterval bounds how often last_used_at is written for the same key.
+	touchInterval = time.Minute
+)
+
+// Hasher hashes API key secrets for storage and verifies them.
+type Hasher interface {
+	Hash(secret string) (string, error)
+	Verify(secret, hash string) (bool, error)
+}
+
+// APIKeyUsecase mints, lists and revokes API keys and authenticates requests with them.
+type APIKeyUsecase struct {
+	repo   repository.APIKeyRepository
+	hasher Hasher
+	now    func() time.Time
+}
+
+// Option configures an APIKeyUsecase.
+type Option func(*APIKeyUsecase)
+
+// WithClock overrides the clock used for revocation and last-used timestamps.
+func WithClock(now func() time.Time) Option {
+	return func(uc *APIKeyUsecase) {
+		uc.now = now
+	}
+}
+
+// NewAPIKeyUsecase creates a new APIKeyUsecase.
+func NewAPIKeyUsecase(repo repository.APIKeyRepository, hasher Hasher, opts ...Option) *APIKeyUsecase {
+	uc := &APIKeyUsecase{repo: repo, hasher: hasher, now: time.Now}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// Mint issues a new API key in the form "mah_<prefix>_<secret>".
+// The returned output is the only place the plaintext key is exposed.
+func (uc *APIKeyUsecase) Mint(ctx context.Context, input MintInput) (*KeyOutput, error) {
+	prefix, err := randomString(prefixBytes, hex.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomString(secretBytes, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := uc.hasher.Hash(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash api key: %w", err)
+	}
+
+	key, err := entity.NewAPIKey(input.Name, prefix, hash, input.Scopes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+	}
+	key.AuthorID = input.AuthorID
+	created, err := uc.repo.Create(ctx, key)
+	if errors.Is(err, repository.ErrAuthorNotFound) {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	output := toKeyOutput(created)
+	output.Key = strings.Join([]string{KeyPrefix, prefix, secret}, "_")
+	return &output, nil
+}
+
+// List retrieves all API keys including revoked ones.
+func (uc *APIKeyUsecase) List(ctx context.Context) ([]KeyOutput, error) {
+	keys, err := uc.repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api keys: %w", err)
+	}
+	outputs := make([]KeyOutput, 0, len(keys))
+	for _, k := range keys {
+		outputs = append(outputs, toKeyOutput(k))
+	}
+	return outputs, nil
+}
+
+// Revoke revokes an API key. Requests with a revoked key are rejected from then on.
+func (uc *APIKeyUsecase) Revoke(ctx context.Context, id uint64) (*KeyOutput, error) {
+	key, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := key.Revoke(uc.now()); err != nil {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrConflict, err)
+	}
+	if err := uc.repo.Update(ctx, key); err != nil {
+		return nil, err
+	}
+	output := toKeyOutput(key)
+	return &output, nil
+}
+
+// Authenticate verifies a plaintext API key and returns its principal,
+// which is bound to the workspace the key was minted in.
+// Malformed, unknown and revoked keys are all reported as ErrUnauthenticated.
+func (uc *APIKeyUsecase) Authenticate(ctx context.Context, rawKey string) (*auth.Principal, error) {
+	prefix, secret, ok := parseKey(rawKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed api key", apperr.ErrUnauthenticated)
+	}
+	// The workspace of the request is not known yet: the key itself decides it.
+	lookup := repository.WithAllWorkspaces(ctx)
+	key, err := uc.repo.FindByPrefix(lookup, prefix)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: unknown api key", apperr.ErrUnauthenticated)
+	}
+	if err != nil {
+		return nil, err
+	}
+	valid, err := uc.hasher.Verify(secret, key.SecretHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify api key %d: %w", key.ID, err)
+	}
+	if !valid {
+		return nil, fmt.Errorf("%w: unknown api key", apperr.ErrUnauthenticated)
+	}
+	if key.IsRevoked() {
+		return nil, fmt.Errorf("%w: api key has been revoked", apperr.ErrUnauthenticated)
+	}
+
+	now := uc.now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= touchInterval {
+		// 最終利用日時は参考情報のため、更新に失敗しても認証は通す
+		if err := uc.repo.TouchLastUsed(lookup, key.ID, now); err != nil {
+			log.Printf("failed to record api key usage: %v", err)
+		}
+	}
+	p := &auth.Principal{KeyID: key.ID, Name: key.Name, Scopes: key.Scopes, WorkspaceID: key.WorkspaceID}
+	if key.AuthorID != nil {
+		p.AuthorID = *key.AuthorID
+	}
+	return p, nil
+}
+
+// parseKey splits "mah_<prefix>_<secret>" into its prefix and secret.
+// The secret is base64url encoded and may itself contain underscores.
+func parseKey(rawKey string) (prefix, secret string, ok bool) {
+	rest, ok := strings.CutPrefix(rawKey, KeyPrefix+"_")
+	if !ok {
+		return "", "", false
+	}
+	prefix, secret, ok = strings.Cut(rest, "_")
+	if !ok || len(prefix) != prefixBytes*2 || secret == "" {
+		return "", "", false
+	}
+	return prefix, secret, true
+}
+
+func randomString(n int, encode func([]byte) string) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return encode(b), nil
+}
+
+func toKeyOutput(k *entity.APIKey) KeyOutput {
+	return KeyOutput{
+		ID:          k.ID,
+		Name:        k.Name,
+		Prefix:      k.Prefix,
+		Scopes:      k.ScopeStrings(),
+		AuthorID:    k.AuthorID,
+		WorkspaceID: k.WorkspaceID,
+		CreatedAt:   k.CreatedAt,
+		LastUsedAt:  k.LastUsedAt,
+		RevokedAt:   k.RevokedAt,
+	}
+}
@@ -0,0 +1,244 @@
+package apikey_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apikey"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+)
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) FindAll(ctx context.Context) ([]*entity.APIKey, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*entity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) FindByID(ctx context.Context, id uint64) (*entity.APIKey, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	args := m.Called(ctx, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.APIKey), args.Error(1)
+}
+
+// Create は戻り値がnilの場合、渡されたキーをそのまま返す
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		if err := args.Error(1); err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+	return args.Get(0).(*entity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Update(ctx context.Context, key *entity.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id uint64, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+// reverseHasher はシークレットを反転した文字列をハッシュとして扱う
+type reverseHasher struct{}
+
+func (reverseHasher) Hash(secret string) (string, error) {
+	return "hash:" + reverse(secret), nil
+}
+
+func (reverseHasher) Verify(secret, hash string) (bool, error) {
+	return hash == "hash:"+reverse(secret), nil
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestAPIKeyUsecase_Mint(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("平文のキーを一度だけ返し、シークレットはハッシュだけを保存する", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*entity.APIKey).ID = 1
+		}).Return(nil, nil)
+
+		uc := apikey.NewAPIKeyUsecase(mockRepo, reverseHasher{})
+		out, err := uc.Mint(ctx, apikey.MintInput{Name: "ci", Scopes: []string{"articles:write", "articles:read"}})
+		require.NoError(t, err)
+
+		assert.Regexp(t, regexp.MustCompile(`^mah_[0-9a-f]{12}_[A-Za-z0-9_-]{43}$`), out.Key)
+		assert.Equal(t, uint64(1), out.ID)
+		assert.Equal(t, []string{"articles:write", "articles:read"}, out.Scopes)
+
+		saved := mockRepo.Calls[0].Arguments.Get(1).(*entity.APIKey)
+		secret := out.Key[len("mah_")+12+1:]
+		assert.True(t, strings.HasPrefix(out.Key, "mah_"+saved.Prefix+"_"))
+		assert.Equal(t, "hash:"+reverse(secret), saved.SecretHash)
+		assert.Equal(t, out.Prefix, saved.Prefix)
+	})
+
+	t.Run("不正なスコープはErrInvalidInput", func(t *testing.T) {
+		uc := apikey.NewAPIKeyUsecase(new(MockAPIKeyRepository), reverseHasher{})
+		_, err := uc.Mint(ctx, apikey.MintInput{Name: "ci", Scopes: []string{"articles:delete"}})
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+	})
+
+	t.Run("スコープがなければErrInvalidInput", func(t *testing.T) {
+		uc := apikey.NewAPIKeyUsecase(new(MockAPIKeyRepository), reverseHasher{})
+		_, err := uc.Mint(ctx, apikey.MintInput{Name: "ci"})
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+	})
+}
+
+func TestAPIKeyUsecase_Revoke(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("失効日時を記録する", func(t *testing.T) {
+		key := &entity.APIKey{ID: 1, Name: "ci", Prefix: "abc", Scopes: []vo.Scope{vo.ScopeArticlesRead}}
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("FindByID", ctx, uint64(1)).Return(key, nil)
+		mockRepo.On("Update", ctx, key).Return(nil)
+
+		uc := apikey.NewAPIKeyUsecase(mockRepo, reverseHasher{}, apikey.WithClock(func() time.Time { return now }))
+		out, err := uc.Revoke(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, &now, out.RevokedAt)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("失効済みのキーはErrConflict", func(t *testing.T) {
+		key := &entity.APIKey{ID: 1, RevokedAt: &now}
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("FindByID", ctx, uint64(1)).Return(key, nil)
+
+		uc := apikey.NewAPIKeyUsecase(mockRepo, reverseHasher{})
+		_, err := uc.Revoke(ctx, 1)
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+	})
+
+	t.Run("存在しないキーはErrNotFound", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("FindByID", ctx, uint64(9)).Return(nil, repository.ErrAPIKeyNotFound)
+
+		uc := apikey.NewAPIKeyUsecase(mockRepo, reverseHasher{})
+		_, err := uc.Revoke(ctx, 9)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
+
+func TestAPIKeyUsecase_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	// キーはワークスペースが決まる前に全てのワークスペースから探す
+	lookup := repository.WithAllWorkspaces(ctx)
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	const prefix = "0123456789ab"
+	// シークレットには "_" が含まれうる
+	const secret = "se_cret"
+
+	newKey := func(lastUsedAt, revokedAt *time.Time) *entity.APIKey {
+		return &entity.APIKey{
+			ID:          7,
+			Name:        "ci",
+			Prefix:      prefix,
+			SecretHash:  "hash:" + reverse(secret),
+			Scopes:      []vo.Scope{vo.ScopeArticlesWrite},
+			WorkspaceID: 2,
+			LastUsedAt:  lastUsedAt,
+			RevokedAt:   revokedAt,
+		}
+	}
+
+	t.Run("有効なキーは呼び出し元を返し、最終利用日時を記録する", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("FindByPrefix", lookup, prefix).Return(newKey(nil, nil), nil)
+		mockRepo.On("TouchLastUsed", lookup, uint64(7), now).Return(nil)
+
+		uc := apikey.NewAPIKeyUsecase(mockRepo, reverseHasher{}, apikey.WithClock(func() time.Time { return now }))
+		p, err := uc.Authenticate(ctx, "mah_"+prefix+"_"+secret)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), p.KeyID)
+		assert.Equal(t, []vo.Scope{vo.ScopeArticlesWrite}, p.Scopes)
+		assert.Equal(t, uint64(2), p.WorkspaceID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("直前に利用されたキーは最終利用日時を更新しない", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("FindByPrefix", lookup, prefix).Return(newKey(ptr(now.Add(-10*time.Second)), nil), nil)
+
+		uc := apikey.NewAPIKeyUsecase(mockRepo, reverseHasher{}, apikey.WithClock(func() time.Time { return now }))
+		_, err := uc.Authenticate(ctx, "mah_"+prefix+"_"+secret)
+		require.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("最終利用日時の更新に失敗しても認証は通す", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("FindByPrefix", lookup, prefix).Return(newKey(nil, nil), nil)
+		mockRepo.On("TouchLastUsed", lookup, uint64(7), mock.Anything).Return(errors.New("db error"))
+
+		uc := apikey.NewAPIKeyUsecase(mockRepo, reverseHasher{})
+		_, err := uc.Authenticate(ctx, "mah_"+prefix+"_"+secret)
+		assert.NoError(t, err)
+	})
+
+	t.Run("認証できないキーはErrUnauthenticated", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("FindByPrefix", lookup, prefix).Return(newKey(nil, ptr(now)), nil).Once()
+		mockRepo.On("FindByPrefix", lookup, prefix).Return(newKey(nil, nil), nil)
+		mockRepo.On("FindByPrefix", lookup, "ffffffffffff").Return(nil, repository.ErrAPIKeyNotFound)
+		uc := apikey.NewAPIKeyUsecase(mockRepo, reverseHasher{})
+
+		tests := []struct {
+			name string
+			key  string
+		}{
+			{"失効済み", "mah_" + prefix + "_" + secret},
+			{"シークレットが異なる", "mah_" + prefix + "_wrong"},
+			{"未知のプレフィックス", "mah_ffffffffffff_" + secret},
+			{"形式が異なる", "Bearer xyz"},
+			{"シークレットがない", "mah_" + prefix + "_"},
+		}
+		for _, tt := range tests {
+			_, err := uc.Authenticate(ctx, tt.key)
+			assert.ErrorIs(t, err, apperr.ErrUnauthenticated, tt.name)
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package apikey
+
+import "time"
+
+// MintInput is the input for minting an API key.
+type MintInput struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// AuthorID is the author whose articles requests with the key act as owner of.
+	AuthorID *uint64 `json:"author_id"`
+}
+
+// KeyOutput is the output for an API key.
+// The plaintext key is only returned when the key is minted.
+type KeyOutput struct {
+	ID          uint64     `json:"id"`
+	Name        string     `json:"name"`
+	Key         string     `json:"key,omitempty"`
+	Prefix      string     `json:"prefix"`
+	Scopes      []string   `json:"scopes"`
+	AuthorID    *uint64    `json:"author_id"`
+	WorkspaceID uint64     `json:"workspace_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+}
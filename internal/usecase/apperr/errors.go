@@ -0,0 +1,16 @@
+// Package apperr defines errors shared across use cases so that
+// delivery layers (e.g. HTTP handlers) can map them to responses.
+package apperr
+
+import "errors"
+
+var (
+	// ErrInvalidInput indicates that the request violates input or domain rules.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrConflict indicates that the request conflicts with the current state.
+	ErrConflict = errors.New("conflict")
+	// ErrUnauthenticated indicates that the caller could not be identified.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden indicates that the caller is not allowed to perform the operation.
+	ErrForbidden = errors.New("forbidden")
+)
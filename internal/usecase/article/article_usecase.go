@@ -2,24 +2,184 @@ package article
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"time"
 
 	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
 	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
 )
 
+// BodyRenderer converts a Markdown article body into sanitized HTML.
+type BodyRenderer interface {
+	Render(body string) (string, error)
+}
+
+// maxSlugSuffix bounds the number of "-n" suffixes tried for a generated slug.
+const maxSlugSuffix = 100
+
+const (
+	defaultTrashLimit = 20
+	maxTrashLimit     = 100
+)
+
+const (
+	// maxBulkTargets bounds the number of articles one bulk operation applies to.
+	maxBulkTargets       = 1000
+	defaultBulkChunkSize = 50
+)
+
+// SlugMovedError is returned when an article is looked up by one of its previous slugs.
+// Slug holds the current slug that the caller should redirect to.
+type SlugMovedError struct {
+	Slug string
+}
+
+func (e *SlugMovedError) Error() string {
+	return fmt.Sprintf("article slug has moved to %q", e.Slug)
+}
+
+// DuplicateLinkError is returned when another article already uses the same link after normalization.
+// ArticleID holds the ID of the existing article. It wraps apperr.ErrConflict.
+type DuplicateLinkError struct {
+	ArticleID uint64
+	Link      string
+}
+
+func (e *DuplicateLinkError) Error() string {
+	return fmt.Sprintf("%v: link %q is already used by article %d", apperr.ErrConflict, e.Link, e.ArticleID)
+}
+
+func (e *DuplicateLinkError) Unwrap() error {
+	return apperr.ErrConflict
+}
+
+// Authorizer decides whether the caller carried by the context may perform an
+// action on an article, or on articles in general when resource is nil.
+// Denials are reported as errors wrapping apperr.ErrForbidden.
+type Authorizer interface {
+	Authorize(ctx context.Context, action auth.Action, resource *auth.Resource) error
+}
+
+// ReviewPolicy reports whether articles of the workspace of the context
+// must be approved in review before they are made public.
+type ReviewPolicy interface {
+	ReviewRequired(ctx context.Context) (bool, error)
+}
+
+// CommentAnchorer moves the comments anchored to passages of an article body
+// when the body changes. It is called within the transaction of the change.
+type CommentAnchorer interface {
+	ReanchorComments(ctx context.Context, articleID uint64, body string) error
+}
+
+// AuditLog records changes of articles. It is called within the transaction
+// of the change, so a change is not committed unless it is recorded.
+type AuditLog interface {
+	Record(ctx context.Context, entry *entity.AuditEntry) error
+}
+
 // ArticleUsecase defines the interface for article use cases.
 type ArticleUsecase struct {
-	repo repository.ArticleRepository
+	repo         repository.ArticleRepository
+	txManager    repository.TxManager
+	renderer     BodyRenderer
+	authorizer   Authorizer
+	reviewPolicy ReviewPolicy
+	anchorer     CommentAnchorer
+	auditLog     AuditLog
+	// trashRetention is how long soft-deleted articles are kept before they are purged.
+	trashRetention time.Duration
+	// bulkChunkSize is the number of articles a bulk operation changes in one transaction.
+	bulkChunkSize int
+	// bodyMaxSize is the maximum size of an article body in bytes; 0 means the default.
+	bodyMaxSize int
+}
+
+// Option configures an ArticleUsecase.
+type Option func(*ArticleUsecase)
+
+// WithBodyRenderer enables body_html on the article detail output.
+func WithBodyRenderer(r BodyRenderer) Option {
+	return func(uc *ArticleUsecase) {
+		uc.renderer = r
+	}
+}
+
+// WithAuthorizer enables authorization by a policy such as auth.ArticlePolicy.
+// Callers that may not read unpublished articles only see published ones.
+// Without it every operation is allowed, e.g. for background workers.
+func WithAuthorizer(a Authorizer) Option {
+	return func(uc *ArticleUsecase) {
+		uc.authorizer = a
+	}
+}
+
+// WithReviewPolicy makes articles publishable only once approved in review
+// when the policy requires it for the workspace of the request.
+func WithReviewPolicy(p ReviewPolicy) Option {
+	return func(uc *ArticleUsecase) {
+		uc.reviewPolicy = p
+	}
+}
+
+// WithCommentAnchorer keeps inline comments anchored to their passages when the body changes.
+func WithCommentAnchorer(a CommentAnchorer) Option {
+	return func(uc *ArticleUsecase) {
+		uc.anchorer = a
+	}
+}
+
+// WithAuditLog records every change of an article in the audit log.
+func WithAuditLog(l AuditLog) Option {
+	return func(uc *ArticleUsecase) {
+		uc.auditLog = l
+	}
+}
+
+// WithTrashRetention reports when each article in the trash will be purged,
+// given the retention period of the purge job.
+func WithTrashRetention(d time.Duration) Option {
+	return func(uc *ArticleUsecase) {
+		uc.trashRetention = d
+	}
+}
+
+// WithBulkChunkSize sets the number of articles a bulk operation changes in one transaction.
+func WithBulkChunkSize(n int) Option {
+	return func(uc *ArticleUsecase) {
+		if n > 0 {
+			uc.bulkChunkSize = n
+		}
+	}
+}
+
+// WithBodyMaxSize sets the maximum size of an article body in bytes for creating
+// and updating articles. Bodies already stored are loaded regardless of it.
+func WithBodyMaxSize(n int) Option {
+	return func(uc *ArticleUsecase) {
+		uc.bodyMaxSize = n
+	}
 }
 
 // NewArticleUsecase creates a new ArticleUsecase.
-func NewArticleUsecase(repo repository.ArticleRepository) *ArticleUsecase {
-	return &ArticleUsecase{repo: repo}
+func NewArticleUsecase(repo repository.ArticleRepository, txManager repository.TxManager, opts ...Option) *ArticleUsecase {
+	uc := &ArticleUsecase{repo: repo, txManager: txManager, bulkChunkSize: defaultBulkChunkSize}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
 }
 
 // FindAllArticles retrieves all articles.
 func (uc *ArticleUsecase) FindAllArticles(ctx context.Context) (*FindByCriteriaOutput, error) {
+	if err := uc.authorize(ctx, auth.ActionReadArticle, nil); err != nil {
+		return nil, err
+	}
 	articles, err := uc.repo.FindAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find all articles: %w", err)
@@ -27,13 +187,24 @@ func (uc *ArticleUsecase) FindAllArticles(ctx context.Context) (*FindByCriteriaO
 
 	var articleOutputs []FindArticleByIDOutput
 	for _, article := range articles {
+		if err := uc.authorizeList(ctx, article); errors.Is(err, apperr.ErrForbidden) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		articleOutputs = append(articleOutputs, FindArticleByIDOutput{
 			ID:           article.ID,
 			Title:        article.Title.String(),
+			Slug:         article.Slug.String(),
 			Body:         article.Body.String(),
 			Status:       article.Status.String(),
 			ProviderType: article.ProviderType.String(),
 			Link:         article.Link.String(),
+			Publications: newPublicationOutputs(article.Publications),
+			LinkPreview:  newLinkPreviewOutput(article.LinkPreview),
+			Tags:         article.TagStrings(),
+			Metadata:     newArticleMetadataOutput(article.Metadata()),
+			AuthorID:     article.AuthorID,
 			CreatedAt:    article.CreatedAt,
 			UpdatedAt:    article.UpdatedAt,
 		})
@@ -50,15 +221,9 @@ func (uc *ArticleUsecase) FindAllArticles(ctx context.Context) (*FindByCriteriaO
 
 // FindByCriteria retrieves articles based on the given criteria.
 func (uc *ArticleUsecase) FindByCriteria(ctx context.Context, criteria FindByCriteriaInput) (*FindByCriteriaOutput, error) {
-	// Convert input criteria to repository criteria
-	repoCriteria := repository.ArticleQueryCriteria{
-		Status:         criteria.Status,
-		ProviderType:   criteria.ProviderType,
-		SortBy:         criteria.SortBy,
-		SortOrder:      criteria.SortOrder,
-		Page:           criteria.Page,
-		Limit:          criteria.Limit,
-		IncludeDeleted: false, // Assuming we don't want to include deleted articles by default
+	repoCriteria, err := uc.queryCriteria(ctx, criteria)
+	if err != nil {
+		return nil, err
 	}
 
 	articles, totalCount, err := uc.repo.FindByCriteria(ctx, repoCriteria)
@@ -72,10 +237,16 @@ func (uc *ArticleUsecase) FindByCriteria(ctx context.Context, criteria FindByCri
 		articleOutputs = append(articleOutputs, FindArticleByIDOutput{
 			ID:           article.ID,
 			Title:        article.Title.String(),
+			Slug:         article.Slug.String(),
 			Body:         article.Body.String(),
 			Status:       article.Status.String(),
 			ProviderType: article.ProviderType.String(),
 			Link:         article.Link.String(),
+			Publications: newPublicationOutputs(article.Publications),
+			LinkPreview:  newLinkPreviewOutput(article.LinkPreview),
+			Tags:         article.TagStrings(),
+			Metadata:     newArticleMetadataOutput(article.Metadata()),
+			AuthorID:     article.AuthorID,
 			CreatedAt:    article.CreatedAt,
 			UpdatedAt:    article.UpdatedAt,
 		})
@@ -96,20 +267,117 @@ func (uc *ArticleUsecase) FindByCriteria(ctx context.Context, criteria FindByCri
 	}, nil
 }
 
+// queryCriteria converts the input criteria to repository criteria after checking
+// that the caller may list the articles. Callers that may not read unpublished
+// articles are limited to published ones.
+func (uc *ArticleUsecase) queryCriteria(ctx context.Context, criteria FindByCriteriaInput) (repository.ArticleQueryCriteria, error) {
+	if err := uc.authorize(ctx, auth.ActionReadArticle, nil); err != nil {
+		return repository.ArticleQueryCriteria{}, err
+	}
+	if criteria.Status != nil && !vo.ArticleStatus(*criteria.Status).IsValid() {
+		return repository.ArticleQueryCriteria{}, fmt.Errorf("%w: invalid status: %q", apperr.ErrInvalidInput, *criteria.Status)
+	}
+	if criteria.IncludeDeleted {
+		if err := uc.authorize(ctx, auth.ActionIncludeDeletedArticle, nil); err != nil {
+			return repository.ArticleQueryCriteria{}, err
+		}
+	}
+	// Callers that may not read unpublished articles only list published ones,
+	// unless they list the articles of an author whose unpublished articles they may read.
+	err := uc.authorize(ctx, auth.ActionReadUnpublishedArticle, &auth.Resource{OwnerID: criteria.AuthorID})
+	if errors.Is(err, apperr.ErrForbidden) {
+		if criteria.Status != nil && *criteria.Status != vo.ArticleStatusPublished.String() {
+			return repository.ArticleQueryCriteria{}, err
+		}
+		published := vo.ArticleStatusPublished.String()
+		criteria.Status = &published
+	} else if err != nil {
+		return repository.ArticleQueryCriteria{}, err
+	}
+	if criteria.MinReadingTime != nil && criteria.MaxReadingTime != nil && *criteria.MinReadingTime > *criteria.MaxReadingTime {
+		return repository.ArticleQueryCriteria{}, fmt.Errorf("%w: min_reading_time must not exceed max_reading_time", apperr.ErrInvalidInput)
+	}
+
+	// Convert input criteria to repository criteria
+	return repository.ArticleQueryCriteria{
+		Status:         criteria.Status,
+		ProviderType:   criteria.ProviderType,
+		Tag:            criteria.Tag,
+		PublishedOn:    criteria.PublishedOn,
+		HasBrokenLinks: criteria.HasBrokenLinks,
+		MinReadingTime: criteria.MinReadingTime,
+		MaxReadingTime: criteria.MaxReadingTime,
+		AuthorID:       criteria.AuthorID,
+		SortBy:         criteria.SortBy,
+		SortOrder:      criteria.SortOrder,
+		Page:           criteria.Page,
+		Limit:          criteria.Limit,
+		IncludeDeleted: criteria.IncludeDeleted,
+	}, nil
+}
+
 // CreateArticle creates a new article.
 func (uc *ArticleUsecase) CreateArticle(ctx context.Context, input CreateArticleInput) (*CreateArticleOutput, error) {
+	if err := uc.authorize(ctx, auth.ActionCreateArticle, nil); err != nil {
+		return nil, err
+	}
+	reviewRequired, err := uc.reviewRequired(ctx)
+	if err != nil {
+		return nil, err
+	}
 	articleEntity, err := entity.NewArticle(
 		input.Title,
 		input.Status,
 		entity.WithBody(input.Body),
+		entity.WithBodyMaxSize(uc.bodyMaxSize),
 		entity.WithLink(input.Link),
 		entity.WithProviderType(input.ProviderType),
+		entity.WithTags(input.Tags),
+		entity.WithSlug(input.Slug),
+		entity.WithAuthorID(uc.authorOf(ctx, input.AuthorID)),
+		entity.WithReviewRequired(reviewRequired),
 	)
+	if errors.Is(err, entity.ErrReviewRequired) || errors.Is(err, entity.ErrInvalidStatusTransition) {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrConflict, err)
+	}
 	if err != nil {
 		return nil, err
 	}
+	// Creating an article on behalf of an author requires the right to update the author's
+	// articles, and creating it in a status other than draft the right to publish them.
+	if input.AuthorID != nil {
+		if err := uc.authorize(ctx, auth.ActionUpdateArticle, resourceOf(articleEntity)); err != nil {
+			return nil, err
+		}
+	}
+	if articleEntity.Status != vo.ArticleStatusDraft {
+		if err := uc.authorize(ctx, auth.ActionPublishArticle, resourceOf(articleEntity)); err != nil {
+			return nil, err
+		}
+	}
 
-	newArticle, err := uc.repo.Create(ctx, articleEntity)
+	var newArticle *entity.Article
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		explicit := input.Slug != nil && *input.Slug != ""
+		if err := uc.ensureUniqueSlug(ctx, articleEntity, explicit); err != nil {
+			return err
+		}
+		if err := uc.ensureUniqueLink(ctx, articleEntity); err != nil {
+			return err
+		}
+		created, err := uc.repo.Create(ctx, articleEntity)
+		if errors.Is(err, repository.ErrArticleSlugConflict) || errors.Is(err, repository.ErrArticleLinkConflict) {
+			return fmt.Errorf("%w: %v", apperr.ErrConflict, err)
+		}
+		if errors.Is(err, repository.ErrAuthorNotFound) {
+			return fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+		}
+		if err != nil {
+			return err
+		}
+		newArticle = created
+		return uc.record(ctx, created, entity.AuditActionCreate, nil)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -117,10 +385,15 @@ func (uc *ArticleUsecase) CreateArticle(ctx context.Context, input CreateArticle
 	return &CreateArticleOutput{
 		ID:           newArticle.ID,
 		Title:        newArticle.Title.String(),
+		Slug:         newArticle.Slug.String(),
 		Body:         newArticle.Body.String(),
 		Status:       newArticle.Status.String(),
 		ProviderType: newArticle.ProviderType.String(),
 		Link:         newArticle.Link.String(),
+		Publications: newPublicationOutputs(newArticle.Publications),
+		Tags:         newArticle.TagStrings(),
+		Metadata:     newArticleMetadataOutput(newArticle.Metadata()),
+		AuthorID:     newArticle.AuthorID,
 		CreatedAt:    newArticle.CreatedAt,
 		UpdatedAt:    newArticle.UpdatedAt,
 	}, nil
@@ -128,43 +401,176 @@ func (uc *ArticleUsecase) CreateArticle(ctx context.Context, input CreateArticle
 
 // FindArticleByID retrieves an article by its ID.
 func (uc *ArticleUsecase) FindArticleByID(ctx context.Context, id uint64) (*FindArticleByIDOutput, error) {
+	if err := uc.authorize(ctx, auth.ActionReadArticle, nil); err != nil {
+		return nil, err
+	}
 	article, err := uc.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := uc.authorizeRead(ctx, article); err != nil {
+		return nil, err
+	}
+	return uc.newDetailOutput(article)
+}
+
+// FindArticleBySlug retrieves an article by its current slug.
+// When the slug is a previous one, a *SlugMovedError holding the current slug is returned.
+func (uc *ArticleUsecase) FindArticleBySlug(ctx context.Context, slug string) (*FindArticleByIDOutput, error) {
+	if err := uc.authorize(ctx, auth.ActionReadArticle, nil); err != nil {
+		return nil, err
+	}
+	article, err := uc.repo.FindBySlug(ctx, slug)
+	if errors.Is(err, repository.ErrNotFound) {
+		current, historyErr := uc.repo.FindCurrentSlug(ctx, slug)
+		if historyErr == nil {
+			return nil, &SlugMovedError{Slug: current}
+		}
+		if !errors.Is(historyErr, repository.ErrNotFound) {
+			return nil, historyErr
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authorizeRead(ctx, article); err != nil {
+		return nil, err
+	}
+	return uc.newDetailOutput(article)
+}
+
+// newDetailOutput converts an article into the detail output, rendering the body when a renderer is configured.
+func (uc *ArticleUsecase) newDetailOutput(article *entity.Article) (*FindArticleByIDOutput, error) {
+	var bodyHTML string
+	if uc.renderer != nil {
+		var err error
+		bodyHTML, err = uc.renderer.Render(article.Body.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to render article %d: %w", article.ID, err)
+		}
+	}
 
 	return &FindArticleByIDOutput{
 		ID:           article.ID,
 		Title:        article.Title.String(),
+		Slug:         article.Slug.String(),
 		Body:         article.Body.String(),
+		BodyHTML:     bodyHTML,
 		Status:       article.Status.String(),
 		ProviderType: article.ProviderType.String(),
 		Link:         article.Link.String(),
+		Publications: newPublicationOutputs(article.Publications),
+		LinkPreview:  newLinkPreviewOutput(article.LinkPreview),
+		Tags:         article.TagStrings(),
+		Metadata:     newArticleMetadataOutput(article.Metadata()),
+		AuthorID:     article.AuthorID,
 		CreatedAt:    article.CreatedAt,
 		UpdatedAt:    article.UpdatedAt,
 	}, nil
 }
 
 // UpdateArticle updates an existing article.
+// The article is locked for update so that concurrent updates are serialized.
+// Changing the content requires ActionUpdateArticle and changing the status
+// ActionPublishArticle; reassigning the article requires ActionUpdateArticle
+// on the new owner's articles as well. The caller must be able to read the
+// article, and an update that changes nothing is not saved.
 func (uc *ArticleUsecase) UpdateArticle(ctx context.Context, id uint64, input UpdateArticleInput) (*UpdateArticleOutput, error) {
-	article, err := uc.repo.FindByID(ctx, id)
-	if err != nil {
+	if err := uc.authorize(ctx, auth.ActionReadArticle, nil); err != nil {
 		return nil, err
 	}
-
-	err = article.Update(
-		input.Title,
-		input.Body,
-		input.Status,
-		input.ProviderType,
-		input.Link,
-	)
-
+	reviewRequired, err := uc.reviewRequired(ctx)
 	if err != nil {
 		return nil, err
 	}
+	var article *entity.Article
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		found, err := uc.repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := uc.authorizeRead(ctx, found); err != nil {
+			return err
+		}
+		if reviewRequired {
+			found.RequireReview()
+		}
+		found.LimitBodySize(uc.bodyMaxSize)
+		owner := resourceOf(found)
+		before := entity.SnapshotArticle(found)
+		previousContent, previousStatus := contentOf(found), found.Status
+		previousLink, previousUpdatedAt := found.Link.Normalized(), found.UpdatedAt
+
+		err = found.Update(
+			input.Title,
+			input.Body,
+			input.Status,
+			input.ProviderType,
+			input.Link,
+		)
+		if isStateConflict(err) {
+			return fmt.Errorf("%w: %v", apperr.ErrConflict, err)
+		}
+		if err != nil {
+			return err
+		}
+		if input.Slug != nil && *input.Slug != found.Slug.String() {
+			exists, err := uc.repo.SlugExists(ctx, *input.Slug, found.ID)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return fmt.Errorf("%w: slug %q is already in use", apperr.ErrConflict, *input.Slug)
+			}
+			if err := found.ChangeSlug(*input.Slug); err != nil {
+				return fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+			}
+		}
+		if input.AuthorID != nil {
+			found.AssignAuthor(input.AuthorID)
+			if !sameOwner(owner.OwnerID, found.AuthorID) {
+				if err := uc.authorize(ctx, auth.ActionUpdateArticle, resourceOf(found)); err != nil {
+					return err
+				}
+			}
+		}
+		if contentOf(found) != previousContent {
+			if err := uc.authorize(ctx, auth.ActionUpdateArticle, owner); err != nil {
+				return err
+			}
+		}
+		if found.Status != previousStatus {
+			if err := uc.authorize(ctx, auth.ActionPublishArticle, owner); err != nil {
+				return err
+			}
+		}
+		if contentOf(found) == previousContent && found.Status == previousStatus && sameOwner(owner.OwnerID, found.AuthorID) {
+			// Nothing changed, so neither the update time nor the events are saved.
+			found.UpdatedAt = previousUpdatedAt
+			found.PullEvents()
+			article = found
+			return nil
+		}
 
-	err = uc.repo.Update(ctx, article)
+		if err := uc.update(ctx, found, previousLink); err != nil {
+			return err
+		}
+		if uc.anchorer != nil && found.Body.String() != previousContent.body {
+			if err := uc.anchorer.ReanchorComments(ctx, found.ID, found.Body.String()); err != nil {
+				return err
+			}
+		}
+		action := entity.AuditActionUpdate
+		if !previousStatus.IsPublic() && found.Status.IsPublic() {
+			action = entity.AuditActionPublish
+		}
+		if err := uc.record(ctx, found, action, before); err != nil {
+			return err
+		}
+		article = found
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -172,20 +578,390 @@ func (uc *ArticleUsecase) UpdateArticle(ctx context.Context, id uint64, input Up
 	return &UpdateArticleOutput{
 		ID:           article.ID,
 		Title:        article.Title.String(),
+		Slug:         article.Slug.String(),
 		Body:         article.Body.String(),
 		Status:       article.Status.String(),
 		ProviderType: article.ProviderType.String(),
 		Link:         article.Link.String(),
+		Publications: newPublicationOutputs(article.Publications),
+		Tags:         article.TagStrings(),
+		Metadata:     newArticleMetadataOutput(article.Metadata()),
+		AuthorID:     article.AuthorID,
 		CreatedAt:    article.CreatedAt,
 		UpdatedAt:    article.UpdatedAt,
 	}, nil
 }
 
-// DeleteArticle deletes an article by its ID.
+// DeleteArticle moves an article to the trash by soft deleting it.
+// It can be restored with RestoreArticle until the retention job purges it.
 func (uc *ArticleUsecase) DeleteArticle(ctx context.Context, id uint64) error {
-	entity, err := uc.repo.FindByID(ctx, id)
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		entity, err := uc.repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := uc.authorize(ctx, auth.ActionDeleteArticle, resourceOf(entity)); err != nil {
+			return err
+		}
+		if err := uc.repo.SoftDelete(ctx, entity.ID); err != nil {
+			return err
+		}
+		return uc.recordDeletion(ctx, entity)
+	})
+}
+
+// RestoreArticle restores a soft-deleted article from the trash.
+// Restoring fails with a conflict if another article has taken its link in the meantime.
+func (uc *ArticleUsecase) RestoreArticle(ctx context.Context, id uint64) (*FindArticleByIDOutput, error) {
+	var article *entity.Article
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		found, err := uc.repo.FindDeletedByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := uc.authorize(ctx, auth.ActionRestoreArticle, resourceOf(found)); err != nil {
+			return err
+		}
+		before := entity.SnapshotArticle(found)
+		if err := found.Restore(); err != nil {
+			return fmt.Errorf("%w: %v", apperr.ErrConflict, err)
+		}
+		// The link of a deleted article is not reserved, so its uniqueness is checked again.
+		if err := uc.update(ctx, found, ""); err != nil {
+			return err
+		}
+		if err := uc.record(ctx, found, entity.AuditActionRestore, before); err != nil {
+			return err
+		}
+		article = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.newDetailOutput(article)
+}
+
+// ListTrash lists the soft-deleted articles, most recently deleted first.
+// Like listing with IncludeDeleted, it is limited to callers who may see deleted articles.
+func (uc *ArticleUsecase) ListTrash(ctx context.Context, input ListTrashInput) (*TrashOutput, error) {
+	if err := uc.authorize(ctx, auth.ActionIncludeDeletedArticle, nil); err != nil {
+		return nil, err
+	}
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 {
+		input.Limit = defaultTrashLimit
+	}
+	if input.Limit > maxTrashLimit {
+		return nil, fmt.Errorf("%w: limit must not exceed %d", apperr.ErrInvalidInput, maxTrashLimit)
+	}
+	sortBy, sortOrder := "deleted_at", "desc"
+	articles, total, err := uc.repo.FindByCriteria(ctx, repository.ArticleQueryCriteria{
+		AuthorID:    input.AuthorID,
+		SortBy:      &sortBy,
+		SortOrder:   &sortOrder,
+		Page:        input.Page,
+		Limit:       input.Limit,
+		DeletedOnly: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deleted articles: %w", err)
+	}
+
+	output := &TrashOutput{
+		Articles:   make([]TrashedArticleOutput, 0, len(articles)),
+		Total:      int64(total),
+		Page:       input.Page,
+		Limit:      input.Limit,
+		TotalPages: (total + input.Limit - 1) / input.Limit,
+	}
+	for _, a := range articles {
+		if a.DeletedAt == nil {
+			continue
+		}
+		item := TrashedArticleOutput{
+			ID:        a.ID,
+			Title:     a.Title.String(),
+			Slug:      a.Slug.String(),
+			Status:    a.Status.String(),
+			AuthorID:  a.AuthorID,
+			DeletedAt: *a.DeletedAt,
+		}
+		if uc.trashRetention > 0 {
+			purgeAt := a.DeletedAt.Add(uc.trashRetention)
+			item.PurgeAt = &purgeAt
+		}
+		output.Articles = append(output.Articles, item)
+	}
+	return output, nil
+}
+
+// record appends the change of article from the snapshot before to the audit log,
+// unless nothing has changed. before is nil for a new article.
+func (uc *ArticleUsecase) record(ctx context.Context, article *entity.Article, action entity.AuditAction, before entity.ArticleSnapshot) error {
+	if uc.auditLog == nil {
+		return nil
+	}
+	entry := entity.NewAuditEntry(article.ID, action, before, entity.SnapshotArticle(article))
+	if !entry.HasChanges() {
+		return nil
+	}
+	return uc.auditLog.Record(ctx, entry)
+}
+
+// recordDeletion appends the deletion of article to the audit log.
+// The repository marks the article as deleted, so the change is the deletion time only.
+func (uc *ArticleUsecase) recordDeletion(ctx context.Context, article *entity.Article) error {
+	if uc.auditLog == nil {
+		return nil
+	}
+	before := entity.SnapshotArticle(article)
+	after := maps.Clone(before)
+	after["deleted_at"] = time.Now().UTC().Format(time.RFC3339Nano)
+	return uc.auditLog.Record(ctx, entity.NewAuditEntry(article.ID, entity.AuditActionDelete, before, after))
+}
+
+// reviewRequired reports whether the workspace of the context requires approval before publishing.
+func (uc *ArticleUsecase) reviewRequired(ctx context.Context) (bool, error) {
+	if uc.reviewPolicy == nil {
+		return false, nil
+	}
+	return uc.reviewPolicy.ReviewRequired(ctx)
+}
+
+// isStateConflict reports whether err is a change that the current state of the article does not allow.
+func isStateConflict(err error) bool {
+	return errors.Is(err, entity.ErrPublicationDuplicated) ||
+		errors.Is(err, entity.ErrInvalidStatusTransition) ||
+		errors.Is(err, entity.ErrReviewRequired) ||
+		errors.Is(err, entity.ErrArticleInReview)
+}
+
+func (uc *ArticleUsecase) authorize(ctx context.Context, action auth.Action, resource *auth.Resource) error {
+	if uc.authorizer == nil {
+		return nil
+	}
+	return uc.authorizer.Authorize(ctx, action, resource)
+}
+
+// authorizeRead checks whether the caller may see the article looked up directly.
+// Unlisted articles are readable by anyone who knows them, like published ones;
+// other articles require ActionReadUnpublishedArticle.
+func (uc *ArticleUsecase) authorizeRead(ctx context.Context, article *entity.Article) error {
+	if article.Status.IsUnlisted() {
+		return nil
+	}
+	return uc.authorizeList(ctx, article)
+}
+
+// authorizeList checks whether the caller may see the article in a listing.
+// Only published articles are listed without ActionReadUnpublishedArticle,
+// so that unlisted ones stay out of listings.
+func (uc *ArticleUsecase) authorizeList(ctx context.Context, article *entity.Article) error {
+	if article.Status.IsPublished() {
+		return nil
+	}
+	return uc.authorize(ctx, auth.ActionReadUnpublishedArticle, resourceOf(article))
+}
+
+func resourceOf(article *entity.Article) *auth.Resource {
+	return &auth.Resource{OwnerID: article.AuthorID}
+}
+
+func sameOwner(x, y *uint64) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return *x == *y
+}
+
+// articleContent is the part of an article whose changes require ActionUpdateArticle.
+type articleContent struct {
+	title, slug, body, providerType, link string
+}
+
+func contentOf(a *entity.Article) articleContent {
+	return articleContent{
+		title:        a.Title.String(),
+		slug:         a.Slug.String(),
+		body:         a.Body.String(),
+		providerType: a.ProviderType.String(),
+		link:         a.Link.String(),
+	}
+}
+
+// authorOf returns the author of a new article: the requested one, or else
+// the author the caller acts as.
+func (uc *ArticleUsecase) authorOf(ctx context.Context, requested *uint64) *uint64 {
+	if requested != nil {
+		return requested
+	}
+	if p, ok := auth.PrincipalFrom(ctx); ok && p.AuthorID != 0 {
+		id := p.AuthorID
+		return &id
+	}
+	return nil
+}
+
+// ensureUniqueSlug makes the slug of a new article unique.
+// An explicitly requested slug that is already taken is a conflict, while a generated
+// slug gets the first free "-n" suffix.
+func (uc *ArticleUsecase) ensureUniqueSlug(ctx context.Context, article *entity.Article, explicit bool) error {
+	base := article.Slug
+	exists, err := uc.repo.SlugExists(ctx, base.String(), article.ID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	if explicit {
+		return fmt.Errorf("%w: slug %q is already in use", apperr.ErrConflict, base)
+	}
+	for n := 2; n <= maxSlugSuffix; n++ {
+		candidate := base.WithSuffix(n)
+		exists, err := uc.repo.SlugExists(ctx, candidate.String(), article.ID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			article.Slug = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no free slug for %q", apperr.ErrConflict, base)
+}
+
+// ensureUniqueLink returns a *DuplicateLinkError when another article already uses the
+// canonical link of the article after normalization.
+func (uc *ArticleUsecase) ensureUniqueLink(ctx context.Context, article *entity.Article) error {
+	if article.Link == nil {
+		return nil
+	}
+	id, err := uc.repo.FindIDByNormalizedLink(ctx, article.Link.Normalized())
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
 	if err != nil {
 		return err
 	}
-	return uc.repo.Delete(ctx, entity.ID)
+	if id == article.ID {
+		return nil
+	}
+	return &DuplicateLinkError{ArticleID: id, Link: article.Link.String()}
+}
+
+// update saves a changed article. previousLink is the normalized canonical link before the change,
+// and the uniqueness of the link is checked only when it has changed.
+func (uc *ArticleUsecase) update(ctx context.Context, article *entity.Article, previousLink string) error {
+	if article.Link.Normalized() != previousLink {
+		if err := uc.ensureUniqueLink(ctx, article); err != nil {
+			return err
+		}
+	}
+	err := uc.repo.Update(ctx, article)
+	if errors.Is(err, repository.ErrArticleSlugConflict) || errors.Is(err, repository.ErrArticleLinkConflict) {
+		return fmt.Errorf("%w: %v", apperr.ErrConflict, err)
+	}
+	if errors.Is(err, repository.ErrAuthorNotFound) {
+		return fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+	}
+	return err
+}
+
+// AddPublication registers a place where the article is published, e.g. a cross-post.
+// The first publication of an article becomes canonical.
+func (uc *ArticleUsecase) AddPublication(ctx context.Context, articleID uint64, input AddPublicationInput) (*PublicationOutput, error) {
+	var added entity.Publication
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		found, err := uc.repo.FindByIDForUpdate(ctx, articleID)
+		if err != nil {
+			return err
+		}
+		if err := uc.authorize(ctx, auth.ActionPublishArticle, resourceOf(found)); err != nil {
+			return err
+		}
+		previousLink := found.Link.Normalized()
+		before := entity.SnapshotArticle(found)
+		err = found.AddPublication(input.ProviderType, input.Link, input.ExternalID, input.PublishedAt)
+		if errors.Is(err, entity.ErrPublicationDuplicated) {
+			return fmt.Errorf("%w: %v", apperr.ErrConflict, err)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+		}
+		if err := uc.update(ctx, found, previousLink); err != nil {
+			return err
+		}
+		added = found.Publications[len(found.Publications)-1]
+		return uc.record(ctx, found, entity.AuditActionUpdate, before)
+	})
+	if err != nil {
+		return nil, err
+	}
+	output := newPublicationOutput(added)
+	return &output, nil
+}
+
+// RemovePublication removes a publication from the article.
+// When the canonical publication is removed, the oldest remaining one becomes canonical.
+func (uc *ArticleUsecase) RemovePublication(ctx context.Context, articleID, publicationID uint64) error {
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		found, err := uc.repo.FindByIDForUpdate(ctx, articleID)
+		if err != nil {
+			return err
+		}
+		if err := uc.authorize(ctx, auth.ActionPublishArticle, resourceOf(found)); err != nil {
+			return err
+		}
+		if _, ok := found.Publication(publicationID); !ok {
+			return fmt.Errorf("publication %d of article %d: %w", publicationID, articleID, repository.ErrPublicationNotFound)
+		}
+		previousLink := found.Link.Normalized()
+		before := entity.SnapshotArticle(found)
+		if err := found.RemovePublication(publicationID); err != nil {
+			return err
+		}
+		if err := uc.update(ctx, found, previousLink); err != nil {
+			return err
+		}
+		return uc.record(ctx, found, entity.AuditActionUpdate, before)
+	})
+}
+
+// SetCanonicalPublication marks the publication as the canonical one of the article.
+// The provider of a published article cannot be changed this way.
+func (uc *ArticleUsecase) SetCanonicalPublication(ctx context.Context, articleID, publicationID uint64) (*FindArticleByIDOutput, error) {
+	var article *entity.Article
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		found, err := uc.repo.FindByIDForUpdate(ctx, articleID)
+		if err != nil {
+			return err
+		}
+		if err := uc.authorize(ctx, auth.ActionPublishArticle, resourceOf(found)); err != nil {
+			return err
+		}
+		if _, ok := found.Publication(publicationID); !ok {
+			return fmt.Errorf("publication %d of article %d: %w", publicationID, articleID, repository.ErrPublicationNotFound)
+		}
+		previousLink := found.Link.Normalized()
+		before := entity.SnapshotArticle(found)
+		if err := found.SetCanonicalPublication(publicationID); err != nil {
+			return fmt.Errorf("%w: %v", apperr.ErrConflict, err)
+		}
+		if err := uc.update(ctx, found, previousLink); err != nil {
+			return err
+		}
+		if err := uc.record(ctx, found, entity.AuditActionUpdate, before); err != nil {
+			return err
+		}
+		article = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.newDetailOutput(article)
 }
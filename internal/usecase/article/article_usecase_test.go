@@ -2,6 +2,7 @@ package article_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -14,7 +15,9 @@ import (
 	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
 	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
 	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
 	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
 )
 
 type MockArticleRepository struct {
@@ -34,8 +37,19 @@ func (m *MockArticleRepository) FindByID(ctx context.Context, id uint64) (*entit
 	return args.Get(0).(*entity.Article), args.Error(1)
 }
 
+func (m *MockArticleRepository) FindByIDForUpdate(ctx context.Context, id uint64) (*entity.Article, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Article), args.Error(1)
+}
+
 func (m *MockArticleRepository) Create(ctx context.Context, article *entity.Article) (*entity.Article, error) {
 	args := m.Called(ctx, article)
+	if fn, ok := args.Get(0).(func(context.Context, *entity.Article) *entity.Article); ok {
+		return fn(ctx, article), args.Error(1)
+	}
 	return args.Get(0).(*entity.Article), args.Error(1)
 }
 
@@ -44,16 +58,78 @@ func (m *MockArticleRepository) Update(ctx context.Context, article *entity.Arti
 	return args.Error(0)
 }
 
-func (m *MockArticleRepository) Delete(ctx context.Context, id uint64) error {
+func (m *MockArticleRepository) SoftDelete(ctx context.Context, id uint64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockArticleRepository) FindDeletedByIDForUpdate(ctx context.Context, id uint64) (*entity.Article, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Article), args.Error(1)
+}
+
+func (m *MockArticleRepository) FindDeletedBefore(ctx context.Context, before time.Time, afterID uint64, limit int) ([]*entity.Article, error) {
+	args := m.Called(ctx, before, afterID, limit)
+	return args.Get(0).([]*entity.Article), args.Error(1)
+}
+
+func (m *MockArticleRepository) Purge(ctx context.Context, id uint64, deletedBefore time.Time) error {
+	args := m.Called(ctx, id, deletedBefore)
+	return args.Error(0)
+}
+
+func (m *MockArticleRepository) FindAfterID(ctx context.Context, afterID uint64, limit int) ([]*entity.Article, error) {
+	args := m.Called(ctx, afterID, limit)
+	return args.Get(0).([]*entity.Article), args.Error(1)
+}
+
+func (m *MockArticleRepository) UpdateMetadata(ctx context.Context, article *entity.Article) (bool, error) {
+	args := m.Called(ctx, article)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockArticleRepository) FindByCriteria(ctx context.Context, criteria repository.ArticleQueryCriteria) ([]*entity.Article, int, error) {
 	args := m.Called(ctx, criteria)
 	return args.Get(0).([]*entity.Article), args.Get(1).(int), args.Error(2)
 }
 
+func (m *MockArticleRepository) FindBySlug(ctx context.Context, slug string) (*entity.Article, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Article), args.Error(1)
+}
+
+func (m *MockArticleRepository) FindCurrentSlug(ctx context.Context, previousSlug string) (string, error) {
+	args := m.Called(ctx, previousSlug)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockArticleRepository) SlugExists(ctx context.Context, slug string, exceptArticleID uint64) (bool, error) {
+	args := m.Called(ctx, slug, exceptArticleID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockArticleRepository) FindIDByNormalizedLink(ctx context.Context, normalizedLink string) (uint64, error) {
+	args := m.Called(ctx, normalizedLink)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+// passthroughTxManager はトランザクションを張らずにfnをそのまま実行する
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (passthroughTxManager) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func ptr[T any](v T) *T {
 	return &v
 }
@@ -63,7 +139,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 
 	t.Run("必須フィールドのみで記事を作成", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.CreateArticleInput{
 			Title:  "テスト記事タイトル",
@@ -78,6 +154,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 			UpdatedAt: time.Now(),
 		}
 
+		mockRepo.On("SlugExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
 		mockRepo.On("Create", ctx, mock.AnythingOfType("*entity.Article")).Return(createdArticle, nil)
 
 		output, err := uc.CreateArticle(ctx, input)
@@ -93,7 +170,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 
 	t.Run("全てのフィールドを指定して記事を作成", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.CreateArticleInput{
 			Title:        "タイトル",
@@ -103,7 +180,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 			Link:         ptr("https://example.com"),
 		}
 
-		body, err := vo.NewArticleBody(ptr("本文"))
+		body, err := vo.NewArticleBody(ptr("本文"), 0)
 		require.NoError(t, err)
 		providerType, err := vo.NewProviderType(ptr("qiita"))
 		require.NoError(t, err)
@@ -121,6 +198,8 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 			UpdatedAt:    time.Now(),
 		}
 
+		mockRepo.On("SlugExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
+		mockRepo.On("FindIDByNormalizedLink", ctx, "https://example.com").Return(uint64(0), repository.ErrArticleNotFound)
 		mockRepo.On("Create", ctx, mock.AnythingOfType("*entity.Article")).Return(createdArticle, nil)
 
 		output, err := uc.CreateArticle(ctx, input)
@@ -140,7 +219,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 
 	t.Run("タイトルが空の場合はエラー", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.CreateArticleInput{
 			Title:  "",
@@ -156,7 +235,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 
 	t.Run("タイトルが文字数制限を超える場合はエラー", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		longTitle := strings.Repeat("a", vo.MaxArticleTitleLength+1)
 		input := article.CreateArticleInput{
@@ -173,7 +252,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 
 	t.Run("本文が空文字列で入力された場合の本文の返り値は空文字", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.CreateArticleInput{
 			Title:        "Valid Title",
@@ -183,7 +262,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 			Link:         ptr("https://example.com"),
 		}
 
-		body, err := vo.NewArticleBody(ptr(""))
+		body, err := vo.NewArticleBody(ptr(""), 0)
 		require.NoError(t, err)
 		providerType, err := vo.NewProviderType(ptr("qiita"))
 		require.NoError(t, err)
@@ -201,6 +280,8 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 			UpdatedAt:    time.Now(),
 		}
 
+		mockRepo.On("SlugExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
+		mockRepo.On("FindIDByNormalizedLink", ctx, mock.Anything).Return(uint64(0), repository.ErrArticleNotFound)
 		mockRepo.On("Create", ctx, mock.AnythingOfType("*entity.Article")).Return(createdArticle, nil)
 
 		// When
@@ -222,7 +303,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 
 	t.Run("本文がnilで入力された場合の本文の返り値は空文字", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.CreateArticleInput{
 			Title:        "Valid Title",
@@ -232,7 +313,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 			Link:         ptr("https://example.com"),
 		}
 
-		body, err := vo.NewArticleBody(ptr(""))
+		body, err := vo.NewArticleBody(ptr(""), 0)
 		require.NoError(t, err)
 		providerType, err := vo.NewProviderType(ptr("qiita"))
 		require.NoError(t, err)
@@ -250,6 +331,8 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 			UpdatedAt:    time.Now(),
 		}
 
+		mockRepo.On("SlugExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
+		mockRepo.On("FindIDByNormalizedLink", ctx, mock.Anything).Return(uint64(0), repository.ErrArticleNotFound)
 		mockRepo.On("Create", ctx, mock.AnythingOfType("*entity.Article")).Return(createdArticle, nil)
 
 		// When
@@ -271,7 +354,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 
 	t.Run("無効なステータスの場合はエラー", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.CreateArticleInput{
 			Title:  "Valid Title",
@@ -287,7 +370,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 
 	t.Run("無効なプロバイダタイプの場合はエラー", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.CreateArticleInput{
 			Title:        "Valid Title",
@@ -304,7 +387,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 
 	t.Run("リポジトリでエラーが発生した場合は適切に処理される", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.CreateArticleInput{
 			Title:  "Valid Title",
@@ -312,6 +395,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 		}
 
 		dbError := fmt.Errorf("db error")
+		mockRepo.On("SlugExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
 		mockRepo.On("Create", ctx, mock.AnythingOfType("*entity.Article")).Return((*entity.Article)(nil), dbError)
 
 		output, err := uc.CreateArticle(ctx, input)
@@ -327,7 +411,7 @@ func TestArticleUsecase_CreateArticle(t *testing.T) {
 func TestArticleUsecase_FindArticles(t *testing.T) {
 	t.Run("全ての記事をページネーションなしで取得", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		expectedArticles := []*entity.Article{
 			{ID: 1, Title: vo.ArticleTitle("Article 1"), Status: vo.ArticleStatus("draft")},
@@ -352,7 +436,7 @@ func TestArticleUsecase_FindArticles(t *testing.T) {
 
 	t.Run("ステータスでフィルタリング", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		status := "draft"
 		expectedArticles := []*entity.Article{
@@ -382,7 +466,7 @@ func TestArticleUsecase_FindArticles(t *testing.T) {
 
 	t.Run("プロバイダタイプでフィルタリング", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		providerType := "qiita"
 		providerTypeVal := vo.ProviderType(providerType)
@@ -413,7 +497,7 @@ func TestArticleUsecase_FindArticles(t *testing.T) {
 
 	t.Run("ソート順を指定して取得", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		expectedArticles := []*entity.Article{
 			{ID: 1, Title: vo.ArticleTitle("Article 1"), CreatedAt: time.Now().Add(-time.Hour)},
@@ -444,7 +528,7 @@ func TestArticleUsecase_FindArticles(t *testing.T) {
 
 	t.Run("ページネーション適用", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		expectedArticles := []*entity.Article{
 			{ID: 1, Title: vo.ArticleTitle("Article 1"), CreatedAt: time.Now().Add(-time.Hour)},
@@ -471,7 +555,7 @@ func TestArticleUsecase_FindArticles(t *testing.T) {
 
 	t.Run("記事が見つからない場合は空の結果", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		mockRepo.On("FindByCriteria", mock.Anything, repository.ArticleQueryCriteria{
 			Page:  1,
@@ -495,7 +579,7 @@ func TestArticleUsecase_FindArticles(t *testing.T) {
 
 	t.Run("リポジトリエラーは適切に処理される", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		mockRepo.On("FindByCriteria", mock.Anything, repository.ArticleQueryCriteria{
 			Page:  1,
@@ -515,18 +599,71 @@ func TestArticleUsecase_FindArticles(t *testing.T) {
 
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("読了時間で絞り込み、メタデータを返す", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		body := vo.ArticleBody("## 手順\n[参考](https://example.com)を読む")
+		expectedArticles := []*entity.Article{
+			{ID: 1, Title: vo.ArticleTitle("Article"), Body: &body},
+		}
+
+		mockRepo.On("FindByCriteria", mock.Anything, repository.ArticleQueryCriteria{
+			MinReadingTime: ptr(1),
+			MaxReadingTime: ptr(5),
+			SortBy:         ptr("reading_time"),
+			Page:           1,
+			Limit:          10,
+		}).Return(expectedArticles, len(expectedArticles), nil)
+
+		output, err := uc.FindByCriteria(context.Background(), article.FindByCriteriaInput{
+			MinReadingTime: ptr(1),
+			MaxReadingTime: ptr(5),
+			SortBy:         ptr("reading_time"),
+			Page:           1,
+			Limit:          10,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, output.Articles, 1)
+		assert.Equal(t, article.ArticleMetadataOutput{
+			Excerpt:            "手順 参考を読む",
+			CharCount:          7,
+			ReadingTimeMinutes: 1,
+			TOC:                []article.HeadingOutput{{Level: 2, Text: "手順"}},
+			Links:              []string{"https://example.com"},
+		}, output.Articles[0].Metadata)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("最小読了時間が最大を超える場合はエラー", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		output, err := uc.FindByCriteria(context.Background(), article.FindByCriteriaInput{
+			MinReadingTime: ptr(10),
+			MaxReadingTime: ptr(5),
+			Page:           1,
+			Limit:          10,
+		})
+
+		assert.Nil(t, output)
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindByCriteria", mock.Anything, mock.Anything)
+	})
 }
 
 func TestArticleUsecase_FindArticleByID(t *testing.T) {
 	ctx := context.Background()
 	t.Run("IDで記事を取得", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		articleID := uint64(1)
 		title, err := vo.NewArticleTitle("Test Article")
 		require.NoError(t, err)
-		body, err := vo.NewArticleBody(ptr("This is a test article body."))
+		body, err := vo.NewArticleBody(ptr("This is a test article body."), 0)
 		require.NoError(t, err)
 		status := vo.ArticleStatus("draft")
 		providerType, err := vo.NewProviderType(ptr("qiita"))
@@ -565,7 +702,7 @@ func TestArticleUsecase_FindArticleByID(t *testing.T) {
 
 	t.Run("記事が見つからない場合はエラー", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		articleID := uint64(999) // 存在しないID
 		mockRepo.On("FindByID", ctx, articleID).Return(nil, fmt.Errorf("article not found"))
@@ -581,7 +718,7 @@ func TestArticleUsecase_FindArticleByID(t *testing.T) {
 
 	t.Run("リポジトリエラーは適切に処理される", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		articleID := uint64(1)
 		mockRepo.On("FindByID", ctx, articleID).Return(nil, fmt.Errorf("db error"))
@@ -594,6 +731,49 @@ func TestArticleUsecase_FindArticleByID(t *testing.T) {
 
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("レンダラを指定した場合は本文のHTMLを返す", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		renderer := stubBodyRenderer(func(body string) (string, error) {
+			return "<p>" + body + "</p>", nil
+		})
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithBodyRenderer(renderer))
+
+		title, err := vo.NewArticleTitle("Test Article")
+		require.NoError(t, err)
+		body, err := vo.NewArticleBody(ptr("本文"), 0)
+		require.NoError(t, err)
+		mockRepo.On("FindByID", ctx, uint64(1)).Return(&entity.Article{ID: 1, Title: title, Body: body, Status: vo.ArticleStatusDraft}, nil)
+
+		output, err := uc.FindArticleByID(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, "<p>本文</p>", output.BodyHTML)
+	})
+
+	t.Run("レンダリングに失敗した場合はエラー", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		renderer := stubBodyRenderer(func(string) (string, error) {
+			return "", fmt.Errorf("render error")
+		})
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithBodyRenderer(renderer))
+
+		title, err := vo.NewArticleTitle("Test Article")
+		require.NoError(t, err)
+		mockRepo.On("FindByID", ctx, uint64(1)).Return(&entity.Article{ID: 1, Title: title, Status: vo.ArticleStatusDraft}, nil)
+
+		output, err := uc.FindArticleByID(ctx, 1)
+
+		assert.Nil(t, output)
+		assert.ErrorContains(t, err, "render error")
+	})
+}
+
+// stubBodyRenderer は関数をBodyRendererとして扱う
+type stubBodyRenderer func(body string) (string, error)
+
+func (f stubBodyRenderer) Render(body string) (string, error) {
+	return f(body)
 }
 
 func TestArticleUsecase_UpdateArticle(t *testing.T) {
@@ -601,7 +781,7 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 
 	t.Run("タイトルのみ更新", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.UpdateArticleInput{
 			Title: ptr("Updated Title"),
@@ -612,7 +792,7 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 		require.NoError(t, err)
 		existingArticle.ID = 1
 
-		mockRepo.On("FindByID", ctx, uint64(1)).Return(existingArticle, nil)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existingArticle, nil)
 		mockRepo.On("Update", ctx, mock.AnythingOfType("*entity.Article")).Return(nil)
 
 		output, err := uc.UpdateArticle(ctx, 1, input)
@@ -625,7 +805,7 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 
 	t.Run("ステータスを下書きから公開済みに変更", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.UpdateArticleInput{
 			Status: ptr("published"),
@@ -636,7 +816,7 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 		require.NoError(t, err)
 		existingArticle.ID = 1
 
-		mockRepo.On("FindByID", ctx, uint64(1)).Return(existingArticle, nil)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existingArticle, nil)
 		mockRepo.On("Update", ctx, mock.AnythingOfType("*entity.Article")).Return(nil)
 
 		output, err := uc.UpdateArticle(ctx, 1, input)
@@ -649,7 +829,7 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 
 	t.Run("オプションフィールドをクリア", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.UpdateArticleInput{
 			Body:         ptr(""),
@@ -666,7 +846,7 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 		require.NoError(t, err)
 		existingArticle.ID = 1
 
-		mockRepo.On("FindByID", ctx, uint64(1)).Return(existingArticle, nil)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existingArticle, nil)
 		mockRepo.On("Update", ctx, mock.AnythingOfType("*entity.Article")).Return(nil)
 
 		output, err := uc.UpdateArticle(ctx, 1, input)
@@ -681,7 +861,7 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 
 	t.Run("記事が見つからない場合はエラー", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.UpdateArticleInput{
 			Body:         ptr("Updated Body"),
@@ -689,7 +869,7 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 			Link:         ptr("Updated Link"),
 		}
 
-		mockRepo.On("FindByID", ctx, uint64(1)).Return(nil, fmt.Errorf("article not found"))
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(nil, fmt.Errorf("article not found"))
 
 		output, err := uc.UpdateArticle(ctx, 1, input)
 
@@ -702,7 +882,7 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 
 	t.Run("更新内容のバリデーションエラー", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.UpdateArticleInput{
 			// タイトルが長すぎる
@@ -714,7 +894,7 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 		require.NoError(t, err)
 		existingArticle.ID = 1
 
-		mockRepo.On("FindByID", ctx, uint64(1)).Return(existingArticle, nil)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existingArticle, nil)
 
 		output, err := uc.UpdateArticle(ctx, 1, input)
 
@@ -727,7 +907,7 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 
 	t.Run("ドメインルール違反の場合はエラー", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.UpdateArticleInput{
 			Status: ptr("invalid_status"), // 無効なステータス
@@ -738,7 +918,7 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 		require.NoError(t, err)
 		existingArticle.ID = 1
 
-		mockRepo.On("FindByID", ctx, uint64(1)).Return(existingArticle, nil)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existingArticle, nil)
 
 		output, err := uc.UpdateArticle(ctx, 1, input)
 
@@ -751,7 +931,7 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 
 	t.Run("リポジトリエラーは適切に処理される", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		input := article.UpdateArticleInput{
 			Title: ptr("Updated Title"),
@@ -762,7 +942,7 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 		require.NoError(t, err)
 		existingArticle.ID = 1
 
-		mockRepo.On("FindByID", ctx, uint64(1)).Return(existingArticle, nil)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existingArticle, nil)
 		mockRepo.On("Update", ctx, mock.AnythingOfType("*entity.Article")).Return(fmt.Errorf("repository error"))
 
 		output, err := uc.UpdateArticle(ctx, 1, input)
@@ -775,10 +955,52 @@ func TestArticleUsecase_UpdateArticle(t *testing.T) {
 	})
 }
 
+func TestArticleUsecase_BodyMaxSize(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("最大サイズを超える本文では作成できない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithBodyMaxSize(3))
+
+		_, err := uc.CreateArticle(ctx, article.CreateArticleInput{Title: "タイトル", Status: "draft", Body: ptr("abcd")})
+
+		assert.Error(t, err)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("最大サイズを超える本文には更新できない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithBodyMaxSize(3))
+		existing, err := entity.NewArticle("タイトル", "draft")
+		require.NoError(t, err)
+		existing.ID = 1
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existing, nil)
+
+		_, err = uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Body: ptr("abcd")})
+
+		assert.Error(t, err)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("既定の最大サイズを超える本文も設定で許可できる", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithBodyMaxSize(vo.DefaultMaxArticleBodySize+1))
+		body := strings.Repeat("a", vo.DefaultMaxArticleBodySize+1)
+		mockRepo.On("SlugExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*entity.Article")).Return(func(_ context.Context, a *entity.Article) *entity.Article {
+			return a
+		}, nil)
+
+		_, err := uc.CreateArticle(ctx, article.CreateArticleInput{Title: "タイトル", Status: "draft", Body: &body})
+
+		assert.NoError(t, err)
+	})
+}
+
 func TestArticleUsecase_DeleteArticle(t *testing.T) {
 	t.Run("記事が削除される", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		id := uint64(1)
 
@@ -787,8 +1009,8 @@ func TestArticleUsecase_DeleteArticle(t *testing.T) {
 		require.NoError(t, err)
 		existingArticle.ID = id
 
-		mockRepo.On("FindByID", mock.Anything, id).Return(existingArticle, nil)
-		mockRepo.On("Delete", mock.Anything, id).Return(nil)
+		mockRepo.On("FindByIDForUpdate", mock.Anything, id).Return(existingArticle, nil)
+		mockRepo.On("SoftDelete", mock.Anything, id).Return(nil)
 
 		err = uc.DeleteArticle(context.Background(), id)
 
@@ -797,10 +1019,10 @@ func TestArticleUsecase_DeleteArticle(t *testing.T) {
 	})
 	t.Run("記事が見つからない場合はエラー", func(t *testing.T) {
 		mockRepo := new(MockArticleRepository)
-		uc := article.NewArticleUsecase(mockRepo)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
 
 		id := uint64(1)
-		mockRepo.On("FindByID", mock.Anything, id).Return(nil, fmt.Errorf("article not found"))
+		mockRepo.On("FindByIDForUpdate", mock.Anything, id).Return(nil, fmt.Errorf("article not found"))
 
 		err := uc.DeleteArticle(context.Background(), id)
 
@@ -809,3 +1031,1313 @@ func TestArticleUsecase_DeleteArticle(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+// recordingTxManager はWithinTx / WithinSavepointの呼び出し回数を記録する
+type recordingTxManager struct {
+	calls      int
+	savepoints int
+}
+
+func (m *recordingTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.calls++
+	return fn(ctx)
+}
+
+func (m *recordingTxManager) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.savepoints++
+	return fn(ctx)
+}
+
+func TestArticleUsecase_Transaction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("更新は行ロックを取得してトランザクション内で実行される", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		txManager := &recordingTxManager{}
+		uc := article.NewArticleUsecase(mockRepo, txManager)
+
+		existingArticle, err := entity.NewArticle("Original Title", "draft")
+		require.NoError(t, err)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existingArticle, nil)
+		mockRepo.On("Update", ctx, mock.AnythingOfType("*entity.Article")).Return(nil)
+
+		_, err = uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Title: ptr("Updated Title")})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, txManager.calls)
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("削除は行ロックを取得してトランザクション内で実行される", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		txManager := &recordingTxManager{}
+		uc := article.NewArticleUsecase(mockRepo, txManager)
+
+		existingArticle := &entity.Article{ID: 1, Title: vo.ArticleTitle("T"), Status: vo.ArticleStatusDraft}
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existingArticle, nil)
+		mockRepo.On("SoftDelete", ctx, uint64(1)).Return(nil)
+
+		err := uc.DeleteArticle(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, txManager.calls)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestArticleUsecase_Slug(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("生成したスラッグが使用済みなら連番を付与する", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("SlugExists", ctx, "hello-world", mock.Anything).Return(true, nil)
+		mockRepo.On("SlugExists", ctx, "hello-world-2", mock.Anything).Return(true, nil)
+		mockRepo.On("SlugExists", ctx, "hello-world-3", mock.Anything).Return(false, nil)
+		mockRepo.On("Create", ctx, mock.MatchedBy(func(a *entity.Article) bool {
+			return a.Slug == "hello-world-3"
+		})).Return(func(_ context.Context, a *entity.Article) *entity.Article {
+			a.ID = 1
+			return a
+		}, nil)
+
+		output, err := uc.CreateArticle(ctx, article.CreateArticleInput{Title: "Hello World", Status: "draft"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "hello-world-3", output.Slug)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("指定したスラッグが使用済みならErrConflict", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("SlugExists", ctx, "taken", mock.Anything).Return(true, nil)
+
+		output, err := uc.CreateArticle(ctx, article.CreateArticleInput{Title: "Hello World", Slug: ptr("taken"), Status: "draft"})
+
+		assert.Nil(t, output)
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("作成時の一意制約違反はErrConflict", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("SlugExists", ctx, "hello-world", mock.Anything).Return(false, nil)
+		mockRepo.On("Create", ctx, mock.Anything).Return((*entity.Article)(nil), repository.ErrArticleSlugConflict)
+
+		_, err := uc.CreateArticle(ctx, article.CreateArticleInput{Title: "Hello World", Status: "draft"})
+
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+	})
+
+	t.Run("スラッグを変更する", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		existing, err := entity.NewArticle("Hello World", "draft")
+		require.NoError(t, err)
+		existing.ID = 1
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existing, nil)
+		// 自身の以前のスラッグには戻せるよう、記事自身の履歴は除いて確認する
+		mockRepo.On("SlugExists", ctx, "new-slug", uint64(1)).Return(false, nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(a *entity.Article) bool {
+			return a.Slug == "new-slug"
+		})).Return(nil)
+
+		output, err := uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Title: ptr("Hello World"), Slug: ptr("new-slug")})
+
+		require.NoError(t, err)
+		assert.Equal(t, "new-slug", output.Slug)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("変更先のスラッグが使用済みならErrConflict", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		existing, err := entity.NewArticle("Hello World", "draft")
+		require.NoError(t, err)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existing, nil)
+		mockRepo.On("SlugExists", ctx, "taken", mock.Anything).Return(true, nil)
+
+		_, err = uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Title: ptr("Hello World"), Slug: ptr("taken")})
+
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("現在のスラッグで記事を取得する", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		found, err := entity.NewArticle("Hello World", "published")
+		require.NoError(t, err)
+		mockRepo.On("FindBySlug", ctx, "hello-world").Return(found, nil)
+
+		output, err := uc.FindArticleBySlug(ctx, "hello-world")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Hello World", output.Title)
+	})
+
+	t.Run("過去のスラッグはSlugMovedErrorで現在のスラッグを返す", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("FindBySlug", ctx, "old-slug").Return(nil, repository.ErrArticleNotFound)
+		mockRepo.On("FindCurrentSlug", ctx, "old-slug").Return("new-slug", nil)
+
+		output, err := uc.FindArticleBySlug(ctx, "old-slug")
+
+		assert.Nil(t, output)
+		var moved *article.SlugMovedError
+		require.ErrorAs(t, err, &moved)
+		assert.Equal(t, "new-slug", moved.Slug)
+	})
+
+	t.Run("履歴にもないスラッグはErrNotFound", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("FindBySlug", ctx, "unknown").Return(nil, repository.ErrArticleNotFound)
+		mockRepo.On("FindCurrentSlug", ctx, "unknown").Return("", repository.ErrArticleNotFound)
+
+		_, err := uc.FindArticleBySlug(ctx, "unknown")
+
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
+
+func TestArticleUsecase_Publications(t *testing.T) {
+	ctx := context.Background()
+
+	newArticle := func(t *testing.T, status string) *entity.Article {
+		t.Helper()
+		a, err := entity.NewArticle("Hello World", status,
+			entity.WithProviderType(ptr("zenn")),
+			entity.WithLink(ptr("https://zenn.dev/example/articles/a")),
+		)
+		require.NoError(t, err)
+		a.ID = 1
+		a.Publications[0].ID = 10
+		return a
+	}
+
+	t.Run("クロスポストを追加する", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(newArticle(t, "published"), nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(a *entity.Article) bool {
+			return len(a.Publications) == 2 && a.Link.String() == "https://zenn.dev/example/articles/a"
+		})).Return(nil)
+
+		output, err := uc.AddPublication(ctx, 1, article.AddPublicationInput{
+			ProviderType: ptr("qiita"),
+			Link:         ptr("https://qiita.com/example/items/a"),
+			ExternalID:   ptr("a"),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "qiita", output.ProviderType)
+		assert.Equal(t, "synced", output.SyncStatus)
+		assert.False(t, output.Canonical)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("同じリンクの投稿先はErrConflict", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(newArticle(t, "draft"), nil)
+
+		_, err := uc.AddPublication(ctx, 1, article.AddPublicationInput{Link: ptr("https://zenn.dev/example/articles/a")})
+
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("プロバイダもリンクもない投稿先はErrInvalidInput", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(newArticle(t, "draft"), nil)
+
+		_, err := uc.AddPublication(ctx, 1, article.AddPublicationInput{})
+
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+	})
+
+	t.Run("存在しない投稿先の削除はErrNotFound", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(newArticle(t, "draft"), nil)
+
+		err := uc.RemovePublication(ctx, 1, 99)
+
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正規の投稿先を削除する", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(newArticle(t, "draft"), nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(a *entity.Article) bool {
+			return len(a.Publications) == 0 && a.ProviderType == nil && a.Link == nil
+		})).Return(nil)
+
+		require.NoError(t, uc.RemovePublication(ctx, 1, 10))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正規の投稿先を切り替える", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		existing := newArticle(t, "draft")
+		require.NoError(t, existing.AddPublication(ptr("qiita"), ptr("https://qiita.com/example/items/a"), nil, nil))
+		existing.Publications[1].ID = 11
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existing, nil)
+		mockRepo.On("FindIDByNormalizedLink", ctx, "https://qiita.com/example/items/a").Return(uint64(0), repository.ErrArticleNotFound)
+		mockRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+		output, err := uc.SetCanonicalPublication(ctx, 1, 11)
+
+		require.NoError(t, err)
+		assert.Equal(t, "qiita", output.ProviderType)
+		assert.Equal(t, "https://qiita.com/example/items/a", output.Link)
+		require.Len(t, output.Publications, 2)
+		assert.False(t, output.Publications[0].Canonical)
+		assert.True(t, output.Publications[1].Canonical)
+	})
+
+	t.Run("公開済みの記事でプロバイダが変わる切り替えはErrConflict", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		existing := newArticle(t, "published")
+		require.NoError(t, existing.AddPublication(ptr("qiita"), nil, nil, nil))
+		existing.Publications[1].ID = 11
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existing, nil)
+
+		_, err := uc.SetCanonicalPublication(ctx, 1, 11)
+
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+	})
+
+	t.Run("投稿先のプロバイダで絞り込む", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("FindByCriteria", ctx, mock.MatchedBy(func(c repository.ArticleQueryCriteria) bool {
+			return c.PublishedOn != nil && *c.PublishedOn == "qiita" && c.ProviderType == nil
+		})).Return([]*entity.Article{}, 0, nil)
+
+		_, err := uc.FindByCriteria(ctx, article.FindByCriteriaInput{PublishedOn: ptr("qiita"), Page: 1, Limit: 10})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestArticleUsecase_FindByCriteria_HasBrokenLinks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("リンク切れの有無で絞り込む", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("FindByCriteria", ctx, mock.MatchedBy(func(c repository.ArticleQueryCriteria) bool {
+			return c.HasBrokenLinks != nil && *c.HasBrokenLinks
+		})).Return([]*entity.Article{}, 0, nil)
+
+		_, err := uc.FindByCriteria(ctx, article.FindByCriteriaInput{HasBrokenLinks: ptr(true), Page: 1, Limit: 10})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestArticleUsecase_FindArticleByID_LinkPreview(t *testing.T) {
+	ctx := context.Background()
+
+	newLinkedArticle := func(t *testing.T, preview *entity.LinkPreview) *entity.Article {
+		t.Helper()
+		a, err := entity.NewArticle("Test Article", "draft",
+			entity.WithProviderType(ptr("qiita")),
+			entity.WithLink(ptr("https://qiita.com/u/items/a")),
+		)
+		require.NoError(t, err)
+		a.ID = 1
+		a.LinkPreview = preview
+		return a
+	}
+
+	t.Run("取得済みのOGPを出力に含める", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("FindByID", ctx, uint64(1)).Return(newLinkedArticle(t, &entity.LinkPreview{
+			URL:       "https://qiita.com/u/items/a",
+			OpenGraph: entity.OpenGraph{Title: "Qiitaの記事", Image: "https://qiita.com/ogp.png", SiteName: "Qiita"},
+			Status:    entity.LinkPreviewFailed,
+		}), nil)
+
+		output, err := uc.FindArticleByID(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, &article.LinkPreviewOutput{Title: "Qiitaの記事", Image: "https://qiita.com/ogp.png", SiteName: "Qiita"}, output.LinkPreview)
+	})
+
+	t.Run("OGPを取得できていない場合は出力しない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		errMsg := "unexpected status: 404"
+		mockRepo.On("FindByID", ctx, uint64(1)).Return(newLinkedArticle(t, &entity.LinkPreview{
+			URL:       "https://qiita.com/u/items/a",
+			Status:    entity.LinkPreviewFailed,
+			LastError: &errMsg,
+		}), nil)
+
+		output, err := uc.FindArticleByID(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Nil(t, output.LinkPreview)
+	})
+}
+
+func TestArticleUsecase_DuplicateLink(t *testing.T) {
+	ctx := context.Background()
+
+	newLinkedArticle := func(t *testing.T, link string) *entity.Article {
+		t.Helper()
+		a, err := entity.NewArticle("タイトル", "draft",
+			entity.WithProviderType(ptr("qiita")),
+			entity.WithLink(ptr(link)),
+		)
+		require.NoError(t, err)
+		a.ID = 1
+		return a
+	}
+
+	t.Run("正規化後に同じリンクの記事がある場合は既存の記事のIDを含む競合エラー", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("SlugExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
+		mockRepo.On("FindIDByNormalizedLink", ctx, "https://example.com/items/a").Return(uint64(7), nil)
+
+		_, err := uc.CreateArticle(ctx, article.CreateArticleInput{
+			Title:        "タイトル",
+			Status:       "draft",
+			ProviderType: ptr("qiita"),
+			Link:         ptr("http://Example.com/items/a/?utm_source=twitter"),
+		})
+
+		var dup *article.DuplicateLinkError
+		require.ErrorAs(t, err, &dup)
+		assert.Equal(t, uint64(7), dup.ArticleID)
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("一意インデックスで検出した競合はErrConflictを返す", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("SlugExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
+		mockRepo.On("FindIDByNormalizedLink", ctx, mock.Anything).Return(uint64(0), repository.ErrArticleNotFound)
+		mockRepo.On("Create", ctx, mock.Anything).Return((*entity.Article)(nil), repository.ErrArticleLinkConflict)
+
+		_, err := uc.CreateArticle(ctx, article.CreateArticleInput{
+			Title:        "タイトル",
+			Status:       "draft",
+			ProviderType: ptr("qiita"),
+			Link:         ptr("https://example.com/items/a"),
+		})
+
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+	})
+
+	t.Run("リンクを他の記事と同じものに変更する場合は競合エラー", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(newLinkedArticle(t, "https://example.com/items/a"), nil)
+		mockRepo.On("FindIDByNormalizedLink", ctx, "https://example.com/items/b").Return(uint64(2), nil)
+
+		_, err := uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Link: ptr("https://example.com/items/b/")})
+
+		var dup *article.DuplicateLinkError
+		require.ErrorAs(t, err, &dup)
+		assert.Equal(t, uint64(2), dup.ArticleID)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正規化後のリンクが変わらない場合は重複を確認しない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(newLinkedArticle(t, "https://example.com/items/a"), nil)
+		mockRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+		_, err := uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Link: ptr("https://example.com/items/a#top")})
+
+		require.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "FindIDByNormalizedLink", mock.Anything, mock.Anything)
+	})
+}
+
+func TestArticleUsecase_Status(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("追加したステータスで絞り込める", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		mockRepo.On("FindByCriteria", ctx, mock.MatchedBy(func(c repository.ArticleQueryCriteria) bool {
+			return c.Status != nil && *c.Status == "archived"
+		})).Return([]*entity.Article{}, 0, nil)
+
+		_, err := uc.FindByCriteria(ctx, article.FindByCriteriaInput{Status: ptr("archived"), Page: 1, Limit: 10})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("無効なステータスでの絞り込みはErrInvalidInput", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		_, err := uc.FindByCriteria(ctx, article.FindByCriteriaInput{Status: ptr("deleted"), Page: 1, Limit: 10})
+
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindByCriteria", mock.Anything, mock.Anything)
+	})
+
+	t.Run("遷移表で許可されていないステータスへの更新はErrConflict", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		archived, err := entity.NewArticle("タイトル", "archived")
+		require.NoError(t, err)
+		archived.ID = 1
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(archived, nil)
+
+		_, err = uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Status: ptr("published")})
+
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestArticleUsecase_Authorization(t *testing.T) {
+	withScope := func(scope vo.Scope) context.Context {
+		return auth.WithPrincipal(context.Background(), &auth.Principal{KeyID: 1, Name: "key", Scopes: []vo.Scope{scope}})
+	}
+
+	t.Run("Authorizerを指定しない場合は認証なしで利用できる", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+		mockRepo.On("FindAll", mock.Anything).Return([]*entity.Article{}, nil)
+
+		_, err := uc.FindAllArticles(context.Background())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("未認証の呼び出しはErrUnauthenticated", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+
+		_, err := uc.FindArticleByID(context.Background(), 1)
+
+		assert.ErrorIs(t, err, apperr.ErrUnauthenticated)
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("参照のスコープでは更新できない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		existing, err := entity.NewArticle("タイトル", "published")
+		require.NoError(t, err)
+		existing.ID = 1
+		ctx := withScope(vo.ScopeArticlesRead)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existing, nil)
+
+		_, err = uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Title: ptr("新しいタイトル")})
+
+		var forbidden *auth.ForbiddenError
+		assert.ErrorAs(t, err, &forbidden)
+		assert.Equal(t, auth.ActionUpdateArticle, forbidden.Action)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("所有者と編集者だけが削除できる", func(t *testing.T) {
+		owner := uint64(7)
+		existing, err := entity.NewArticle("タイトル", "draft", entity.WithAuthorID(&owner))
+		require.NoError(t, err)
+		existing.ID = 1
+
+		tests := []struct {
+			name      string
+			principal *auth.Principal
+			wantErr   error
+		}{
+			{name: "所有者", principal: &auth.Principal{Name: "alice", AuthorID: owner, Scopes: []vo.Scope{vo.ScopeArticlesWrite}}},
+			{name: "編集者", principal: &auth.Principal{Name: "bob", Roles: []string{"editor"}, Scopes: []vo.Scope{vo.ScopeArticlesWrite}}},
+			{name: "他の執筆者", principal: &auth.Principal{Name: "carol", AuthorID: owner + 1, Scopes: []vo.Scope{vo.ScopeArticlesWrite}}, wantErr: apperr.ErrForbidden},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockRepo := new(MockArticleRepository)
+				uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+				ctx := auth.WithPrincipal(context.Background(), tt.principal)
+				mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existing, nil)
+				if tt.wantErr == nil {
+					mockRepo.On("SoftDelete", ctx, uint64(1)).Return(nil)
+				}
+
+				err := uc.DeleteArticle(ctx, 1)
+
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+					mockRepo.AssertNotCalled(t, "SoftDelete", mock.Anything, mock.Anything)
+					return
+				}
+				assert.NoError(t, err)
+				mockRepo.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("作成した記事は呼び出し元の執筆者の所有になる", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "alice", AuthorID: 7, Scopes: []vo.Scope{vo.ScopeArticlesWrite}})
+		mockRepo.On("SlugExists", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(a *entity.Article) bool {
+			return a.IsOwnedBy(7)
+		})).Return(func(_ context.Context, a *entity.Article) *entity.Article { return a }, nil)
+
+		output, err := uc.CreateArticle(ctx, article.CreateArticleInput{Title: "タイトル", Status: "draft"})
+
+		require.NoError(t, err)
+		require.NotNil(t, output.AuthorID)
+		assert.Equal(t, uint64(7), *output.AuthorID)
+	})
+
+	t.Run("他の執筆者の記事として作成できるのは編集者だけ", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "alice", AuthorID: 7, Scopes: []vo.Scope{vo.ScopeArticlesWrite}})
+		other := uint64(8)
+
+		_, err := uc.CreateArticle(ctx, article.CreateArticleInput{Title: "タイトル", Status: "draft", AuthorID: &other})
+
+		assert.ErrorIs(t, err, apperr.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("所有者は記事を他の執筆者に付け替えられない", func(t *testing.T) {
+		owner := uint64(7)
+		existing, err := entity.NewArticle("タイトル", "draft", entity.WithAuthorID(&owner))
+		require.NoError(t, err)
+		existing.ID = 1
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "alice", AuthorID: owner, Scopes: []vo.Scope{vo.ScopeArticlesWrite}})
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existing, nil)
+		other := owner + 1
+
+		_, err = uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{AuthorID: &other})
+
+		assert.ErrorIs(t, err, apperr.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestArticleUsecase_Policy(t *testing.T) {
+	withRoles := func(roles ...string) context.Context {
+		return auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "u-1", Name: "user", Roles: roles, AuthorID: 99})
+	}
+	newArticle := func(t *testing.T, status string) *entity.Article {
+		t.Helper()
+		body := "本文"
+		a, err := entity.NewArticle("タイトル", status, entity.WithBody(&body))
+		require.NoError(t, err)
+		a.ID = 1
+		return a
+	}
+
+	t.Run("閲覧者の一覧は公開済みの記事に限られる", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		ctx := withRoles("viewer")
+		mockRepo.On("FindByCriteria", ctx, mock.MatchedBy(func(c repository.ArticleQueryCriteria) bool {
+			return c.Status != nil && *c.Status == "published"
+		})).Return([]*entity.Article{}, 0, nil)
+
+		_, err := uc.FindByCriteria(ctx, article.FindByCriteriaInput{Page: 1, Limit: 10})
+		require.NoError(t, err)
+
+		_, err = uc.FindByCriteria(ctx, article.FindByCriteriaInput{Status: ptr("draft"), Page: 1, Limit: 10})
+		assert.ErrorIs(t, err, apperr.ErrForbidden)
+		mockRepo.AssertNumberOfCalls(t, "FindByCriteria", 1)
+	})
+
+	t.Run("閲覧者は下書きを変更のない内容でも更新できない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		ctx := withRoles("viewer")
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(newArticle(t, "draft"), nil)
+
+		_, err := uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{})
+
+		var forbidden *auth.ForbiddenError
+		require.ErrorAs(t, err, &forbidden)
+		assert.Equal(t, auth.ActionReadUnpublishedArticle, forbidden.Action)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("変更のない更新は保存せずイベントも記録しない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		ctx := withRoles("viewer")
+		published := newArticle(t, "published")
+		published.PullEvents()
+		updatedAt := published.UpdatedAt
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(published, nil)
+
+		output, err := uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Title: ptr("タイトル"), Body: ptr("本文")})
+
+		require.NoError(t, err)
+		assert.Equal(t, updatedAt, output.UpdatedAt)
+		assert.Empty(t, published.Events())
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("閲覧者は下書きを参照できない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		mockRepo.On("FindByID", mock.Anything, uint64(1)).Return(newArticle(t, "draft"), nil)
+
+		_, err := uc.FindArticleByID(withRoles("viewer"), 1)
+		assert.ErrorIs(t, err, apperr.ErrForbidden)
+
+		_, err = uc.FindArticleByID(withRoles("reviewer"), 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("限定公開の記事は閲覧者も直接参照できるが一覧には含まれない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		ctx := withRoles("viewer")
+		unlisted := newArticle(t, "unlisted")
+		mockRepo.On("FindByID", ctx, uint64(1)).Return(unlisted, nil)
+		mockRepo.On("FindBySlug", ctx, unlisted.Slug.String()).Return(unlisted, nil)
+		mockRepo.On("FindAll", ctx).Return([]*entity.Article{unlisted}, nil)
+
+		_, err := uc.FindArticleByID(ctx, 1)
+		require.NoError(t, err)
+		_, err = uc.FindArticleBySlug(ctx, unlisted.Slug.String())
+		require.NoError(t, err)
+
+		output, err := uc.FindAllArticles(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, output.Articles)
+	})
+
+	t.Run("レビュアーは公開できるが本文の変更と削除はできない", func(t *testing.T) {
+		ctx := withRoles("reviewer")
+
+		publishRepo := new(MockArticleRepository)
+		draft := newArticle(t, "draft")
+		publishRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(draft, nil)
+		publishRepo.On("Update", ctx, draft).Return(nil)
+		uc := article.NewArticleUsecase(publishRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		_, err := uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Title: ptr("タイトル"), Body: ptr("本文"), Status: ptr("published")})
+		require.NoError(t, err)
+		assert.Equal(t, vo.ArticleStatusPublished, draft.Status)
+
+		editRepo := new(MockArticleRepository)
+		editRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(newArticle(t, "draft"), nil)
+		uc = article.NewArticleUsecase(editRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		_, err = uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Title: ptr("別のタイトル"), Body: ptr("本文")})
+		assert.ErrorIs(t, err, apperr.ErrForbidden)
+		editRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+
+		deleteRepo := new(MockArticleRepository)
+		deleteRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(newArticle(t, "published"), nil)
+		uc = article.NewArticleUsecase(deleteRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		err = uc.DeleteArticle(ctx, 1)
+		assert.ErrorIs(t, err, apperr.ErrForbidden)
+		deleteRepo.AssertNotCalled(t, "SoftDelete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("削除済みを含む一覧は管理者だけ", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		input := article.FindByCriteriaInput{Page: 1, Limit: 10, IncludeDeleted: true}
+
+		_, err := uc.FindByCriteria(withRoles("editor"), input)
+		var forbidden *auth.ForbiddenError
+		require.ErrorAs(t, err, &forbidden)
+		assert.Equal(t, auth.ActionIncludeDeletedArticle, forbidden.Action)
+
+		ctx := withRoles("admin")
+		mockRepo.On("FindByCriteria", ctx, mock.MatchedBy(func(c repository.ArticleQueryCriteria) bool {
+			return c.IncludeDeleted && c.Status == nil
+		})).Return([]*entity.Article{}, 0, nil)
+		_, err = uc.FindByCriteria(ctx, input)
+		assert.NoError(t, err)
+	})
+}
+
+// stubReviewPolicy はワークスペースの設定の代わりに固定の値を返す
+type stubReviewPolicy bool
+
+func (p stubReviewPolicy) ReviewRequired(context.Context) (bool, error) {
+	return bool(p), nil
+}
+
+func TestArticleUsecase_ReviewPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("レビューが必要なワークスペースでは公開状態で作成できない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithReviewPolicy(stubReviewPolicy(true)))
+
+		_, err := uc.CreateArticle(ctx, article.CreateArticleInput{Title: "タイトル", Status: "published"})
+
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("承認されていない下書きは公開できない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithReviewPolicy(stubReviewPolicy(true)))
+
+		draft, err := entity.NewArticle("タイトル", "draft")
+		require.NoError(t, err)
+		draft.ID = 1
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(draft, nil)
+
+		_, err = uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Status: ptr("published")})
+
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("レビュー中の記事の本文は変更できない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		now := time.Now()
+		inReview, err := entity.ReconstituteArticle(1, "タイトル", "slug", "in_review", ptr("本文"), nil, nil, now, now, nil)
+		require.NoError(t, err)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(inReview, nil)
+
+		_, err = uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Body: ptr("別の本文")})
+
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+// recordingAnchorer は本文の変更で呼び出された記事と本文を記録する
+type recordingAnchorer struct {
+	calls []string
+}
+
+func (a *recordingAnchorer) ReanchorComments(_ context.Context, articleID uint64, body string) error {
+	a.calls = append(a.calls, fmt.Sprintf("%d:%s", articleID, body))
+	return nil
+}
+
+func TestArticleUsecase_ReanchorComments(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	mockRepo := new(MockArticleRepository)
+	anchorer := &recordingAnchorer{}
+	uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithCommentAnchorer(anchorer))
+
+	found, err := entity.ReconstituteArticle(1, "タイトル", "slug", "draft", ptr("本文"), nil, nil, now, now, nil)
+	require.NoError(t, err)
+	mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(found, nil)
+	mockRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+	_, err = uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Title: ptr("別のタイトル"), Body: ptr("本文")})
+	require.NoError(t, err)
+	assert.Empty(t, anchorer.calls, "本文が変わらなければ呼び出さない")
+
+	_, err = uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Body: ptr("前書き。本文")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1:前書き。本文"}, anchorer.calls)
+}
+
+// recordingAuditLog は記録された監査ログを保持する
+type recordingAuditLog struct {
+	entries []*entity.AuditEntry
+	err     error
+}
+
+func (l *recordingAuditLog) Record(_ context.Context, entry *entity.AuditEntry) error {
+	if l.err != nil {
+		return l.err
+	}
+	l.entries = append(l.entries, entry)
+	return nil
+}
+
+func TestArticleUsecase_AuditLog(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	newFixture := func(t *testing.T) (*MockArticleRepository, *recordingAuditLog, *article.ArticleUsecase) {
+		t.Helper()
+		mockRepo := new(MockArticleRepository)
+		auditLog := &recordingAuditLog{}
+		found, err := entity.ReconstituteArticle(1, "タイトル", "slug", "draft", ptr("本文"), nil, nil, now, now, nil)
+		require.NoError(t, err)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(found, nil)
+		mockRepo.On("Update", ctx, mock.Anything).Return(nil)
+		mockRepo.On("SoftDelete", ctx, uint64(1)).Return(nil)
+		return mockRepo, auditLog, article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuditLog(auditLog))
+	}
+
+	t.Run("更新は変更されたフィールドを記録する", func(t *testing.T) {
+		_, auditLog, uc := newFixture(t)
+
+		_, err := uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Title: ptr("別のタイトル"), Body: ptr("本文")})
+		require.NoError(t, err)
+
+		require.Len(t, auditLog.entries, 1)
+		entry := auditLog.entries[0]
+		assert.Equal(t, entity.AuditActionUpdate, entry.Action)
+		assert.Equal(t, uint64(1), entry.ArticleID)
+		assert.Equal(t, map[string]entity.FieldChange{"title": {Before: "タイトル", After: "別のタイトル"}}, entry.Changes)
+	})
+
+	t.Run("公開されていないステータスからの公開はpublishとして記録する", func(t *testing.T) {
+		_, auditLog, uc := newFixture(t)
+
+		_, err := uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Body: ptr("本文"), Status: ptr("published")})
+		require.NoError(t, err)
+
+		require.Len(t, auditLog.entries, 1)
+		assert.Equal(t, entity.AuditActionPublish, auditLog.entries[0].Action)
+		assert.Equal(t, entity.FieldChange{Before: "draft", After: "published"}, auditLog.entries[0].Changes["status"])
+	})
+
+	t.Run("変更のない更新は記録しない", func(t *testing.T) {
+		_, auditLog, uc := newFixture(t)
+
+		_, err := uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Body: ptr("本文")})
+		require.NoError(t, err)
+
+		assert.Empty(t, auditLog.entries)
+	})
+
+	t.Run("削除は削除日時を記録する", func(t *testing.T) {
+		_, auditLog, uc := newFixture(t)
+
+		require.NoError(t, uc.DeleteArticle(ctx, 1))
+
+		require.Len(t, auditLog.entries, 1)
+		entry := auditLog.entries[0]
+		assert.Equal(t, entity.AuditActionDelete, entry.Action)
+		require.Contains(t, entry.Changes, "deleted_at")
+		assert.Nil(t, entry.Changes["deleted_at"].Before)
+		assert.Len(t, entry.Changes, 1)
+	})
+
+	t.Run("記録に失敗した変更はエラーにする", func(t *testing.T) {
+		_, auditLog, uc := newFixture(t)
+		auditLog.err = errors.New("audit log unavailable")
+
+		_, err := uc.UpdateArticle(ctx, 1, article.UpdateArticleInput{Title: ptr("別のタイトル"), Body: ptr("本文")})
+		assert.ErrorContains(t, err, "audit log unavailable")
+	})
+}
+
+func TestArticleUsecase_Trash(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	deletedAt := now.Add(-3 * 24 * time.Hour)
+
+	newDeleted := func(t *testing.T, link *string) *entity.Article {
+		t.Helper()
+		var provider *string
+		if link != nil {
+			provider = ptr("qiita")
+		}
+		a, err := entity.ReconstituteArticle(1, "タイトル", "slug", "draft", ptr("本文"), provider, link, now, now, &deletedAt)
+		require.NoError(t, err)
+		return a
+	}
+
+	t.Run("復元すると削除日時が消え、監査ログに記録する", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		auditLog := &recordingAuditLog{}
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuditLog(auditLog))
+		mockRepo.On("FindDeletedByIDForUpdate", ctx, uint64(1)).Return(newDeleted(t, nil), nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(a *entity.Article) bool { return a.DeletedAt == nil })).Return(nil)
+
+		output, err := uc.RestoreArticle(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), output.ID)
+		require.Len(t, auditLog.entries, 1)
+		assert.Equal(t, entity.AuditActionRestore, auditLog.entries[0].Action)
+		assert.Nil(t, auditLog.entries[0].Changes["deleted_at"].After)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("ゴミ箱にない記事は見つからない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+		mockRepo.On("FindDeletedByIDForUpdate", ctx, uint64(1)).Return(nil, fmt.Errorf("deleted article 1: %w", repository.ErrArticleNotFound))
+
+		_, err := uc.RestoreArticle(ctx, 1)
+
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("削除中に同じリンクの記事が作られていれば復元できない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+		mockRepo.On("FindDeletedByIDForUpdate", ctx, uint64(1)).Return(newDeleted(t, ptr("https://example.com/items/a")), nil)
+		mockRepo.On("FindIDByNormalizedLink", ctx, "https://example.com/items/a").Return(uint64(9), nil)
+
+		_, err := uc.RestoreArticle(ctx, 1)
+
+		var dup *article.DuplicateLinkError
+		require.ErrorAs(t, err, &dup)
+		assert.Equal(t, uint64(9), dup.ArticleID)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("管理者以外は復元できない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		editorCtx := auth.WithPrincipal(ctx, &auth.Principal{Name: "bob", Roles: []string{"editor"}, Scopes: []vo.Scope{vo.ScopeArticlesWrite}})
+		mockRepo.On("FindDeletedByIDForUpdate", editorCtx, uint64(1)).Return(newDeleted(t, nil), nil)
+
+		_, err := uc.RestoreArticle(editorCtx, 1)
+
+		var forbidden *auth.ForbiddenError
+		require.ErrorAs(t, err, &forbidden)
+		assert.Equal(t, auth.ActionRestoreArticle, forbidden.Action)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("一覧は削除済みの記事だけを新しく削除された順に返し、完全に削除される日時を含む", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithTrashRetention(30*24*time.Hour))
+		mockRepo.On("FindByCriteria", ctx, mock.MatchedBy(func(c repository.ArticleQueryCriteria) bool {
+			return c.DeletedOnly && *c.SortBy == "deleted_at" && *c.SortOrder == "desc" && c.Page == 1 && c.Limit == 20
+		})).Return([]*entity.Article{newDeleted(t, nil)}, 1, nil)
+
+		output, err := uc.ListTrash(ctx, article.ListTrashInput{})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), output.Total)
+		require.Len(t, output.Articles, 1)
+		assert.Equal(t, deletedAt, output.Articles[0].DeletedAt)
+		require.NotNil(t, output.Articles[0].PurgeAt)
+		assert.Equal(t, deletedAt.Add(30*24*time.Hour), *output.Articles[0].PurgeAt)
+	})
+
+	t.Run("保持期間がなければ完全に削除される日時を含まない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+		mockRepo.On("FindByCriteria", ctx, mock.Anything).Return([]*entity.Article{newDeleted(t, nil)}, 1, nil)
+
+		output, err := uc.ListTrash(ctx, article.ListTrashInput{})
+
+		require.NoError(t, err)
+		require.Len(t, output.Articles, 1)
+		assert.Nil(t, output.Articles[0].PurgeAt)
+	})
+
+	t.Run("上限を超える件数はErrInvalidInput", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		_, err := uc.ListTrash(ctx, article.ListTrashInput{Limit: 101})
+
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindByCriteria", mock.Anything, mock.Anything)
+	})
+}
+
+func TestArticleUsecase_BulkApply(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	newArticle := func(t *testing.T, id uint64, status string) *entity.Article {
+		t.Helper()
+		a, err := entity.ReconstituteArticle(id, "タイトル", fmt.Sprintf("slug-%d", id), status, ptr("本文"), nil, nil, now, now, nil)
+		require.NoError(t, err)
+		return a
+	}
+	withID := func(id uint64) any {
+		return mock.MatchedBy(func(a *entity.Article) bool { return a.ID == id })
+	}
+
+	t.Run("IDの重複を除いて昇順にチャンクごとのトランザクションと記事ごとのセーブポイントで公開し、適用できない記事は失敗として返す", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		txManager := &recordingTxManager{}
+		auditLog := &recordingAuditLog{}
+		uc := article.NewArticleUsecase(mockRepo, txManager, article.WithBulkChunkSize(2), article.WithAuditLog(auditLog))
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(newArticle(t, 1, "draft"), nil)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(2)).Return(newArticle(t, 2, "published"), nil)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(3)).Return(newArticle(t, 3, "draft"), nil)
+		mockRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+		output, err := uc.BulkApply(ctx, article.BulkInput{Operation: article.BulkOperationPublish, IDs: []uint64{3, 1, 2, 1}})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, txManager.calls)
+		assert.Equal(t, 3, txManager.savepoints, "記事ごとにセーブポイントで囲む")
+		assert.Equal(t, 3, output.Total)
+		assert.Equal(t, 2, output.Succeeded)
+		assert.Equal(t, 1, output.Failed)
+		require.Len(t, output.Results, 3)
+		assert.Equal(t, article.BulkItemResult{ID: 1, OK: true}, output.Results[0])
+		assert.Equal(t, uint64(2), output.Results[1].ID)
+		assert.False(t, output.Results[1].OK)
+		assert.Contains(t, output.Results[1].Error, "already published")
+		assert.Equal(t, article.BulkItemResult{ID: 3, OK: true}, output.Results[2])
+		mockRepo.AssertNumberOfCalls(t, "Update", 2)
+		require.Len(t, auditLog.entries, 2)
+		assert.Equal(t, entity.AuditActionPublish, auditLog.entries[0].Action)
+	})
+
+	t.Run("公開済みの記事のプロバイダは変更できない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(newArticle(t, 1, "draft"), nil)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(2)).Return(newArticle(t, 2, "published"), nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(a *entity.Article) bool {
+			return a.ID == 1 && a.ProviderType.String() == "zenn"
+		})).Return(nil)
+
+		output, err := uc.BulkApply(ctx, article.BulkInput{Operation: article.BulkOperationChangeProvider, IDs: []uint64{1, 2}, ProviderType: ptr("zenn")})
+
+		require.NoError(t, err)
+		assert.True(t, output.Results[0].OK)
+		assert.False(t, output.Results[1].OK)
+		assert.Contains(t, output.Results[1].Error, "cannot change provider for a published article")
+		mockRepo.AssertNumberOfCalls(t, "Update", 1)
+	})
+
+	t.Run("プロバイダの変更はアウトボックスに更新イベントを書き込む", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+		draft := newArticle(t, 1, "draft")
+		draft.PullEvents()
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(draft, nil)
+		var events []entity.ArticleEvent
+		mockRepo.On("Update", ctx, mock.AnythingOfType("*entity.Article")).
+			Run(func(args mock.Arguments) { events = args.Get(1).(*entity.Article).Events() }).
+			Return(nil)
+
+		output, err := uc.BulkApply(ctx, article.BulkInput{Operation: article.BulkOperationChangeProvider, IDs: []uint64{1}, ProviderType: ptr("zenn")})
+
+		require.NoError(t, err)
+		assert.True(t, output.Results[0].OK)
+		require.Len(t, events, 1)
+		assert.Equal(t, entity.ArticleEventUpdated, events[0].Type)
+	})
+
+	t.Run("ドライランは変更せずに結果だけを返す", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		auditLog := &recordingAuditLog{}
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuditLog(auditLog))
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(newArticle(t, 1, "published"), nil)
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(2)).Return(nil, fmt.Errorf("article 2: %w", repository.ErrArticleNotFound))
+
+		output, err := uc.BulkApply(ctx, article.BulkInput{Operation: article.BulkOperationDelete, IDs: []uint64{1, 2}, DryRun: true})
+
+		require.NoError(t, err)
+		assert.True(t, output.DryRun)
+		assert.Equal(t, 1, output.Succeeded)
+		assert.Equal(t, 1, output.Failed)
+		mockRepo.AssertNotCalled(t, "SoftDelete", mock.Anything, mock.Anything)
+		assert.Empty(t, auditLog.entries)
+	})
+
+	t.Run("タグの追加は既存のタグを残す", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+		existing := newArticle(t, 1, "draft")
+		require.NoError(t, existing.AddTags("go"))
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(1)).Return(existing, nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(a *entity.Article) bool {
+			return assert.ObjectsAreEqual([]string{"go", "import"}, a.TagStrings())
+		})).Return(nil)
+
+		output, err := uc.BulkApply(ctx, article.BulkInput{Operation: article.BulkOperationAddTags, IDs: []uint64{1}, Tags: []string{"Import", "go"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, output.Succeeded)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("絞り込みの条件に一致する削除済みの記事を復元する", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+		deletedAt := now.Add(-time.Hour)
+		deleted := newArticle(t, 5, "draft")
+		deleted.DeletedAt = &deletedAt
+		mockRepo.On("FindByCriteria", ctx, mock.MatchedBy(func(c repository.ArticleQueryCriteria) bool {
+			return c.DeletedOnly && !c.IncludeDeleted && c.Page == 1 && c.Limit == 1000 && *c.Tag == "import"
+		})).Return([]*entity.Article{deleted}, 1, nil)
+		mockRepo.On("FindDeletedByIDForUpdate", ctx, uint64(5)).Return(deleted, nil)
+		mockRepo.On("Update", ctx, withID(5)).Return(nil)
+
+		output, err := uc.BulkApply(ctx, article.BulkInput{Operation: article.BulkOperationRestore, Filter: &article.FindByCriteriaInput{Tag: ptr("import")}})
+
+		require.NoError(t, err)
+		assert.Equal(t, []article.BulkItemResult{{ID: 5, OK: true}}, output.Results)
+		assert.Nil(t, deleted.DeletedAt)
+	})
+
+	t.Run("上限を超える記事に一致する絞り込みはErrInvalidInput", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+		mockRepo.On("FindByCriteria", ctx, mock.Anything).Return([]*entity.Article{}, 1001, nil)
+
+		_, err := uc.BulkApply(ctx, article.BulkInput{Operation: article.BulkOperationUnpublish, Filter: &article.FindByCriteriaInput{}})
+
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindByIDForUpdate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("予期しないエラーはチャンクをロールバックし、チャンクの記事を失敗として返す", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithBulkChunkSize(3))
+		for _, id := range []uint64{1, 2, 3, 4} {
+			mockRepo.On("FindByIDForUpdate", ctx, id).Return(newArticle(t, id, "published"), nil)
+		}
+		mockRepo.On("FindByIDForUpdate", ctx, uint64(5)).Return(newArticle(t, 5, "draft"), nil)
+		mockRepo.On("Update", ctx, withID(1)).Return(nil)
+		mockRepo.On("Update", ctx, withID(2)).Return(errors.New("connection reset"))
+		mockRepo.On("Update", ctx, withID(4)).Return(nil)
+
+		output, err := uc.BulkApply(ctx, article.BulkInput{Operation: article.BulkOperationUnpublish, IDs: []uint64{1, 2, 3, 4, 5}})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, output.Succeeded)
+		assert.Equal(t, 4, output.Failed)
+		assert.Contains(t, output.Results[0].Error, "rolled back")
+		assert.Contains(t, output.Results[1].Error, "rolled back")
+		assert.Contains(t, output.Results[2].Error, "rolled back")
+		assert.True(t, output.Results[3].OK)
+		assert.Contains(t, output.Results[4].Error, "not public")
+	})
+
+	t.Run("所有していない記事は権限がないため失敗として返す", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{}, article.WithAuthorizer(auth.ArticlePolicy))
+		authorCtx := auth.WithPrincipal(ctx, &auth.Principal{Name: "alice", Roles: []string{"author"}, AuthorID: 7, Scopes: []vo.Scope{vo.ScopeArticlesWrite}})
+		owner, other := uint64(7), uint64(8)
+		own := newArticle(t, 1, "draft")
+		own.AuthorID = &owner
+		others := newArticle(t, 2, "draft")
+		others.AuthorID = &other
+		mockRepo.On("FindByIDForUpdate", authorCtx, uint64(1)).Return(own, nil)
+		mockRepo.On("FindByIDForUpdate", authorCtx, uint64(2)).Return(others, nil)
+		mockRepo.On("SoftDelete", authorCtx, uint64(1)).Return(nil)
+
+		output, err := uc.BulkApply(authorCtx, article.BulkInput{Operation: article.BulkOperationDelete, IDs: []uint64{1, 2}})
+
+		require.NoError(t, err)
+		assert.True(t, output.Results[0].OK)
+		assert.Contains(t, output.Results[1].Error, "forbidden")
+		mockRepo.AssertNotCalled(t, "SoftDelete", authorCtx, uint64(2))
+	})
+
+	t.Run("不正な入力はErrInvalidInput", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			input article.BulkInput
+		}{
+			{name: "不明な操作", input: article.BulkInput{Operation: "archive", IDs: []uint64{1}}},
+			{name: "対象の指定なし", input: article.BulkInput{Operation: article.BulkOperationPublish}},
+			{name: "IDと絞り込みの両方", input: article.BulkInput{Operation: article.BulkOperationPublish, IDs: []uint64{1}, Filter: &article.FindByCriteriaInput{}}},
+			{name: "不正なプロバイダ", input: article.BulkInput{Operation: article.BulkOperationChangeProvider, IDs: []uint64{1}, ProviderType: ptr("medium")}},
+			{name: "タグの指定なし", input: article.BulkInput{Operation: article.BulkOperationAddTags, IDs: []uint64{1}}},
+			{name: "不正なタグ", input: article.BulkInput{Operation: article.BulkOperationAddTags, IDs: []uint64{1}, Tags: []string{"a b"}}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockRepo := new(MockArticleRepository)
+				uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+				_, err := uc.BulkApply(ctx, tt.input)
+
+				assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+				mockRepo.AssertNotCalled(t, "FindByIDForUpdate", mock.Anything, mock.Anything)
+			})
+		}
+	})
+}
+
+func TestArticleUsecase_RecomputeMetadata(t *testing.T) {
+	newArticle := func(t *testing.T, id, workspaceID uint64) *entity.Article {
+		t.Helper()
+		body := "本文"
+		a, err := entity.NewArticle("タイトル", "draft", entity.WithBody(&body))
+		require.NoError(t, err)
+		a.ID, a.WorkspaceID = id, workspaceID
+		return a
+	}
+
+	t.Run("全ての記事を記事のワークスペースで保存し、変わった件数を返す", func(t *testing.T) {
+		ctx := repository.WithAllWorkspaces(context.Background())
+		first, second := newArticle(t, 1, 10), newArticle(t, 2, 20)
+		mockRepo := new(MockArticleRepository)
+		mockRepo.On("FindAfterID", ctx, uint64(0), 100).Return([]*entity.Article{first, second}, nil)
+		mockRepo.On("UpdateMetadata", mock.MatchedBy(func(ctx context.Context) bool {
+			id, all, ok := repository.WorkspaceFrom(ctx)
+			return ok && !all && id == 10
+		}), first).Return(true, nil)
+		mockRepo.On("UpdateMetadata", mock.MatchedBy(func(ctx context.Context) bool {
+			id, all, ok := repository.WorkspaceFrom(ctx)
+			return ok && !all && id == 20
+		}), second).Return(false, nil)
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		output, err := uc.RecomputeMetadata(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, &article.RecomputeMetadataOutput{Scanned: 2, Updated: 1}, output)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("保存に失敗した場合はエラー", func(t *testing.T) {
+		ctx := repository.WithAllWorkspaces(context.Background())
+		mockRepo := new(MockArticleRepository)
+		mockRepo.On("FindAfterID", ctx, uint64(0), 100).Return([]*entity.Article{newArticle(t, 1, 10)}, nil)
+		mockRepo.On("UpdateMetadata", mock.Anything, mock.Anything).Return(false, errors.New("db error"))
+		uc := article.NewArticleUsecase(mockRepo, passthroughTxManager{})
+
+		_, err := uc.RecomputeMetadata(ctx)
+
+		assert.Error(t, err)
+	})
+}
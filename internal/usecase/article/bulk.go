@@ -0,0 +1,248 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+// bulkActions maps each bulk operation to the action authorized per article.
+var bulkActions = map[BulkOperation]auth.Action{
+	BulkOperationPublish:        auth.ActionPublishArticle,
+	BulkOperationUnpublish:      auth.ActionPublishArticle,
+	BulkOperationDelete:         auth.ActionDeleteArticle,
+	BulkOperationRestore:        auth.ActionRestoreArticle,
+	BulkOperationChangeProvider: auth.ActionUpdateArticle,
+	BulkOperationAddTags:        auth.ActionUpdateArticle,
+}
+
+// BulkApply applies an operation to the articles given by IDs or a filter.
+// The articles are changed in chunks, each within a transaction, and each article
+// within a savepoint. An article the operation cannot be applied to, e.g. a
+// published one for change_provider or one whose link conflicts, is reported as
+// failed and its changes are rolled back to the savepoint without affecting the
+// others. An unexpected error rolls back its chunk, and the articles of the
+// chunk are reported as failed.
+func (uc *ArticleUsecase) BulkApply(ctx context.Context, input BulkInput) (*BulkOutput, error) {
+	if err := uc.authorize(ctx, auth.ActionReadArticle, nil); err != nil {
+		return nil, err
+	}
+	if err := validateBulkInput(input); err != nil {
+		return nil, err
+	}
+	ids, err := uc.bulkTargets(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	reviewRequired, err := uc.reviewRequired(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &BulkOutput{
+		Operation: input.Operation,
+		DryRun:    input.DryRun,
+		Total:     len(ids),
+		Results:   make([]BulkItemResult, 0, len(ids)),
+	}
+	for chunk := range slices.Chunk(ids, uc.bulkChunkSize) {
+		output.Results = append(output.Results, uc.applyBulkChunk(ctx, input, chunk, reviewRequired)...)
+	}
+	for _, r := range output.Results {
+		if r.OK {
+			output.Succeeded++
+		} else {
+			output.Failed++
+		}
+	}
+	return output, nil
+}
+
+func validateBulkInput(input BulkInput) error {
+	if _, ok := bulkActions[input.Operation]; !ok {
+		return fmt.Errorf("%w: invalid operation: %q", apperr.ErrInvalidInput, input.Operation)
+	}
+	if (len(input.IDs) == 0) == (input.Filter == nil) {
+		return fmt.Errorf("%w: specify either ids or filter", apperr.ErrInvalidInput)
+	}
+	if len(input.IDs) > maxBulkTargets {
+		return fmt.Errorf("%w: ids must not exceed %d", apperr.ErrInvalidInput, maxBulkTargets)
+	}
+	switch input.Operation {
+	case BulkOperationChangeProvider:
+		if _, err := vo.NewProviderType(input.ProviderType); err != nil {
+			return fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+		}
+	case BulkOperationAddTags:
+		if len(input.Tags) == 0 {
+			return fmt.Errorf("%w: tags are required for %s", apperr.ErrInvalidInput, input.Operation)
+		}
+		for _, t := range input.Tags {
+			if _, err := vo.NewTag(t); err != nil {
+				return fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+			}
+		}
+	}
+	return nil
+}
+
+// bulkTargets returns the IDs of the target articles in ascending order.
+// A filter may match at most maxBulkTargets articles, so that the caller
+// narrows it down rather than changing more articles than intended.
+func (uc *ArticleUsecase) bulkTargets(ctx context.Context, input BulkInput) ([]uint64, error) {
+	if input.Filter == nil {
+		ids := slices.Clone(input.IDs)
+		slices.Sort(ids)
+		return slices.Compact(ids), nil
+	}
+	criteria, err := uc.queryCriteria(ctx, *input.Filter)
+	if err != nil {
+		return nil, err
+	}
+	criteria.Page, criteria.Limit = 1, maxBulkTargets
+	if input.Operation == BulkOperationRestore {
+		criteria.IncludeDeleted, criteria.DeletedOnly = false, true
+	}
+	articles, total, err := uc.repo.FindByCriteria(ctx, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find articles by criteria: %w", err)
+	}
+	if total > maxBulkTargets {
+		return nil, fmt.Errorf("%w: filter matches %d articles, more than %d", apperr.ErrInvalidInput, total, maxBulkTargets)
+	}
+	ids := make([]uint64, 0, len(articles))
+	for _, a := range articles {
+		ids = append(ids, a.ID)
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// applyBulkChunk applies the operation to the articles of a chunk within a transaction.
+// Each article is applied within a savepoint, so that a failed statement of one
+// article, e.g. a unique violation, neither aborts the transaction nor leaves
+// part of the changes of the article behind.
+func (uc *ArticleUsecase) applyBulkChunk(ctx context.Context, input BulkInput, ids []uint64, reviewRequired bool) []BulkItemResult {
+	var results []BulkItemResult
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		results = make([]BulkItemResult, 0, len(ids))
+		for _, id := range ids {
+			err := uc.txManager.WithinSavepoint(ctx, func(ctx context.Context) error {
+				return uc.applyBulkItem(ctx, input, id, reviewRequired)
+			})
+			if err != nil && !isBulkItemError(err) {
+				return fmt.Errorf("article %d: %w", id, err)
+			}
+			results = append(results, newBulkItemResult(id, err))
+		}
+		return nil
+	})
+	if err == nil {
+		return results
+	}
+	// Articles that failed on their own keep their error; the changes of the others were rolled back.
+	log.Printf("bulk %s: rolled back the chunk of articles %d to %d: %v", input.Operation, ids[0], ids[len(ids)-1], err)
+	rolledBack := make([]BulkItemResult, 0, len(ids))
+	for i, id := range ids {
+		if i < len(results) && !results[i].OK {
+			rolledBack = append(rolledBack, results[i])
+			continue
+		}
+		rolledBack = append(rolledBack, BulkItemResult{ID: id, Error: "rolled back due to an internal error"})
+	}
+	return rolledBack
+}
+
+// applyBulkItem applies the operation to one article. In a dry run the article
+// is checked in the same way but not saved.
+func (uc *ArticleUsecase) applyBulkItem(ctx context.Context, input BulkInput, id uint64, reviewRequired bool) error {
+	find := uc.repo.FindByIDForUpdate
+	if input.Operation == BulkOperationRestore {
+		find = uc.repo.FindDeletedByIDForUpdate
+	}
+	found, err := find(ctx, id)
+	if err != nil {
+		return err
+	}
+	if reviewRequired {
+		found.RequireReview()
+	}
+	if err := uc.authorize(ctx, bulkActions[input.Operation], resourceOf(found)); err != nil {
+		return err
+	}
+	before := entity.SnapshotArticle(found)
+	previousStatus := found.Status
+	previousLink := found.Link.Normalized()
+
+	switch input.Operation {
+	case BulkOperationPublish:
+		err = found.Publish()
+	case BulkOperationUnpublish:
+		if !found.Status.IsPublic() {
+			err = fmt.Errorf("article is not public")
+		} else {
+			err = found.Draft()
+		}
+	case BulkOperationRestore:
+		err = found.Restore()
+	case BulkOperationChangeProvider:
+		provider, _ := vo.NewProviderType(input.ProviderType)
+		err = found.ChangeProvider(provider)
+	case BulkOperationAddTags:
+		err = found.AddTags(input.Tags...)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", apperr.ErrConflict, err)
+	}
+	if input.Operation == BulkOperationRestore {
+		// The link of a deleted article is not reserved, so its uniqueness is checked again.
+		if err := uc.ensureUniqueLink(ctx, found); err != nil {
+			return err
+		}
+	}
+	if input.DryRun {
+		return nil
+	}
+
+	if input.Operation == BulkOperationDelete {
+		if err := uc.repo.SoftDelete(ctx, found.ID); err != nil {
+			return err
+		}
+		return uc.recordDeletion(ctx, found)
+	}
+	if err := uc.update(ctx, found, previousLink); err != nil {
+		return err
+	}
+	action := entity.AuditActionUpdate
+	switch {
+	case input.Operation == BulkOperationRestore:
+		action = entity.AuditActionRestore
+	case !previousStatus.IsPublic() && found.Status.IsPublic():
+		action = entity.AuditActionPublish
+	}
+	return uc.record(ctx, found, action, before)
+}
+
+// isBulkItemError reports whether err only concerns the article it occurred on,
+// so that the other articles of the chunk can still be changed.
+func isBulkItemError(err error) bool {
+	return errors.Is(err, repository.ErrNotFound) ||
+		errors.Is(err, apperr.ErrInvalidInput) ||
+		errors.Is(err, apperr.ErrConflict) ||
+		errors.Is(err, apperr.ErrForbidden)
+}
+
+func newBulkItemResult(id uint64, err error) BulkItemResult {
+	if err != nil {
+		return BulkItemResult{ID: id, Error: err.Error()}
+	}
+	return BulkItemResult{ID: id, OK: true}
+}
@@ -1,15 +1,31 @@
 package article
 
-import "time"
+import (
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+)
 
 // FindByCriteriaInput is the input for retrieving articles by criteria.
 type FindByCriteriaInput struct {
-	Status       *string `json:"status" validate:"omitempty,oneof=draft published"`
+	Status       *string `json:"status" validate:"omitempty,oneof=draft scheduled published unlisted archived"`
 	ProviderType *string `json:"provider_type" validate:"omitempty"`
-	SortBy       *string `json:"sort_by" validate:"omitempty,oneof=created_at updated_at title"`
-	SortOrder    *string `json:"sort_order" validate:"omitempty,oneof=asc desc"`
-	Page         int     `json:"page" validate:"gte=1"`
-	Limit        int     `json:"limit" validate:"gte=1,lte=100"`
+	Tag          *string `json:"tag" validate:"omitempty"`
+	// PublishedOn matches articles cross-posted to the provider, while ProviderType only matches the canonical one.
+	PublishedOn *string `json:"published_on" validate:"omitempty"`
+	// HasBrokenLinks filters by whether the latest link check found broken links.
+	HasBrokenLinks *bool `json:"has_broken_links" validate:"omitempty"`
+	// MinReadingTime and MaxReadingTime filter by the estimated reading time in minutes.
+	MinReadingTime *int    `json:"min_reading_time" validate:"omitempty,gte=0"`
+	MaxReadingTime *int    `json:"max_reading_time" validate:"omitempty,gte=0"`
+	AuthorID       *uint64 `json:"author_id" validate:"omitempty"`
+	SortBy         *string `json:"sort_by" validate:"omitempty,oneof=created_at updated_at title reading_time char_count"`
+	SortOrder      *string `json:"sort_order" validate:"omitempty,oneof=asc desc"`
+	Page           int     `json:"page" validate:"gte=1"`
+	Limit          int     `json:"limit" validate:"gte=1,lte=100"`
+	// IncludeDeleted also lists soft-deleted articles. It is limited to admins.
+	IncludeDeleted bool `json:"include_deleted"`
 }
 
 // FindByCriteriaOutput is the output for retrieving articles by criteria.
@@ -21,56 +37,259 @@ type FindByCriteriaOutput struct {
 	TotalPages int                     `json:"total_pages"`
 }
 
+// ListTrashInput is the input for listing the soft-deleted articles in the trash,
+// most recently deleted first.
+type ListTrashInput struct {
+	AuthorID *uint64
+	Page     int
+	Limit    int
+}
+
+// TrashedArticleOutput is a soft-deleted article. PurgeAt is when the retention
+// job permanently deletes it, if a retention period is configured.
+type TrashedArticleOutput struct {
+	ID        uint64     `json:"id"`
+	Title     string     `json:"title"`
+	Slug      string     `json:"slug"`
+	Status    string     `json:"status"`
+	AuthorID  *uint64    `json:"author_id"`
+	DeletedAt time.Time  `json:"deleted_at"`
+	PurgeAt   *time.Time `json:"purge_at,omitempty"`
+}
+
+// TrashOutput is a page of the trash.
+type TrashOutput struct {
+	Articles   []TrashedArticleOutput `json:"articles"`
+	Total      int64                  `json:"total"`
+	Page       int                    `json:"page"`
+	Limit      int                    `json:"limit"`
+	TotalPages int                    `json:"total_pages"`
+}
+
 // CreateArticleInput is the input for creating an article.
+// Slug is generated from Title when omitted.
 type CreateArticleInput struct {
-	Title        string  `json:"title"`
-	Body         *string `json:"body,omitempty"`
-	Status       string  `json:"status,omitempty"`
-	ProviderType *string `json:"provider_type,omitempty"`
-	Link         *string `json:"link,omitempty"`
+	Title        string   `json:"title"`
+	Slug         *string  `json:"slug,omitempty"`
+	Body         *string  `json:"body,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	ProviderType *string  `json:"provider_type,omitempty"`
+	Link         *string  `json:"link,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	// AuthorID defaults to the author the caller acts as.
+	AuthorID *uint64 `json:"author_id,omitempty"`
 }
 
 // CreateArticleOutput is the output for creating an article.
 type CreateArticleOutput struct {
-	ID           uint64    `json:"id"`
-	Title        string    `json:"title"`
-	Body         string    `json:"body"`
-	Status       string    `json:"status"`
-	ProviderType string    `json:"provider_type"`
-	Link         string    `json:"link"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           uint64                `json:"id"`
+	Title        string                `json:"title"`
+	Slug         string                `json:"slug"`
+	Body         string                `json:"body"`
+	Status       string                `json:"status"`
+	ProviderType string                `json:"provider_type"`
+	Link         string                `json:"link"`
+	Publications []PublicationOutput   `json:"publications"`
+	Tags         []string              `json:"tags"`
+	Metadata     ArticleMetadataOutput `json:"metadata"`
+	AuthorID     *uint64               `json:"author_id"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
 }
 
 // FindArticleByIDOutput is the output for finding an article by ID.
+// BodyHTML is the sanitized HTML rendered from Body and is only set on the detail view.
+// LinkPreview is the Open Graph data of Link and is omitted until it has been fetched.
 type FindArticleByIDOutput struct {
-	ID           uint64    `json:"id"`
-	Title        string    `json:"title"`
-	Body         string    `json:"body"`
-	Status       string    `json:"status"`
-	ProviderType string    `json:"provider_type"`
-	Link         string    `json:"link"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           uint64                `json:"id"`
+	Title        string                `json:"title"`
+	Slug         string                `json:"slug"`
+	Body         string                `json:"body"`
+	BodyHTML     string                `json:"body_html,omitempty"`
+	Status       string                `json:"status"`
+	ProviderType string                `json:"provider_type"`
+	Link         string                `json:"link"`
+	Publications []PublicationOutput   `json:"publications"`
+	LinkPreview  *LinkPreviewOutput    `json:"link_preview,omitempty"`
+	Tags         []string              `json:"tags"`
+	Metadata     ArticleMetadataOutput `json:"metadata"`
+	AuthorID     *uint64               `json:"author_id"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
 }
 
 // UpdateArticleInput is the input for updating an article.
+// Unlike the other fields, a nil Slug or AuthorID leaves the current value unchanged.
 type UpdateArticleInput struct {
 	Title        *string `json:"title,omitempty"`
+	Slug         *string `json:"slug,omitempty"`
 	Body         *string `json:"body,omitempty"`
 	Status       *string `json:"status,omitempty"`
 	ProviderType *string `json:"provider_type,omitempty"`
 	Link         *string `json:"link,omitempty"`
+	AuthorID     *uint64 `json:"author_id,omitempty"`
 }
 
 // UpdateArticleOutput is the output for updating an article.
 type UpdateArticleOutput struct {
-	ID           uint64    `json:"id"`
-	Title        string    `json:"title"`
-	Body         string    `json:"body"`
-	Status       string    `json:"status"`
-	ProviderType string    `json:"provider_type"`
-	Link         string    `json:"link"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           uint64                `json:"id"`
+	Title        string                `json:"title"`
+	Slug         string                `json:"slug"`
+	Body         string                `json:"body"`
+	Status       string                `json:"status"`
+	ProviderType string                `json:"provider_type"`
+	Link         string                `json:"link"`
+	Publications []PublicationOutput   `json:"publications"`
+	Tags         []string              `json:"tags"`
+	Metadata     ArticleMetadataOutput `json:"metadata"`
+	AuthorID     *uint64               `json:"author_id"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+}
+
+// AddPublicationInput is the input for adding a publication to an article.
+// Either ProviderType or Link is required.
+type AddPublicationInput struct {
+	ProviderType *string    `json:"provider_type,omitempty"`
+	Link         *string    `json:"link,omitempty"`
+	ExternalID   *string    `json:"external_id,omitempty"`
+	PublishedAt  *time.Time `json:"published_at,omitempty"`
+}
+
+// PublicationOutput is a place where an article is published.
+// Exactly one publication of an article is canonical.
+type PublicationOutput struct {
+	ID           uint64     `json:"id"`
+	ProviderType string     `json:"provider_type"`
+	Link         string     `json:"link"`
+	ExternalID   *string    `json:"external_id,omitempty"`
+	PublishedAt  *time.Time `json:"published_at,omitempty"`
+	SyncStatus   string     `json:"sync_status"`
+	Canonical    bool       `json:"canonical"`
+}
+
+func newPublicationOutput(p entity.Publication) PublicationOutput {
+	return PublicationOutput{
+		ID:           p.ID,
+		ProviderType: p.ProviderType.String(),
+		Link:         p.Link.String(),
+		ExternalID:   p.ExternalID,
+		PublishedAt:  p.PublishedAt,
+		SyncStatus:   p.SyncStatus.String(),
+		Canonical:    p.Canonical,
+	}
+}
+
+func newPublicationOutputs(pubs []entity.Publication) []PublicationOutput {
+	outputs := make([]PublicationOutput, 0, len(pubs))
+	for _, p := range pubs {
+		outputs = append(outputs, newPublicationOutput(p))
+	}
+	return outputs
+}
+
+// LinkPreviewOutput is the Open Graph data of the article link used to render a link card.
+type LinkPreviewOutput struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Image       string `json:"image"`
+	SiteName    string `json:"site_name"`
+}
+
+// newLinkPreviewOutput returns nil when nothing has been fetched yet.
+// A preview whose last refresh failed keeps serving the previously fetched data.
+func newLinkPreviewOutput(p *entity.LinkPreview) *LinkPreviewOutput {
+	if p == nil || p.OpenGraph.IsEmpty() {
+		return nil
+	}
+	return &LinkPreviewOutput{
+		Title:       p.OpenGraph.Title,
+		Description: p.OpenGraph.Description,
+		Image:       p.OpenGraph.Image,
+		SiteName:    p.OpenGraph.SiteName,
+	}
+}
+
+// HeadingOutput is a single entry of the table of contents.
+type HeadingOutput struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+}
+
+// ArticleMetadataOutput is the metadata derived from the article body.
+type ArticleMetadataOutput struct {
+	Excerpt            string          `json:"excerpt"`
+	CharCount          int             `json:"char_count"`
+	ReadingTimeMinutes int             `json:"reading_time_minutes"`
+	TOC                []HeadingOutput `json:"toc"`
+	Links              []string        `json:"links"`
+}
+
+func newArticleMetadataOutput(m vo.ArticleMetadata) ArticleMetadataOutput {
+	toc := make([]HeadingOutput, 0, len(m.Headings))
+	for _, h := range m.Headings {
+		toc = append(toc, HeadingOutput{Level: h.Level, Text: h.Text})
+	}
+	return ArticleMetadataOutput{
+		Excerpt:            m.Excerpt,
+		CharCount:          m.CharCount,
+		ReadingTimeMinutes: m.ReadingTimeMinutes,
+		TOC:                toc,
+		Links:              m.Links,
+	}
+}
+
+// BulkOperation is an operation applied to many articles at once by BulkApply.
+type BulkOperation string
+
+const (
+	BulkOperationPublish   BulkOperation = "publish"
+	BulkOperationUnpublish BulkOperation = "unpublish"
+	// BulkOperationDelete moves the articles to the trash.
+	BulkOperationDelete  BulkOperation = "delete"
+	BulkOperationRestore BulkOperation = "restore"
+	// BulkOperationChangeProvider sets the provider of the articles to ProviderType.
+	BulkOperationChangeProvider BulkOperation = "change_provider"
+	// BulkOperationAddTags adds Tags to the articles, keeping their existing tags.
+	BulkOperationAddTags BulkOperation = "add_tags"
+)
+
+// BulkInput is the input for applying an operation to many articles.
+// The targets are given either by IDs or by Filter. Filter selects the articles
+// a FindByCriteria call would list, ignoring its paging, and for restore the
+// deleted articles that match it.
+type BulkInput struct {
+	Operation BulkOperation        `json:"operation"`
+	IDs       []uint64             `json:"ids,omitempty"`
+	Filter    *FindByCriteriaInput `json:"filter,omitempty"`
+	// ProviderType is the new provider for change_provider; nil clears it.
+	ProviderType *string  `json:"provider_type,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	// DryRun checks the operation against every target without changing any.
+	DryRun bool `json:"dry_run"`
+}
+
+// BulkItemResult is the result of the operation on one article.
+// Error explains why the article was not changed.
+type BulkItemResult struct {
+	ID    uint64 `json:"id"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// BulkOutput is the result of a bulk operation, one item per target in ID order.
+type BulkOutput struct {
+	Operation BulkOperation    `json:"operation"`
+	DryRun    bool             `json:"dry_run"`
+	Total     int              `json:"total"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+// RecomputeMetadataOutput reports how many articles were checked and how many
+// had their stored metadata corrected.
+type RecomputeMetadataOutput struct {
+	Scanned int `json:"scanned"`
+	Updated int `json:"updated"`
 }
@@ -0,0 +1,41 @@
+package article
+
+import (
+	"context"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+)
+
+const recomputeBatchSize = 100
+
+// RecomputeMetadata recomputes the metadata stored for filtering and sorting,
+// i.e. the character count and reading time, from the body of every article
+// including soft-deleted ones. It corrects values estimated by a migration or
+// computed by an older version of the rules. Articles are read in batches and
+// each is saved in its own workspace, so ctx may span all workspaces; like
+// purging the trash, it is run from the command line rather than authorized
+// per caller. Neither the update time nor events of the articles change.
+func (uc *ArticleUsecase) RecomputeMetadata(ctx context.Context) (*RecomputeMetadataOutput, error) {
+	output := &RecomputeMetadataOutput{}
+	var afterID uint64
+	for {
+		articles, err := uc.repo.FindAfterID(ctx, afterID, recomputeBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range articles {
+			afterID = a.ID
+			updated, err := uc.repo.UpdateMetadata(repository.WithWorkspace(ctx, a.WorkspaceID), a)
+			if err != nil {
+				return nil, err
+			}
+			output.Scanned++
+			if updated {
+				output.Updated++
+			}
+		}
+		if len(articles) < recomputeBatchSize {
+			return output, nil
+		}
+	}
+}
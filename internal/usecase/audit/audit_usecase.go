@@ -0,0 +1,206 @@
+// Package audit records who changed which article, when and how, and lets
+// admins query and export the record.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+const (
+	// anonymousActor is recorded as the caller when authentication is disabled.
+	anonymousActor = "anonymous"
+	defaultLimit   = 100
+	maxLimit       = 1000
+	// exportBatchSize is the number of entries read at a time while exporting.
+	exportBatchSize = 500
+)
+
+// Authorizer checks whether the caller carried by the context may perform
+// an operation that requires the given scope.
+type Authorizer interface {
+	Authorize(ctx context.Context, required vo.Scope) error
+}
+
+// AuditUsecase records changes of articles in the audit log and reads them back.
+type AuditUsecase struct {
+	repo       repository.AuditLogRepository
+	authorizer Authorizer
+}
+
+// Option configures an AuditUsecase.
+type Option func(*AuditUsecase)
+
+// WithAuthorizer enables authorization: reading the audit log requires articles:admin.
+func WithAuthorizer(a Authorizer) Option {
+	return func(uc *AuditUsecase) {
+		uc.authorizer = a
+	}
+}
+
+// NewAuditUsecase creates a new AuditUsecase.
+func NewAuditUsecase(repo repository.AuditLogRepository, opts ...Option) *AuditUsecase {
+	uc := &AuditUsecase{repo: repo}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// Record appends an entry to the audit log, filling in the caller and the
+// request carried by ctx. It is called by the article use case within the
+// transaction of the change so that the change and its entry commit together.
+func (uc *AuditUsecase) Record(ctx context.Context, entry *entity.AuditEntry) error {
+	entry.Actor = actorOf(ctx)
+	if info, ok := RequestFrom(ctx); ok {
+		entry.RequestID, entry.IP = info.ID, info.IP
+	}
+	return uc.repo.Append(ctx, entry)
+}
+
+// Query returns a page of the entries matching the filter, oldest first.
+func (uc *AuditUsecase) Query(ctx context.Context, input QueryInput) (*EntriesOutput, error) {
+	if err := uc.authorize(ctx); err != nil {
+		return nil, err
+	}
+	criteria, err := criteriaOf(input)
+	if err != nil {
+		return nil, err
+	}
+	if criteria.Limit <= 0 {
+		criteria.Limit = defaultLimit
+	}
+	if criteria.Limit > maxLimit {
+		return nil, fmt.Errorf("%w: limit must not exceed %d", apperr.ErrInvalidInput, maxLimit)
+	}
+	entries, err := uc.repo.Find(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &EntriesOutput{Entries: make([]EntryOutput, 0, len(entries))}
+	for _, e := range entries {
+		output.Entries = append(output.Entries, newEntryOutput(e))
+	}
+	if len(entries) == criteria.Limit {
+		next := entries[len(entries)-1].ID
+		output.NextCursor = &next
+	}
+	return output, nil
+}
+
+// Export writes the entries matching the filter to w as JSON Lines, oldest first.
+// Unlike Query it is not paged: it writes every matching entry, up to Limit if set.
+func (uc *AuditUsecase) Export(ctx context.Context, input QueryInput, w io.Writer) error {
+	if err := uc.authorize(ctx); err != nil {
+		return err
+	}
+	criteria, err := criteriaOf(input)
+	if err != nil {
+		return err
+	}
+	remaining := criteria.Limit
+	enc := json.NewEncoder(w)
+	for {
+		criteria.Limit = exportBatchSize
+		if remaining > 0 && remaining < exportBatchSize {
+			criteria.Limit = remaining
+		}
+		entries, err := uc.repo.Find(ctx, criteria)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := enc.Encode(newEntryOutput(e)); err != nil {
+				return fmt.Errorf("failed to write audit log entry %d: %w", e.ID, err)
+			}
+		}
+		if remaining > 0 {
+			remaining -= len(entries)
+			if remaining == 0 {
+				return nil
+			}
+		}
+		if len(entries) < criteria.Limit {
+			return nil
+		}
+		criteria.AfterID = entries[len(entries)-1].ID
+	}
+}
+
+func (uc *AuditUsecase) authorize(ctx context.Context) error {
+	if uc.authorizer == nil {
+		return nil
+	}
+	return uc.authorizer.Authorize(ctx, vo.ScopeArticlesAdmin)
+}
+
+// criteriaOf validates the filter and converts it into repository criteria.
+func criteriaOf(input QueryInput) (repository.AuditLogQueryCriteria, error) {
+	criteria := repository.AuditLogQueryCriteria{
+		ArticleID: input.ArticleID,
+		Actor:     input.Actor,
+		From:      input.From,
+		To:        input.To,
+		AfterID:   input.After,
+		Limit:     input.Limit,
+	}
+	if input.Action != nil {
+		action := entity.AuditAction(*input.Action)
+		if !action.IsValid() {
+			return criteria, fmt.Errorf("%w: invalid action: %q", apperr.ErrInvalidInput, *input.Action)
+		}
+		criteria.Action = &action
+	}
+	if input.From != nil && input.To != nil && !input.From.Before(*input.To) {
+		return criteria, fmt.Errorf("%w: from must be before to", apperr.ErrInvalidInput)
+	}
+	if input.Limit < 0 {
+		return criteria, fmt.Errorf("%w: limit must not be negative", apperr.ErrInvalidInput)
+	}
+	return criteria, nil
+}
+
+// actorOf returns the caller of ctx as recorded in the audit log.
+func actorOf(ctx context.Context) entity.AuditActor {
+	p, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return entity.AuditActor{Name: anonymousActor}
+	}
+	actor := entity.AuditActor{Name: p.Name, Subject: p.Subject}
+	if p.KeyID != 0 {
+		id := p.KeyID
+		actor.KeyID = &id
+	}
+	if p.AuthorID != 0 {
+		id := p.AuthorID
+		actor.AuthorID = &id
+	}
+	return actor
+}
+
+func newEntryOutput(e *entity.AuditEntry) EntryOutput {
+	return EntryOutput{
+		ID:        e.ID,
+		ArticleID: e.ArticleID,
+		Action:    e.Action.String(),
+		Actor: ActorOutput{
+			Name:     e.Actor.Name,
+			Subject:  e.Actor.Subject,
+			KeyID:    e.Actor.KeyID,
+			AuthorID: e.Actor.AuthorID,
+		},
+		Changes:   e.Changes,
+		RequestID: e.RequestID,
+		IP:        e.IP,
+		CreatedAt: e.CreatedAt,
+	}
+}
@@ -0,0 +1,188 @@
+package audit_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/audit"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+type MockAuditLogRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogRepository) Append(ctx context.Context, entry *entity.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogRepository) Find(ctx context.Context, criteria repository.AuditLogQueryCriteria) ([]*entity.AuditEntry, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AuditEntry), args.Error(1)
+}
+
+func entries(ids ...uint64) []*entity.AuditEntry {
+	result := make([]*entity.AuditEntry, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, &entity.AuditEntry{ID: id, ArticleID: 1, Action: entity.AuditActionUpdate, Actor: entity.AuditActor{Name: "alice"}})
+	}
+	return result
+}
+
+func TestAuditUsecase_Record(t *testing.T) {
+	t.Parallel()
+
+	t.Run("呼び出し元とリクエストを記録する", func(t *testing.T) {
+		t.Parallel()
+		repo := new(MockAuditLogRepository)
+		repo.On("Append", mock.Anything, mock.Anything).Return(nil)
+		uc := audit.NewAuditUsecase(repo)
+
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{KeyID: 3, Name: "ci", AuthorID: 7})
+		ctx = audit.WithRequest(ctx, audit.RequestInfo{ID: "req-1", IP: "192.0.2.1"})
+		entry := entity.NewAuditEntry(1, entity.AuditActionCreate, nil, entity.ArticleSnapshot{"title": "T"})
+		require.NoError(t, uc.Record(ctx, entry))
+
+		assert.Equal(t, "ci", entry.Actor.Name)
+		assert.Equal(t, uint64(3), *entry.Actor.KeyID)
+		assert.Equal(t, uint64(7), *entry.Actor.AuthorID)
+		assert.Equal(t, "req-1", entry.RequestID)
+		assert.Equal(t, "192.0.2.1", entry.IP)
+		repo.AssertCalled(t, "Append", ctx, entry)
+	})
+
+	t.Run("認証が無効な場合は匿名で記録する", func(t *testing.T) {
+		t.Parallel()
+		repo := new(MockAuditLogRepository)
+		repo.On("Append", mock.Anything, mock.Anything).Return(nil)
+		uc := audit.NewAuditUsecase(repo)
+
+		entry := entity.NewAuditEntry(1, entity.AuditActionDelete, nil, entity.ArticleSnapshot{"deleted_at": "2026-01-01T00:00:00Z"})
+		require.NoError(t, uc.Record(context.Background(), entry))
+
+		assert.Equal(t, entity.AuditActor{Name: "anonymous"}, entry.Actor)
+		assert.Empty(t, entry.RequestID)
+	})
+}
+
+func TestAuditUsecase_Query(t *testing.T) {
+	t.Parallel()
+
+	admin := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "admin", Scopes: []vo.Scope{vo.ScopeArticlesAdmin}})
+	writer := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "writer", Scopes: []vo.Scope{vo.ScopeArticlesWrite}})
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		input     audit.QueryInput
+		criteria  repository.AuditLogQueryCriteria
+		found     []*entity.AuditEntry
+		wantIDs   []uint64
+		wantNext  *uint64
+		wantError error
+	}{
+		{
+			name:     "既定の件数で返す",
+			ctx:      admin,
+			criteria: repository.AuditLogQueryCriteria{Limit: 100},
+			found:    entries(1, 2),
+			wantIDs:  []uint64{1, 2},
+		},
+		{
+			name:     "件数に達した場合は次のカーソルを返す",
+			ctx:      admin,
+			input:    audit.QueryInput{Action: ptr("publish"), After: 10, Limit: 2},
+			criteria: repository.AuditLogQueryCriteria{Action: ptr(entity.AuditActionPublish), AfterID: 10, Limit: 2},
+			found:    entries(11, 12),
+			wantIDs:  []uint64{11, 12},
+			wantNext: ptr(uint64(12)),
+		},
+		{
+			name:      "未知の操作は不正な入力",
+			ctx:       admin,
+			input:     audit.QueryInput{Action: ptr("archive")},
+			wantError: apperr.ErrInvalidInput,
+		},
+		{
+			name:      "上限を超える件数は不正な入力",
+			ctx:       admin,
+			input:     audit.QueryInput{Limit: 1001},
+			wantError: apperr.ErrInvalidInput,
+		},
+		{
+			name:      "articles:adminのない呼び出し元は拒否",
+			ctx:       writer,
+			wantError: apperr.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			repo := new(MockAuditLogRepository)
+			repo.On("Find", mock.Anything, tt.criteria).Return(tt.found, nil)
+			uc := audit.NewAuditUsecase(repo, audit.WithAuthorizer(auth.ScopeAuthorizer{}))
+
+			output, err := uc.Query(tt.ctx, tt.input)
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+				repo.AssertNotCalled(t, "Find", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			var ids []uint64
+			for _, e := range output.Entries {
+				ids = append(ids, e.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantNext, output.NextCursor)
+		})
+	}
+}
+
+func TestAuditUsecase_Export(t *testing.T) {
+	t.Parallel()
+
+	// 全件を書き出すまでカーソルを進めて読み込む
+	repo := new(MockAuditLogRepository)
+	first := make([]uint64, 500)
+	for i := range first {
+		first[i] = uint64(i + 1)
+	}
+	repo.On("Find", mock.Anything, repository.AuditLogQueryCriteria{Limit: 500}).Return(entries(first...), nil)
+	repo.On("Find", mock.Anything, repository.AuditLogQueryCriteria{AfterID: 500, Limit: 500}).Return(entries(501), nil)
+	uc := audit.NewAuditUsecase(repo)
+
+	var buf bytes.Buffer
+	require.NoError(t, uc.Export(context.Background(), audit.QueryInput{}, &buf))
+
+	scanner := bufio.NewScanner(&buf)
+	var lines int
+	var last audit.EntryOutput
+	for scanner.Scan() {
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &last))
+		lines++
+	}
+	assert.Equal(t, 501, lines)
+	assert.Equal(t, uint64(501), last.ID)
+	assert.Equal(t, "alice", last.Actor.Name)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
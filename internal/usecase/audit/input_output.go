@@ -0,0 +1,49 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+)
+
+// QueryInput is the filter of the audit log. All fields are optional.
+// From and To bound the time of the entries as [From, To).
+// After is the cursor returned as NextCursor by the previous page.
+type QueryInput struct {
+	ArticleID *uint64
+	Action    *string
+	// Actor matches the name of the caller or the subject of its bearer token.
+	Actor *string
+	From  *time.Time
+	To    *time.Time
+	After uint64
+	Limit int
+}
+
+// ActorOutput is the caller who made a change.
+type ActorOutput struct {
+	Name     string  `json:"name"`
+	Subject  string  `json:"subject,omitempty"`
+	KeyID    *uint64 `json:"key_id,omitempty"`
+	AuthorID *uint64 `json:"author_id,omitempty"`
+}
+
+// EntryOutput is an entry of the audit log. Changes maps each changed field
+// of the article to its values before and after the change.
+type EntryOutput struct {
+	ID        uint64                        `json:"id"`
+	ArticleID uint64                        `json:"article_id"`
+	Action    string                        `json:"action"`
+	Actor     ActorOutput                   `json:"actor"`
+	Changes   map[string]entity.FieldChange `json:"changes"`
+	RequestID string                        `json:"request_id,omitempty"`
+	IP        string                        `json:"ip,omitempty"`
+	CreatedAt time.Time                     `json:"created_at"`
+}
+
+// EntriesOutput is a page of the audit log, oldest first.
+// NextCursor is set when more entries may follow and is passed as After to fetch them.
+type EntriesOutput struct {
+	Entries    []EntryOutput `json:"entries"`
+	NextCursor *uint64       `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,24 @@
+package audit
+
+import "context"
+
+// RequestInfo identifies the request that caused a change, as recorded in the audit log.
+type RequestInfo struct {
+	// ID is the ID of the request, e.g. from the X-Request-ID header.
+	ID string
+	// IP is the IP address of the client.
+	IP string
+}
+
+type requestKey struct{}
+
+// WithRequest returns a copy of ctx that carries info.
+func WithRequest(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestKey{}, info)
+}
+
+// RequestFrom returns the request information carried by ctx, if any.
+func RequestFrom(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestKey{}).(RequestInfo)
+	return info, ok
+}
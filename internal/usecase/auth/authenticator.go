@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+)
+
+// Authenticator identifies the caller from a credential presented with a request.
+type Authenticator interface {
+	Authenticate(ctx context.Context, credential string) (*Principal, error)
+}
+
+// CredentialRouter passes JWTs to Token and any other credential to APIKey.
+// A nil authenticator rejects the credentials routed to it.
+type CredentialRouter struct {
+	APIKey Authenticator
+	Token  Authenticator
+}
+
+// Authenticate implements Authenticator.
+func (r CredentialRouter) Authenticate(ctx context.Context, credential string) (*Principal, error) {
+	next, kind := r.APIKey, "api key"
+	// JWTはドットで区切った3つのセグメントからなり、APIキーはドットを含まない
+	if strings.Count(credential, ".") == 2 {
+		next, kind = r.Token, "bearer token"
+	}
+	if next == nil {
+		return nil, fmt.Errorf("%w: %s authentication is not enabled", apperr.ErrUnauthenticated, kind)
+	}
+	return next.Authenticate(ctx, credential)
+}
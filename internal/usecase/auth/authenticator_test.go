@@ -0,0 +1,36 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+// namedAuthenticator は受け取った資格情報を名前に入れた呼び出し元を返す
+type namedAuthenticator string
+
+func (n namedAuthenticator) Authenticate(_ context.Context, credential string) (*auth.Principal, error) {
+	return &auth.Principal{Name: string(n) + ":" + credential}, nil
+}
+
+func TestCredentialRouter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	router := auth.CredentialRouter{APIKey: namedAuthenticator("apikey"), Token: namedAuthenticator("token")}
+
+	p, err := router.Authenticate(ctx, "mah_0123456789ab_secret")
+	require.NoError(t, err)
+	assert.Equal(t, "apikey:mah_0123456789ab_secret", p.Name)
+
+	p, err = router.Authenticate(ctx, "header.payload.signature")
+	require.NoError(t, err)
+	assert.Equal(t, "token:header.payload.signature", p.Name)
+
+	_, err = auth.CredentialRouter{APIKey: namedAuthenticator("apikey")}.Authenticate(ctx, "header.payload.signature")
+	assert.ErrorIs(t, err, apperr.ErrUnauthenticated)
+}
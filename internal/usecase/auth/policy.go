@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+)
+
+// Role is a set of permissions granted to a caller.
+type Role string
+
+const (
+	// RoleAdmin may do anything, including restoring and listing deleted articles.
+	RoleAdmin Role = "admin"
+	// RoleEditor may change and delete any article.
+	RoleEditor Role = "editor"
+	// RoleReviewer may read any article and publish it, but not edit or delete it.
+	RoleReviewer Role = "reviewer"
+	// RoleAuthor may create articles and change, publish and delete its own.
+	RoleAuthor Role = "author"
+	// RoleViewer may only read published articles.
+	RoleViewer Role = "viewer"
+)
+
+// scopeRoles are the roles of callers that have no identity provider roles,
+// e.g. API keys, by their highest scope.
+var scopeRoles = []struct {
+	scope vo.Scope
+	role  Role
+}{
+	{vo.ScopeArticlesAdmin, RoleAdmin},
+	{vo.ScopeArticlesWrite, RoleAuthor},
+	{vo.ScopeArticlesRead, RoleViewer},
+}
+
+// EffectiveRoles returns the roles the policy evaluates for the principal.
+// Roles granted by the identity provider take precedence; otherwise the
+// role is derived from the highest scope.
+func (p *Principal) EffectiveRoles() []Role {
+	if len(p.Roles) > 0 {
+		roles := make([]Role, 0, len(p.Roles))
+		for _, r := range p.Roles {
+			roles = append(roles, Role(r))
+		}
+		return roles
+	}
+	for _, sr := range scopeRoles {
+		if p.HasScope(sr.scope) {
+			return []Role{sr.role}
+		}
+	}
+	return nil
+}
+
+// Action is an operation on articles that the policy decides on.
+type Action string
+
+const (
+	ActionReadArticle            Action = "article:read"
+	ActionReadUnpublishedArticle Action = "article:read_unpublished"
+	ActionCreateArticle          Action = "article:create"
+	// ActionUpdateArticle covers changes to the content, slug and owner of an article.
+	ActionUpdateArticle Action = "article:update"
+	// ActionPublishArticle covers changes to the status and publications of an article.
+	ActionPublishArticle        Action = "article:publish"
+	ActionDeleteArticle         Action = "article:delete"
+	ActionRestoreArticle        Action = "article:restore"
+	ActionIncludeDeletedArticle Action = "article:include_deleted"
+	// ActionSubmitReview covers submitting an article for review and withdrawing it.
+	ActionSubmitReview Action = "review:submit"
+	// ActionDecideReview covers approving and requesting changes to an article in review.
+	ActionDecideReview Action = "review:decide"
+	// ActionCommentReview covers commenting on reviews and on passages of an article,
+	// replying to comments and resolving their threads.
+	ActionCommentReview Action = "review:comment"
+	// ActionReadComment covers reading the reviews and inline comments of an article.
+	ActionReadComment Action = "comment:read"
+	// ActionEditComment covers editing and deleting a comment. The resource is owned by the commenter.
+	ActionEditComment Action = "comment:edit"
+)
+
+// Resource is the article an action is performed on.
+// A nil resource stands for articles in general, e.g. when listing.
+type Resource struct {
+	// OwnerID is the author who owns the article, or nil if it has none.
+	OwnerID *uint64
+}
+
+// Rule grants an action to Any of the roles on every article and to Own
+// of the roles on the articles owned by the caller.
+type Rule struct {
+	Action Action
+	Any    []Role
+	Own    []Role
+}
+
+// Policy decides actions by a table of rules. Actions without a rule are denied.
+type Policy struct {
+	rules map[Action]Rule
+}
+
+// NewPolicy creates a policy from rules. A later rule for the same action replaces an earlier one.
+func NewPolicy(rules []Rule) *Policy {
+	p := &Policy{rules: make(map[Action]Rule, len(rules))}
+	for _, r := range rules {
+		p.rules[r.Action] = r
+	}
+	return p
+}
+
+// ArticlePolicy is the default policy of the article use cases.
+var ArticlePolicy = NewPolicy([]Rule{
+	{Action: ActionReadArticle, Any: []Role{RoleAdmin, RoleEditor, RoleReviewer, RoleAuthor, RoleViewer}},
+	{Action: ActionReadUnpublishedArticle, Any: []Role{RoleAdmin, RoleEditor, RoleReviewer}, Own: []Role{RoleAuthor}},
+	{Action: ActionCreateArticle, Any: []Role{RoleAdmin, RoleEditor, RoleAuthor}},
+	{Action: ActionUpdateArticle, Any: []Role{RoleAdmin, RoleEditor}, Own: []Role{RoleAuthor}},
+	{Action: ActionPublishArticle, Any: []Role{RoleAdmin, RoleEditor, RoleReviewer}, Own: []Role{RoleAuthor}},
+	{Action: ActionDeleteArticle, Any: []Role{RoleAdmin, RoleEditor}, Own: []Role{RoleAuthor}},
+	{Action: ActionRestoreArticle, Any: []Role{RoleAdmin}},
+	{Action: ActionIncludeDeletedArticle, Any: []Role{RoleAdmin}},
+	{Action: ActionSubmitReview, Any: []Role{RoleAdmin, RoleEditor}, Own: []Role{RoleAuthor}},
+	{Action: ActionDecideReview, Any: []Role{RoleAdmin, RoleEditor, RoleReviewer}},
+	{Action: ActionCommentReview, Any: []Role{RoleAdmin, RoleEditor, RoleReviewer}, Own: []Role{RoleAuthor}},
+	{Action: ActionReadComment, Any: []Role{RoleAdmin, RoleEditor, RoleReviewer}, Own: []Role{RoleAuthor}},
+	{Action: ActionEditComment, Any: []Role{RoleAdmin, RoleEditor}, Own: []Role{RoleReviewer, RoleAuthor}},
+})
+
+// ForbiddenError is returned when the policy denies an action.
+// It wraps apperr.ErrForbidden.
+type ForbiddenError struct {
+	Principal string
+	Action    Action
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("%v: %q may not perform %s", apperr.ErrForbidden, e.Principal, e.Action)
+}
+
+func (e *ForbiddenError) Unwrap() error {
+	return apperr.ErrForbidden
+}
+
+// Allows reports whether the principal may perform the action on the resource.
+func (p *Policy) Allows(principal *Principal, action Action, resource *Resource) bool {
+	rule, ok := p.rules[action]
+	if !ok {
+		return false
+	}
+	roles := principal.EffectiveRoles()
+	for _, r := range roles {
+		if slices.Contains(rule.Any, r) {
+			return true
+		}
+	}
+	if resource == nil || resource.OwnerID == nil || principal.AuthorID == 0 || *resource.OwnerID != principal.AuthorID {
+		return false
+	}
+	for _, r := range roles {
+		if slices.Contains(rule.Own, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize returns ErrUnauthenticated if ctx carries no principal and
+// a *ForbiddenError if the policy denies the action.
+func (p *Policy) Authorize(ctx context.Context, action Action, resource *Resource) error {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok {
+		return fmt.Errorf("%w: %s requires a caller", apperr.ErrUnauthenticated, action)
+	}
+	if !p.Allows(principal, action, resource) {
+		return &ForbiddenError{Principal: principal.Name, Action: action}
+	}
+	return nil
+}
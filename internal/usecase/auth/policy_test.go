@@ -0,0 +1,121 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+func TestArticlePolicy(t *testing.T) {
+	t.Parallel()
+
+	const self = uint64(7)
+	own := &auth.Resource{OwnerID: ptr(self)}
+	others := &auth.Resource{OwnerID: ptr(self + 1)}
+
+	// 各ロールが他人の記事と自分の記事に対して許可される操作
+	type grant struct{ others, own bool }
+	table := map[auth.Action]map[auth.Role]grant{
+		auth.ActionReadArticle: {
+			auth.RoleAdmin: {true, true}, auth.RoleEditor: {true, true}, auth.RoleReviewer: {true, true}, auth.RoleAuthor: {true, true}, auth.RoleViewer: {true, true},
+		},
+		auth.ActionReadUnpublishedArticle: {
+			auth.RoleAdmin: {true, true}, auth.RoleEditor: {true, true}, auth.RoleReviewer: {true, true}, auth.RoleAuthor: {false, true}, auth.RoleViewer: {false, false},
+		},
+		auth.ActionCreateArticle: {
+			auth.RoleAdmin: {true, true}, auth.RoleEditor: {true, true}, auth.RoleReviewer: {false, false}, auth.RoleAuthor: {true, true}, auth.RoleViewer: {false, false},
+		},
+		auth.ActionUpdateArticle: {
+			auth.RoleAdmin: {true, true}, auth.RoleEditor: {true, true}, auth.RoleReviewer: {false, false}, auth.RoleAuthor: {false, true}, auth.RoleViewer: {false, false},
+		},
+		auth.ActionPublishArticle: {
+			auth.RoleAdmin: {true, true}, auth.RoleEditor: {true, true}, auth.RoleReviewer: {true, true}, auth.RoleAuthor: {false, true}, auth.RoleViewer: {false, false},
+		},
+		auth.ActionDeleteArticle: {
+			auth.RoleAdmin: {true, true}, auth.RoleEditor: {true, true}, auth.RoleReviewer: {false, false}, auth.RoleAuthor: {false, true}, auth.RoleViewer: {false, false},
+		},
+		auth.ActionRestoreArticle: {
+			auth.RoleAdmin: {true, true}, auth.RoleEditor: {false, false}, auth.RoleReviewer: {false, false}, auth.RoleAuthor: {false, false}, auth.RoleViewer: {false, false},
+		},
+		auth.ActionIncludeDeletedArticle: {
+			auth.RoleAdmin: {true, true}, auth.RoleEditor: {false, false}, auth.RoleReviewer: {false, false}, auth.RoleAuthor: {false, false}, auth.RoleViewer: {false, false},
+		},
+		auth.ActionSubmitReview: {
+			auth.RoleAdmin: {true, true}, auth.RoleEditor: {true, true}, auth.RoleReviewer: {false, false}, auth.RoleAuthor: {false, true}, auth.RoleViewer: {false, false},
+		},
+		auth.ActionDecideReview: {
+			auth.RoleAdmin: {true, true}, auth.RoleEditor: {true, true}, auth.RoleReviewer: {true, true}, auth.RoleAuthor: {false, false}, auth.RoleViewer: {false, false},
+		},
+		auth.ActionCommentReview: {
+			auth.RoleAdmin: {true, true}, auth.RoleEditor: {true, true}, auth.RoleReviewer: {true, true}, auth.RoleAuthor: {false, true}, auth.RoleViewer: {false, false},
+		},
+		auth.ActionReadComment: {
+			auth.RoleAdmin: {true, true}, auth.RoleEditor: {true, true}, auth.RoleReviewer: {true, true}, auth.RoleAuthor: {false, true}, auth.RoleViewer: {false, false},
+		},
+		auth.ActionEditComment: {
+			auth.RoleAdmin: {true, true}, auth.RoleEditor: {true, true}, auth.RoleReviewer: {false, true}, auth.RoleAuthor: {false, true}, auth.RoleViewer: {false, false},
+		},
+	}
+
+	for action, roles := range table {
+		for role, want := range roles {
+			t.Run(string(action)+"/"+string(role), func(t *testing.T) {
+				t.Parallel()
+				p := &auth.Principal{Name: string(role), Roles: []string{string(role)}, AuthorID: self}
+				assert.Equal(t, want.others, auth.ArticlePolicy.Allows(p, action, others), "others")
+				assert.Equal(t, want.own, auth.ArticlePolicy.Allows(p, action, own), "own")
+			})
+		}
+	}
+}
+
+func TestPolicy_Authorize(t *testing.T) {
+	t.Parallel()
+
+	policy := auth.NewPolicy([]auth.Rule{{Action: auth.ActionReadArticle, Any: []auth.Role{auth.RoleViewer}}})
+
+	err := policy.Authorize(context.Background(), auth.ActionReadArticle, nil)
+	assert.ErrorIs(t, err, apperr.ErrUnauthenticated)
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "ci", Scopes: []vo.Scope{vo.ScopeArticlesRead}})
+	assert.NoError(t, policy.Authorize(ctx, auth.ActionReadArticle, nil))
+
+	err = policy.Authorize(ctx, auth.ActionDeleteArticle, nil)
+	var forbidden *auth.ForbiddenError
+	require.ErrorAs(t, err, &forbidden)
+	assert.ErrorIs(t, err, apperr.ErrForbidden)
+	assert.Equal(t, auth.ActionDeleteArticle, forbidden.Action)
+}
+
+func TestPrincipal_EffectiveRoles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		principal auth.Principal
+		want      []auth.Role
+	}{
+		{name: "IdPのロールを優先する", principal: auth.Principal{Roles: []string{"reviewer"}, Scopes: []vo.Scope{vo.ScopeArticlesWrite}}, want: []auth.Role{auth.RoleReviewer}},
+		{name: "管理者のスコープはadmin", principal: auth.Principal{Scopes: []vo.Scope{vo.ScopeArticlesAdmin}}, want: []auth.Role{auth.RoleAdmin}},
+		{name: "書き込みのスコープはauthor", principal: auth.Principal{Scopes: []vo.Scope{vo.ScopeArticlesRead, vo.ScopeArticlesWrite}}, want: []auth.Role{auth.RoleAuthor}},
+		{name: "参照のスコープはviewer", principal: auth.Principal{Scopes: []vo.Scope{vo.ScopeArticlesRead}}, want: []auth.Role{auth.RoleViewer}},
+		{name: "ロールもスコープもなし", principal: auth.Principal{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.principal.EffectiveRoles())
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,75 @@
+// Package auth carries the authenticated caller through context.Context
+// so that use cases can check what the caller is allowed to do.
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// KeyID is the ID of the API key the caller authenticated with.
+	// It is zero for callers authenticated with a bearer token.
+	KeyID uint64
+	// Subject is the "sub" claim of the bearer token the caller authenticated with.
+	Subject string
+	Name    string
+	// Roles are the roles granted by the identity provider, evaluated by the
+	// article policy as Role values.
+	Roles  []string
+	Scopes []vo.Scope
+	// AuthorID is the author the caller acts as. It is zero if the caller
+	// is not linked to an author and therefore owns no articles.
+	AuthorID uint64
+	// WorkspaceID is the workspace the caller is bound to. It is zero if the
+	// caller may act in whichever workspace the request addresses.
+	WorkspaceID uint64
+}
+
+// HasScope reports whether any of the principal's scopes includes required.
+func (p *Principal) HasScope(required vo.Scope) bool {
+	for _, s := range p.Scopes {
+		if s.Includes(required) {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx that carries p.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom returns the principal carried by ctx, if any.
+func PrincipalFrom(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// Require returns ErrUnauthenticated if ctx carries no principal and
+// ErrForbidden if the principal lacks the required scope.
+func Require(ctx context.Context, required vo.Scope) error {
+	p, ok := PrincipalFrom(ctx)
+	if !ok {
+		return fmt.Errorf("%w: %s is required", apperr.ErrUnauthenticated, required)
+	}
+	if !p.HasScope(required) {
+		return fmt.Errorf("%w: %q does not have %s", apperr.ErrForbidden, p.Name, required)
+	}
+	return nil
+}
+
+// ScopeAuthorizer authorizes operations by the scopes of the principal in the context.
+type ScopeAuthorizer struct{}
+
+// Authorize implements the Authorizer interfaces of the use cases.
+func (ScopeAuthorizer) Authorize(ctx context.Context, required vo.Scope) error {
+	return Require(ctx, required)
+}
@@ -0,0 +1,42 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+func TestRequire(t *testing.T) {
+	t.Parallel()
+
+	writer := auth.WithPrincipal(context.Background(), &auth.Principal{KeyID: 1, Name: "ci", Scopes: []vo.Scope{vo.ScopeArticlesWrite}})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		required vo.Scope
+		wantErr  error
+	}{
+		{name: "上位のスコープで下位の操作ができる", ctx: writer, required: vo.ScopeArticlesRead},
+		{name: "同じスコープの操作ができる", ctx: writer, required: vo.ScopeArticlesWrite},
+		{name: "足りないスコープはErrForbidden", ctx: writer, required: vo.ScopeArticlesAdmin, wantErr: apperr.ErrForbidden},
+		{name: "未認証はErrUnauthenticated", ctx: context.Background(), required: vo.ScopeArticlesRead, wantErr: apperr.ErrUnauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := auth.Require(tt.ctx, tt.required)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package author
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+const (
+	defaultProfileLimit = 20
+	maxProfileLimit     = 100
+)
+
+// ArticleLister lists articles by criteria, e.g. *article.ArticleUsecase.
+type ArticleLister interface {
+	FindByCriteria(ctx context.Context, criteria article.FindByCriteriaInput) (*article.FindByCriteriaOutput, error)
+}
+
+// Authorizer checks whether the caller carried by the context may perform
+// an operation that requires the given scope.
+type Authorizer interface {
+	Authorize(ctx context.Context, required vo.Scope) error
+}
+
+// AuthorUsecase manages authors and serves their profiles.
+type AuthorUsecase struct {
+	repo       repository.AuthorRepository
+	articles   ArticleLister
+	authorizer Authorizer
+}
+
+// Option configures an AuthorUsecase.
+type Option func(*AuthorUsecase)
+
+// WithAuthorizer enables authorization: reads require articles:read and
+// creating or updating authors requires articles:admin.
+func WithAuthorizer(a Authorizer) Option {
+	return func(uc *AuthorUsecase) {
+		uc.authorizer = a
+	}
+}
+
+// NewAuthorUsecase creates a new AuthorUsecase.
+func NewAuthorUsecase(repo repository.AuthorRepository, articles ArticleLister, opts ...Option) *AuthorUsecase {
+	uc := &AuthorUsecase{repo: repo, articles: articles}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// ListAuthors retrieves all authors.
+func (uc *AuthorUsecase) ListAuthors(ctx context.Context) ([]AuthorOutput, error) {
+	if err := uc.authorize(ctx, vo.ScopeArticlesRead); err != nil {
+		return nil, err
+	}
+	authors, err := uc.repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find authors: %w", err)
+	}
+	outputs := make([]AuthorOutput, 0, len(authors))
+	for _, a := range authors {
+		outputs = append(outputs, newAuthorOutput(a))
+	}
+	return outputs, nil
+}
+
+// FindProfile retrieves an author together with a page of the author's published articles.
+func (uc *AuthorUsecase) FindProfile(ctx context.Context, id uint64, input ProfileInput) (*ProfileOutput, error) {
+	if err := uc.authorize(ctx, vo.ScopeArticlesRead); err != nil {
+		return nil, err
+	}
+	a, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	page := max(input.Page, 1)
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultProfileLimit
+	}
+	limit = min(limit, maxProfileLimit)
+	status := vo.ArticleStatusPublished.String()
+	articles, err := uc.articles.FindByCriteria(ctx, article.FindByCriteriaInput{
+		Status:   &status,
+		AuthorID: &a.ID,
+		Page:     page,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ProfileOutput{Author: newAuthorOutput(a), Articles: *articles}, nil
+}
+
+// CreateAuthor creates a new author.
+func (uc *AuthorUsecase) CreateAuthor(ctx context.Context, input CreateAuthorInput) (*AuthorOutput, error) {
+	if err := uc.authorize(ctx, vo.ScopeArticlesAdmin); err != nil {
+		return nil, err
+	}
+	a, err := entity.NewAuthor(input.DisplayName, input.ProviderUsernames, input.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+	}
+	created, err := uc.repo.Create(ctx, a)
+	if errors.Is(err, repository.ErrAuthorSubjectConflict) {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrConflict, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	output := newAuthorOutput(created)
+	return &output, nil
+}
+
+// UpdateAuthor updates an existing author.
+func (uc *AuthorUsecase) UpdateAuthor(ctx context.Context, id uint64, input UpdateAuthorInput) (*AuthorOutput, error) {
+	if err := uc.authorize(ctx, vo.ScopeArticlesAdmin); err != nil {
+		return nil, err
+	}
+	a, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.Update(input.DisplayName, input.ProviderUsernames, input.Subject); err != nil {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+	}
+	err = uc.repo.Update(ctx, a)
+	if errors.Is(err, repository.ErrAuthorSubjectConflict) {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrConflict, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	output := newAuthorOutput(a)
+	return &output, nil
+}
+
+func (uc *AuthorUsecase) authorize(ctx context.Context, required vo.Scope) error {
+	if uc.authorizer == nil {
+		return nil
+	}
+	return uc.authorizer.Authorize(ctx, required)
+}
+
+// SubjectResolver links callers authenticated with a bearer token to the
+// author registered with the token's subject.
+type SubjectResolver struct {
+	next auth.Authenticator
+	repo repository.AuthorRepository
+}
+
+// NewSubjectResolver wraps next so that its principals act as their author.
+func NewSubjectResolver(next auth.Authenticator, repo repository.AuthorRepository) *SubjectResolver {
+	return &SubjectResolver{next: next, repo: repo}
+}
+
+// Authenticate implements auth.Authenticator. Callers whose subject is not
+// registered to an author are authenticated without one.
+// Authors are looked up in the workspace the caller is bound to, or else
+// in the workspace of the request.
+func (r *SubjectResolver) Authenticate(ctx context.Context, credential string) (*auth.Principal, error) {
+	p, err := r.next.Authenticate(ctx, credential)
+	if err != nil {
+		return nil, err
+	}
+	if p.Subject == "" || p.AuthorID != 0 {
+		return p, nil
+	}
+	if p.WorkspaceID != 0 {
+		ctx = repository.WithWorkspace(ctx, p.WorkspaceID)
+	}
+	a, err := r.repo.FindBySubject(ctx, p.Subject)
+	if errors.Is(err, repository.ErrNotFound) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve author of %q: %w", p.Subject, err)
+	}
+	p.AuthorID = a.ID
+	return p, nil
+}
@@ -0,0 +1,179 @@
+package author_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/author"
+)
+
+type MockAuthorRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuthorRepository) FindAll(ctx context.Context) ([]*entity.Author, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*entity.Author), args.Error(1)
+}
+
+func (m *MockAuthorRepository) FindByID(ctx context.Context, id uint64) (*entity.Author, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Author), args.Error(1)
+}
+
+func (m *MockAuthorRepository) FindBySubject(ctx context.Context, subject string) (*entity.Author, error) {
+	args := m.Called(ctx, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Author), args.Error(1)
+}
+
+func (m *MockAuthorRepository) Create(ctx context.Context, a *entity.Author) (*entity.Author, error) {
+	args := m.Called(ctx, a)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Author), args.Error(1)
+}
+
+func (m *MockAuthorRepository) Update(ctx context.Context, a *entity.Author) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+type MockArticleLister struct {
+	mock.Mock
+}
+
+func (m *MockArticleLister) FindByCriteria(ctx context.Context, criteria article.FindByCriteriaInput) (*article.FindByCriteriaOutput, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*article.FindByCriteriaOutput), args.Error(1)
+}
+
+// stubAuthenticator は常に同じ呼び出し元を返す
+type stubAuthenticator struct {
+	principal auth.Principal
+}
+
+func (s stubAuthenticator) Authenticate(context.Context, string) (*auth.Principal, error) {
+	p := s.principal
+	return &p, nil
+}
+
+func newAuthor(t *testing.T, id uint64) *entity.Author {
+	t.Helper()
+	a, err := entity.NewAuthor("Alice", map[string]string{"zenn": "alice"}, nil)
+	require.NoError(t, err)
+	a.ID = id
+	return a
+}
+
+func TestAuthorUsecase_FindProfile(t *testing.T) {
+	t.Parallel()
+
+	repo := new(MockAuthorRepository)
+	articles := new(MockArticleLister)
+	uc := author.NewAuthorUsecase(repo, articles)
+	ctx := context.Background()
+	repo.On("FindByID", ctx, uint64(3)).Return(newAuthor(t, 3), nil)
+	articles.On("FindByCriteria", ctx, mock.MatchedBy(func(c article.FindByCriteriaInput) bool {
+		return *c.AuthorID == 3 && *c.Status == "published" && c.Page == 1 && c.Limit == 100
+	})).Return(&article.FindByCriteriaOutput{Articles: []article.FindArticleByIDOutput{{ID: 10}}, Total: 1, Page: 1, Limit: 100, TotalPages: 1}, nil)
+
+	output, err := uc.FindProfile(ctx, 3, author.ProfileInput{Limit: 500})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", output.Author.DisplayName)
+	assert.Equal(t, map[string]string{"zenn": "https://zenn.dev/alice"}, output.Author.ProfileURLs)
+	assert.Len(t, output.Articles.Articles, 1)
+}
+
+func TestAuthorUsecase_CreateAuthor(t *testing.T) {
+	t.Parallel()
+
+	admin := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "ops", Scopes: []vo.Scope{vo.ScopeArticlesAdmin}})
+	writer := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "ci", Scopes: []vo.Scope{vo.ScopeArticlesWrite}})
+
+	t.Run("執筆者の作成には管理者のスコープが必要", func(t *testing.T) {
+		t.Parallel()
+		repo := new(MockAuthorRepository)
+		uc := author.NewAuthorUsecase(repo, new(MockArticleLister), author.WithAuthorizer(auth.ScopeAuthorizer{}))
+
+		_, err := uc.CreateAuthor(writer, author.CreateAuthorInput{DisplayName: "Alice"})
+
+		assert.ErrorIs(t, err, apperr.ErrForbidden)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("不正なユーザー名はErrInvalidInput", func(t *testing.T) {
+		t.Parallel()
+		uc := author.NewAuthorUsecase(new(MockAuthorRepository), new(MockArticleLister), author.WithAuthorizer(auth.ScopeAuthorizer{}))
+
+		_, err := uc.CreateAuthor(admin, author.CreateAuthorInput{DisplayName: "Alice", ProviderUsernames: map[string]string{"qiita": "a b"}})
+
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+	})
+
+	t.Run("使用済みのsubjectはErrConflict", func(t *testing.T) {
+		t.Parallel()
+		repo := new(MockAuthorRepository)
+		uc := author.NewAuthorUsecase(repo, new(MockArticleLister), author.WithAuthorizer(auth.ScopeAuthorizer{}))
+		repo.On("Create", admin, mock.Anything).Return(nil, fmt.Errorf("subject %q: %w", "idp|1", repository.ErrAuthorSubjectConflict))
+		subject := "idp|1"
+
+		_, err := uc.CreateAuthor(admin, author.CreateAuthorInput{DisplayName: "Alice", Subject: &subject})
+
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+	})
+}
+
+func TestSubjectResolver(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := new(MockAuthorRepository)
+	repo.On("FindBySubject", ctx, "idp|1").Return(newAuthor(t, 5), nil)
+	repo.On("FindBySubject", ctx, "idp|2").Return(nil, fmt.Errorf("author: %w", repository.ErrAuthorNotFound))
+	repo.On("FindBySubject", repository.WithWorkspace(ctx, 3), "idp|1").Return(newAuthor(t, 7), nil)
+
+	tests := []struct {
+		name         string
+		principal    auth.Principal
+		wantAuthorID uint64
+	}{
+		{name: "subjectに対応する執筆者として扱う", principal: auth.Principal{Subject: "idp|1"}, wantAuthorID: 5},
+		{name: "登録されていないsubjectは執筆者なし", principal: auth.Principal{Subject: "idp|2"}},
+		{name: "APIキーの執筆者はそのまま", principal: auth.Principal{KeyID: 1, AuthorID: 9}, wantAuthorID: 9},
+		{name: "ワークスペースに属する呼び出し元はそのワークスペースの執筆者として扱う", principal: auth.Principal{Subject: "idp|1", WorkspaceID: 3}, wantAuthorID: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := author.NewSubjectResolver(stubAuthenticator{principal: tt.principal}, repo)
+
+			p, err := r.Authenticate(ctx, "credential")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAuthorID, p.AuthorID)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package author
+
+import (
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+)
+
+// CreateAuthorInput is the input for creating an author.
+// ProviderUsernames maps a provider ("qiita", "zenn" or "note") to the username on it.
+type CreateAuthorInput struct {
+	DisplayName       string            `json:"display_name"`
+	ProviderUsernames map[string]string `json:"provider_usernames,omitempty"`
+	// Subject is the "sub" claim of the identity provider the author signs in with.
+	Subject *string `json:"subject,omitempty"`
+}
+
+// UpdateAuthorInput is the input for updating an author.
+// Nil fields are left unchanged, an empty username removes the provider and
+// an empty Subject unlinks the identity provider account.
+type UpdateAuthorInput struct {
+	DisplayName       *string           `json:"display_name,omitempty"`
+	ProviderUsernames map[string]string `json:"provider_usernames,omitempty"`
+	Subject           *string           `json:"subject,omitempty"`
+}
+
+// AuthorOutput is the output for an author.
+type AuthorOutput struct {
+	ID                uint64            `json:"id"`
+	DisplayName       string            `json:"display_name"`
+	ProviderUsernames map[string]string `json:"provider_usernames"`
+	// ProfileURLs maps a provider to the author's profile page on it.
+	ProfileURLs map[string]string `json:"profile_urls"`
+	Subject     *string           `json:"subject,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// ProfileInput is the input for retrieving an author profile.
+type ProfileInput struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+}
+
+// ProfileOutput is an author together with the author's published articles.
+type ProfileOutput struct {
+	Author   AuthorOutput                 `json:"author"`
+	Articles article.FindByCriteriaOutput `json:"articles"`
+}
+
+func newAuthorOutput(a *entity.Author) AuthorOutput {
+	profiles := make(map[string]string, len(a.ProviderUsernames))
+	for _, p := range a.Providers() {
+		if url, ok := a.ProfileURL(p); ok {
+			profiles[p.String()] = url
+		}
+	}
+	return AuthorOutput{
+		ID:                a.ID,
+		DisplayName:       a.DisplayName,
+		ProviderUsernames: a.ProviderUsernameStrings(),
+		ProfileURLs:       profiles,
+		Subject:           a.Subject,
+		CreatedAt:         a.CreatedAt,
+		UpdatedAt:         a.UpdatedAt,
+	}
+}
@@ -0,0 +1,307 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+// anonymousActor is recorded as the commenter when authentication is disabled.
+const anonymousActor = "anonymous"
+
+// Authorizer decides whether the caller of ctx may perform an action on an article or a comment.
+type Authorizer interface {
+	Authorize(ctx context.Context, action auth.Action, resource *auth.Resource) error
+}
+
+// CommentUsecase manages comments anchored to passages of article bodies.
+type CommentUsecase struct {
+	articles   repository.ArticleRepository
+	comments   repository.InlineCommentRepository
+	txManager  repository.TxManager
+	authorizer Authorizer
+}
+
+// Option configures a CommentUsecase.
+type Option func(*CommentUsecase)
+
+// WithAuthorizer enables authorization of comment operations.
+func WithAuthorizer(a Authorizer) Option {
+	return func(uc *CommentUsecase) {
+		uc.authorizer = a
+	}
+}
+
+// NewCommentUsecase creates a new CommentUsecase.
+func NewCommentUsecase(articles repository.ArticleRepository, comments repository.InlineCommentRepository, txManager repository.TxManager, opts ...Option) *CommentUsecase {
+	uc := &CommentUsecase{
+		articles:  articles,
+		comments:  comments,
+		txManager: txManager,
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// List returns the comment threads of an article ordered by the position of
+// their passages. Outdated threads, whose passage was removed from the body, come last.
+func (uc *CommentUsecase) List(ctx context.Context, articleID uint64, input ListCommentsInput) (*ThreadsOutput, error) {
+	article, err := uc.articles.FindByID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authorize(ctx, auth.ActionReadComment, resourceOf(article)); err != nil {
+		return nil, err
+	}
+	comments, err := uc.comments.FindByArticleID(ctx, article.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	threads := make([]*entity.InlineComment, 0, len(comments))
+	replies := make(map[uint64][]CommentOutput)
+	for _, c := range comments {
+		if c.IsReply() {
+			replies[*c.ParentID] = append(replies[*c.ParentID], newCommentOutput(c))
+			continue
+		}
+		if c.ResolvedAt != nil && !input.IncludeResolved {
+			continue
+		}
+		threads = append(threads, c)
+	}
+	sort.SliceStable(threads, func(i, j int) bool {
+		if threads[i].Outdated != threads[j].Outdated {
+			return !threads[i].Outdated
+		}
+		return threads[i].Anchor.Start < threads[j].Anchor.Start
+	})
+
+	output := &ThreadsOutput{ArticleID: article.ID, Threads: make([]ThreadOutput, 0, len(threads))}
+	for _, c := range threads {
+		thread := ThreadOutput{CommentOutput: newCommentOutput(c), Replies: replies[c.ID]}
+		if thread.Replies == nil {
+			thread.Replies = []CommentOutput{}
+		}
+		output.Threads = append(output.Threads, thread)
+	}
+	return output, nil
+}
+
+// Create starts a comment thread on a passage of the current body of an article.
+// The article is locked so that the passage cannot change while the comment is added.
+func (uc *CommentUsecase) Create(ctx context.Context, articleID uint64, input CreateCommentInput) (*CommentOutput, error) {
+	var created *entity.InlineComment
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		article, err := uc.articles.FindByIDForUpdate(ctx, articleID)
+		if err != nil {
+			return err
+		}
+		if err := uc.authorize(ctx, auth.ActionCommentReview, resourceOf(article)); err != nil {
+			return err
+		}
+		anchor, err := vo.NewTextAnchor(article.Body.String(), input.Start, input.End, input.Quote)
+		if err != nil {
+			return fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+		}
+		c, err := entity.NewInlineComment(article.ID, anchor, actorOf(ctx), input.Body)
+		if err != nil {
+			return fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+		}
+		created, err = uc.comments.Create(ctx, c)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	output := newCommentOutput(created)
+	return &output, nil
+}
+
+// Reply replies to a comment thread.
+func (uc *CommentUsecase) Reply(ctx context.Context, articleID, commentID uint64, input ReplyInput) (*CommentOutput, error) {
+	parent, err := uc.find(ctx, articleID, commentID, auth.ActionCommentReview)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := parent.Reply(actorOf(ctx), input.Body)
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+	created, err := uc.comments.Create(ctx, reply)
+	if err != nil {
+		return nil, err
+	}
+	output := newCommentOutput(created)
+	return &output, nil
+}
+
+// Update edits a comment. Callers other than admins and editors may only edit their own comments.
+func (uc *CommentUsecase) Update(ctx context.Context, articleID, commentID uint64, input UpdateCommentInput) (*CommentOutput, error) {
+	c, err := uc.find(ctx, articleID, commentID, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authorize(ctx, auth.ActionEditComment, commenterOf(c)); err != nil {
+		return nil, err
+	}
+	if err := c.Edit(input.Body); err != nil {
+		return nil, invalidInput(err)
+	}
+	if err := uc.comments.Update(ctx, c); err != nil {
+		return nil, err
+	}
+	output := newCommentOutput(c)
+	return &output, nil
+}
+
+// Delete deletes a comment, and its replies if it starts a thread.
+// Callers other than admins and editors may only delete their own comments.
+func (uc *CommentUsecase) Delete(ctx context.Context, articleID, commentID uint64) error {
+	c, err := uc.find(ctx, articleID, commentID, "")
+	if err != nil {
+		return err
+	}
+	if err := uc.authorize(ctx, auth.ActionEditComment, commenterOf(c)); err != nil {
+		return err
+	}
+	return uc.comments.Delete(ctx, c.ID)
+}
+
+// Resolve marks a comment thread as resolved.
+func (uc *CommentUsecase) Resolve(ctx context.Context, articleID, commentID uint64) (*CommentOutput, error) {
+	return uc.resolve(ctx, articleID, commentID, func(c *entity.InlineComment) error {
+		return c.Resolve(actorOf(ctx))
+	})
+}
+
+// Unresolve reopens a resolved comment thread.
+func (uc *CommentUsecase) Unresolve(ctx context.Context, articleID, commentID uint64) (*CommentOutput, error) {
+	return uc.resolve(ctx, articleID, commentID, func(c *entity.InlineComment) error {
+		return c.Unresolve()
+	})
+}
+
+func (uc *CommentUsecase) resolve(ctx context.Context, articleID, commentID uint64, fn func(*entity.InlineComment) error) (*CommentOutput, error) {
+	c, err := uc.find(ctx, articleID, commentID, auth.ActionCommentReview)
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(c); err != nil {
+		return nil, invalidInput(err)
+	}
+	if err := uc.comments.Update(ctx, c); err != nil {
+		return nil, err
+	}
+	output := newCommentOutput(c)
+	return &output, nil
+}
+
+// ReanchorComments moves the comment threads of an article to the passages
+// of its new body. Threads whose passage is no longer found are marked as outdated.
+// It is called by the article use case within the transaction that changes the body.
+func (uc *CommentUsecase) ReanchorComments(ctx context.Context, articleID uint64, body string) error {
+	comments, err := uc.comments.FindByArticleID(ctx, articleID)
+	if err != nil {
+		return err
+	}
+	for _, c := range comments {
+		if !c.Reanchor(body) {
+			continue
+		}
+		if err := uc.comments.Update(ctx, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// find returns the comment of the article, authorizing action on the article unless it is empty.
+func (uc *CommentUsecase) find(ctx context.Context, articleID, commentID uint64, action auth.Action) (*entity.InlineComment, error) {
+	article, err := uc.articles.FindByID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if action != "" {
+		if err := uc.authorize(ctx, action, resourceOf(article)); err != nil {
+			return nil, err
+		}
+	}
+	c, err := uc.comments.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if c.ArticleID != article.ID {
+		return nil, fmt.Errorf("comment %d of article %d: %w", commentID, article.ID, repository.ErrInlineCommentNotFound)
+	}
+	return c, nil
+}
+
+func (uc *CommentUsecase) authorize(ctx context.Context, action auth.Action, resource *auth.Resource) error {
+	if uc.authorizer == nil {
+		return nil
+	}
+	return uc.authorizer.Authorize(ctx, action, resource)
+}
+
+func resourceOf(article *entity.Article) *auth.Resource {
+	return &auth.Resource{OwnerID: article.AuthorID}
+}
+
+// commenterOf returns the comment as a resource owned by its commenter.
+func commenterOf(c *entity.InlineComment) *auth.Resource {
+	return &auth.Resource{OwnerID: c.Commenter.AuthorID}
+}
+
+// actorOf returns the caller of ctx as recorded on comments.
+func actorOf(ctx context.Context) entity.ReviewActor {
+	p, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return entity.ReviewActor{Name: anonymousActor}
+	}
+	actor := entity.ReviewActor{Name: p.Name}
+	if p.AuthorID != 0 {
+		id := p.AuthorID
+		actor.AuthorID = &id
+	}
+	return actor
+}
+
+// invalidInput maps an error of the comment entity, e.g. an empty body or
+// a reply to a reply, to ErrInvalidInput.
+func invalidInput(err error) error {
+	return fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+}
+
+func newCommentOutput(c *entity.InlineComment) CommentOutput {
+	output := CommentOutput{
+		ID:         c.ID,
+		ParentID:   c.ParentID,
+		Outdated:   c.Outdated,
+		Commenter:  newActorOutput(c.Commenter),
+		Body:       c.Body,
+		ResolvedAt: c.ResolvedAt,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+	if c.Anchor != nil {
+		output.Anchor = &AnchorOutput{Start: c.Anchor.Start, End: c.Anchor.End, Quote: c.Anchor.Quote}
+	}
+	if c.ResolvedBy != nil {
+		resolvedBy := newActorOutput(*c.ResolvedBy)
+		output.ResolvedBy = &resolvedBy
+	}
+	return output
+}
+
+func newActorOutput(a entity.ReviewActor) ActorOutput {
+	return ActorOutput{Name: a.Name, AuthorID: a.AuthorID}
+}
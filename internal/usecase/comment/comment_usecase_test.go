@@ -0,0 +1,280 @@
+package comment_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/comment"
+)
+
+type MockInlineCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockInlineCommentRepository) FindByArticleID(ctx context.Context, articleID uint64) ([]*entity.InlineComment, error) {
+	args := m.Called(ctx, articleID)
+	return args.Get(0).([]*entity.InlineComment), args.Error(1)
+}
+
+func (m *MockInlineCommentRepository) FindByID(ctx context.Context, id uint64) (*entity.InlineComment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InlineComment), args.Error(1)
+}
+
+func (m *MockInlineCommentRepository) Create(ctx context.Context, c *entity.InlineComment) (*entity.InlineComment, error) {
+	args := m.Called(ctx, c)
+	if fn, ok := args.Get(0).(func(context.Context, *entity.InlineComment) *entity.InlineComment); ok {
+		return fn(ctx, c), args.Error(1)
+	}
+	return args.Get(0).(*entity.InlineComment), args.Error(1)
+}
+
+func (m *MockInlineCommentRepository) Update(ctx context.Context, c *entity.InlineComment) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *MockInlineCommentRepository) Delete(ctx context.Context, id uint64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// stubArticleRepository は1件の記事だけを保持する
+type stubArticleRepository struct {
+	repository.ArticleRepository
+	article *entity.Article
+}
+
+func (r *stubArticleRepository) FindByID(_ context.Context, id uint64) (*entity.Article, error) {
+	if r.article.ID != id {
+		return nil, repository.ErrArticleNotFound
+	}
+	return r.article, nil
+}
+
+func (r *stubArticleRepository) FindByIDForUpdate(ctx context.Context, id uint64) (*entity.Article, error) {
+	return r.FindByID(ctx, id)
+}
+
+// passthroughTxManager はトランザクションを張らずにfnをそのまま実行する
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (passthroughTxManager) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+const (
+	ownerID    = uint64(7)
+	reviewerID = uint64(8)
+	body       = "はじめに。並行処理の話。おわりに。"
+)
+
+func newArticle(t *testing.T) *entity.Article {
+	t.Helper()
+	b, err := vo.NewArticleBody(ptr(body), 0)
+	require.NoError(t, err)
+	return &entity.Article{ID: 1, Body: b, Status: vo.ArticleStatusInReview, AuthorID: ptr(ownerID)}
+}
+
+func newThread(t *testing.T, id uint64, start, end int, commenter uint64) *entity.InlineComment {
+	t.Helper()
+	anchor, err := vo.NewTextAnchor(body, start, end, "")
+	require.NoError(t, err)
+	c, err := entity.NewInlineComment(1, anchor, entity.ReviewActor{Name: "reviewer", AuthorID: ptr(commenter)}, "コメント")
+	require.NoError(t, err)
+	c.ID = id
+	return c
+}
+
+func withRole(role auth.Role, authorID uint64) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Name: string(role), Roles: []string{string(role)}, AuthorID: authorID})
+}
+
+func TestCommentUsecase_Create(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		input   comment.CreateCommentInput
+		want    *comment.AnchorOutput
+		wantErr error
+	}{
+		{
+			name:  "レビュアーが本文の範囲にコメントする",
+			ctx:   withRole(auth.RoleReviewer, reviewerID),
+			input: comment.CreateCommentInput{Start: 5, End: 9, Quote: "並行処理", Body: "具体例がほしい"},
+			want:  &comment.AnchorOutput{Start: 5, End: 9, Quote: "並行処理"},
+		},
+		{
+			name:    "引用が本文と一致しない場合はErrInvalidInput",
+			ctx:     withRole(auth.RoleReviewer, reviewerID),
+			input:   comment.CreateCommentInput{Start: 5, End: 9, Quote: "並列処理", Body: "具体例がほしい"},
+			wantErr: apperr.ErrInvalidInput,
+		},
+		{
+			name:    "本文を超える範囲はErrInvalidInput",
+			ctx:     withRole(auth.RoleReviewer, reviewerID),
+			input:   comment.CreateCommentInput{Start: 5, End: 100, Body: "具体例がほしい"},
+			wantErr: apperr.ErrInvalidInput,
+		},
+		{
+			name:    "閲覧者はコメントできない",
+			ctx:     withRole(auth.RoleViewer, 0),
+			input:   comment.CreateCommentInput{Start: 5, End: 9, Body: "具体例がほしい"},
+			wantErr: apperr.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			comments := new(MockInlineCommentRepository)
+			comments.On("Create", mock.Anything, mock.Anything).Return(func(_ context.Context, c *entity.InlineComment) *entity.InlineComment {
+				c.ID = 10
+				return c
+			}, nil)
+			uc := comment.NewCommentUsecase(&stubArticleRepository{article: newArticle(t)}, comments, passthroughTxManager{}, comment.WithAuthorizer(auth.ArticlePolicy))
+
+			output, err := uc.Create(tt.ctx, 1, tt.input)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				comments.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, uint64(10), output.ID)
+			assert.Equal(t, tt.want, output.Anchor)
+			assert.Equal(t, ptr(reviewerID), output.Commenter.AuthorID)
+		})
+	}
+}
+
+func TestCommentUsecase_List(t *testing.T) {
+	t.Parallel()
+
+	later := newThread(t, 10, 13, 17, reviewerID)
+	earlier := newThread(t, 11, 0, 4, reviewerID)
+	outdated := newThread(t, 12, 5, 9, reviewerID)
+	outdated.Outdated = true
+	resolved := newThread(t, 13, 5, 9, reviewerID)
+	require.NoError(t, resolved.Resolve(entity.ReviewActor{Name: "author"}))
+	reply, err := later.Reply(entity.ReviewActor{Name: "author"}, "直します")
+	require.NoError(t, err)
+	reply.ID = 14
+
+	comments := new(MockInlineCommentRepository)
+	comments.On("FindByArticleID", mock.Anything, uint64(1)).Return([]*entity.InlineComment{later, earlier, outdated, resolved, reply}, nil)
+	uc := comment.NewCommentUsecase(&stubArticleRepository{article: newArticle(t)}, comments, passthroughTxManager{}, comment.WithAuthorizer(auth.ArticlePolicy))
+
+	output, err := uc.List(withRole(auth.RoleAuthor, ownerID), 1, comment.ListCommentsInput{})
+	require.NoError(t, err)
+	ids := make([]uint64, 0, len(output.Threads))
+	for _, th := range output.Threads {
+		ids = append(ids, th.ID)
+	}
+	assert.Equal(t, []uint64{11, 10, 12}, ids, "範囲の位置の順で、古くなったスレッドは最後")
+	require.Len(t, output.Threads[1].Replies, 1)
+	assert.Equal(t, uint64(14), output.Threads[1].Replies[0].ID)
+
+	output, err = uc.List(withRole(auth.RoleAuthor, ownerID), 1, comment.ListCommentsInput{IncludeResolved: true})
+	require.NoError(t, err)
+	assert.Len(t, output.Threads, 4)
+
+	_, err = uc.List(withRole(auth.RoleAuthor, ownerID+1), 1, comment.ListCommentsInput{})
+	assert.ErrorIs(t, err, apperr.ErrForbidden, "他人の記事のコメントは読めない")
+}
+
+func TestCommentUsecase_Manage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("返信への返信はErrInvalidInput", func(t *testing.T) {
+		t.Parallel()
+		thread := newThread(t, 10, 5, 9, reviewerID)
+		reply, err := thread.Reply(entity.ReviewActor{Name: "author"}, "直します")
+		require.NoError(t, err)
+		reply.ID = 11
+		comments := new(MockInlineCommentRepository)
+		comments.On("FindByID", mock.Anything, uint64(11)).Return(reply, nil)
+		uc := comment.NewCommentUsecase(&stubArticleRepository{article: newArticle(t)}, comments, passthroughTxManager{})
+
+		_, err = uc.Reply(context.Background(), 1, 11, comment.ReplyInput{Body: "お願いします"})
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+	})
+
+	t.Run("他の記事のコメントは見つからない", func(t *testing.T) {
+		t.Parallel()
+		thread := newThread(t, 10, 5, 9, reviewerID)
+		thread.ArticleID = 2
+		comments := new(MockInlineCommentRepository)
+		comments.On("FindByID", mock.Anything, uint64(10)).Return(thread, nil)
+		uc := comment.NewCommentUsecase(&stubArticleRepository{article: newArticle(t)}, comments, passthroughTxManager{})
+
+		_, err := uc.Resolve(context.Background(), 1, 10)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("自分のコメントだけ編集・削除できる", func(t *testing.T) {
+		t.Parallel()
+		thread := newThread(t, 10, 5, 9, reviewerID)
+		comments := new(MockInlineCommentRepository)
+		comments.On("FindByID", mock.Anything, uint64(10)).Return(thread, nil)
+		comments.On("Update", mock.Anything, thread).Return(nil)
+		comments.On("Delete", mock.Anything, uint64(10)).Return(nil)
+		uc := comment.NewCommentUsecase(&stubArticleRepository{article: newArticle(t)}, comments, passthroughTxManager{}, comment.WithAuthorizer(auth.ArticlePolicy))
+
+		_, err := uc.Update(withRole(auth.RoleAuthor, ownerID), 1, 10, comment.UpdateCommentInput{Body: "書き換え"})
+		assert.ErrorIs(t, err, apperr.ErrForbidden)
+		assert.ErrorIs(t, uc.Delete(withRole(auth.RoleReviewer, reviewerID+1), 1, 10), apperr.ErrForbidden)
+
+		output, err := uc.Update(withRole(auth.RoleReviewer, reviewerID), 1, 10, comment.UpdateCommentInput{Body: "書き換え"})
+		require.NoError(t, err)
+		assert.Equal(t, "書き換え", output.Body)
+		require.NoError(t, uc.Delete(withRole(auth.RoleEditor, 0), 1, 10))
+	})
+}
+
+func TestCommentUsecase_ReanchorComments(t *testing.T) {
+	t.Parallel()
+
+	moved := newThread(t, 10, 5, 9, reviewerID)
+	removed := newThread(t, 11, 13, 17, reviewerID)
+	unchanged := newThread(t, 12, 0, 4, reviewerID)
+	reply, err := moved.Reply(entity.ReviewActor{Name: "author"}, "直します")
+	require.NoError(t, err)
+	reply.ID = 13
+
+	comments := new(MockInlineCommentRepository)
+	comments.On("FindByArticleID", mock.Anything, uint64(1)).Return([]*entity.InlineComment{moved, removed, unchanged, reply}, nil)
+	comments.On("Update", mock.Anything, mock.Anything).Return(nil)
+	uc := comment.NewCommentUsecase(&stubArticleRepository{article: newArticle(t)}, comments, passthroughTxManager{})
+
+	require.NoError(t, uc.ReanchorComments(context.Background(), 1, "はじめに。ここで並行処理の話。"))
+
+	assert.Equal(t, 8, moved.Anchor.Start)
+	assert.False(t, moved.Outdated)
+	assert.True(t, removed.Outdated)
+	comments.AssertCalled(t, "Update", mock.Anything, moved)
+	comments.AssertCalled(t, "Update", mock.Anything, removed)
+	comments.AssertNotCalled(t, "Update", mock.Anything, reply)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,69 @@
+package comment
+
+import "time"
+
+// CreateCommentInput is the input for commenting on a passage of an article body.
+// Start and End are character offsets of the passage [Start, End) in the current body.
+// Quote, if given, must equal the passage so that ranges computed on a stale body are rejected.
+type CreateCommentInput struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Quote string `json:"quote"`
+	Body  string `json:"body"`
+}
+
+// ReplyInput is the input for replying to a comment thread.
+type ReplyInput struct {
+	Body string `json:"body"`
+}
+
+// UpdateCommentInput is the input for editing a comment.
+type UpdateCommentInput struct {
+	Body string `json:"body"`
+}
+
+// ListCommentsInput is the input for listing the comment threads of an article.
+// Resolved threads are omitted unless IncludeResolved is set.
+type ListCommentsInput struct {
+	IncludeResolved bool
+}
+
+// ActorOutput is a caller who commented or resolved a thread.
+type ActorOutput struct {
+	Name     string  `json:"name"`
+	AuthorID *uint64 `json:"author_id,omitempty"`
+}
+
+// AnchorOutput is the passage of the body a thread is anchored to.
+type AnchorOutput struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Quote string `json:"quote"`
+}
+
+// CommentOutput is a comment. Anchor, Outdated and the resolution are only
+// set on the first comment of a thread.
+type CommentOutput struct {
+	ID         uint64        `json:"id"`
+	ParentID   *uint64       `json:"parent_id,omitempty"`
+	Anchor     *AnchorOutput `json:"anchor,omitempty"`
+	Outdated   bool          `json:"outdated,omitempty"`
+	Commenter  ActorOutput   `json:"commenter"`
+	Body       string        `json:"body"`
+	ResolvedBy *ActorOutput  `json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time    `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+// ThreadOutput is a comment thread with its replies, oldest first.
+type ThreadOutput struct {
+	CommentOutput
+	Replies []CommentOutput `json:"replies"`
+}
+
+// ThreadsOutput is the comment threads of an article in the order of their passages.
+type ThreadsOutput struct {
+	ArticleID uint64         `json:"article_id"`
+	Threads   []ThreadOutput `json:"threads"`
+}
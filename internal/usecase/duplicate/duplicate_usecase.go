@@ -0,0 +1,99 @@
+package duplicate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+const (
+	defaultThreshold = 0.5
+	defaultLimit     = 50
+	maxLimit         = 200
+)
+
+// DuplicateUsecase finds articles that are likely to be duplicates of each other.
+// Duplicate links are rejected when an article is saved, so only titles are searched here.
+type DuplicateUsecase struct {
+	repo       repository.SimilarTitleFinder
+	authorizer Authorizer
+}
+
+// Authorizer decides whether the caller of ctx may perform an action on articles.
+type Authorizer interface {
+	Authorize(ctx context.Context, action auth.Action, resource *auth.Resource) error
+}
+
+// Option configures a DuplicateUsecase.
+type Option func(*DuplicateUsecase)
+
+// WithAuthorizer enables authorization, limiting callers that may not read
+// unpublished articles to pairs of published ones.
+func WithAuthorizer(a Authorizer) Option {
+	return func(uc *DuplicateUsecase) {
+		uc.authorizer = a
+	}
+}
+
+// NewDuplicateUsecase creates a new DuplicateUsecase.
+func NewDuplicateUsecase(repo repository.SimilarTitleFinder, opts ...Option) *DuplicateUsecase {
+	uc := &DuplicateUsecase{repo: repo}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// SimilarTitles lists pairs of non-deleted articles whose titles have a trigram similarity of at least the threshold.
+// Callers that may not read unpublished articles only see pairs of published ones.
+func (uc *DuplicateUsecase) SimilarTitles(ctx context.Context, input SimilarTitlesInput) (*SimilarTitlesOutput, error) {
+	if err := uc.authorize(ctx, auth.ActionReadArticle, nil); err != nil {
+		return nil, err
+	}
+	publishedOnly := false
+	if err := uc.authorize(ctx, auth.ActionReadUnpublishedArticle, nil); errors.Is(err, apperr.ErrForbidden) {
+		publishedOnly = true
+	} else if err != nil {
+		return nil, err
+	}
+	threshold := defaultThreshold
+	if input.Threshold != nil {
+		threshold = *input.Threshold
+	}
+	if threshold <= 0 || threshold > 1 {
+		return nil, fmt.Errorf("%w: threshold must be greater than 0 and at most 1", apperr.ErrInvalidInput)
+	}
+	limit := input.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if limit < 0 || limit > maxLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", apperr.ErrInvalidInput, maxLimit)
+	}
+
+	pairs, err := uc.repo.FindSimilarTitles(ctx, threshold, limit, publishedOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find similar titles: %w", err)
+	}
+
+	output := &SimilarTitlesOutput{Threshold: threshold, Pairs: make([]SimilarTitlePairOutput, 0, len(pairs))}
+	for _, p := range pairs {
+		output.Pairs = append(output.Pairs, SimilarTitlePairOutput{
+			Article:    ArticleTitleOutput{ID: p.ArticleID, Title: p.Title},
+			Other:      ArticleTitleOutput{ID: p.OtherID, Title: p.OtherTitle},
+			Similarity: p.Similarity,
+		})
+	}
+	return output, nil
+}
+
+func (uc *DuplicateUsecase) authorize(ctx context.Context, action auth.Action, resource *auth.Resource) error {
+	if uc.authorizer == nil {
+		return nil
+	}
+	return uc.authorizer.Authorize(ctx, action, resource)
+}
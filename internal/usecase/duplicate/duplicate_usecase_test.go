@@ -0,0 +1,99 @@
+package duplicate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/duplicate"
+)
+
+// stubSimilarTitleFinder は決められた組を返し、受け取った条件を記録する
+type stubSimilarTitleFinder struct {
+	pairs         []repository.SimilarTitlePair
+	err           error
+	threshold     float64
+	limit         int
+	publishedOnly bool
+}
+
+func (f *stubSimilarTitleFinder) FindSimilarTitles(_ context.Context, threshold float64, limit int, publishedOnly bool) ([]repository.SimilarTitlePair, error) {
+	f.threshold, f.limit, f.publishedOnly = threshold, limit, publishedOnly
+	return f.pairs, f.err
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestDuplicateUsecase_SimilarTitles(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("既定の条件で似たタイトルの組を返す", func(t *testing.T) {
+		finder := &stubSimilarTitleFinder{pairs: []repository.SimilarTitlePair{
+			{ArticleID: 1, Title: "Goのエラー処理入門", OtherID: 3, OtherTitle: "Goのエラー処理入門 (改訂版)", Similarity: 0.8},
+		}}
+		uc := duplicate.NewDuplicateUsecase(finder)
+
+		out, err := uc.SimilarTitles(ctx, duplicate.SimilarTitlesInput{})
+		require.NoError(t, err)
+		assert.Equal(t, &duplicate.SimilarTitlesOutput{
+			Threshold: 0.5,
+			Pairs: []duplicate.SimilarTitlePairOutput{{
+				Article:    duplicate.ArticleTitleOutput{ID: 1, Title: "Goのエラー処理入門"},
+				Other:      duplicate.ArticleTitleOutput{ID: 3, Title: "Goのエラー処理入門 (改訂版)"},
+				Similarity: 0.8,
+			}},
+		}, out)
+		assert.Equal(t, 0.5, finder.threshold)
+		assert.Equal(t, 50, finder.limit)
+	})
+
+	t.Run("未公開の記事を参照できない利用者には公開済みの記事どうしの組だけを返す", func(t *testing.T) {
+		finder := &stubSimilarTitleFinder{}
+		uc := duplicate.NewDuplicateUsecase(finder, duplicate.WithAuthorizer(auth.ArticlePolicy))
+
+		_, err := uc.SimilarTitles(auth.WithPrincipal(ctx, &auth.Principal{Name: "viewer", Roles: []string{"viewer"}}), duplicate.SimilarTitlesInput{})
+		require.NoError(t, err)
+		assert.True(t, finder.publishedOnly)
+
+		_, err = uc.SimilarTitles(auth.WithPrincipal(ctx, &auth.Principal{Name: "editor", Roles: []string{"editor"}}), duplicate.SimilarTitlesInput{})
+		require.NoError(t, err)
+		assert.False(t, finder.publishedOnly)
+	})
+
+	t.Run("重複候補がない場合は空の配列を返す", func(t *testing.T) {
+		uc := duplicate.NewDuplicateUsecase(&stubSimilarTitleFinder{})
+
+		out, err := uc.SimilarTitles(ctx, duplicate.SimilarTitlesInput{Threshold: ptr(0.9), Limit: 10})
+		require.NoError(t, err)
+		assert.NotNil(t, out.Pairs)
+		assert.Empty(t, out.Pairs)
+	})
+
+	t.Run("範囲外の条件はErrInvalidInput", func(t *testing.T) {
+		uc := duplicate.NewDuplicateUsecase(&stubSimilarTitleFinder{})
+		for _, input := range []duplicate.SimilarTitlesInput{
+			{Threshold: ptr(0.0)},
+			{Threshold: ptr(1.5)},
+			{Limit: -1},
+			{Limit: 201},
+		} {
+			_, err := uc.SimilarTitles(ctx, input)
+			assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+		}
+	})
+
+	t.Run("リポジトリのエラーを返す", func(t *testing.T) {
+		uc := duplicate.NewDuplicateUsecase(&stubSimilarTitleFinder{err: errors.New("db error")})
+
+		_, err := uc.SimilarTitles(ctx, duplicate.SimilarTitlesInput{})
+		assert.ErrorContains(t, err, "db error")
+	})
+}
@@ -0,0 +1,27 @@
+package duplicate
+
+// SimilarTitlesInput is the input for listing articles with near-duplicate titles.
+// Threshold is the minimum trigram similarity (0 < Threshold <= 1) and defaults when nil.
+type SimilarTitlesInput struct {
+	Threshold *float64
+	Limit     int
+}
+
+// ArticleTitleOutput identifies an article of a near-duplicate pair.
+type ArticleTitleOutput struct {
+	ID    uint64 `json:"id"`
+	Title string `json:"title"`
+}
+
+// SimilarTitlePairOutput is a pair of articles whose titles are similar.
+type SimilarTitlePairOutput struct {
+	Article    ArticleTitleOutput `json:"article"`
+	Other      ArticleTitleOutput `json:"other"`
+	Similarity float64            `json:"similarity"`
+}
+
+// SimilarTitlesOutput lists near-duplicate pairs ordered by similarity, most similar first.
+type SimilarTitlesOutput struct {
+	Threshold float64                  `json:"threshold"`
+	Pairs     []SimilarTitlePairOutput `json:"pairs"`
+}
@@ -0,0 +1,126 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+)
+
+const defaultItemLimit = 50
+
+// FeedInput is the input for building a feed of published articles.
+type FeedInput struct {
+	ProviderType *string
+	Tag          *string
+}
+
+// FeedItem is a single article in a feed.
+type FeedItem struct {
+	ID          uint64
+	Title       string
+	Link        string
+	Categories  []string
+	PublishedAt time.Time
+	UpdatedAt   time.Time
+}
+
+// FeedOutput is a provider-agnostic feed that can be rendered as RSS, Atom or JSON Feed.
+type FeedOutput struct {
+	Items []FeedItem
+	// LastModified is the latest change to any article in the workspace, including
+	// unpublishing and deletion, so that it never moves backwards when an item drops
+	// out of the feed. It is zero when there are no articles.
+	LastModified time.Time
+}
+
+// FeedUsecase builds feeds of published articles.
+type FeedUsecase struct {
+	repo    repository.ArticleRepository
+	changes repository.ArticleChangeTracker
+	limit   int
+}
+
+// NewFeedUsecase creates a new FeedUsecase.
+func NewFeedUsecase(repo repository.ArticleRepository, changes repository.ArticleChangeTracker, limit int) *FeedUsecase {
+	if limit <= 0 {
+		limit = defaultItemLimit
+	}
+	return &FeedUsecase{repo: repo, changes: changes, limit: limit}
+}
+
+// BuildFeed retrieves the latest published, non-deleted articles in the order they
+// were first published. Articles without a link are excluded in the query, since
+// the link is the item URL.
+func (uc *FeedUsecase) BuildFeed(ctx context.Context, input FeedInput) (*FeedOutput, error) {
+	if input.ProviderType != nil {
+		if _, err := vo.NewProviderType(input.ProviderType); err != nil {
+			return nil, fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+		}
+	}
+	var tag *string
+	if input.Tag != nil {
+		t, err := vo.NewTag(*input.Tag)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+		}
+		normalized := t.String()
+		tag = &normalized
+	}
+
+	status := vo.ArticleStatusPublished.String()
+	sortBy := "published_at"
+	sortOrder := "desc"
+	hasLink := true
+	articles, _, err := uc.repo.FindByCriteria(ctx, repository.ArticleQueryCriteria{
+		Status:       &status,
+		ProviderType: input.ProviderType,
+		Tag:          tag,
+		HasLink:      &hasLink,
+		SortBy:       &sortBy,
+		SortOrder:    &sortOrder,
+		Page:         1,
+		Limit:        uc.limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find published articles: %w", err)
+	}
+
+	lastModified, err := uc.changes.LatestChangeAt(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find latest article change: %w", err)
+	}
+
+	output := &FeedOutput{Items: make([]FeedItem, 0, len(articles)), LastModified: lastModified}
+	for _, a := range articles {
+		output.Items = append(output.Items, toFeedItem(a))
+	}
+	return output, nil
+}
+
+// toFeedItem converts an article into a feed item. PublishedAt falls back to
+// CreatedAt for an article whose first publication was not recorded.
+func toFeedItem(a *entity.Article) FeedItem {
+	categories := make([]string, 0, len(a.Tags)+1)
+	if a.ProviderType != nil {
+		categories = append(categories, a.ProviderType.DisplayName())
+	}
+	categories = append(categories, a.TagStrings()...)
+
+	publishedAt := a.CreatedAt
+	if a.PublishedAt != nil {
+		publishedAt = *a.PublishedAt
+	}
+	return FeedItem{
+		ID:          a.ID,
+		Title:       a.Title.String(),
+		Link:        a.Link.String(),
+		Categories:  categories,
+		PublishedAt: publishedAt,
+		UpdatedAt:   a.UpdatedAt,
+	}
+}
@@ -0,0 +1,131 @@
+package feed_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/feed"
+)
+
+type MockArticleRepository struct {
+	mock.Mock
+	repository.ArticleRepository
+}
+
+func (m *MockArticleRepository) FindByCriteria(ctx context.Context, criteria repository.ArticleQueryCriteria) ([]*entity.Article, int, error) {
+	args := m.Called(ctx, criteria)
+	return args.Get(0).([]*entity.Article), args.Get(1).(int), args.Error(2)
+}
+
+func (m *MockArticleRepository) LatestChangeAt(ctx context.Context) (time.Time, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestFeedUsecase_BuildFeed(t *testing.T) {
+	ctx := context.Background()
+	older := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("リンクのある公開済み記事を公開日時の新しい順にフィード項目に変換する", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := feed.NewFeedUsecase(mockRepo, mockRepo, 10)
+
+		qiita := vo.ProviderTypeQiita
+		link := vo.Link("https://qiita.com/items/1")
+		published := older.Add(24 * time.Hour)
+		articles := []*entity.Article{
+			{ID: 1, Title: "A", ProviderType: &qiita, Link: &link, Tags: []vo.Tag{"go"}, PublishedAt: &published, CreatedAt: older, UpdatedAt: newer},
+		}
+		mockRepo.On("FindByCriteria", ctx, repository.ArticleQueryCriteria{
+			Status:    ptr("published"),
+			HasLink:   ptr(true),
+			SortBy:    ptr("published_at"),
+			SortOrder: ptr("desc"),
+			Page:      1,
+			Limit:     10,
+		}).Return(articles, 1, nil)
+		mockRepo.On("LatestChangeAt", ctx).Return(newer, nil)
+
+		out, err := uc.BuildFeed(ctx, feed.FeedInput{})
+
+		require.NoError(t, err)
+		require.Len(t, out.Items, 1)
+		assert.Equal(t, "https://qiita.com/items/1", out.Items[0].Link)
+		assert.Equal(t, []string{"Qiita", "go"}, out.Items[0].Categories)
+		assert.Equal(t, published, out.Items[0].PublishedAt)
+		assert.Equal(t, newer, out.LastModified)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("公開日時が記録されていない記事は作成日時を公開日時にする", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := feed.NewFeedUsecase(mockRepo, mockRepo, 10)
+
+		link := vo.Link("https://zenn.dev/articles/1")
+		mockRepo.On("FindByCriteria", ctx, mock.Anything).
+			Return([]*entity.Article{{ID: 1, Title: "A", Link: &link, CreatedAt: older, UpdatedAt: newer}}, 1, nil)
+		mockRepo.On("LatestChangeAt", ctx).Return(newer, nil)
+
+		out, err := uc.BuildFeed(ctx, feed.FeedInput{})
+
+		require.NoError(t, err)
+		require.Len(t, out.Items, 1)
+		assert.Equal(t, older, out.Items[0].PublishedAt)
+	})
+
+	t.Run("タグは正規化して検索条件に渡す", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := feed.NewFeedUsecase(mockRepo, mockRepo, 10)
+
+		mockRepo.On("FindByCriteria", ctx, mock.MatchedBy(func(c repository.ArticleQueryCriteria) bool {
+			return c.Tag != nil && *c.Tag == "go" && *c.ProviderType == "zenn"
+		})).Return([]*entity.Article{}, 0, nil)
+		mockRepo.On("LatestChangeAt", ctx).Return(time.Time{}, nil)
+
+		out, err := uc.BuildFeed(ctx, feed.FeedInput{ProviderType: ptr("zenn"), Tag: ptr("Go")})
+
+		require.NoError(t, err)
+		assert.Empty(t, out.Items)
+		assert.True(t, out.LastModified.IsZero())
+	})
+
+	t.Run("最新の記事が非公開になっても最終更新日時は戻らない", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := feed.NewFeedUsecase(mockRepo, mockRepo, 10)
+		unpublishedAt := newer.Add(time.Hour)
+
+		link := vo.Link("https://zenn.dev/articles/1")
+		mockRepo.On("FindByCriteria", ctx, mock.Anything).
+			Return([]*entity.Article{{ID: 1, Title: "A", Link: &link, CreatedAt: older, UpdatedAt: older}}, 1, nil)
+		mockRepo.On("LatestChangeAt", ctx).Return(unpublishedAt, nil)
+
+		out, err := uc.BuildFeed(ctx, feed.FeedInput{})
+
+		require.NoError(t, err)
+		assert.Equal(t, unpublishedAt, out.LastModified)
+	})
+
+	t.Run("無効なプロバイダはErrInvalidInput", func(t *testing.T) {
+		mockRepo := new(MockArticleRepository)
+		uc := feed.NewFeedUsecase(mockRepo, mockRepo, 10)
+
+		_, err := uc.BuildFeed(ctx, feed.FeedInput{ProviderType: ptr("unknown")})
+
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindByCriteria")
+	})
+}
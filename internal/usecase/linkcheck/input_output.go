@@ -0,0 +1,41 @@
+package linkcheck
+
+import "time"
+
+// RunOutput is the result of a link checking run.
+type RunOutput struct {
+	Articles int `json:"articles"`
+	Checked  int `json:"checked"`
+	Broken   int `json:"broken"`
+	Skipped  int `json:"skipped"`
+}
+
+// LinkCheckOutput is the latest check result of a URL.
+type LinkCheckOutput struct {
+	URL        string    `json:"url"`
+	Source     string    `json:"source"`
+	Status     string    `json:"status"`
+	StatusCode *int      `json:"status_code,omitempty"`
+	Redirects  []string  `json:"redirects"`
+	Error      *string   `json:"error,omitempty"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// ArticleLinksOutput is the check results of the links of an article.
+type ArticleLinksOutput struct {
+	ArticleID uint64            `json:"article_id"`
+	Links     []LinkCheckOutput `json:"links"`
+}
+
+// BrokenArticleOutput is an article that has broken links.
+type BrokenArticleOutput struct {
+	ArticleID uint64            `json:"article_id"`
+	Title     string            `json:"title"`
+	Links     []LinkCheckOutput `json:"links"`
+}
+
+// ReportOutput is the report of broken links grouped by article.
+type ReportOutput struct {
+	BrokenLinks int                   `json:"broken_links"`
+	Articles    []BrokenArticleOutput `json:"articles"`
+}
@@ -0,0 +1,250 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+)
+
+const defaultConcurrency = 4
+
+// Result is the outcome of checking a single URL.
+// A non-empty SkipReason means the URL was intentionally not requested, e.g. disallowed by robots.txt.
+type Result struct {
+	StatusCode int
+	Redirects  []string
+	SkipReason string
+	Err        error
+}
+
+// Checker requests a URL and reports its final status.
+// Implementations are expected to be safe for concurrent use and to apply per-host rate limits.
+type Checker interface {
+	Check(ctx context.Context, url string) Result
+}
+
+// LinkCheckUsecase checks the publication links and body URLs of articles.
+type LinkCheckUsecase struct {
+	repo        repository.LinkCheckRepository
+	articles    repository.ArticleRepository
+	checker     Checker
+	concurrency int
+	now         func() time.Time
+}
+
+// Option configures a LinkCheckUsecase.
+type Option func(*LinkCheckUsecase)
+
+// WithConcurrency bounds the number of URLs checked at the same time.
+func WithConcurrency(n int) Option {
+	return func(uc *LinkCheckUsecase) {
+		if n > 0 {
+			uc.concurrency = n
+		}
+	}
+}
+
+// WithClock overrides the clock used for the checked time.
+func WithClock(now func() time.Time) Option {
+	return func(uc *LinkCheckUsecase) {
+		uc.now = now
+	}
+}
+
+// NewLinkCheckUsecase creates a new LinkCheckUsecase.
+func NewLinkCheckUsecase(repo repository.LinkCheckRepository, articles repository.ArticleRepository, checker Checker, opts ...Option) *LinkCheckUsecase {
+	uc := &LinkCheckUsecase{
+		repo:        repo,
+		articles:    articles,
+		checker:     checker,
+		concurrency: defaultConcurrency,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// linkTarget is a URL found in an article.
+type linkTarget struct {
+	url    string
+	source entity.LinkSource
+}
+
+// Run checks every link of the articles that are not deleted and replaces the stored results.
+// A URL shared by several articles is requested only once per run.
+func (uc *LinkCheckUsecase) Run(ctx context.Context) (*RunOutput, error) {
+	articles, err := uc.articles.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find articles to check: %w", err)
+	}
+
+	targets := make(map[uint64][]linkTarget, len(articles))
+	var urls []string
+	seen := make(map[string]bool)
+	for _, a := range articles {
+		targets[a.ID] = articleLinks(a)
+		for _, t := range targets[a.ID] {
+			if !seen[t.url] {
+				seen[t.url] = true
+				urls = append(urls, t.url)
+			}
+		}
+	}
+
+	results := uc.checkAll(ctx, urls)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	out := &RunOutput{Articles: len(articles)}
+	checkedAt := uc.now()
+	for _, a := range articles {
+		checks := make([]*entity.LinkCheck, 0, len(targets[a.ID]))
+		for _, t := range targets[a.ID] {
+			check, err := newLinkCheck(a.ID, t, results[t.url], checkedAt)
+			if err != nil {
+				return nil, err
+			}
+			switch check.Status {
+			case entity.LinkCheckBroken:
+				out.Broken++
+			case entity.LinkCheckSkipped:
+				out.Skipped++
+			}
+			out.Checked++
+			checks = append(checks, check)
+		}
+		if err := uc.repo.ReplaceForArticle(ctx, a.ID, checks); err != nil {
+			return nil, fmt.Errorf("failed to save link checks of article %d: %w", a.ID, err)
+		}
+	}
+	return out, nil
+}
+
+// ArticleLinks returns the latest check results of an article.
+func (uc *LinkCheckUsecase) ArticleLinks(ctx context.Context, articleID uint64) (*ArticleLinksOutput, error) {
+	if _, err := uc.articles.FindByID(ctx, articleID); err != nil {
+		return nil, err
+	}
+	checks, err := uc.repo.FindByArticleID(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find link checks of article %d: %w", articleID, err)
+	}
+	return &ArticleLinksOutput{ArticleID: articleID, Links: newLinkCheckOutputs(checks)}, nil
+}
+
+// Report returns the broken links grouped by article.
+func (uc *LinkCheckUsecase) Report(ctx context.Context) (*ReportOutput, error) {
+	checks, err := uc.repo.FindBroken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find broken links: %w", err)
+	}
+
+	out := &ReportOutput{Articles: []BrokenArticleOutput{}}
+	for _, c := range checks {
+		n := len(out.Articles)
+		if n == 0 || out.Articles[n-1].ArticleID != c.ArticleID {
+			a, err := uc.articles.FindByID(ctx, c.ArticleID)
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			out.Articles = append(out.Articles, BrokenArticleOutput{ArticleID: a.ID, Title: a.Title.String(), Links: []LinkCheckOutput{}})
+			n++
+		}
+		out.Articles[n-1].Links = append(out.Articles[n-1].Links, newLinkCheckOutput(c))
+		out.BrokenLinks++
+	}
+	return out, nil
+}
+
+// checkAll checks the URLs with bounded concurrency.
+func (uc *LinkCheckUsecase) checkAll(ctx context.Context, urls []string) map[string]Result {
+	results := make(map[string]Result, len(urls))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, uc.concurrency)
+	for _, u := range urls {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return results
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(u string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			r := uc.checker.Check(ctx, u)
+			mu.Lock()
+			results[u] = r
+			mu.Unlock()
+		}(u)
+	}
+	wg.Wait()
+	return results
+}
+
+// articleLinks returns the publication links and body URLs of an article without duplicates.
+// A URL that is both a publication link and in the body is reported as a publication link.
+func articleLinks(a *entity.Article) []linkTarget {
+	var targets []linkTarget
+	seen := make(map[string]bool)
+	for _, p := range a.Publications {
+		if p.Link != nil && !seen[p.Link.String()] {
+			seen[p.Link.String()] = true
+			targets = append(targets, linkTarget{url: p.Link.String(), source: entity.LinkSourcePublication})
+		}
+	}
+	for _, u := range a.Metadata().Links {
+		if !seen[u] {
+			seen[u] = true
+			targets = append(targets, linkTarget{url: u, source: entity.LinkSourceBody})
+		}
+	}
+	return targets
+}
+
+func newLinkCheck(articleID uint64, t linkTarget, r Result, checkedAt time.Time) (*entity.LinkCheck, error) {
+	if r.SkipReason != "" {
+		return entity.NewSkippedLinkCheck(articleID, t.url, t.source, r.SkipReason, checkedAt), nil
+	}
+	var errMsg string
+	if r.Err != nil {
+		errMsg = r.Err.Error()
+	}
+	check, err := entity.NewLinkCheck(articleID, t.url, t.source, r.StatusCode, r.Redirects, errMsg, checkedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record link check of article %d: %w", articleID, err)
+	}
+	return check, nil
+}
+
+func newLinkCheckOutput(c *entity.LinkCheck) LinkCheckOutput {
+	return LinkCheckOutput{
+		URL:        c.URL,
+		Source:     c.Source.String(),
+		Status:     c.Status.String(),
+		StatusCode: c.StatusCode,
+		Redirects:  c.Redirects,
+		Error:      c.LastError,
+		CheckedAt:  c.CheckedAt,
+	}
+}
+
+func newLinkCheckOutputs(checks []*entity.LinkCheck) []LinkCheckOutput {
+	outputs := make([]LinkCheckOutput, 0, len(checks))
+	for _, c := range checks {
+		outputs = append(outputs, newLinkCheckOutput(c))
+	}
+	return outputs
+}
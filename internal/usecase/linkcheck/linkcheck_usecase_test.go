@@ -0,0 +1,199 @@
+package linkcheck_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/linkcheck"
+)
+
+type MockLinkCheckRepository struct {
+	mock.Mock
+}
+
+func (m *MockLinkCheckRepository) ReplaceForArticle(ctx context.Context, articleID uint64, checks []*entity.LinkCheck) error {
+	args := m.Called(ctx, articleID, checks)
+	return args.Error(0)
+}
+
+func (m *MockLinkCheckRepository) FindByArticleID(ctx context.Context, articleID uint64) ([]*entity.LinkCheck, error) {
+	args := m.Called(ctx, articleID)
+	return args.Get(0).([]*entity.LinkCheck), args.Error(1)
+}
+
+func (m *MockLinkCheckRepository) FindBroken(ctx context.Context) ([]*entity.LinkCheck, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*entity.LinkCheck), args.Error(1)
+}
+
+// stubArticleRepository は保持している記事だけが存在する
+type stubArticleRepository struct {
+	repository.ArticleRepository
+	articles []*entity.Article
+}
+
+func (r stubArticleRepository) FindAll(_ context.Context) ([]*entity.Article, error) {
+	return r.articles, nil
+}
+
+func (r stubArticleRepository) FindByID(_ context.Context, id uint64) (*entity.Article, error) {
+	for _, a := range r.articles {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, repository.ErrArticleNotFound
+}
+
+// stubChecker はURLごとに決められた結果を返し、リクエストされたURLを記録する
+type stubChecker struct {
+	mu        sync.Mutex
+	results   map[string]linkcheck.Result
+	requested []string
+}
+
+func (c *stubChecker) Check(_ context.Context, url string) linkcheck.Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requested = append(c.requested, url)
+	return c.results[url]
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func newTestArticle(t *testing.T, id uint64, title string, opts ...entity.ArticleOption) *entity.Article {
+	t.Helper()
+	a, err := entity.NewArticle(title, "draft", opts...)
+	require.NoError(t, err)
+	a.ID = id
+	return a
+}
+
+func TestLinkCheckUsecase_Run(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("投稿先のリンクと本文中のURLをチェックして記事ごとに保存する", func(t *testing.T) {
+		articles := []*entity.Article{
+			newTestArticle(t, 1, "記事1",
+				entity.WithProviderType(ptr("qiita")),
+				entity.WithLink(ptr("https://qiita.com/u/items/a")),
+				entity.WithBody(ptr("参考: https://example.com/ok と https://example.com/gone")),
+			),
+			newTestArticle(t, 2, "記事2",
+				entity.WithBody(ptr("https://example.com/ok https://example.com/private")),
+			),
+		}
+		checker := &stubChecker{results: map[string]linkcheck.Result{
+			"https://qiita.com/u/items/a": {StatusCode: 200, Redirects: []string{"https://qiita.com/u/items/a?x=1"}},
+			"https://example.com/ok":      {StatusCode: 200},
+			"https://example.com/gone":    {StatusCode: 404},
+			"https://example.com/private": {SkipReason: "disallowed by robots.txt"},
+		}}
+		mockRepo := new(MockLinkCheckRepository)
+		uc := linkcheck.NewLinkCheckUsecase(mockRepo, stubArticleRepository{articles: articles}, checker,
+			linkcheck.WithConcurrency(2),
+			linkcheck.WithClock(func() time.Time { return now }),
+		)
+
+		var saved = map[uint64][]*entity.LinkCheck{}
+		mockRepo.On("ReplaceForArticle", ctx, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				saved[args.Get(1).(uint64)] = args.Get(2).([]*entity.LinkCheck)
+			}).
+			Return(nil)
+
+		out, err := uc.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &linkcheck.RunOutput{Articles: 2, Checked: 5, Broken: 1, Skipped: 1}, out)
+		// 複数の記事に含まれるURLは1回だけリクエストする
+		assert.ElementsMatch(t, []string{
+			"https://qiita.com/u/items/a", "https://example.com/ok", "https://example.com/gone", "https://example.com/private",
+		}, checker.requested)
+
+		require.Len(t, saved[1], 3)
+		assert.Equal(t, entity.LinkSourcePublication, saved[1][0].Source)
+		assert.Equal(t, entity.LinkCheckOK, saved[1][0].Status)
+		assert.Equal(t, []string{"https://qiita.com/u/items/a?x=1"}, saved[1][0].Redirects)
+		assert.Equal(t, now, saved[1][0].CheckedAt)
+		assert.Equal(t, entity.LinkSourceBody, saved[1][2].Source)
+		assert.Equal(t, entity.LinkCheckBroken, saved[1][2].Status)
+		assert.Equal(t, 404, *saved[1][2].StatusCode)
+
+		require.Len(t, saved[2], 2)
+		assert.Equal(t, entity.LinkCheckSkipped, saved[2][1].Status)
+		assert.Equal(t, "disallowed by robots.txt", *saved[2][1].LastError)
+	})
+
+	t.Run("応答がないURLはエラーを記録してリンク切れとする", func(t *testing.T) {
+		articles := []*entity.Article{newTestArticle(t, 1, "記事1", entity.WithBody(ptr("https://down.example.com/")))}
+		checker := &stubChecker{results: map[string]linkcheck.Result{
+			"https://down.example.com/": {Err: errors.New("connection refused")},
+		}}
+		mockRepo := new(MockLinkCheckRepository)
+		uc := linkcheck.NewLinkCheckUsecase(mockRepo, stubArticleRepository{articles: articles}, checker)
+
+		mockRepo.On("ReplaceForArticle", ctx, uint64(1), mock.MatchedBy(func(checks []*entity.LinkCheck) bool {
+			return len(checks) == 1 && checks[0].IsBroken() && checks[0].StatusCode == nil && *checks[0].LastError == "connection refused"
+		})).Return(nil)
+
+		out, err := uc.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, out.Broken)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestLinkCheckUsecase_Report(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("リンク切れを記事ごとにまとめる", func(t *testing.T) {
+		articles := []*entity.Article{newTestArticle(t, 1, "記事1"), newTestArticle(t, 3, "記事3")}
+		mockRepo := new(MockLinkCheckRepository)
+		uc := linkcheck.NewLinkCheckUsecase(mockRepo, stubArticleRepository{articles: articles}, &stubChecker{})
+
+		broken := func(articleID uint64, url string) *entity.LinkCheck {
+			c, err := entity.NewLinkCheck(articleID, url, entity.LinkSourceBody, 404, nil, "", now)
+			require.NoError(t, err)
+			return c
+		}
+		mockRepo.On("FindBroken", ctx).Return([]*entity.LinkCheck{
+			broken(1, "https://example.com/a"),
+			broken(1, "https://example.com/b"),
+			// 削除済みの記事は含めない
+			broken(2, "https://example.com/c"),
+			broken(3, "https://example.com/d"),
+		}, nil)
+
+		out, err := uc.Report(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, out.BrokenLinks)
+		require.Len(t, out.Articles, 2)
+		assert.Equal(t, "記事1", out.Articles[0].Title)
+		assert.Len(t, out.Articles[0].Links, 2)
+		assert.Equal(t, uint64(3), out.Articles[1].ArticleID)
+	})
+}
+
+func TestLinkCheckUsecase_ArticleLinks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("存在しない記事はErrNotFoundを返す", func(t *testing.T) {
+		uc := linkcheck.NewLinkCheckUsecase(new(MockLinkCheckRepository), stubArticleRepository{}, &stubChecker{})
+
+		_, err := uc.ArticleLinks(ctx, 99)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
@@ -0,0 +1,38 @@
+package linkpreview
+
+import (
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+)
+
+// RefreshOutput is the result of a refresh run.
+type RefreshOutput struct {
+	Fetched int `json:"fetched"`
+	Failed  int `json:"failed"`
+}
+
+// PreviewOutput is the stored Open Graph data of a URL together with the outcome of the last fetch.
+type PreviewOutput struct {
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Image       string    `json:"image"`
+	SiteName    string    `json:"site_name"`
+	Status      string    `json:"status"`
+	Error       *string   `json:"error,omitempty"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+func newPreviewOutput(p *entity.LinkPreview) *PreviewOutput {
+	return &PreviewOutput{
+		URL:         p.URL,
+		Title:       p.OpenGraph.Title,
+		Description: p.OpenGraph.Description,
+		Image:       p.OpenGraph.Image,
+		SiteName:    p.OpenGraph.SiteName,
+		Status:      p.Status.String(),
+		Error:       p.LastError,
+		FetchedAt:   p.FetchedAt,
+	}
+}
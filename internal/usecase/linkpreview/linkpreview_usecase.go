@@ -0,0 +1,149 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+)
+
+const (
+	defaultTTL        = 7 * 24 * time.Hour
+	defaultRetryAfter = 24 * time.Hour
+	defaultBatchSize  = 50
+)
+
+// Fetcher fetches the Open Graph data of a page.
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) (entity.OpenGraph, error)
+}
+
+// LinkPreviewUsecase fetches and stores the Open Graph data of article links
+// so that clients can render link cards without requesting external sites.
+type LinkPreviewUsecase struct {
+	repo       repository.LinkPreviewRepository
+	articles   repository.ArticleRepository
+	fetcher    Fetcher
+	ttl        time.Duration
+	retryAfter time.Duration
+	batchSize  int
+	now        func() time.Time
+}
+
+// Option configures a LinkPreviewUsecase.
+type Option func(*LinkPreviewUsecase)
+
+// WithRefreshPolicy sets how long a fetched preview is kept before it is fetched again,
+// and how long to wait before retrying a failed fetch.
+func WithRefreshPolicy(ttl, retryAfter time.Duration) Option {
+	return func(uc *LinkPreviewUsecase) {
+		if ttl > 0 {
+			uc.ttl = ttl
+		}
+		if retryAfter > 0 {
+			uc.retryAfter = retryAfter
+		}
+	}
+}
+
+// WithBatchSize bounds the number of previews fetched per refresh run.
+func WithBatchSize(n int) Option {
+	return func(uc *LinkPreviewUsecase) {
+		if n > 0 {
+			uc.batchSize = n
+		}
+	}
+}
+
+// WithClock overrides the clock used for the fetched time and the refresh policy.
+func WithClock(now func() time.Time) Option {
+	return func(uc *LinkPreviewUsecase) {
+		uc.now = now
+	}
+}
+
+// NewLinkPreviewUsecase creates a new LinkPreviewUsecase.
+func NewLinkPreviewUsecase(repo repository.LinkPreviewRepository, articles repository.ArticleRepository, fetcher Fetcher, opts ...Option) *LinkPreviewUsecase {
+	uc := &LinkPreviewUsecase{
+		repo:       repo,
+		articles:   articles,
+		fetcher:    fetcher,
+		ttl:        defaultTTL,
+		retryAfter: defaultRetryAfter,
+		batchSize:  defaultBatchSize,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// Refresh fetches the previews of article links that have never been fetched or are due by the refresh policy.
+// A failed fetch is recorded on the preview and does not abort the run.
+func (uc *LinkPreviewUsecase) Refresh(ctx context.Context) (*RefreshOutput, error) {
+	now := uc.now()
+	targets, err := uc.repo.FindRefreshTargets(ctx, now.Add(-uc.ttl), now.Add(-uc.retryAfter), uc.batchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find link previews to refresh: %w", err)
+	}
+
+	out := &RefreshOutput{}
+	for _, p := range targets {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := uc.fetch(ctx, p); err != nil {
+			log.Printf("link preview: failed to fetch %s: %v", p.URL, err)
+			out.Failed++
+		} else {
+			out.Fetched++
+		}
+		if err := uc.repo.Save(ctx, p); err != nil {
+			return nil, fmt.Errorf("failed to save link preview of %s: %w", p.URL, err)
+		}
+	}
+	return out, nil
+}
+
+// RefreshArticle fetches the preview of an article link immediately regardless of the refresh policy.
+// The outcome, including a failed fetch, is returned as the stored preview.
+func (uc *LinkPreviewUsecase) RefreshArticle(ctx context.Context, articleID uint64) (*PreviewOutput, error) {
+	a, err := uc.articles.FindByID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if a.Link == nil {
+		return nil, fmt.Errorf("%w: article %d has no link", apperr.ErrInvalidInput, articleID)
+	}
+
+	p, err := uc.repo.FindByURL(ctx, a.Link.String())
+	if errors.Is(err, repository.ErrLinkPreviewNotFound) {
+		p, err = entity.NewLinkPreview(a.Link.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find link preview of article %d: %w", articleID, err)
+	}
+
+	_ = uc.fetch(ctx, p)
+	if err := uc.repo.Save(ctx, p); err != nil {
+		return nil, fmt.Errorf("failed to save link preview of article %d: %w", articleID, err)
+	}
+	return newPreviewOutput(p), nil
+}
+
+// fetch fetches the Open Graph data and records the outcome on the preview.
+func (uc *LinkPreviewUsecase) fetch(ctx context.Context, p *entity.LinkPreview) error {
+	og, err := uc.fetcher.Fetch(ctx, p.URL)
+	if err != nil {
+		p.Failed(err.Error(), uc.now())
+		return err
+	}
+	p.Fetched(og, uc.now())
+	return nil
+}
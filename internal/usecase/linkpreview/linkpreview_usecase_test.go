@@ -0,0 +1,195 @@
+package linkpreview_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/linkpreview"
+)
+
+type MockLinkPreviewRepository struct {
+	mock.Mock
+}
+
+func (m *MockLinkPreviewRepository) FindByURL(ctx context.Context, url string) (*entity.LinkPreview, error) {
+	args := m.Called(ctx, url)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.LinkPreview), args.Error(1)
+}
+
+func (m *MockLinkPreviewRepository) FindRefreshTargets(ctx context.Context, fetchedBefore, failedBefore time.Time, limit int) ([]*entity.LinkPreview, error) {
+	args := m.Called(ctx, fetchedBefore, failedBefore, limit)
+	return args.Get(0).([]*entity.LinkPreview), args.Error(1)
+}
+
+func (m *MockLinkPreviewRepository) Save(ctx context.Context, preview *entity.LinkPreview) error {
+	args := m.Called(ctx, preview)
+	return args.Error(0)
+}
+
+// stubArticleRepository は保持している記事だけが存在する
+type stubArticleRepository struct {
+	repository.ArticleRepository
+	articles []*entity.Article
+}
+
+func (r stubArticleRepository) FindByID(_ context.Context, id uint64) (*entity.Article, error) {
+	for _, a := range r.articles {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, repository.ErrArticleNotFound
+}
+
+// stubFetcher はURLごとに決められたOGPを返し、登録されていないURLはエラーにする
+type stubFetcher struct {
+	pages     map[string]entity.OpenGraph
+	requested []string
+}
+
+func (f *stubFetcher) Fetch(_ context.Context, url string) (entity.OpenGraph, error) {
+	f.requested = append(f.requested, url)
+	og, ok := f.pages[url]
+	if !ok {
+		return entity.OpenGraph{}, errors.New("unexpected status: 404")
+	}
+	return og, nil
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestLinkPreviewUsecase_Refresh(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("更新方針に従って対象を探し、取得の結果をURLごとに保存する", func(t *testing.T) {
+		stale := &entity.LinkPreview{
+			ID:        1,
+			URL:       "https://example.com/stale",
+			OpenGraph: entity.OpenGraph{Title: "古いタイトル", Image: "https://example.com/old.png"},
+			Status:    entity.LinkPreviewFetched,
+			FetchedAt: now.Add(-8 * 24 * time.Hour),
+		}
+		fetcher := &stubFetcher{pages: map[string]entity.OpenGraph{
+			"https://example.com/new": {Title: "新しい記事", SiteName: "Example"},
+		}}
+		mockRepo := new(MockLinkPreviewRepository)
+		mockRepo.On("FindRefreshTargets", ctx, now.Add(-48*time.Hour), now.Add(-time.Hour), 10).
+			Return([]*entity.LinkPreview{{URL: "https://example.com/new"}, stale}, nil)
+		mockRepo.On("Save", ctx, mock.Anything).Return(nil)
+
+		uc := linkpreview.NewLinkPreviewUsecase(mockRepo, stubArticleRepository{}, fetcher,
+			linkpreview.WithRefreshPolicy(48*time.Hour, time.Hour),
+			linkpreview.WithBatchSize(10),
+			linkpreview.WithClock(func() time.Time { return now }),
+		)
+		out, err := uc.Refresh(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &linkpreview.RefreshOutput{Fetched: 1, Failed: 1}, out)
+		assert.Equal(t, []string{"https://example.com/new", "https://example.com/stale"}, fetcher.requested)
+
+		saved := mockRepo.Calls[1].Arguments.Get(1).(*entity.LinkPreview)
+		assert.Equal(t, entity.LinkPreviewFetched, saved.Status)
+		assert.Equal(t, entity.OpenGraph{Title: "新しい記事", SiteName: "Example"}, saved.OpenGraph)
+		assert.Equal(t, now, saved.FetchedAt)
+
+		// 取得に失敗しても以前のOGPは残す
+		assert.Equal(t, entity.LinkPreviewFailed, stale.Status)
+		assert.Equal(t, ptr("unexpected status: 404"), stale.LastError)
+		assert.Equal(t, "古いタイトル", stale.OpenGraph.Title)
+		assert.Equal(t, now, stale.FetchedAt)
+	})
+
+	t.Run("保存に失敗した場合はエラーを返す", func(t *testing.T) {
+		mockRepo := new(MockLinkPreviewRepository)
+		mockRepo.On("FindRefreshTargets", ctx, mock.Anything, mock.Anything, mock.Anything).
+			Return([]*entity.LinkPreview{{URL: "https://example.com/new"}}, nil)
+		mockRepo.On("Save", ctx, mock.Anything).Return(errors.New("db error"))
+
+		uc := linkpreview.NewLinkPreviewUsecase(mockRepo, stubArticleRepository{}, &stubFetcher{})
+		_, err := uc.Refresh(ctx)
+		assert.ErrorContains(t, err, "db error")
+	})
+}
+
+func TestLinkPreviewUsecase_RefreshArticle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	newArticle := func(t *testing.T, id uint64, opts ...entity.ArticleOption) *entity.Article {
+		t.Helper()
+		a, err := entity.NewArticle("記事", "draft", opts...)
+		require.NoError(t, err)
+		a.ID = id
+		return a
+	}
+	articles := stubArticleRepository{articles: []*entity.Article{
+		newArticle(t, 1, entity.WithProviderType(ptr("qiita")), entity.WithLink(ptr("https://qiita.com/u/items/a"))),
+		newArticle(t, 2),
+	}}
+
+	t.Run("未取得のリンクのOGPを取得して保存する", func(t *testing.T) {
+		fetcher := &stubFetcher{pages: map[string]entity.OpenGraph{
+			"https://qiita.com/u/items/a": {Title: "Qiitaの記事", SiteName: "Qiita"},
+		}}
+		mockRepo := new(MockLinkPreviewRepository)
+		mockRepo.On("FindByURL", ctx, "https://qiita.com/u/items/a").Return(nil, repository.ErrLinkPreviewNotFound)
+		mockRepo.On("Save", ctx, mock.Anything).Return(nil)
+
+		uc := linkpreview.NewLinkPreviewUsecase(mockRepo, articles, fetcher, linkpreview.WithClock(func() time.Time { return now }))
+		out, err := uc.RefreshArticle(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, &linkpreview.PreviewOutput{
+			URL:       "https://qiita.com/u/items/a",
+			Title:     "Qiitaの記事",
+			SiteName:  "Qiita",
+			Status:    "fetched",
+			FetchedAt: now,
+		}, out)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("取得に失敗した場合も結果を保存して返す", func(t *testing.T) {
+		mockRepo := new(MockLinkPreviewRepository)
+		mockRepo.On("FindByURL", ctx, "https://qiita.com/u/items/a").Return(&entity.LinkPreview{
+			ID:        3,
+			URL:       "https://qiita.com/u/items/a",
+			OpenGraph: entity.OpenGraph{Title: "以前のタイトル"},
+			Status:    entity.LinkPreviewFetched,
+		}, nil)
+		mockRepo.On("Save", ctx, mock.Anything).Return(nil)
+
+		uc := linkpreview.NewLinkPreviewUsecase(mockRepo, articles, &stubFetcher{}, linkpreview.WithClock(func() time.Time { return now }))
+		out, err := uc.RefreshArticle(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "failed", out.Status)
+		assert.Equal(t, ptr("unexpected status: 404"), out.Error)
+		assert.Equal(t, "以前のタイトル", out.Title)
+	})
+
+	t.Run("リンクのない記事はErrInvalidInputを返す", func(t *testing.T) {
+		uc := linkpreview.NewLinkPreviewUsecase(new(MockLinkPreviewRepository), articles, &stubFetcher{})
+		_, err := uc.RefreshArticle(ctx, 2)
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+	})
+
+	t.Run("存在しない記事はErrNotFoundを返す", func(t *testing.T) {
+		uc := linkpreview.NewLinkPreviewUsecase(new(MockLinkPreviewRepository), articles, &stubFetcher{})
+		_, err := uc.RefreshArticle(ctx, 99)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import "time"
+
+// Counters are the engagement counters of a single publication.
+// Providers that do not have a counter leave it zero.
+type Counters struct {
+	Likes     int `json:"likes"`
+	Stocks    int `json:"stocks"`
+	Bookmarks int `json:"bookmarks"`
+	Total     int `json:"total"`
+}
+
+// CollectOutput is the result of a collection run.
+type CollectOutput struct {
+	Collected int `json:"collected"`
+	Skipped   int `json:"skipped"`
+	Failed    int `json:"failed"`
+}
+
+// ArticleMetricsInput is the input for retrieving the metrics of an article.
+// From defaults to 30 days before To, and To defaults to now.
+type ArticleMetricsInput struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// SnapshotOutput is a single collected snapshot.
+type SnapshotOutput struct {
+	PublicationID uint64    `json:"publication_id"`
+	ProviderType  string    `json:"provider_type"`
+	Likes         int       `json:"likes"`
+	Stocks        int       `json:"stocks"`
+	Bookmarks     int       `json:"bookmarks"`
+	CollectedAt   time.Time `json:"collected_at"`
+}
+
+// ArticleMetricsOutput is the time series of an article within the period.
+// Latest sums the latest snapshot of each publication and Growth is the increase within the period.
+type ArticleMetricsOutput struct {
+	ArticleID uint64           `json:"article_id"`
+	From      time.Time        `json:"from"`
+	To        time.Time        `json:"to"`
+	Latest    Counters         `json:"latest"`
+	Growth    Counters         `json:"growth"`
+	Snapshots []SnapshotOutput `json:"snapshots"`
+}
+
+// LeaderboardInput is the input for ranking articles by the growth of their metrics.
+type LeaderboardInput struct {
+	From   *time.Time
+	To     *time.Time
+	SortBy *string `validate:"omitempty,oneof=likes stocks bookmarks total"`
+	Limit  int     `validate:"omitempty,gte=1,lte=100"`
+}
+
+// LeaderboardEntry is a ranked article.
+type LeaderboardEntry struct {
+	Rank      int      `json:"rank"`
+	ArticleID uint64   `json:"article_id"`
+	Title     string   `json:"title"`
+	Growth    Counters `json:"growth"`
+}
+
+// LeaderboardOutput is the ranking of articles within the period.
+type LeaderboardOutput struct {
+	From    time.Time          `json:"from"`
+	To      time.Time          `json:"to"`
+	SortBy  string             `json:"sort_by"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
@@ -0,0 +1,283 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+const (
+	defaultPeriod           = 30 * 24 * time.Hour
+	defaultLeaderboardLimit = 20
+	maxLeaderboardLimit     = 100
+	defaultLeaderboardSort  = "total"
+)
+
+var leaderboardSortKeys = map[string]bool{"likes": true, "stocks": true, "bookmarks": true, "total": true}
+
+// ErrTargetNotSupported is returned by a Fetcher when the publication cannot be identified on the provider,
+// e.g. the link does not point to an article. Such targets are skipped instead of counted as failures.
+var ErrTargetNotSupported = errors.New("metric target is not supported")
+
+// Fetcher fetches the current engagement counters of a publication from a provider.
+type Fetcher interface {
+	Fetch(ctx context.Context, target repository.MetricTarget) (Counters, error)
+}
+
+// Authorizer decides whether the caller of ctx may perform an action on articles.
+type Authorizer interface {
+	Authorize(ctx context.Context, action auth.Action, resource *auth.Resource) error
+}
+
+// MetricsUsecase collects engagement metrics from providers and aggregates them.
+type MetricsUsecase struct {
+	repo       repository.ArticleMetricRepository
+	articles   repository.ArticleRepository
+	fetchers   map[vo.ProviderType]Fetcher
+	authorizer Authorizer
+	now        func() time.Time
+}
+
+// Option configures a MetricsUsecase.
+type Option func(*MetricsUsecase)
+
+// WithFetcher registers the fetcher for a provider. Publications on providers without a fetcher are skipped.
+func WithFetcher(providerType vo.ProviderType, f Fetcher) Option {
+	return func(uc *MetricsUsecase) {
+		uc.fetchers[providerType] = f
+	}
+}
+
+// WithAuthorizer enables authorization of reading metrics. Callers that may
+// not read unpublished articles only see the metrics of published and unlisted
+// articles, and only published ones on the leaderboard.
+func WithAuthorizer(a Authorizer) Option {
+	return func(uc *MetricsUsecase) {
+		uc.authorizer = a
+	}
+}
+
+// WithClock overrides the clock used for snapshots and default periods.
+func WithClock(now func() time.Time) Option {
+	return func(uc *MetricsUsecase) {
+		uc.now = now
+	}
+}
+
+// NewMetricsUsecase creates a new MetricsUsecase.
+func NewMetricsUsecase(repo repository.ArticleMetricRepository, articles repository.ArticleRepository, opts ...Option) *MetricsUsecase {
+	uc := &MetricsUsecase{
+		repo:     repo,
+		articles: articles,
+		fetchers: make(map[vo.ProviderType]Fetcher),
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// Collect fetches the counters of every linked publication and stores them as snapshots.
+// A failure on one publication is logged and does not stop the others.
+func (uc *MetricsUsecase) Collect(ctx context.Context) (*CollectOutput, error) {
+	targets, err := uc.repo.FindTargets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find metric targets: %w", err)
+	}
+
+	out := &CollectOutput{}
+	collectedAt := uc.now()
+	snapshots := make([]*entity.ArticleMetric, 0, len(targets))
+	for _, target := range targets {
+		fetcher, ok := uc.fetchers[target.ProviderType]
+		if !ok {
+			out.Skipped++
+			continue
+		}
+		counters, err := fetcher.Fetch(ctx, target)
+		if errors.Is(err, ErrTargetNotSupported) {
+			out.Skipped++
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			log.Printf("metrics: failed to fetch publication %d of article %d: %v", target.PublicationID, target.ArticleID, err)
+			out.Failed++
+			continue
+		}
+		snapshot, err := entity.NewArticleMetric(
+			target.ArticleID,
+			target.PublicationID,
+			target.ProviderType,
+			counters.Likes,
+			counters.Stocks,
+			counters.Bookmarks,
+			collectedAt,
+		)
+		if err != nil {
+			log.Printf("metrics: invalid counters for publication %d of article %d: %v", target.PublicationID, target.ArticleID, err)
+			out.Failed++
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if len(snapshots) > 0 {
+		if err := uc.repo.Save(ctx, snapshots); err != nil {
+			return nil, fmt.Errorf("failed to save article metrics: %w", err)
+		}
+	}
+	out.Collected = len(snapshots)
+	return out, nil
+}
+
+// ArticleMetrics returns the snapshots of an article within the period together with its growth.
+// Like reading the article itself, an article other than a published or unlisted
+// one requires ActionReadUnpublishedArticle.
+func (uc *MetricsUsecase) ArticleMetrics(ctx context.Context, articleID uint64, input ArticleMetricsInput) (*ArticleMetricsOutput, error) {
+	if err := uc.authorize(ctx, auth.ActionReadArticle, nil); err != nil {
+		return nil, err
+	}
+	from, to, err := uc.period(input.From, input.To)
+	if err != nil {
+		return nil, err
+	}
+	article, err := uc.articles.FindByID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if !article.Status.IsPublished() && !article.Status.IsUnlisted() {
+		if err := uc.authorize(ctx, auth.ActionReadUnpublishedArticle, &auth.Resource{OwnerID: article.AuthorID}); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshots, err := uc.repo.FindByArticleID(ctx, articleID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find metrics of article %d: %w", articleID, err)
+	}
+
+	out := &ArticleMetricsOutput{
+		ArticleID: articleID,
+		From:      from,
+		To:        to,
+		Snapshots: make([]SnapshotOutput, 0, len(snapshots)),
+	}
+	first := make(map[uint64]*entity.ArticleMetric)
+	last := make(map[uint64]*entity.ArticleMetric)
+	for _, s := range snapshots {
+		if _, ok := first[s.PublicationID]; !ok {
+			first[s.PublicationID] = s
+		}
+		last[s.PublicationID] = s
+		out.Snapshots = append(out.Snapshots, SnapshotOutput{
+			PublicationID: s.PublicationID,
+			ProviderType:  s.ProviderType.String(),
+			Likes:         s.Likes,
+			Stocks:        s.Stocks,
+			Bookmarks:     s.Bookmarks,
+			CollectedAt:   s.CollectedAt,
+		})
+	}
+	for publicationID, l := range last {
+		f := first[publicationID]
+		out.Latest = out.Latest.add(l.Likes, l.Stocks, l.Bookmarks)
+		out.Growth = out.Growth.add(l.Likes-f.Likes, l.Stocks-f.Stocks, l.Bookmarks-f.Bookmarks)
+	}
+	return out, nil
+}
+
+// Leaderboard ranks articles by the growth of their metrics within the period.
+// Callers that may not read unpublished articles only see published ones.
+func (uc *MetricsUsecase) Leaderboard(ctx context.Context, input LeaderboardInput) (*LeaderboardOutput, error) {
+	if err := uc.authorize(ctx, auth.ActionReadArticle, nil); err != nil {
+		return nil, err
+	}
+	publishedOnly := false
+	if err := uc.authorize(ctx, auth.ActionReadUnpublishedArticle, nil); errors.Is(err, apperr.ErrForbidden) {
+		publishedOnly = true
+	} else if err != nil {
+		return nil, err
+	}
+	from, to, err := uc.period(input.From, input.To)
+	if err != nil {
+		return nil, err
+	}
+	sortBy := defaultLeaderboardSort
+	if input.SortBy != nil && *input.SortBy != "" {
+		if !leaderboardSortKeys[*input.SortBy] {
+			return nil, fmt.Errorf("%w: unknown sort key %q", apperr.ErrInvalidInput, *input.SortBy)
+		}
+		sortBy = *input.SortBy
+	}
+	limit := input.Limit
+	if limit == 0 {
+		limit = defaultLeaderboardLimit
+	}
+	if limit < 0 || limit > maxLeaderboardLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", apperr.ErrInvalidInput, maxLeaderboardLimit)
+	}
+
+	growth, err := uc.repo.FindGrowth(ctx, from, to, sortBy, limit, publishedOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate metric growth: %w", err)
+	}
+
+	out := &LeaderboardOutput{
+		From:    from,
+		To:      to,
+		SortBy:  sortBy,
+		Entries: make([]LeaderboardEntry, 0, len(growth)),
+	}
+	for i, g := range growth {
+		out.Entries = append(out.Entries, LeaderboardEntry{
+			Rank:      i + 1,
+			ArticleID: g.ArticleID,
+			Title:     g.Title,
+			Growth:    Counters{}.add(g.Likes, g.Stocks, g.Bookmarks),
+		})
+	}
+	return out, nil
+}
+
+func (uc *MetricsUsecase) authorize(ctx context.Context, action auth.Action, resource *auth.Resource) error {
+	if uc.authorizer == nil {
+		return nil
+	}
+	return uc.authorizer.Authorize(ctx, action, resource)
+}
+
+// period resolves the optional bounds of a period.
+func (uc *MetricsUsecase) period(from, to *time.Time) (time.Time, time.Time, error) {
+	end := uc.now()
+	if to != nil {
+		end = *to
+	}
+	start := end.Add(-defaultPeriod)
+	if from != nil {
+		start = *from
+	}
+	if start.After(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: from must not be after to", apperr.ErrInvalidInput)
+	}
+	return start, end, nil
+}
+
+func (c Counters) add(likes, stocks, bookmarks int) Counters {
+	c.Likes += likes
+	c.Stocks += stocks
+	c.Bookmarks += bookmarks
+	c.Total = c.Likes + c.Stocks + c.Bookmarks
+	return c
+}
@@ -0,0 +1,231 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/metrics"
+)
+
+type MockArticleMetricRepository struct {
+	mock.Mock
+}
+
+func (m *MockArticleMetricRepository) FindTargets(ctx context.Context) ([]repository.MetricTarget, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]repository.MetricTarget), args.Error(1)
+}
+
+func (m *MockArticleMetricRepository) Save(ctx context.Context, metrics []*entity.ArticleMetric) error {
+	args := m.Called(ctx, metrics)
+	return args.Error(0)
+}
+
+func (m *MockArticleMetricRepository) FindByArticleID(ctx context.Context, articleID uint64, from, to time.Time) ([]*entity.ArticleMetric, error) {
+	args := m.Called(ctx, articleID, from, to)
+	return args.Get(0).([]*entity.ArticleMetric), args.Error(1)
+}
+
+func (m *MockArticleMetricRepository) FindGrowth(ctx context.Context, from, to time.Time, sortBy string, limit int, publishedOnly bool) ([]repository.MetricGrowth, error) {
+	args := m.Called(ctx, from, to, sortBy, limit, publishedOnly)
+	return args.Get(0).([]repository.MetricGrowth), args.Error(1)
+}
+
+// stubArticleRepository はIDが1の下書きの記事だけが存在する
+type stubArticleRepository struct {
+	repository.ArticleRepository
+}
+
+func (stubArticleRepository) FindByID(_ context.Context, id uint64) (*entity.Article, error) {
+	if id != 1 {
+		return nil, repository.ErrArticleNotFound
+	}
+	return &entity.Article{ID: id, Status: vo.ArticleStatusDraft}, nil
+}
+
+type stubFetcher func(target repository.MetricTarget) (metrics.Counters, error)
+
+func (f stubFetcher) Fetch(_ context.Context, target repository.MetricTarget) (metrics.Counters, error) {
+	return f(target)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestMetricsUsecase_Collect(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("投稿先ごとに指標を取得して保存する", func(t *testing.T) {
+		mockRepo := new(MockArticleMetricRepository)
+		qiita := stubFetcher(func(target repository.MetricTarget) (metrics.Counters, error) {
+			switch target.PublicationID {
+			case 10:
+				return metrics.Counters{Likes: 5, Stocks: 3}, nil
+			case 11:
+				return metrics.Counters{}, fmt.Errorf("item: %w", metrics.ErrTargetNotSupported)
+			default:
+				return metrics.Counters{}, errors.New("rate limited")
+			}
+		})
+		uc := metrics.NewMetricsUsecase(mockRepo, stubArticleRepository{},
+			metrics.WithFetcher(vo.ProviderTypeQiita, qiita),
+			metrics.WithClock(func() time.Time { return now }),
+		)
+
+		mockRepo.On("FindTargets", ctx).Return([]repository.MetricTarget{
+			{ArticleID: 1, PublicationID: 10, ProviderType: vo.ProviderTypeQiita, Link: ptr("https://qiita.com/u/items/a")},
+			{ArticleID: 1, PublicationID: 11, ProviderType: vo.ProviderTypeQiita, Link: ptr("https://qiita.com/u")},
+			{ArticleID: 2, PublicationID: 12, ProviderType: vo.ProviderTypeQiita, Link: ptr("https://qiita.com/u/items/b")},
+			{ArticleID: 2, PublicationID: 13, ProviderType: vo.ProviderTypeNote, Link: ptr("https://note.com/u/n/c")},
+		}, nil)
+		mockRepo.On("Save", ctx, mock.MatchedBy(func(ms []*entity.ArticleMetric) bool {
+			return len(ms) == 1 && ms[0].PublicationID == 10 && ms[0].Likes == 5 && ms[0].Stocks == 3 && ms[0].CollectedAt.Equal(now)
+		})).Return(nil)
+
+		out, err := uc.Collect(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, metrics.CollectOutput{Collected: 1, Skipped: 2, Failed: 1}, *out)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("取得できたものがなければ保存しない", func(t *testing.T) {
+		mockRepo := new(MockArticleMetricRepository)
+		uc := metrics.NewMetricsUsecase(mockRepo, stubArticleRepository{})
+
+		mockRepo.On("FindTargets", ctx).Return([]repository.MetricTarget{
+			{ArticleID: 1, PublicationID: 10, ProviderType: vo.ProviderTypeZenn, Link: ptr("https://zenn.dev/u/articles/a")},
+		}, nil)
+
+		out, err := uc.Collect(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, out.Skipped)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMetricsUsecase_ArticleMetrics(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("期間内の増分を投稿先ごとに求めて合算する", func(t *testing.T) {
+		mockRepo := new(MockArticleMetricRepository)
+		uc := metrics.NewMetricsUsecase(mockRepo, stubArticleRepository{}, metrics.WithClock(func() time.Time { return now }))
+
+		from := now.Add(-30 * 24 * time.Hour)
+		day := func(d int) time.Time { return from.Add(time.Duration(d) * 24 * time.Hour) }
+		mockRepo.On("FindByArticleID", ctx, uint64(1), from, now).Return([]*entity.ArticleMetric{
+			{ArticleID: 1, PublicationID: 10, ProviderType: vo.ProviderTypeQiita, Likes: 10, Stocks: 4, CollectedAt: day(1)},
+			{ArticleID: 1, PublicationID: 11, ProviderType: vo.ProviderTypeZenn, Likes: 2, Bookmarks: 1, CollectedAt: day(1)},
+			{ArticleID: 1, PublicationID: 10, ProviderType: vo.ProviderTypeQiita, Likes: 15, Stocks: 6, CollectedAt: day(2)},
+			{ArticleID: 1, PublicationID: 11, ProviderType: vo.ProviderTypeZenn, Likes: 5, Bookmarks: 1, CollectedAt: day(2)},
+		}, nil)
+
+		out, err := uc.ArticleMetrics(ctx, 1, metrics.ArticleMetricsInput{})
+
+		require.NoError(t, err)
+		assert.Len(t, out.Snapshots, 4)
+		assert.Equal(t, metrics.Counters{Likes: 20, Stocks: 6, Bookmarks: 1, Total: 27}, out.Latest)
+		assert.Equal(t, metrics.Counters{Likes: 8, Stocks: 2, Bookmarks: 0, Total: 10}, out.Growth)
+	})
+
+	t.Run("fromがtoより後ならErrInvalidInput", func(t *testing.T) {
+		uc := metrics.NewMetricsUsecase(new(MockArticleMetricRepository), stubArticleRepository{})
+
+		_, err := uc.ArticleMetrics(ctx, 1, metrics.ArticleMetricsInput{From: ptr(now), To: ptr(now.Add(-time.Hour))})
+
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+	})
+
+	t.Run("閲覧者は下書きの記事の指標を参照できない", func(t *testing.T) {
+		mockRepo := new(MockArticleMetricRepository)
+		uc := metrics.NewMetricsUsecase(mockRepo, stubArticleRepository{}, metrics.WithAuthorizer(auth.ArticlePolicy))
+		viewer := auth.WithPrincipal(ctx, &auth.Principal{Name: "viewer", Roles: []string{"viewer"}})
+
+		_, err := uc.ArticleMetrics(viewer, 1, metrics.ArticleMetricsInput{})
+
+		var forbidden *auth.ForbiddenError
+		require.ErrorAs(t, err, &forbidden)
+		assert.Equal(t, auth.ActionReadUnpublishedArticle, forbidden.Action)
+		mockRepo.AssertNotCalled(t, "FindByArticleID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("存在しない記事はErrNotFound", func(t *testing.T) {
+		uc := metrics.NewMetricsUsecase(new(MockArticleMetricRepository), stubArticleRepository{})
+
+		_, err := uc.ArticleMetrics(ctx, 2, metrics.ArticleMetricsInput{})
+
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
+
+func TestMetricsUsecase_Leaderboard(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("増分の大きい順に順位を付ける", func(t *testing.T) {
+		mockRepo := new(MockArticleMetricRepository)
+		uc := metrics.NewMetricsUsecase(mockRepo, stubArticleRepository{})
+
+		mockRepo.On("FindGrowth", ctx, from, to, "likes", 20, false).Return([]repository.MetricGrowth{
+			{ArticleID: 2, Title: "B", Likes: 30, Total: 30},
+			{ArticleID: 1, Title: "A", Likes: 10, Stocks: 5, Total: 15},
+		}, nil)
+
+		out, err := uc.Leaderboard(ctx, metrics.LeaderboardInput{From: &from, To: &to, SortBy: ptr("likes")})
+
+		require.NoError(t, err)
+		assert.Equal(t, "likes", out.SortBy)
+		require.Len(t, out.Entries, 2)
+		assert.Equal(t, 1, out.Entries[0].Rank)
+		assert.Equal(t, uint64(2), out.Entries[0].ArticleID)
+		assert.Equal(t, metrics.Counters{Likes: 10, Stocks: 5, Total: 15}, out.Entries[1].Growth)
+	})
+
+	t.Run("未公開の記事を参照できない利用者は公開済みの記事だけを順位付けする", func(t *testing.T) {
+		mockRepo := new(MockArticleMetricRepository)
+		uc := metrics.NewMetricsUsecase(mockRepo, stubArticleRepository{}, metrics.WithAuthorizer(auth.ArticlePolicy))
+		viewer := auth.WithPrincipal(ctx, &auth.Principal{Name: "viewer", Roles: []string{"viewer"}})
+		editor := auth.WithPrincipal(ctx, &auth.Principal{Name: "editor", Roles: []string{"editor"}})
+		mockRepo.On("FindGrowth", viewer, from, to, "total", 20, true).Return([]repository.MetricGrowth{}, nil)
+		mockRepo.On("FindGrowth", editor, from, to, "total", 20, false).Return([]repository.MetricGrowth{}, nil)
+
+		_, err := uc.Leaderboard(viewer, metrics.LeaderboardInput{From: &from, To: &to})
+		require.NoError(t, err)
+		_, err = uc.Leaderboard(editor, metrics.LeaderboardInput{From: &from, To: &to})
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("未知の並び替えキーはErrInvalidInput", func(t *testing.T) {
+		uc := metrics.NewMetricsUsecase(new(MockArticleMetricRepository), stubArticleRepository{})
+
+		_, err := uc.Leaderboard(ctx, metrics.LeaderboardInput{SortBy: ptr("views")})
+
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+	})
+
+	t.Run("上限を超える件数はErrInvalidInput", func(t *testing.T) {
+		uc := metrics.NewMetricsUsecase(new(MockArticleMetricRepository), stubArticleRepository{})
+
+		_, err := uc.Leaderboard(ctx, metrics.LeaderboardInput{Limit: 101})
+
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+	})
+}
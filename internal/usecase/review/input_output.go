@@ -0,0 +1,54 @@
+package review
+
+import "time"
+
+// SubmitInput is the input for submitting an article for review.
+type SubmitInput struct {
+	Comment string `json:"comment"`
+}
+
+// DecisionInput is the input for approving, requesting changes to or withdrawing a review.
+// A comment is required to request changes.
+type DecisionInput struct {
+	Comment string `json:"comment"`
+}
+
+// CommentInput is the input for commenting on the latest review of an article.
+// A comment with ParentID replies to the thread started by that comment.
+type CommentInput struct {
+	Body     string  `json:"body"`
+	ParentID *uint64 `json:"parent_id"`
+}
+
+// ActorOutput is a caller who submitted, decided or commented on a review.
+type ActorOutput struct {
+	Name     string  `json:"name"`
+	AuthorID *uint64 `json:"author_id,omitempty"`
+}
+
+// CommentOutput is a comment on a review.
+type CommentOutput struct {
+	ID        uint64      `json:"id"`
+	ParentID  *uint64     `json:"parent_id,omitempty"`
+	Commenter ActorOutput `json:"commenter"`
+	Body      string      `json:"body"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// ReviewOutput is a review request with its comments.
+type ReviewOutput struct {
+	ID          uint64          `json:"id"`
+	ArticleID   uint64          `json:"article_id"`
+	Status      string          `json:"status"`
+	SubmittedBy ActorOutput     `json:"submitted_by"`
+	DecidedBy   *ActorOutput    `json:"decided_by,omitempty"`
+	DecidedAt   *time.Time      `json:"decided_at,omitempty"`
+	Comments    []CommentOutput `json:"comments"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// ReviewsOutput is a list of review requests.
+type ReviewsOutput struct {
+	Reviews []ReviewOutput `json:"reviews"`
+}
@@ -0,0 +1,350 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+)
+
+// anonymousActor is recorded as the actor when authentication is disabled.
+const anonymousActor = "anonymous"
+
+// Authorizer decides whether the caller of ctx may perform an action on an article.
+type Authorizer interface {
+	Authorize(ctx context.Context, action auth.Action, resource *auth.Resource) error
+}
+
+// AuditLog records changes of articles within the transaction of the change.
+type AuditLog interface {
+	Record(ctx context.Context, entry *entity.AuditEntry) error
+}
+
+// ReviewUsecase runs the editorial review of articles: authors submit drafts,
+// reviewers approve them or request changes, and both discuss them in comments.
+// The status of the article and of its review request are changed together
+// while the article is locked.
+type ReviewUsecase struct {
+	articles   repository.ArticleRepository
+	reviews    repository.ReviewRequestRepository
+	txManager  repository.TxManager
+	authorizer Authorizer
+	auditLog   AuditLog
+	now        func() time.Time
+}
+
+// Option configures a ReviewUsecase.
+type Option func(*ReviewUsecase)
+
+// WithAuthorizer enables authorization of review operations.
+func WithAuthorizer(a Authorizer) Option {
+	return func(uc *ReviewUsecase) {
+		uc.authorizer = a
+	}
+}
+
+// WithAuditLog records the status changes of articles in review in the audit log.
+func WithAuditLog(l AuditLog) Option {
+	return func(uc *ReviewUsecase) {
+		uc.auditLog = l
+	}
+}
+
+// NewReviewUsecase creates a new ReviewUsecase.
+func NewReviewUsecase(articles repository.ArticleRepository, reviews repository.ReviewRequestRepository, txManager repository.TxManager, opts ...Option) *ReviewUsecase {
+	uc := &ReviewUsecase{
+		articles:  articles,
+		reviews:   reviews,
+		txManager: txManager,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// Submit puts a draft in review and opens a review request for it.
+func (uc *ReviewUsecase) Submit(ctx context.Context, articleID uint64, input SubmitInput) (*ReviewOutput, error) {
+	var request *entity.ReviewRequest
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		article, err := uc.articles.FindByIDForUpdate(ctx, articleID)
+		if err != nil {
+			return err
+		}
+		if err := uc.authorize(ctx, auth.ActionSubmitReview, resourceOf(article)); err != nil {
+			return err
+		}
+		before := entity.SnapshotArticle(article)
+		if err := article.SubmitForReview(); err != nil {
+			return domainError(err)
+		}
+		request, err = entity.NewReviewRequest(article.ID, actorOf(ctx), input.Comment)
+		if err != nil {
+			return domainError(err)
+		}
+		if err := uc.articles.Update(ctx, article); err != nil {
+			return err
+		}
+		if err := uc.record(ctx, article, before); err != nil {
+			return err
+		}
+		request, err = uc.reviews.Create(ctx, request)
+		if errors.Is(err, repository.ErrReviewRequestConflict) {
+			return fmt.Errorf("%w: %v", apperr.ErrConflict, err)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newReviewOutput(request), nil
+}
+
+// Approve approves the pending review of an article so that it can be published.
+// Reviewers may not approve the articles they own.
+func (uc *ReviewUsecase) Approve(ctx context.Context, articleID uint64, input DecisionInput) (*ReviewOutput, error) {
+	return uc.decide(ctx, articleID, func(ctx context.Context, article *entity.Article, request *entity.ReviewRequest) error {
+		if p, ok := auth.PrincipalFrom(ctx); ok && p.AuthorID != 0 && article.AuthorID != nil && *article.AuthorID == p.AuthorID {
+			return &auth.ForbiddenError{Principal: p.Name, Action: auth.ActionDecideReview}
+		}
+		if err := request.Approve(actorOf(ctx), input.Comment); err != nil {
+			return err
+		}
+		return article.ApproveReview(uc.now())
+	})
+}
+
+// RequestChanges rejects the pending review of an article and returns it to draft.
+func (uc *ReviewUsecase) RequestChanges(ctx context.Context, articleID uint64, input DecisionInput) (*ReviewOutput, error) {
+	return uc.decide(ctx, articleID, func(ctx context.Context, article *entity.Article, request *entity.ReviewRequest) error {
+		if err := request.RequestChanges(actorOf(ctx), input.Comment); err != nil {
+			return err
+		}
+		return article.ReturnToDraft()
+	})
+}
+
+// Withdraw withdraws the pending or approved review of an article and returns it to draft.
+func (uc *ReviewUsecase) Withdraw(ctx context.Context, articleID uint64, input DecisionInput) (*ReviewOutput, error) {
+	return uc.change(ctx, articleID, auth.ActionSubmitReview, func(ctx context.Context, article *entity.Article, request *entity.ReviewRequest) error {
+		if err := request.Withdraw(actorOf(ctx)); err != nil {
+			return err
+		}
+		if input.Comment != "" {
+			if _, err := request.AddComment(actorOf(ctx), input.Comment, nil); err != nil {
+				return err
+			}
+		}
+		return article.ReturnToDraft()
+	})
+}
+
+func (uc *ReviewUsecase) decide(ctx context.Context, articleID uint64, fn func(context.Context, *entity.Article, *entity.ReviewRequest) error) (*ReviewOutput, error) {
+	return uc.change(ctx, articleID, auth.ActionDecideReview, fn)
+}
+
+// change locks the article in review, applies fn to it and its open review
+// request, and saves both.
+func (uc *ReviewUsecase) change(ctx context.Context, articleID uint64, action auth.Action, fn func(context.Context, *entity.Article, *entity.ReviewRequest) error) (*ReviewOutput, error) {
+	var request *entity.ReviewRequest
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		article, err := uc.articles.FindByIDForUpdate(ctx, articleID)
+		if err != nil {
+			return err
+		}
+		if err := uc.authorize(ctx, action, resourceOf(article)); err != nil {
+			return err
+		}
+		if !article.Status.IsInReview() {
+			return fmt.Errorf("%w: article %d is %s, not in review", apperr.ErrConflict, article.ID, article.Status)
+		}
+		request, err = uc.reviews.FindOpenByArticleID(ctx, article.ID)
+		if err != nil {
+			return err
+		}
+		before := entity.SnapshotArticle(article)
+		if err := fn(ctx, article, request); err != nil {
+			return domainError(err)
+		}
+		if err := uc.articles.Update(ctx, article); err != nil {
+			return err
+		}
+		if err := uc.record(ctx, article, before); err != nil {
+			return err
+		}
+		return uc.reviews.Update(ctx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newReviewOutput(request), nil
+}
+
+// Comment adds a comment to the latest review of an article, whether or not
+// it has been decided, so that authors can follow up on requested changes.
+func (uc *ReviewUsecase) Comment(ctx context.Context, articleID uint64, input CommentInput) (*CommentOutput, error) {
+	var comment entity.ReviewComment
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		article, err := uc.articles.FindByIDForUpdate(ctx, articleID)
+		if err != nil {
+			return err
+		}
+		if err := uc.authorize(ctx, auth.ActionCommentReview, resourceOf(article)); err != nil {
+			return err
+		}
+		request, err := uc.reviews.FindLatestByArticleID(ctx, article.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := request.AddComment(actorOf(ctx), input.Body, input.ParentID); err != nil {
+			return domainError(err)
+		}
+		if err := uc.reviews.Update(ctx, request); err != nil {
+			return err
+		}
+		comment = request.Comments[len(request.Comments)-1]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	output := newCommentOutput(comment)
+	return &output, nil
+}
+
+// ListForArticle returns the reviews of an article, newest first.
+func (uc *ReviewUsecase) ListForArticle(ctx context.Context, articleID uint64) (*ReviewsOutput, error) {
+	article, err := uc.articles.FindByID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authorize(ctx, auth.ActionReadComment, resourceOf(article)); err != nil {
+		return nil, err
+	}
+	requests, err := uc.reviews.FindByArticleID(ctx, article.ID)
+	if err != nil {
+		return nil, err
+	}
+	return newReviewsOutput(requests), nil
+}
+
+// Queue returns the reviews in the given status, oldest first.
+// The status defaults to pending, which is the queue of reviews awaiting a decision.
+func (uc *ReviewUsecase) Queue(ctx context.Context, status string) (*ReviewsOutput, error) {
+	if err := uc.authorize(ctx, auth.ActionDecideReview, nil); err != nil {
+		return nil, err
+	}
+	s := vo.ReviewStatusPending
+	if status != "" {
+		var err error
+		if s, err = vo.NewReviewStatus(status); err != nil {
+			return nil, fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+		}
+	}
+	requests, err := uc.reviews.FindByStatus(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	return newReviewsOutput(requests), nil
+}
+
+// record appends the change of the article from the snapshot before to the audit log.
+func (uc *ReviewUsecase) record(ctx context.Context, article *entity.Article, before entity.ArticleSnapshot) error {
+	if uc.auditLog == nil {
+		return nil
+	}
+	entry := entity.NewAuditEntry(article.ID, entity.AuditActionUpdate, before, entity.SnapshotArticle(article))
+	if !entry.HasChanges() {
+		return nil
+	}
+	return uc.auditLog.Record(ctx, entry)
+}
+
+func (uc *ReviewUsecase) authorize(ctx context.Context, action auth.Action, resource *auth.Resource) error {
+	if uc.authorizer == nil {
+		return nil
+	}
+	return uc.authorizer.Authorize(ctx, action, resource)
+}
+
+func resourceOf(article *entity.Article) *auth.Resource {
+	return &auth.Resource{OwnerID: article.AuthorID}
+}
+
+// actorOf returns the caller of ctx as recorded on reviews and comments.
+func actorOf(ctx context.Context) entity.ReviewActor {
+	p, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return entity.ReviewActor{Name: anonymousActor}
+	}
+	actor := entity.ReviewActor{Name: p.Name}
+	if p.AuthorID != 0 {
+		id := p.AuthorID
+		actor.AuthorID = &id
+	}
+	return actor
+}
+
+// domainError maps an error of the review or article entity to an application error.
+// Errors that the current state does not allow are conflicts and the others are invalid input.
+func domainError(err error) error {
+	var forbidden *auth.ForbiddenError
+	switch {
+	case errors.As(err, &forbidden):
+		return err
+	case errors.Is(err, entity.ErrReviewClosed), errors.Is(err, entity.ErrInvalidStatusTransition):
+		return fmt.Errorf("%w: %v", apperr.ErrConflict, err)
+	default:
+		return fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+	}
+}
+
+func newReviewsOutput(requests []*entity.ReviewRequest) *ReviewsOutput {
+	output := &ReviewsOutput{Reviews: make([]ReviewOutput, 0, len(requests))}
+	for _, r := range requests {
+		output.Reviews = append(output.Reviews, *newReviewOutput(r))
+	}
+	return output
+}
+
+func newReviewOutput(r *entity.ReviewRequest) *ReviewOutput {
+	output := &ReviewOutput{
+		ID:          r.ID,
+		ArticleID:   r.ArticleID,
+		Status:      r.Status.String(),
+		SubmittedBy: newActorOutput(r.SubmittedBy),
+		DecidedAt:   r.DecidedAt,
+		Comments:    make([]CommentOutput, 0, len(r.Comments)),
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	if r.DecidedBy != nil {
+		decidedBy := newActorOutput(*r.DecidedBy)
+		output.DecidedBy = &decidedBy
+	}
+	for _, c := range r.Comments {
+		output.Comments = append(output.Comments, newCommentOutput(c))
+	}
+	return output
+}
+
+func newCommentOutput(c entity.ReviewComment) CommentOutput {
+	return CommentOutput{
+		ID:        c.ID,
+		ParentID:  c.ParentID,
+		Commenter: newActorOutput(c.Commenter),
+		Body:      c.Body,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+func newActorOutput(a entity.ReviewActor) ActorOutput {
+	return ActorOutput{Name: a.Name, AuthorID: a.AuthorID}
+}
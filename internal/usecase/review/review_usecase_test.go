@@ -0,0 +1,315 @@
+package review_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/review"
+)
+
+type MockReviewRequestRepository struct {
+	mock.Mock
+}
+
+func (m *MockReviewRequestRepository) FindOpenByArticleID(ctx context.Context, articleID uint64) (*entity.ReviewRequest, error) {
+	args := m.Called(ctx, articleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ReviewRequest), args.Error(1)
+}
+
+func (m *MockReviewRequestRepository) FindLatestByArticleID(ctx context.Context, articleID uint64) (*entity.ReviewRequest, error) {
+	args := m.Called(ctx, articleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ReviewRequest), args.Error(1)
+}
+
+func (m *MockReviewRequestRepository) FindByArticleID(ctx context.Context, articleID uint64) ([]*entity.ReviewRequest, error) {
+	args := m.Called(ctx, articleID)
+	return args.Get(0).([]*entity.ReviewRequest), args.Error(1)
+}
+
+func (m *MockReviewRequestRepository) FindByStatus(ctx context.Context, status vo.ReviewStatus) ([]*entity.ReviewRequest, error) {
+	args := m.Called(ctx, status)
+	return args.Get(0).([]*entity.ReviewRequest), args.Error(1)
+}
+
+func (m *MockReviewRequestRepository) Create(ctx context.Context, request *entity.ReviewRequest) (*entity.ReviewRequest, error) {
+	args := m.Called(ctx, request)
+	if fn, ok := args.Get(0).(func(context.Context, *entity.ReviewRequest) *entity.ReviewRequest); ok {
+		return fn(ctx, request), args.Error(1)
+	}
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ReviewRequest), args.Error(1)
+}
+
+func (m *MockReviewRequestRepository) Update(ctx context.Context, request *entity.ReviewRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
+// stubArticleRepository は1件の記事だけを保持し、更新された記事を記録する
+type stubArticleRepository struct {
+	repository.ArticleRepository
+	article *entity.Article
+	updated *entity.Article
+}
+
+func (r *stubArticleRepository) FindByID(_ context.Context, id uint64) (*entity.Article, error) {
+	if r.article == nil || r.article.ID != id {
+		return nil, repository.ErrArticleNotFound
+	}
+	return r.article, nil
+}
+
+func (r *stubArticleRepository) FindByIDForUpdate(ctx context.Context, id uint64) (*entity.Article, error) {
+	return r.FindByID(ctx, id)
+}
+
+func (r *stubArticleRepository) Update(_ context.Context, article *entity.Article) error {
+	r.updated = article
+	return nil
+}
+
+// passthroughTxManager はトランザクションを張らずにfnをそのまま実行する
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (passthroughTxManager) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+const ownerID = uint64(7)
+
+func articleIn(status vo.ArticleStatus) *entity.Article {
+	owner := ownerID
+	return &entity.Article{ID: 1, Title: "記事", Status: status, AuthorID: &owner}
+}
+
+func withRole(role auth.Role, authorID uint64) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Name: string(role), Roles: []string{string(role)}, AuthorID: authorID})
+}
+
+func TestReviewUsecase_Submit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("自分の下書きをレビューに提出する", func(t *testing.T) {
+		t.Parallel()
+		articles := &stubArticleRepository{article: articleIn(vo.ArticleStatusDraft)}
+		reviews := new(MockReviewRequestRepository)
+		reviews.On("Create", mock.Anything, mock.AnythingOfType("*entity.ReviewRequest")).Return(func(_ context.Context, r *entity.ReviewRequest) *entity.ReviewRequest {
+			r.ID = 3
+			return r
+		}, nil)
+		uc := review.NewReviewUsecase(articles, reviews, passthroughTxManager{}, review.WithAuthorizer(auth.ArticlePolicy))
+
+		output, err := uc.Submit(withRole(auth.RoleAuthor, ownerID), 1, review.SubmitInput{Comment: "確認をお願いします"})
+		require.NoError(t, err)
+		assert.Equal(t, "pending", output.Status)
+		assert.Equal(t, "author", output.SubmittedBy.Name)
+		assert.Equal(t, ptr(ownerID), output.SubmittedBy.AuthorID)
+		require.Len(t, output.Comments, 1)
+		require.NotNil(t, articles.updated)
+		assert.Equal(t, vo.ArticleStatusInReview, articles.updated.Status)
+	})
+
+	t.Run("他人の記事は提出できない", func(t *testing.T) {
+		t.Parallel()
+		articles := &stubArticleRepository{article: articleIn(vo.ArticleStatusDraft)}
+		uc := review.NewReviewUsecase(articles, new(MockReviewRequestRepository), passthroughTxManager{}, review.WithAuthorizer(auth.ArticlePolicy))
+
+		_, err := uc.Submit(withRole(auth.RoleAuthor, ownerID+1), 1, review.SubmitInput{})
+		assert.ErrorIs(t, err, apperr.ErrForbidden)
+		assert.Nil(t, articles.updated)
+	})
+
+	t.Run("下書き以外は提出できない", func(t *testing.T) {
+		t.Parallel()
+		articles := &stubArticleRepository{article: articleIn(vo.ArticleStatusPublished)}
+		uc := review.NewReviewUsecase(articles, new(MockReviewRequestRepository), passthroughTxManager{})
+
+		_, err := uc.Submit(context.Background(), 1, review.SubmitInput{})
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+	})
+}
+
+func TestReviewUsecase_Decide(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		decide        func(uc *review.ReviewUsecase, ctx context.Context) (*review.ReviewOutput, error)
+		wantStatus    string
+		wantArticle   vo.ArticleStatus
+		wantApproved  bool
+		wantErr       error
+		requestStatus vo.ReviewStatus
+	}{
+		{
+			name: "レビュアーが承認する",
+			ctx:  withRole(auth.RoleReviewer, 0),
+			decide: func(uc *review.ReviewUsecase, ctx context.Context) (*review.ReviewOutput, error) {
+				return uc.Approve(ctx, 1, review.DecisionInput{})
+			},
+			wantStatus:   "approved",
+			wantArticle:  vo.ArticleStatusInReview,
+			wantApproved: true,
+		},
+		{
+			name: "レビュアーが差し戻すと下書きに戻る",
+			ctx:  withRole(auth.RoleReviewer, 0),
+			decide: func(uc *review.ReviewUsecase, ctx context.Context) (*review.ReviewOutput, error) {
+				return uc.RequestChanges(ctx, 1, review.DecisionInput{Comment: "導入を短くしてください"})
+			},
+			wantStatus:  "changes_requested",
+			wantArticle: vo.ArticleStatusDraft,
+		},
+		{
+			name: "コメントなしでは差し戻せない",
+			ctx:  withRole(auth.RoleReviewer, 0),
+			decide: func(uc *review.ReviewUsecase, ctx context.Context) (*review.ReviewOutput, error) {
+				return uc.RequestChanges(ctx, 1, review.DecisionInput{})
+			},
+			wantErr: apperr.ErrInvalidInput,
+		},
+		{
+			name: "自分の記事は承認できない",
+			ctx:  withRole(auth.RoleEditor, ownerID),
+			decide: func(uc *review.ReviewUsecase, ctx context.Context) (*review.ReviewOutput, error) {
+				return uc.Approve(ctx, 1, review.DecisionInput{})
+			},
+			wantErr: apperr.ErrForbidden,
+		},
+		{
+			name: "執筆者は承認できない",
+			ctx:  withRole(auth.RoleAuthor, ownerID+1),
+			decide: func(uc *review.ReviewUsecase, ctx context.Context) (*review.ReviewOutput, error) {
+				return uc.Approve(ctx, 1, review.DecisionInput{})
+			},
+			wantErr: apperr.ErrForbidden,
+		},
+		{
+			name: "承認済みの依頼は承認し直せない",
+			ctx:  withRole(auth.RoleReviewer, 0),
+			decide: func(uc *review.ReviewUsecase, ctx context.Context) (*review.ReviewOutput, error) {
+				return uc.Approve(ctx, 1, review.DecisionInput{})
+			},
+			requestStatus: vo.ReviewStatusApproved,
+			wantErr:       apperr.ErrConflict,
+		},
+		{
+			name: "執筆者が承認済みの依頼を取り下げる",
+			ctx:  withRole(auth.RoleAuthor, ownerID),
+			decide: func(uc *review.ReviewUsecase, ctx context.Context) (*review.ReviewOutput, error) {
+				return uc.Withdraw(ctx, 1, review.DecisionInput{})
+			},
+			requestStatus: vo.ReviewStatusApproved,
+			wantStatus:    "withdrawn",
+			wantArticle:   vo.ArticleStatusDraft,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			articles := &stubArticleRepository{article: articleIn(vo.ArticleStatusInReview)}
+			request, err := entity.NewReviewRequest(1, entity.ReviewActor{Name: "author"}, "")
+			require.NoError(t, err)
+			if tt.requestStatus != "" {
+				request.Status = tt.requestStatus
+			}
+			reviews := new(MockReviewRequestRepository)
+			reviews.On("FindOpenByArticleID", mock.Anything, uint64(1)).Return(request, nil)
+			reviews.On("Update", mock.Anything, request).Return(nil)
+			uc := review.NewReviewUsecase(articles, reviews, passthroughTxManager{}, review.WithAuthorizer(auth.ArticlePolicy))
+
+			output, err := tt.decide(uc, tt.ctx)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, articles.updated)
+				reviews.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, output.Status)
+			require.NotNil(t, articles.updated)
+			assert.Equal(t, tt.wantArticle, articles.updated.Status)
+			assert.Equal(t, tt.wantApproved, articles.updated.ApprovedAt != nil)
+		})
+	}
+
+	t.Run("レビュー中でない記事は判断できない", func(t *testing.T) {
+		t.Parallel()
+		articles := &stubArticleRepository{article: articleIn(vo.ArticleStatusPublished)}
+		uc := review.NewReviewUsecase(articles, new(MockReviewRequestRepository), passthroughTxManager{})
+
+		_, err := uc.Approve(context.Background(), 1, review.DecisionInput{})
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+	})
+}
+
+func TestReviewUsecase_Comment(t *testing.T) {
+	t.Parallel()
+
+	articles := &stubArticleRepository{article: articleIn(vo.ArticleStatusDraft)}
+	request, err := entity.NewReviewRequest(1, entity.ReviewActor{Name: "author"}, "")
+	require.NoError(t, err)
+	require.NoError(t, request.RequestChanges(entity.ReviewActor{Name: "reviewer"}, "直してください"))
+	request.Comments[0].ID = 5
+	reviews := new(MockReviewRequestRepository)
+	reviews.On("FindLatestByArticleID", mock.Anything, uint64(1)).Return(request, nil)
+	reviews.On("Update", mock.Anything, request).Return(nil)
+	uc := review.NewReviewUsecase(articles, reviews, passthroughTxManager{}, review.WithAuthorizer(auth.ArticlePolicy))
+
+	output, err := uc.Comment(withRole(auth.RoleAuthor, ownerID), 1, review.CommentInput{Body: "直しました", ParentID: ptr(uint64(5))})
+	require.NoError(t, err)
+	assert.Equal(t, "直しました", output.Body)
+	assert.Equal(t, ptr(uint64(5)), output.ParentID)
+
+	_, err = uc.Comment(withRole(auth.RoleViewer, 0), 1, review.CommentInput{Body: "コメント"})
+	assert.ErrorIs(t, err, apperr.ErrForbidden)
+
+	_, err = uc.Comment(withRole(auth.RoleAuthor, ownerID), 1, review.CommentInput{Body: " "})
+	assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+}
+
+func TestReviewUsecase_Queue(t *testing.T) {
+	t.Parallel()
+
+	reviews := new(MockReviewRequestRepository)
+	reviews.On("FindByStatus", mock.Anything, vo.ReviewStatusPending).Return([]*entity.ReviewRequest{{ID: 1, ArticleID: 2, Status: vo.ReviewStatusPending}}, nil)
+	uc := review.NewReviewUsecase(&stubArticleRepository{}, reviews, passthroughTxManager{}, review.WithAuthorizer(auth.ArticlePolicy))
+
+	output, err := uc.Queue(withRole(auth.RoleReviewer, 0), "")
+	require.NoError(t, err)
+	require.Len(t, output.Reviews, 1)
+	assert.Equal(t, uint64(2), output.Reviews[0].ArticleID)
+
+	_, err = uc.Queue(withRole(auth.RoleReviewer, 0), "unknown")
+	assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+
+	_, err = uc.Queue(withRole(auth.RoleAuthor, ownerID), "")
+	assert.ErrorIs(t, err, apperr.ErrForbidden)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
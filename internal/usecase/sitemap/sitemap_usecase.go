@@ -0,0 +1,96 @@
+package sitemap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+)
+
+// MaxURLsPerSitemap is the maximum number of URLs allowed in a single sitemap file
+// by the sitemaps.org protocol.
+const MaxURLsPerSitemap = 50000
+
+// ErrSitemapPageNotFound is returned when the requested sitemap page does not exist.
+var ErrSitemapPageNotFound = fmt.Errorf("sitemap page %w", repository.ErrNotFound)
+
+// URL is a single <url> entry of a sitemap.
+type URL struct {
+	Loc          string
+	LastModified time.Time
+}
+
+// SitemapUsecase streams published articles as sitemap entries.
+type SitemapUsecase struct {
+	repo       repository.PublishedArticleReader
+	siteURL    string
+	perSitemap int
+}
+
+// NewSitemapUsecase creates a new SitemapUsecase.
+// perSitemap is capped at MaxURLsPerSitemap; zero or negative values use the cap.
+func NewSitemapUsecase(repo repository.PublishedArticleReader, siteURL string, perSitemap int) *SitemapUsecase {
+	if perSitemap <= 0 || perSitemap > MaxURLsPerSitemap {
+		perSitemap = MaxURLsPerSitemap
+	}
+	return &SitemapUsecase{
+		repo:       repo,
+		siteURL:    strings.TrimRight(siteURL, "/"),
+		perSitemap: perSitemap,
+	}
+}
+
+// ForSite returns a copy of the usecase that builds URLs under siteURL, such as the
+// site URL configured for the request's workspace. An empty siteURL keeps the default.
+func (uc *SitemapUsecase) ForSite(siteURL string) *SitemapUsecase {
+	siteURL = strings.TrimRight(siteURL, "/")
+	if siteURL == "" || siteURL == uc.siteURL {
+		return uc
+	}
+	site := *uc
+	site.siteURL = siteURL
+	return &site
+}
+
+// PageCount returns how many sitemap files are needed to list every published article.
+// It is always at least 1 so that an empty site still serves a valid urlset.
+func (uc *SitemapUsecase) PageCount(ctx context.Context) (int, error) {
+	total, err := uc.repo.CountPublished(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count published articles: %w", err)
+	}
+	if total == 0 {
+		return 1, nil
+	}
+	return (total + uc.perSitemap - 1) / uc.perSitemap, nil
+}
+
+// StreamPage passes the URLs of the given 1-based sitemap page to fn one at a time,
+// without loading the whole page into memory.
+func (uc *SitemapUsecase) StreamPage(ctx context.Context, page int, fn func(URL) error) error {
+	if page < 1 {
+		return fmt.Errorf("%d: %w", page, ErrSitemapPageNotFound)
+	}
+	offset := (page - 1) * uc.perSitemap
+	return uc.repo.StreamPublished(ctx, offset, uc.perSitemap, func(a repository.ArticleLastModified) error {
+		return fn(URL{Loc: uc.ArticleURL(a.Slug), LastModified: a.UpdatedAt})
+	})
+}
+
+// ArticleURL returns the public URL of an article on the site, which is addressed by its slug.
+func (uc *SitemapUsecase) ArticleURL(slug string) string {
+	return uc.siteURL + "/articles/by-slug/" + url.PathEscape(slug)
+}
+
+// SitemapURL returns the public URL of the given 1-based sitemap page.
+func (uc *SitemapUsecase) SitemapURL(page int) string {
+	return fmt.Sprintf("%s/sitemaps/%d.xml", uc.siteURL, page)
+}
+
+// IndexURL returns the public URL of the top-level sitemap.
+func (uc *SitemapUsecase) IndexURL() string {
+	return uc.siteURL + "/sitemap.xml"
+}
@@ -0,0 +1,125 @@
+package sitemap_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/sitemap"
+)
+
+type MockPublishedArticleReader struct {
+	mock.Mock
+}
+
+func (m *MockPublishedArticleReader) CountPublished(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPublishedArticleReader) StreamPublished(ctx context.Context, offset, limit int, fn func(repository.ArticleLastModified) error) error {
+	args := m.Called(ctx, offset, limit)
+	if rows, ok := args.Get(0).([]repository.ArticleLastModified); ok {
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func TestSitemapUsecase_PageCount(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		perSitemap int
+		total      int
+		want       int
+	}{
+		{name: "記事がなくても1ページ", perSitemap: 10, total: 0, want: 1},
+		{name: "上限ちょうどは1ページ", perSitemap: 10, total: 10, want: 1},
+		{name: "上限を超えると分割する", perSitemap: 10, total: 11, want: 2},
+		{name: "0以下の指定はプロトコル上限を使う", perSitemap: 0, total: sitemap.MaxURLsPerSitemap + 1, want: 2},
+		{name: "プロトコル上限を超える指定は上限に丸める", perSitemap: 100000, total: 60000, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPublishedArticleReader)
+			mockRepo.On("CountPublished", ctx).Return(tt.total, nil)
+			uc := sitemap.NewSitemapUsecase(mockRepo, "https://example.com/", tt.perSitemap)
+
+			got, err := uc.PageCount(ctx)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSitemapUsecase_StreamPage(t *testing.T) {
+	ctx := context.Background()
+	updatedAt := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("ページに対応するoffsetで記事URLを逐次渡す", func(t *testing.T) {
+		mockRepo := new(MockPublishedArticleReader)
+		mockRepo.On("StreamPublished", ctx, 10, 10).Return([]repository.ArticleLastModified{
+			{ID: 11, Slug: "hello", UpdatedAt: updatedAt},
+			{ID: 12, Slug: "world", UpdatedAt: updatedAt},
+		}, nil)
+		uc := sitemap.NewSitemapUsecase(mockRepo, "https://example.com/", 10)
+
+		var got []sitemap.URL
+		err := uc.StreamPage(ctx, 2, func(u sitemap.URL) error {
+			got = append(got, u)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []sitemap.URL{
+			{Loc: "https://example.com/articles/by-slug/hello", LastModified: updatedAt},
+			{Loc: "https://example.com/articles/by-slug/world", LastModified: updatedAt},
+		}, got)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("コールバックのエラーで中断する", func(t *testing.T) {
+		mockRepo := new(MockPublishedArticleReader)
+		mockRepo.On("StreamPublished", ctx, 0, 10).Return([]repository.ArticleLastModified{{ID: 1}, {ID: 2}}, nil)
+		uc := sitemap.NewSitemapUsecase(mockRepo, "https://example.com", 10)
+		writeErr := errors.New("broken pipe")
+
+		calls := 0
+		err := uc.StreamPage(ctx, 1, func(sitemap.URL) error {
+			calls++
+			return writeErr
+		})
+
+		assert.ErrorIs(t, err, writeErr)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("0ページ目はErrNotFound", func(t *testing.T) {
+		uc := sitemap.NewSitemapUsecase(new(MockPublishedArticleReader), "https://example.com", 10)
+
+		err := uc.StreamPage(ctx, 0, func(sitemap.URL) error { return nil })
+
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
+
+func TestSitemapUsecase_ForSite(t *testing.T) {
+	uc := sitemap.NewSitemapUsecase(new(MockPublishedArticleReader), "https://example.com", 10)
+
+	assert.Equal(t, "https://team-a.example.com/sitemap.xml", uc.ForSite("https://team-a.example.com/").IndexURL())
+	assert.Equal(t, "https://example.com/sitemap.xml", uc.ForSite("").IndexURL())
+	assert.Equal(t, "https://example.com/sitemap.xml", uc.IndexURL())
+}
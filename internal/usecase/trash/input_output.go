@@ -0,0 +1,27 @@
+package trash
+
+import "time"
+
+// PurgeInput is the input for purging the trash.
+// With DryRun set, the articles that would be purged are reported but kept.
+type PurgeInput struct {
+	DryRun bool `json:"dry_run"`
+}
+
+// PurgedArticleOutput is an article that was, or in a dry run would be, purged.
+type PurgedArticleOutput struct {
+	ID          uint64    `json:"id"`
+	WorkspaceID uint64    `json:"workspace_id"`
+	Title       string    `json:"title"`
+	Slug        string    `json:"slug"`
+	DeletedAt   time.Time `json:"deleted_at"`
+}
+
+// PurgeReport is the result of purging the trash. Articles deleted before
+// Cutoff are purged; Articles lists them, oldest ID first.
+type PurgeReport struct {
+	DryRun   bool                  `json:"dry_run"`
+	Cutoff   time.Time             `json:"cutoff"`
+	Purged   int                   `json:"purged"`
+	Articles []PurgedArticleOutput `json:"articles"`
+}
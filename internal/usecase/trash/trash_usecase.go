@@ -0,0 +1,133 @@
+// Package trash permanently deletes articles that have stayed in the trash,
+// i.e. soft deleted, for longer than the retention period.
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+)
+
+const defaultBatchSize = 100
+
+// AuditLog records changes of articles within the transaction of the change.
+type AuditLog interface {
+	Record(ctx context.Context, entry *entity.AuditEntry) error
+}
+
+// TrashUsecase purges soft-deleted articles after a retention period.
+// Listing and restoring the trash is done by the article use case.
+type TrashUsecase struct {
+	articles  repository.ArticleRepository
+	txManager repository.TxManager
+	retention time.Duration
+	batchSize int
+	auditLog  AuditLog
+	now       func() time.Time
+}
+
+// Option configures a TrashUsecase.
+type Option func(*TrashUsecase)
+
+// WithAuditLog records each purged article in the audit log.
+func WithAuditLog(l AuditLog) Option {
+	return func(uc *TrashUsecase) {
+		uc.auditLog = l
+	}
+}
+
+// WithBatchSize sets the number of articles read at a time.
+func WithBatchSize(n int) Option {
+	return func(uc *TrashUsecase) {
+		if n > 0 {
+			uc.batchSize = n
+		}
+	}
+}
+
+// NewTrashUsecase creates a new TrashUsecase that purges articles soft deleted
+// more than retention ago.
+func NewTrashUsecase(articles repository.ArticleRepository, txManager repository.TxManager, retention time.Duration, opts ...Option) *TrashUsecase {
+	uc := &TrashUsecase{
+		articles:  articles,
+		txManager: txManager,
+		retention: retention,
+		batchSize: defaultBatchSize,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// Purge permanently deletes the articles soft deleted more than the retention
+// period ago, together with their tags, publications, reviews and comments.
+// Each article is purged in its own transaction within its workspace, so ctx
+// may span all workspaces. Articles restored while the purge runs are skipped.
+func (uc *TrashUsecase) Purge(ctx context.Context, input PurgeInput) (*PurgeReport, error) {
+	if uc.retention <= 0 {
+		return nil, fmt.Errorf("%w: trash retention is not configured", apperr.ErrConflict)
+	}
+	report := &PurgeReport{
+		DryRun:   input.DryRun,
+		Cutoff:   uc.now().Add(-uc.retention),
+		Articles: []PurgedArticleOutput{},
+	}
+	var afterID uint64
+	for {
+		articles, err := uc.articles.FindDeletedBefore(ctx, report.Cutoff, afterID, uc.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range articles {
+			afterID = a.ID
+			if !input.DryRun {
+				err := uc.purge(ctx, a, report.Cutoff)
+				if errors.Is(err, repository.ErrArticleNotFound) {
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+				report.Purged++
+			}
+			report.Articles = append(report.Articles, newPurgedArticleOutput(a))
+		}
+		if len(articles) < uc.batchSize {
+			return report, nil
+		}
+	}
+}
+
+// purge deletes an article and records the deletion in the workspace of the article.
+func (uc *TrashUsecase) purge(ctx context.Context, article *entity.Article, cutoff time.Time) error {
+	ctx = repository.WithWorkspace(ctx, article.WorkspaceID)
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.articles.Purge(ctx, article.ID, cutoff); err != nil {
+			return err
+		}
+		if uc.auditLog == nil {
+			return nil
+		}
+		return uc.auditLog.Record(ctx, entity.NewAuditEntry(article.ID, entity.AuditActionPurge, entity.SnapshotArticle(article), nil))
+	})
+}
+
+func newPurgedArticleOutput(a *entity.Article) PurgedArticleOutput {
+	output := PurgedArticleOutput{
+		ID:          a.ID,
+		WorkspaceID: a.WorkspaceID,
+		Title:       a.Title.String(),
+		Slug:        a.Slug.String(),
+	}
+	if a.DeletedAt != nil {
+		output.DeletedAt = *a.DeletedAt
+	}
+	return output
+}
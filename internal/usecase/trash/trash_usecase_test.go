@@ -0,0 +1,171 @@
+package trash_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/trash"
+)
+
+// stubArticleRepository はゴミ箱の記事を保持し、完全な削除を記録する
+type stubArticleRepository struct {
+	repository.ArticleRepository
+	deleted  []*entity.Article
+	restored map[uint64]bool
+	purged   []uint64
+	// purgeErr は完全な削除に失敗させるエラー
+	purgeErr error
+}
+
+func (s *stubArticleRepository) FindDeletedBefore(_ context.Context, before time.Time, afterID uint64, limit int) ([]*entity.Article, error) {
+	var found []*entity.Article
+	for _, a := range s.deleted {
+		if a.ID > afterID && a.DeletedAt.Before(before) && len(found) < limit {
+			found = append(found, a)
+		}
+	}
+	return found, nil
+}
+
+func (s *stubArticleRepository) Purge(ctx context.Context, id uint64, _ time.Time) error {
+	if s.purgeErr != nil {
+		return s.purgeErr
+	}
+	if s.restored[id] {
+		return fmt.Errorf("deleted article %d: %w", id, repository.ErrArticleNotFound)
+	}
+	if wsID, _, _ := repository.WorkspaceFrom(ctx); wsID != 2 {
+		return fmt.Errorf("purged in workspace %d", wsID)
+	}
+	s.purged = append(s.purged, id)
+	return nil
+}
+
+// passthroughTxManager はトランザクションを張らずにfnをそのまま実行する
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (passthroughTxManager) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// recordingAuditLog は記録された監査ログを保持する
+type recordingAuditLog struct {
+	entries []*entity.AuditEntry
+}
+
+func (l *recordingAuditLog) Record(_ context.Context, entry *entity.AuditEntry) error {
+	l.entries = append(l.entries, entry)
+	return nil
+}
+
+func deletedArticle(id uint64, deletedAt time.Time) *entity.Article {
+	return &entity.Article{ID: id, Title: "記事", Slug: "slug", WorkspaceID: 2, DeletedAt: &deletedAt}
+}
+
+func newRepository(now time.Time) *stubArticleRepository {
+	return &stubArticleRepository{
+		deleted: []*entity.Article{
+			deletedArticle(1, now.Add(-40*24*time.Hour)),
+			deletedArticle(2, now.Add(-10*24*time.Hour)),
+			deletedArticle(3, now.Add(-31*24*time.Hour)),
+			deletedArticle(4, now.Add(-60*24*time.Hour)),
+		},
+		restored: map[uint64]bool{},
+	}
+}
+
+func reportIDs(report *trash.PurgeReport) []uint64 {
+	var ids []uint64
+	for _, a := range report.Articles {
+		ids = append(ids, a.ID)
+	}
+	return ids
+}
+
+func TestTrashUsecase_Purge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	retention := 30 * 24 * time.Hour
+	ctx := repository.WithAllWorkspaces(context.Background())
+
+	t.Run("保持期間を過ぎた記事を完全に削除し、監査ログに記録する", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepository(now)
+		auditLog := &recordingAuditLog{}
+		uc := trash.NewTrashUsecase(repo, passthroughTxManager{}, retention, trash.WithAuditLog(auditLog), trash.WithBatchSize(2))
+
+		report, err := uc.Purge(ctx, trash.PurgeInput{})
+		require.NoError(t, err)
+
+		assert.False(t, report.DryRun)
+		assert.Equal(t, 3, report.Purged)
+		assert.Equal(t, []uint64{1, 3, 4}, reportIDs(report))
+		assert.Equal(t, []uint64{1, 3, 4}, repo.purged)
+		require.Len(t, auditLog.entries, 3)
+		assert.Equal(t, entity.AuditActionPurge, auditLog.entries[0].Action)
+		assert.Equal(t, entity.FieldChange{Before: "記事", After: nil}, auditLog.entries[0].Changes["title"])
+	})
+
+	t.Run("ドライランは削除せずに対象を報告する", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepository(now)
+		auditLog := &recordingAuditLog{}
+		uc := trash.NewTrashUsecase(repo, passthroughTxManager{}, retention, trash.WithAuditLog(auditLog), trash.WithBatchSize(2))
+
+		report, err := uc.Purge(ctx, trash.PurgeInput{DryRun: true})
+		require.NoError(t, err)
+
+		assert.True(t, report.DryRun)
+		assert.Zero(t, report.Purged)
+		assert.Equal(t, []uint64{1, 3, 4}, reportIDs(report))
+		assert.Empty(t, repo.purged)
+		assert.Empty(t, auditLog.entries)
+	})
+
+	t.Run("途中で復元された記事は飛ばす", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepository(now)
+		repo.restored[3] = true
+		uc := trash.NewTrashUsecase(repo, passthroughTxManager{}, retention)
+
+		report, err := uc.Purge(ctx, trash.PurgeInput{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, report.Purged)
+		assert.Equal(t, []uint64{1, 4}, reportIDs(report))
+	})
+
+	t.Run("削除に失敗した場合はエラー", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepository(now)
+		repo.purgeErr = errors.New("connection reset")
+		uc := trash.NewTrashUsecase(repo, passthroughTxManager{}, retention)
+
+		_, err := uc.Purge(ctx, trash.PurgeInput{})
+		assert.ErrorContains(t, err, "connection reset")
+	})
+
+	t.Run("保持期間が設定されていなければ削除しない", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepository(now)
+		uc := trash.NewTrashUsecase(repo, passthroughTxManager{}, 0)
+
+		_, err := uc.Purge(ctx, trash.PurgeInput{DryRun: true})
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+		assert.Empty(t, repo.purged)
+	})
+}
@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+)
+
+// CreateSubscriptionInput is the input for creating a webhook subscription.
+type CreateSubscriptionInput struct {
+	URL string `json:"url"`
+	// Secret is generated when omitted.
+	Secret     *string  `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types"`
+}
+
+// UpdateSubscriptionInput is the input for updating a webhook subscription.
+type UpdateSubscriptionInput struct {
+	URL        *string  `json:"url,omitempty"`
+	Secret     *string  `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+	Active     *bool    `json:"active,omitempty"`
+}
+
+// SubscriptionOutput is the output for a webhook subscription.
+// The secret is only returned when the subscription is created.
+type SubscriptionOutput struct {
+	ID         uint64    `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// DeliveryOutput is the output for a webhook delivery log entry.
+type DeliveryOutput struct {
+	ID             uint64     `json:"id"`
+	SubscriptionID uint64     `json:"subscription_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus *int       `json:"response_status"`
+	LastError      *string    `json:"last_error"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	ReplayOf       *uint64    `json:"replay_of"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+}
+
+// DispatchInput is the input for dispatching an article event to subscribers.
+type DispatchInput struct {
+	// MessageID identifies the outbox message of the event, so that processing
+	// the message again enqueues no further deliveries. Zero means none.
+	MessageID  uint64
+	Event      string
+	OccurredAt time.Time
+	Article    article.FindArticleByIDOutput
+}
+
+// Payload is the JSON body sent to webhook subscribers.
+type Payload struct {
+	Event      string                        `json:"event"`
+	OccurredAt time.Time                     `json:"occurred_at"`
+	Data       article.FindArticleByIDOutput `json:"data"`
+}
@@ -0,0 +1,349 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+)
+
+const (
+	defaultMaxAttempts = 8
+	defaultBaseBackoff = 30 * time.Second
+	defaultMaxBackoff  = 6 * time.Hour
+	deliveryLogLimit   = 100
+)
+
+// DeliveryRequest is a single signed HTTP delivery to a subscriber.
+type DeliveryRequest struct {
+	DeliveryID uint64
+	URL        string
+	Secret     string
+	EventType  string
+	Payload    []byte
+}
+
+// Sender sends a webhook delivery and returns the response status code.
+// A non-nil error with a non-zero status code means the subscriber responded with a failure.
+type Sender interface {
+	Send(ctx context.Context, req DeliveryRequest) (int, error)
+}
+
+// WebhookUsecase manages webhook subscriptions and their deliveries.
+type WebhookUsecase struct {
+	subscriptions repository.WebhookSubscriptionRepository
+	deliveries    repository.WebhookDeliveryRepository
+	sender        Sender
+	maxAttempts   int
+	baseBackoff   time.Duration
+	maxBackoff    time.Duration
+	now           func() time.Time
+}
+
+// Option configures a WebhookUsecase.
+type Option func(*WebhookUsecase)
+
+// WithRetryPolicy sets the maximum attempts and the exponential backoff bounds.
+func WithRetryPolicy(maxAttempts int, base, max time.Duration) Option {
+	return func(uc *WebhookUsecase) {
+		if maxAttempts > 0 {
+			uc.maxAttempts = maxAttempts
+		}
+		if base > 0 {
+			uc.baseBackoff = base
+		}
+		if max > 0 {
+			uc.maxBackoff = max
+		}
+	}
+}
+
+// WithClock overrides the clock used for scheduling retries.
+func WithClock(now func() time.Time) Option {
+	return func(uc *WebhookUsecase) {
+		uc.now = now
+	}
+}
+
+// NewWebhookUsecase creates a new WebhookUsecase.
+func NewWebhookUsecase(
+	subscriptions repository.WebhookSubscriptionRepository,
+	deliveries repository.WebhookDeliveryRepository,
+	sender Sender,
+	opts ...Option,
+) *WebhookUsecase {
+	uc := &WebhookUsecase{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		sender:        sender,
+		maxAttempts:   defaultMaxAttempts,
+		baseBackoff:   defaultBaseBackoff,
+		maxBackoff:    defaultMaxBackoff,
+		now:           time.Now,
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// ListSubscriptions retrieves all webhook subscriptions.
+func (uc *WebhookUsecase) ListSubscriptions(ctx context.Context) ([]SubscriptionOutput, error) {
+	subs, err := uc.subscriptions.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook subscriptions: %w", err)
+	}
+	outputs := make([]SubscriptionOutput, 0, len(subs))
+	for _, s := range subs {
+		outputs = append(outputs, toSubscriptionOutput(s, false))
+	}
+	return outputs, nil
+}
+
+// FindSubscriptionByID retrieves a webhook subscription by its ID.
+func (uc *WebhookUsecase) FindSubscriptionByID(ctx context.Context, id uint64) (*SubscriptionOutput, error) {
+	sub, err := uc.subscriptions.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	output := toSubscriptionOutput(sub, false)
+	return &output, nil
+}
+
+// CreateSubscription creates a new webhook subscription.
+// The returned output is the only place the secret is exposed.
+func (uc *WebhookUsecase) CreateSubscription(ctx context.Context, input CreateSubscriptionInput) (*SubscriptionOutput, error) {
+	secret := ""
+	if input.Secret != nil {
+		secret = *input.Secret
+	} else {
+		generated, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	sub, err := entity.NewWebhookSubscription(input.URL, secret, input.EventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+	}
+
+	created, err := uc.subscriptions.Create(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	output := toSubscriptionOutput(created, true)
+	return &output, nil
+}
+
+// UpdateSubscription updates an existing webhook subscription.
+func (uc *WebhookUsecase) UpdateSubscription(ctx context.Context, id uint64, input UpdateSubscriptionInput) (*SubscriptionOutput, error) {
+	sub, err := uc.subscriptions.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := sub.Update(input.URL, input.Secret, input.EventTypes, input.Active); err != nil {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+	}
+	if err := uc.subscriptions.Update(ctx, sub); err != nil {
+		return nil, err
+	}
+	output := toSubscriptionOutput(sub, false)
+	return &output, nil
+}
+
+// DeleteSubscription deletes a webhook subscription by its ID.
+func (uc *WebhookUsecase) DeleteSubscription(ctx context.Context, id uint64) error {
+	return uc.subscriptions.Delete(ctx, id)
+}
+
+// ListDeliveries retrieves the most recent deliveries of a subscription.
+func (uc *WebhookUsecase) ListDeliveries(ctx context.Context, subscriptionID uint64) ([]DeliveryOutput, error) {
+	if _, err := uc.subscriptions.FindByID(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	deliveries, err := uc.deliveries.FindBySubscriptionID(ctx, subscriptionID, deliveryLogLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook deliveries: %w", err)
+	}
+	outputs := make([]DeliveryOutput, 0, len(deliveries))
+	for _, d := range deliveries {
+		outputs = append(outputs, toDeliveryOutput(d))
+	}
+	return outputs, nil
+}
+
+// ReplayDelivery enqueues a new delivery with the same payload as an existing one.
+func (uc *WebhookUsecase) ReplayDelivery(ctx context.Context, subscriptionID uint64, deliveryID uint64) (*DeliveryOutput, error) {
+	original, err := uc.deliveries.FindByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original.SubscriptionID != subscriptionID {
+		return nil, fmt.Errorf("delivery %d of subscription %d: %w", deliveryID, subscriptionID, repository.ErrWebhookDeliveryNotFound)
+	}
+
+	replay, err := uc.deliveries.Create(ctx, original.Replay())
+	if err != nil {
+		return nil, err
+	}
+	output := toDeliveryOutput(replay)
+	return &output, nil
+}
+
+// Dispatch enqueues a delivery for every active subscription of the event.
+// The deliveries are enqueued all at once, and none of them again for the same message.
+func (uc *WebhookUsecase) Dispatch(ctx context.Context, input DispatchInput) error {
+	eventType := entity.ArticleEventType(input.Event)
+	if !eventType.IsValid() {
+		return fmt.Errorf("%w: unknown event type %s", apperr.ErrInvalidInput, input.Event)
+	}
+
+	subs, err := uc.subscriptions.FindActiveByEventType(ctx, eventType)
+	if err != nil {
+		return fmt.Errorf("failed to find subscriptions for %s: %w", eventType, err)
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(Payload{
+		Event:      input.Event,
+		OccurredAt: input.OccurredAt,
+		Data:       input.Article,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	deliveries := make([]*entity.WebhookDelivery, 0, len(subs))
+	for _, sub := range subs {
+		if input.MessageID != 0 {
+			deliveries = append(deliveries, entity.NewWebhookDeliveryForMessage(input.MessageID, sub.ID, eventType, payload))
+		} else {
+			deliveries = append(deliveries, entity.NewWebhookDelivery(sub.ID, eventType, payload))
+		}
+	}
+	if err := uc.deliveries.CreateAll(ctx, deliveries); err != nil {
+		return fmt.Errorf("failed to enqueue deliveries for %s: %w", eventType, err)
+	}
+	return nil
+}
+
+// DeliverDue sends pending deliveries whose next attempt is due and returns how many were processed.
+// An error on one delivery does not stop the others; the errors are joined and
+// returned after the batch. A delivery whose result could not be saved stays
+// claimed by the repository and is retried once its claim expires.
+func (uc *WebhookUsecase) DeliverDue(ctx context.Context, limit int) (int, error) {
+	deliveries, err := uc.deliveries.FindDue(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find due deliveries: %w", err)
+	}
+
+	var errs []error
+	for _, d := range deliveries {
+		if err := uc.deliver(ctx, d); err != nil {
+			errs = append(errs, fmt.Errorf("delivery %d: %w", d.ID, err))
+		}
+	}
+	return len(deliveries), errors.Join(errs...)
+}
+
+func (uc *WebhookUsecase) deliver(ctx context.Context, d *entity.WebhookDelivery) error {
+	sub, err := uc.subscriptions.FindByID(ctx, d.SubscriptionID)
+	if errors.Is(err, repository.ErrWebhookSubscriptionNotFound) {
+		d.RecordFailure(nil, "subscription not found", nil)
+		return uc.deliveries.Update(ctx, d)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find subscription %d: %w", d.SubscriptionID, err)
+	}
+
+	if !sub.Active {
+		d.RecordFailure(nil, "subscription is inactive", nil)
+		return uc.deliveries.Update(ctx, d)
+	}
+
+	statusCode, sendErr := uc.sender.Send(ctx, DeliveryRequest{
+		DeliveryID: d.ID,
+		URL:        sub.URL,
+		Secret:     sub.Secret,
+		EventType:  d.EventType.String(),
+		Payload:    d.Payload,
+	})
+	if sendErr == nil {
+		d.RecordSuccess(statusCode, uc.now())
+		return uc.deliveries.Update(ctx, d)
+	}
+
+	var code *int
+	if statusCode != 0 {
+		code = &statusCode
+	}
+	var next *time.Time
+	if d.Attempts+1 < uc.maxAttempts {
+		t := uc.now().Add(uc.backoff(d.Attempts + 1))
+		next = &t
+	}
+	d.RecordFailure(code, sendErr.Error(), next)
+	return uc.deliveries.Update(ctx, d)
+}
+
+// backoff returns the exponential backoff for the given attempt count.
+func (uc *WebhookUsecase) backoff(attempts int) time.Duration {
+	d := uc.baseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= uc.maxBackoff {
+			return uc.maxBackoff
+		}
+	}
+	return d
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func toSubscriptionOutput(s *entity.WebhookSubscription, withSecret bool) SubscriptionOutput {
+	output := SubscriptionOutput{
+		ID:         s.ID,
+		URL:        s.URL,
+		EventTypes: s.EventTypeStrings(),
+		Active:     s.Active,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+	if withSecret {
+		output.Secret = s.Secret
+	}
+	return output
+}
+
+func toDeliveryOutput(d *entity.WebhookDelivery) DeliveryOutput {
+	return DeliveryOutput{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventType:      d.EventType.String(),
+		Status:         d.Status.String(),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		NextAttemptAt:  d.NextAttemptAt,
+		ReplayOf:       d.ReplayOf,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
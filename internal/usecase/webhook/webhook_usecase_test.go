@@ -0,0 +1,380 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/article"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/webhook"
+)
+
+type MockSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSubscriptionRepository) FindAll(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*entity.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) FindByID(ctx context.Context, id uint64) (*entity.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) FindActiveByEventType(ctx context.Context, eventType entity.ArticleEventType) ([]*entity.WebhookSubscription, error) {
+	args := m.Called(ctx, eventType)
+	return args.Get(0).([]*entity.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) Create(ctx context.Context, s *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	args := m.Called(ctx, s)
+	if fn, ok := args.Get(0).(func(context.Context, *entity.WebhookSubscription) *entity.WebhookSubscription); ok {
+		return fn(ctx, s), args.Error(1)
+	}
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) Update(ctx context.Context, s *entity.WebhookSubscription) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) Delete(ctx context.Context, id uint64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockDeliveryRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeliveryRepository) FindByID(ctx context.Context, id uint64) (*entity.WebhookDelivery, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockDeliveryRepository) FindBySubscriptionID(ctx context.Context, subscriptionID uint64, limit int) ([]*entity.WebhookDelivery, error) {
+	args := m.Called(ctx, subscriptionID, limit)
+	return args.Get(0).([]*entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockDeliveryRepository) FindDue(ctx context.Context, limit int) ([]*entity.WebhookDelivery, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]*entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockDeliveryRepository) Create(ctx context.Context, d *entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	args := m.Called(ctx, d)
+	if fn, ok := args.Get(0).(func(context.Context, *entity.WebhookDelivery) *entity.WebhookDelivery); ok {
+		return fn(ctx, d), args.Error(1)
+	}
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockDeliveryRepository) CreateAll(ctx context.Context, deliveries []*entity.WebhookDelivery) error {
+	args := m.Called(ctx, deliveries)
+	return args.Error(0)
+}
+
+func (m *MockDeliveryRepository) Update(ctx context.Context, d *entity.WebhookDelivery) error {
+	args := m.Called(ctx, d)
+	return args.Error(0)
+}
+
+type MockSender struct {
+	mock.Mock
+}
+
+func (m *MockSender) Send(ctx context.Context, req webhook.DeliveryRequest) (int, error) {
+	args := m.Called(ctx, req)
+	return args.Int(0), args.Error(1)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func newSubscription(t *testing.T, id uint64, eventTypes ...string) *entity.WebhookSubscription {
+	t.Helper()
+	sub, err := entity.NewWebhookSubscription("https://example.com/hook", "0123456789abcdef", eventTypes)
+	require.NoError(t, err)
+	sub.ID = id
+	return sub
+}
+
+func TestWebhookUsecase_CreateSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("シークレット未指定の場合は生成して返す", func(t *testing.T) {
+		subs := new(MockSubscriptionRepository)
+		uc := webhook.NewWebhookUsecase(subs, new(MockDeliveryRepository), new(MockSender))
+
+		subs.On("Create", ctx, mock.AnythingOfType("*entity.WebhookSubscription")).
+			Return(func(_ context.Context, s *entity.WebhookSubscription) *entity.WebhookSubscription {
+				s.ID = 1
+				return s
+			}, nil)
+
+		output, err := uc.CreateSubscription(ctx, webhook.CreateSubscriptionInput{
+			URL:        "https://example.com/hook",
+			EventTypes: []string{"article.published"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), output.ID)
+		assert.Len(t, output.Secret, 64)
+		subs.AssertExpectations(t)
+	})
+
+	t.Run("無効な入力はErrInvalidInput", func(t *testing.T) {
+		subs := new(MockSubscriptionRepository)
+		uc := webhook.NewWebhookUsecase(subs, new(MockDeliveryRepository), new(MockSender))
+
+		_, err := uc.CreateSubscription(ctx, webhook.CreateSubscriptionInput{
+			URL:        "https://example.com/hook",
+			EventTypes: []string{"article.unknown"},
+		})
+
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+		subs.AssertNotCalled(t, "Create")
+	})
+}
+
+func TestWebhookUsecase_Dispatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("購読ごとの配信をまとめて作成する", func(t *testing.T) {
+		subs := new(MockSubscriptionRepository)
+		deliveries := new(MockDeliveryRepository)
+		uc := webhook.NewWebhookUsecase(subs, deliveries, new(MockSender))
+
+		subs.On("FindActiveByEventType", ctx, entity.ArticleEventPublished).
+			Return([]*entity.WebhookSubscription{newSubscription(t, 1, "article.published"), newSubscription(t, 2, "article.published")}, nil)
+
+		var created []*entity.WebhookDelivery
+		deliveries.On("CreateAll", ctx, mock.AnythingOfType("[]*entity.WebhookDelivery")).
+			Run(func(args mock.Arguments) {
+				created = args.Get(1).([]*entity.WebhookDelivery)
+			}).
+			Return(nil).Once()
+
+		err := uc.Dispatch(ctx, webhook.DispatchInput{
+			MessageID: 7,
+			Event:     "article.published",
+			Article:   article.FindArticleByIDOutput{ID: 10, Title: "T", Status: "published"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, created, 2)
+		for i, d := range created {
+			assert.Equal(t, uint64(i+1), d.SubscriptionID)
+			// 同じメッセージを再び処理しても重複しないよう、メッセージを記録する
+			require.NotNil(t, d.MessageID)
+			assert.Equal(t, uint64(7), *d.MessageID)
+		}
+		var payload webhook.Payload
+		require.NoError(t, json.Unmarshal(created[0].Payload, &payload))
+		assert.Equal(t, "article.published", payload.Event)
+		assert.Equal(t, uint64(10), payload.Data.ID)
+		deliveries.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("配信を作成できなければエラーを返す", func(t *testing.T) {
+		subs := new(MockSubscriptionRepository)
+		deliveries := new(MockDeliveryRepository)
+		uc := webhook.NewWebhookUsecase(subs, deliveries, new(MockSender))
+
+		subs.On("FindActiveByEventType", ctx, entity.ArticleEventPublished).
+			Return([]*entity.WebhookSubscription{newSubscription(t, 1, "article.published")}, nil)
+		deliveries.On("CreateAll", ctx, mock.Anything).Return(errors.New("db down"))
+
+		err := uc.Dispatch(ctx, webhook.DispatchInput{MessageID: 7, Event: "article.published"})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("購読がなければ何もしない", func(t *testing.T) {
+		subs := new(MockSubscriptionRepository)
+		deliveries := new(MockDeliveryRepository)
+		uc := webhook.NewWebhookUsecase(subs, deliveries, new(MockSender))
+
+		subs.On("FindActiveByEventType", ctx, entity.ArticleEventDeleted).Return([]*entity.WebhookSubscription{}, nil)
+
+		err := uc.Dispatch(ctx, webhook.DispatchInput{Event: "article.deleted"})
+
+		require.NoError(t, err)
+		deliveries.AssertNotCalled(t, "CreateAll", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookUsecase_DeliverDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newUsecase := func() (*webhook.WebhookUsecase, *MockSubscriptionRepository, *MockDeliveryRepository, *MockSender) {
+		subs := new(MockSubscriptionRepository)
+		deliveries := new(MockDeliveryRepository)
+		sender := new(MockSender)
+		uc := webhook.NewWebhookUsecase(subs, deliveries, sender,
+			webhook.WithRetryPolicy(3, time.Minute, time.Hour),
+			webhook.WithClock(func() time.Time { return now }),
+		)
+		return uc, subs, deliveries, sender
+	}
+
+	t.Run("成功した配信は成功として記録される", func(t *testing.T) {
+		uc, subs, deliveries, sender := newUsecase()
+		d := entity.NewWebhookDelivery(1, entity.ArticleEventPublished, []byte(`{}`))
+		d.ID = 5
+
+		deliveries.On("FindDue", ctx, 10).Return([]*entity.WebhookDelivery{d}, nil)
+		subs.On("FindByID", ctx, uint64(1)).Return(newSubscription(t, 1, "article.published"), nil)
+		sender.On("Send", ctx, mock.MatchedBy(func(req webhook.DeliveryRequest) bool {
+			return req.DeliveryID == 5 && req.EventType == "article.published"
+		})).Return(200, nil)
+		deliveries.On("Update", ctx, d).Return(nil)
+
+		n, err := uc.DeliverDue(ctx, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, entity.WebhookDeliverySucceeded, d.Status)
+		assert.Equal(t, 200, *d.ResponseStatus)
+	})
+
+	t.Run("失敗した配信は指数バックオフで再試行される", func(t *testing.T) {
+		uc, subs, deliveries, sender := newUsecase()
+		d := entity.NewWebhookDelivery(1, entity.ArticleEventPublished, []byte(`{}`))
+		d.Attempts = 1
+
+		deliveries.On("FindDue", ctx, 10).Return([]*entity.WebhookDelivery{d}, nil)
+		subs.On("FindByID", ctx, uint64(1)).Return(newSubscription(t, 1, "article.published"), nil)
+		sender.On("Send", ctx, mock.Anything).Return(503, fmt.Errorf("unavailable"))
+		deliveries.On("Update", ctx, d).Return(nil)
+
+		_, err := uc.DeliverDue(ctx, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.WebhookDeliveryPending, d.Status)
+		assert.Equal(t, 2, d.Attempts)
+		assert.Equal(t, now.Add(2*time.Minute), d.NextAttemptAt)
+		assert.Equal(t, 503, *d.ResponseStatus)
+	})
+
+	t.Run("最大試行回数に達したら失敗として確定する", func(t *testing.T) {
+		uc, subs, deliveries, sender := newUsecase()
+		d := entity.NewWebhookDelivery(1, entity.ArticleEventPublished, []byte(`{}`))
+		d.Attempts = 2
+
+		deliveries.On("FindDue", ctx, 10).Return([]*entity.WebhookDelivery{d}, nil)
+		subs.On("FindByID", ctx, uint64(1)).Return(newSubscription(t, 1, "article.published"), nil)
+		sender.On("Send", ctx, mock.Anything).Return(0, fmt.Errorf("connection refused"))
+		deliveries.On("Update", ctx, d).Return(nil)
+
+		_, err := uc.DeliverDue(ctx, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.WebhookDeliveryFailed, d.Status)
+		assert.Nil(t, d.ResponseStatus)
+	})
+
+	t.Run("一件のエラーで残りの配信を止めずにエラーをまとめて返す", func(t *testing.T) {
+		uc, subs, deliveries, sender := newUsecase()
+		broken := entity.NewWebhookDelivery(1, entity.ArticleEventPublished, []byte(`{}`))
+		broken.ID = 5
+		ok := entity.NewWebhookDelivery(2, entity.ArticleEventPublished, []byte(`{}`))
+		ok.ID = 6
+
+		deliveries.On("FindDue", ctx, 10).Return([]*entity.WebhookDelivery{broken, ok}, nil)
+		subs.On("FindByID", ctx, uint64(1)).Return(nil, fmt.Errorf("connection reset"))
+		subs.On("FindByID", ctx, uint64(2)).Return(newSubscription(t, 2, "article.published"), nil)
+		sender.On("Send", ctx, mock.Anything).Return(200, nil)
+		deliveries.On("Update", ctx, ok).Return(nil)
+
+		n, err := uc.DeliverDue(ctx, 10)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "delivery 5")
+		assert.Equal(t, 2, n)
+		assert.Equal(t, entity.WebhookDeliverySucceeded, ok.Status)
+		deliveries.AssertNotCalled(t, "Update", ctx, broken)
+	})
+
+	t.Run("購読が見つからない配信は失敗として確定する", func(t *testing.T) {
+		uc, subs, deliveries, sender := newUsecase()
+		d := entity.NewWebhookDelivery(1, entity.ArticleEventPublished, []byte(`{}`))
+
+		deliveries.On("FindDue", ctx, 10).Return([]*entity.WebhookDelivery{d}, nil)
+		subs.On("FindByID", ctx, uint64(1)).Return(nil, repository.ErrWebhookSubscriptionNotFound)
+		deliveries.On("Update", ctx, d).Return(nil)
+
+		_, err := uc.DeliverDue(ctx, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.WebhookDeliveryFailed, d.Status)
+		sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookUsecase_ReplayDelivery(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("同じペイロードで再送を作成する", func(t *testing.T) {
+		deliveries := new(MockDeliveryRepository)
+		uc := webhook.NewWebhookUsecase(new(MockSubscriptionRepository), deliveries, new(MockSender))
+
+		original := entity.NewWebhookDelivery(1, entity.ArticleEventPublished, []byte(`{"a":1}`))
+		original.ID = 7
+		deliveries.On("FindByID", ctx, uint64(7)).Return(original, nil)
+		deliveries.On("Create", ctx, mock.AnythingOfType("*entity.WebhookDelivery")).
+			Return(func(_ context.Context, d *entity.WebhookDelivery) *entity.WebhookDelivery {
+				d.ID = 8
+				return d
+			}, nil)
+
+		output, err := uc.ReplayDelivery(ctx, 1, 7)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(8), output.ID)
+		assert.Equal(t, ptr(uint64(7)), output.ReplayOf)
+		assert.Equal(t, "pending", output.Status)
+	})
+
+	t.Run("別の購読の配信は見つからない扱い", func(t *testing.T) {
+		deliveries := new(MockDeliveryRepository)
+		uc := webhook.NewWebhookUsecase(new(MockSubscriptionRepository), deliveries, new(MockSender))
+
+		original := entity.NewWebhookDelivery(2, entity.ArticleEventPublished, []byte(`{}`))
+		original.ID = 7
+		deliveries.On("FindByID", ctx, uint64(7)).Return(original, nil)
+
+		_, err := uc.ReplayDelivery(ctx, 1, 7)
+
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+		deliveries.AssertNotCalled(t, "Create")
+	})
+}
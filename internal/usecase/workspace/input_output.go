@@ -0,0 +1,78 @@
+package workspace
+
+import (
+	"time"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+)
+
+// CreateWorkspaceInput is the input for creating a workspace.
+// Slug addresses the workspace as a subdomain or in the X-Workspace header.
+type CreateWorkspaceInput struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// SettingsInput overrides the server-wide feed settings for a workspace.
+// Empty fields fall back to the server-wide settings. RequireReview makes
+// articles publishable only once approved in review.
+type SettingsInput struct {
+	FeedTitle       string `json:"feed_title"`
+	FeedDescription string `json:"feed_description"`
+	SiteURL         string `json:"site_url"`
+	RequireReview   bool   `json:"require_review"`
+}
+
+// UpdateWorkspaceInput is the input for updating a workspace.
+// Nil fields are left unchanged. ProviderCredentials maps a provider ("qiita",
+// "zenn" or "note") to its access token; an empty token removes the provider.
+type UpdateWorkspaceInput struct {
+	Name                *string           `json:"name,omitempty"`
+	Settings            *SettingsInput    `json:"settings,omitempty"`
+	ProviderCredentials map[string]string `json:"provider_credentials,omitempty"`
+}
+
+// SettingsOutput is the settings of a workspace.
+type SettingsOutput struct {
+	FeedTitle       string `json:"feed_title"`
+	FeedDescription string `json:"feed_description"`
+	SiteURL         string `json:"site_url"`
+	RequireReview   bool   `json:"require_review"`
+}
+
+// WorkspaceOutput is the output for a workspace.
+// Credentials are never returned; CredentialProviders lists the providers that have one.
+type WorkspaceOutput struct {
+	ID                  uint64         `json:"id"`
+	Slug                string         `json:"slug"`
+	Name                string         `json:"name"`
+	Settings            SettingsOutput `json:"settings"`
+	CredentialProviders []string       `json:"credential_providers"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+}
+
+func newSettingsOutput(s entity.WorkspaceSettings) SettingsOutput {
+	return SettingsOutput{
+		FeedTitle:       s.FeedTitle,
+		FeedDescription: s.FeedDescription,
+		SiteURL:         s.SiteURL,
+		RequireReview:   s.RequireReview,
+	}
+}
+
+func newWorkspaceOutput(w *entity.Workspace) WorkspaceOutput {
+	providers := make([]string, 0, len(w.ProviderCredentials))
+	for _, p := range w.CredentialProviders() {
+		providers = append(providers, p.String())
+	}
+	return WorkspaceOutput{
+		ID:                  w.ID,
+		Slug:                w.Slug,
+		Name:                w.Name,
+		Settings:            newSettingsOutput(w.Settings),
+		CredentialProviders: providers,
+		CreatedAt:           w.CreatedAt,
+		UpdatedAt:           w.UpdatedAt,
+	}
+}
@@ -0,0 +1,179 @@
+// Package workspace manages the workspaces that isolate the data of the
+// teams sharing the hub, together with their settings and provider credentials.
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+)
+
+// Authorizer checks whether the caller carried by the context may perform
+// an operation that requires the given scope.
+type Authorizer interface {
+	Authorize(ctx context.Context, required vo.Scope) error
+}
+
+// WorkspaceUsecase manages workspaces and resolves the workspace of requests.
+type WorkspaceUsecase struct {
+	repo       repository.WorkspaceRepository
+	authorizer Authorizer
+}
+
+// Option configures a WorkspaceUsecase.
+type Option func(*WorkspaceUsecase)
+
+// WithAuthorizer enables authorization: reading and changing the settings
+// of the current workspace requires articles:admin.
+func WithAuthorizer(a Authorizer) Option {
+	return func(uc *WorkspaceUsecase) {
+		uc.authorizer = a
+	}
+}
+
+// NewWorkspaceUsecase creates a new WorkspaceUsecase.
+func NewWorkspaceUsecase(repo repository.WorkspaceRepository, opts ...Option) *WorkspaceUsecase {
+	uc := &WorkspaceUsecase{repo: repo}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// Create creates a workspace. Like List, it is an operator task run from
+// the command line and is not subject to authorization.
+func (uc *WorkspaceUsecase) Create(ctx context.Context, input CreateWorkspaceInput) (*WorkspaceOutput, error) {
+	w, err := entity.NewWorkspace(input.Slug, input.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+	}
+	created, err := uc.repo.Create(ctx, w)
+	if errors.Is(err, repository.ErrWorkspaceSlugConflict) {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrConflict, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	output := newWorkspaceOutput(created)
+	return &output, nil
+}
+
+// List retrieves all workspaces.
+func (uc *WorkspaceUsecase) List(ctx context.Context) ([]WorkspaceOutput, error) {
+	workspaces, err := uc.repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find workspaces: %w", err)
+	}
+	outputs := make([]WorkspaceOutput, 0, len(workspaces))
+	for _, w := range workspaces {
+		outputs = append(outputs, newWorkspaceOutput(w))
+	}
+	return outputs, nil
+}
+
+// ResolveWorkspace returns the ID of the workspace with the slug.
+func (uc *WorkspaceUsecase) ResolveWorkspace(ctx context.Context, slug string) (uint64, error) {
+	if slug == entity.DefaultWorkspaceSlug {
+		return entity.DefaultWorkspaceID, nil
+	}
+	w, err := uc.repo.FindBySlug(ctx, slug)
+	if err != nil {
+		return 0, err
+	}
+	return w.ID, nil
+}
+
+// Current retrieves the workspace of the context.
+func (uc *WorkspaceUsecase) Current(ctx context.Context) (*WorkspaceOutput, error) {
+	if err := uc.authorize(ctx, vo.ScopeArticlesAdmin); err != nil {
+		return nil, err
+	}
+	w, err := uc.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	output := newWorkspaceOutput(w)
+	return &output, nil
+}
+
+// UpdateCurrent updates the name, settings and provider credentials of the workspace of the context.
+func (uc *WorkspaceUsecase) UpdateCurrent(ctx context.Context, input UpdateWorkspaceInput) (*WorkspaceOutput, error) {
+	if err := uc.authorize(ctx, vo.ScopeArticlesAdmin); err != nil {
+		return nil, err
+	}
+	w, err := uc.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var settings *entity.WorkspaceSettings
+	if input.Settings != nil {
+		settings = &entity.WorkspaceSettings{
+			FeedTitle:       input.Settings.FeedTitle,
+			FeedDescription: input.Settings.FeedDescription,
+			SiteURL:         input.Settings.SiteURL,
+			RequireReview:   input.Settings.RequireReview,
+		}
+	}
+	if err := w.Update(input.Name, settings, input.ProviderCredentials); err != nil {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+	}
+	if err := uc.repo.Update(ctx, w); err != nil {
+		return nil, err
+	}
+	output := newWorkspaceOutput(w)
+	return &output, nil
+}
+
+// Settings returns the settings of the workspace of the context, e.g. to
+// render its public feeds. It is not subject to authorization and returns
+// empty settings if the context addresses no single workspace.
+func (uc *WorkspaceUsecase) Settings(ctx context.Context) (SettingsOutput, error) {
+	if _, all, ok := repository.WorkspaceFrom(ctx); !ok || all {
+		return SettingsOutput{}, nil
+	}
+	w, err := uc.current(ctx)
+	if err != nil {
+		return SettingsOutput{}, err
+	}
+	return newSettingsOutput(w.Settings), nil
+}
+
+// ReviewRequired reports whether articles of the workspace of the context
+// must be approved in review before they are made public.
+func (uc *WorkspaceUsecase) ReviewRequired(ctx context.Context) (bool, error) {
+	settings, err := uc.Settings(ctx)
+	if err != nil {
+		return false, err
+	}
+	return settings.RequireReview, nil
+}
+
+// Credential returns the access token the workspace has for the provider.
+func (uc *WorkspaceUsecase) Credential(ctx context.Context, workspaceID uint64, provider vo.ProviderType) (string, bool, error) {
+	w, err := uc.repo.FindByID(ctx, workspaceID)
+	if err != nil {
+		return "", false, err
+	}
+	token, ok := w.Credential(provider)
+	return token, ok, nil
+}
+
+func (uc *WorkspaceUsecase) current(ctx context.Context) (*entity.Workspace, error) {
+	id, all, ok := repository.WorkspaceFrom(ctx)
+	if !ok || all {
+		return nil, repository.ErrWorkspaceRequired
+	}
+	return uc.repo.FindByID(ctx, id)
+}
+
+func (uc *WorkspaceUsecase) authorize(ctx context.Context, required vo.Scope) error {
+	if uc.authorizer == nil {
+		return nil
+	}
+	return uc.authorizer.Authorize(ctx, required)
+}
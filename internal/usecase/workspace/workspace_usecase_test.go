@@ -0,0 +1,194 @@
+package workspace_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/entity"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/repository"
+	"github.com/umekikazuya/momenture-article-hub/internal/domain/vo"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/apperr"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/auth"
+	"github.com/umekikazuya/momenture-article-hub/internal/usecase/workspace"
+)
+
+type MockWorkspaceRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkspaceRepository) FindAll(ctx context.Context) ([]*entity.Workspace, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*entity.Workspace), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) FindByID(ctx context.Context, id uint64) (*entity.Workspace, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Workspace), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) FindBySlug(ctx context.Context, slug string) (*entity.Workspace, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Workspace), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) Create(ctx context.Context, w *entity.Workspace) (*entity.Workspace, error) {
+	args := m.Called(ctx, w)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Workspace), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) Update(ctx context.Context, w *entity.Workspace) error {
+	args := m.Called(ctx, w)
+	return args.Error(0)
+}
+
+func newWorkspace(t *testing.T, id uint64, slug string) *entity.Workspace {
+	t.Helper()
+	w, err := entity.NewWorkspace(slug, "Team "+slug)
+	require.NoError(t, err)
+	w.ID = id
+	return w
+}
+
+func TestWorkspaceUsecase_Create(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   workspace.CreateWorkspaceInput
+		repoErr error
+		wantErr error
+	}{
+		{name: "ワークスペースを作成する", input: workspace.CreateWorkspaceInput{Slug: "team-a", Name: "Team A"}},
+		{name: "DNSラベルでないスラッグは不正な入力", input: workspace.CreateWorkspaceInput{Slug: "Team_A", Name: "Team A"}, wantErr: apperr.ErrInvalidInput},
+		{name: "スラッグの重複は競合", input: workspace.CreateWorkspaceInput{Slug: "team-a", Name: "Team A"}, repoErr: repository.ErrWorkspaceSlugConflict, wantErr: apperr.ErrConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			repo := new(MockWorkspaceRepository)
+			uc := workspace.NewWorkspaceUsecase(repo)
+			ctx := context.Background()
+			if tt.repoErr != nil {
+				repo.On("Create", ctx, mock.Anything).Return(nil, tt.repoErr)
+			} else {
+				repo.On("Create", ctx, mock.Anything).Return(newWorkspace(t, 2, "team-a"), nil)
+			}
+
+			output, err := uc.Create(ctx, tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, uint64(2), output.ID)
+			assert.Equal(t, "team-a", output.Slug)
+		})
+	}
+}
+
+func TestWorkspaceUsecase_ResolveWorkspace(t *testing.T) {
+	t.Parallel()
+
+	repo := new(MockWorkspaceRepository)
+	uc := workspace.NewWorkspaceUsecase(repo)
+	ctx := context.Background()
+	repo.On("FindBySlug", ctx, "team-a").Return(newWorkspace(t, 2, "team-a"), nil)
+	repo.On("FindBySlug", ctx, "unknown").Return(nil, fmt.Errorf("workspace %q: %w", "unknown", repository.ErrWorkspaceNotFound))
+
+	id, err := uc.ResolveWorkspace(ctx, "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), id)
+
+	id, err = uc.ResolveWorkspace(ctx, entity.DefaultWorkspaceSlug)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DefaultWorkspaceID, id)
+
+	_, err = uc.ResolveWorkspace(ctx, "unknown")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestWorkspaceUsecase_UpdateCurrent(t *testing.T) {
+	t.Parallel()
+
+	admin := auth.WithPrincipal(repository.WithWorkspace(context.Background(), 2), &auth.Principal{Name: "ops", Scopes: []vo.Scope{vo.ScopeArticlesAdmin}})
+	writer := auth.WithPrincipal(repository.WithWorkspace(context.Background(), 2), &auth.Principal{Name: "ci", Scopes: []vo.Scope{vo.ScopeArticlesWrite}})
+
+	t.Run("設定とプロバイダの認証情報を更新する", func(t *testing.T) {
+		t.Parallel()
+		repo := new(MockWorkspaceRepository)
+		uc := workspace.NewWorkspaceUsecase(repo, workspace.WithAuthorizer(auth.ScopeAuthorizer{}))
+		repo.On("FindByID", admin, uint64(2)).Return(newWorkspace(t, 2, "team-a"), nil)
+		repo.On("Update", admin, mock.MatchedBy(func(w *entity.Workspace) bool {
+			token, ok := w.Credential(vo.ProviderTypeQiita)
+			return ok && token == "secret" && w.Settings.SiteURL == "https://team-a.example.com"
+		})).Return(nil)
+
+		output, err := uc.UpdateCurrent(admin, workspace.UpdateWorkspaceInput{
+			Settings:            &workspace.SettingsInput{FeedTitle: "Team A", SiteURL: "https://team-a.example.com/"},
+			ProviderCredentials: map[string]string{"qiita": "secret"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Team A", output.Settings.FeedTitle)
+		assert.Equal(t, []string{"qiita"}, output.CredentialProviders)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("未知のプロバイダは不正な入力", func(t *testing.T) {
+		t.Parallel()
+		repo := new(MockWorkspaceRepository)
+		uc := workspace.NewWorkspaceUsecase(repo, workspace.WithAuthorizer(auth.ScopeAuthorizer{}))
+		repo.On("FindByID", admin, uint64(2)).Return(newWorkspace(t, 2, "team-a"), nil)
+
+		_, err := uc.UpdateCurrent(admin, workspace.UpdateWorkspaceInput{ProviderCredentials: map[string]string{"hatena": "secret"}})
+
+		assert.ErrorIs(t, err, apperr.ErrInvalidInput)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("更新には管理者のスコープが必要", func(t *testing.T) {
+		t.Parallel()
+		repo := new(MockWorkspaceRepository)
+		uc := workspace.NewWorkspaceUsecase(repo, workspace.WithAuthorizer(auth.ScopeAuthorizer{}))
+
+		_, err := uc.UpdateCurrent(writer, workspace.UpdateWorkspaceInput{})
+
+		assert.ErrorIs(t, err, apperr.ErrForbidden)
+		repo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWorkspaceUsecase_Settings(t *testing.T) {
+	t.Parallel()
+
+	repo := new(MockWorkspaceRepository)
+	uc := workspace.NewWorkspaceUsecase(repo)
+	w := newWorkspace(t, 2, "team-a")
+	title := "Team A"
+	require.NoError(t, w.Update(nil, &entity.WorkspaceSettings{FeedTitle: title}, nil))
+	ctx := repository.WithWorkspace(context.Background(), 2)
+	repo.On("FindByID", ctx, uint64(2)).Return(w, nil)
+
+	settings, err := uc.Settings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, title, settings.FeedTitle)
+
+	settings, err = uc.Settings(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, settings)
+}